@@ -1,3 +1,5 @@
+//go:build ignore
+
 package main
 
 import (
@@ -54,7 +56,7 @@ func main() {
 			fmt.Printf("   Cost: $%.4f\n", result.Job.EstimatedCost)
 			
 			// Display key results
-			if benchmarkData, ok := result.BenchmarkData["results"].(map[string]interface{}); ok {
+			if benchmarkData, ok := result.BenchmarkData.AsMap()["results"].(map[string]interface{}); ok {
 				fmt.Printf("   Results:\n")
 				for key, value := range benchmarkData {
 					fmt.Printf("     %s: %v\n", key, value)
@@ -185,7 +187,7 @@ func analyzePerformance(results []*awspkg.AsyncBenchmarkResult) map[string]map[s
 		}
 		
 		// Extract performance metrics
-		if benchmarkData, ok := result.BenchmarkData["results"].(map[string]interface{}); ok {
+		if benchmarkData, ok := result.BenchmarkData.AsMap()["results"].(map[string]interface{}); ok {
 			switch benchmarkSuite {
 			case "stream":
 				if triad, ok := benchmarkData["triad_bandwidth_mbps"].(float64); ok {
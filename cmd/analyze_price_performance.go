@@ -1,52 +1,27 @@
+//go:build ignore
+
 package main
 
 import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"os"
-	"path/filepath"
 	"sort"
-	"strings"
 	"time"
 
 	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/pricing"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/storage"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/warehouse"
 )
 
-// BenchmarkResult represents a parsed benchmark result file
+// BenchmarkResult holds the headline metrics of one ingested result
 type BenchmarkResult struct {
-	InstanceType string
-	Region       string
-	FilePath     string
-	Performance  struct {
-		Memory struct {
-			Stream *struct {
-				Copy struct {
-					Bandwidth float64 `json:"bandwidth"`
-				} `json:"copy"`
-				Scale struct {
-					Bandwidth float64 `json:"bandwidth"`
-				} `json:"scale"`
-				Add struct {
-					Bandwidth float64 `json:"bandwidth"`
-				} `json:"add"`
-				Triad struct {
-					Bandwidth float64 `json:"bandwidth"`
-				} `json:"triad"`
-			} `json:"stream"`
-			CoreMark *struct {
-				Score float64 `json:"score"`
-			} `json:"coremark"`
-			HPL *struct {
-				GFLOPS float64 `json:"gflops"`
-			} `json:"hpl"`
-		} `json:"memory"`
-	} `json:"performance"`
-	Metadata struct {
-		InstanceType string `json:"instanceType"`
-		Region       string `json:"region"`
-	} `json:"metadata"`
+	InstanceType   string
+	Region         string
+	ResultKey      string
+	TriadBandwidth float64
+	CoreMarkScore  float64
 }
 
 // PricePerformanceAnalysis contains the analysis results
@@ -93,8 +68,9 @@ type InstanceRanking struct {
 
 func main() {
 	if len(os.Args) < 2 {
-		fmt.Println("Usage: go run cmd/analyze_price_performance.go <results_directory>")
+		fmt.Println("Usage: go run cmd/analyze_price_performance.go <results_directory|warehouse>")
 		fmt.Println("Example: go run cmd/analyze_price_performance.go results/2025-06-30")
+		fmt.Println("Example: go run cmd/analyze_price_performance.go data/warehouse.json.gz")
 		os.Exit(1)
 	}
 
@@ -117,15 +93,16 @@ func main() {
 	fmt.Println(string(output))
 }
 
-func analyzePricePerformance(ctx context.Context, resultsDir string) (*PricePerformanceAnalysis, error) {
-	// Parse all benchmark result files
-	results, err := parseBenchmarkResults(resultsDir)
+func analyzePricePerformance(ctx context.Context, source string) (*PricePerformanceAnalysis, error) {
+	// Query the benchmark results from the warehouse
+	resultsWarehouse, err := openWarehouse(ctx, source)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse benchmark results: %w", err)
+		return nil, fmt.Errorf("failed to load benchmark results: %w", err)
 	}
+	results := queryBenchmarkResults(resultsWarehouse)
 
 	if len(results) == 0 {
-		return nil, fmt.Errorf("no benchmark results found in %s", resultsDir)
+		return nil, fmt.Errorf("no benchmark results found in %s", source)
 	}
 
 	// Create pricing service
@@ -135,8 +112,8 @@ func analyzePricePerformance(ctx context.Context, resultsDir string) (*PricePerf
 	var instanceAnalyses []InstancePricePerformance
 	
 	for _, result := range results {
-		instanceType := result.Metadata.InstanceType
-		region := result.Metadata.Region
+		instanceType := result.InstanceType
+		region := result.Region
 		
 		// Get pricing data
 		pricingData, err := pricingService.GetInstancePricing(ctx, instanceType, region)
@@ -151,15 +128,15 @@ func analyzePricePerformance(ctx context.Context, resultsDir string) (*PricePerf
 		}
 
 		// Extract STREAM performance if available
-		if result.Performance.Memory.Stream != nil {
-			bandwidth := result.Performance.Memory.Stream.Triad.Bandwidth
+		if result.TriadBandwidth > 0 {
+			bandwidth := result.TriadBandwidth
 			analysis.TriadBandwidth = bandwidth
 			analysis.CostPerGBps = pricingData.OnDemand / bandwidth
 		}
 
 		// Extract CoreMark performance if available
-		if result.Performance.Memory.CoreMark != nil {
-			score := result.Performance.Memory.CoreMark.Score
+		if result.CoreMarkScore > 0 {
+			score := result.CoreMarkScore
 			analysis.CoreMarkScore = score
 			scoreMOps := score / 1000000.0 // Convert to millions of ops/sec
 			analysis.CostPerMOps = pricingData.OnDemand / scoreMOps
@@ -188,7 +165,7 @@ func analyzePricePerformance(ctx context.Context, resultsDir string) (*PricePerf
 	// Determine region from first result
 	region := "unknown"
 	if len(results) > 0 {
-		region = results[0].Metadata.Region
+		region = results[0].Region
 	}
 
 	return &PricePerformanceAnalysis{
@@ -200,34 +177,59 @@ func analyzePricePerformance(ctx context.Context, resultsDir string) (*PricePerf
 	}, nil
 }
 
-func parseBenchmarkResults(resultsDir string) ([]BenchmarkResult, error) {
-	var results []BenchmarkResult
+// openWarehouse opens a saved warehouse, or ingests a results directory
+// into an in-memory one.
+func openWarehouse(ctx context.Context, source string) (*warehouse.Warehouse, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return warehouse.Open(source)
+	}
 
-	err := filepath.Walk(resultsDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
+	resultsWarehouse := warehouse.New()
+	store := storage.NewLocalStorage(source, storage.Config{KeyPrefix: "instance-benchmarks/"})
+	if _, err := resultsWarehouse.Ingest(ctx, store, storage.ResultQuery{OnSkip: warnSkippedResult}); err != nil {
+		return nil, err
+	}
+	return resultsWarehouse, nil
+}
 
-		if !strings.HasSuffix(path, ".json") {
-			return nil
-		}
+// warnSkippedResult warns about a file in the results directory that is
+// not a valid result. It writes to stderr so the JSON output stays valid.
+func warnSkippedResult(skipped storage.SkippedResult) {
+	fmt.Fprintf(os.Stderr, "Warning: Skipping %s: %v\n", skipped.Key, skipped.Err)
+}
 
-		data, err := ioutil.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("failed to read %s: %w", path, err)
+// queryBenchmarkResults collects the STREAM triad bandwidth and CoreMark
+// score of every result in the warehouse.
+func queryBenchmarkResults(resultsWarehouse *warehouse.Warehouse) []BenchmarkResult {
+	var results []BenchmarkResult
+	index := make(map[string]int)
+	rows := resultsWarehouse.Query(warehouse.Filter{
+		Suites:  []string{"stream", "coremark"},
+		Metrics: []string{"triad.bandwidth", "score"},
+	})
+	for _, row := range rows {
+		i, ok := index[row.ResultKey]
+		if !ok {
+			i = len(results)
+			index[row.ResultKey] = i
+			results = append(results, BenchmarkResult{
+				InstanceType: row.InstanceType,
+				Region:       row.Region,
+				ResultKey:    row.ResultKey,
+			})
 		}
-
-		var result BenchmarkResult
-		if err := json.Unmarshal(data, &result); err != nil {
-			return fmt.Errorf("failed to parse %s: %w", path, err)
+		switch {
+		case row.Suite == "stream" && row.Metric == "triad.bandwidth":
+			results[i].TriadBandwidth = row.Value
+		case row.Suite == "coremark" && row.Metric == "score":
+			results[i].CoreMarkScore = row.Value
 		}
-
-		result.FilePath = path
-		results = append(results, result)
-		return nil
-	})
-
-	return results, err
+	}
+	return results
 }
 
 func calculateSummary(analyses []InstancePricePerformance) PricePerformanceSummary {
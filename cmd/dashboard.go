@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	awspkg "github.com/scttfrdmn/aws-instance-benchmarks/pkg/aws"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/dashboard"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/pricing"
+)
+
+// startDashboard subscribes a live progress dashboard to events and returns
+// a function that stops it.
+//
+// On a terminal the dashboard redraws a table on stdout, so the run's log
+// lines are written to logPath instead. Elsewhere, e.g. in CI, it prints a
+// line per job change between the regular log lines.
+func startDashboard(events *awspkg.EventBus, logPath string) (func(), error) {
+	screen := os.Stdout
+	interactive := dashboard.IsTerminal(screen)
+
+	var logFile *os.File
+	if interactive {
+		if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create log directory: %w", err)
+		}
+		var err error
+		logFile, err = os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		os.Stdout = logFile
+	}
+
+	board := dashboard.New(screen, interactive).WithPricing(onDemandPrice())
+	events.Subscribe(board)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		board.Run(ctx, dashboard.DefaultRefreshInterval)
+		close(done)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+		if logFile != nil {
+			os.Stdout = screen
+			logFile.Close()
+			fmt.Printf("📝 Full log: %s\n", logPath)
+		}
+	}, nil
+}
+
+// onDemandPrice looks up the hourly on-demand price of an instance type.
+func onDemandPrice() func(instanceType, region string) (float64, bool) {
+	pricingService := pricing.NewPricingService()
+	return func(instanceType, region string) (float64, bool) {
+		price, err := pricingService.GetInstancePricing(context.Background(), instanceType, region)
+		if err != nil {
+			return 0, false
+		}
+		return price.OnDemand, true
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	awspkg "github.com/scttfrdmn/aws-instance-benchmarks/pkg/aws"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/monitoring"
+)
+
+// startEventSinks subscribes the event stream consumers requested on the
+// command line and returns a function that delivers their remaining events
+// and closes them.
+//
+// eventsPath receives one JSON event per line; "-" writes to stdout. Each
+// webhook receives every event as a JSON POST, and metrics, if not nil,
+// publishes CloudWatch metrics for jobs as they finish. Webhooks and
+// CloudWatch are called from their own goroutines so slow endpoints do not
+// hold up the benchmarks.
+func startEventSinks(events *awspkg.EventBus, eventsPath string, webhooks []string, metrics *monitoring.MetricsCollector) (func(), error) {
+	var closers []func()
+	stop := func() {
+		// Close the sinks in reverse order of subscription
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}
+
+	switch eventsPath {
+	case "":
+	case "-":
+		events.Subscribe(awspkg.NewNDJSONSink(os.Stdout))
+	default:
+		if err := os.MkdirAll(filepath.Dir(eventsPath), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create event stream directory: %w", err)
+		}
+		file, err := os.OpenFile(eventsPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open event stream: %w", err)
+		}
+		events.Subscribe(awspkg.NewNDJSONSink(file))
+		closers = append(closers, func() { file.Close() })
+		fmt.Printf("📡 Event stream: %s\n", eventsPath)
+	}
+
+	buffered := func(name string, sink awspkg.EventSink) {
+		bufferedSink := awspkg.NewBufferedSink(sink, awspkg.DefaultEventBufferSize)
+		events.Subscribe(bufferedSink)
+		closers = append(closers, func() {
+			if dropped := bufferedSink.Close(); dropped > 0 {
+				fmt.Printf("⚠️  Dropped %d events for %s\n", dropped, name)
+			}
+		})
+	}
+	for _, url := range webhooks {
+		buffered(url, awspkg.NewWebhookSink(url))
+	}
+	if metrics != nil {
+		buffered("CloudWatch", metrics)
+	}
+
+	return stop, nil
+}
@@ -7,6 +7,8 @@ package main
 
 import (
 	"context"
+	"crypto/md5"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,6 +16,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -33,6 +36,7 @@ import (
 	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/scheduler"
 	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/schema"
 	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/storage"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/warehouse"
 	"github.com/spf13/cobra"
 )
 
@@ -45,6 +49,7 @@ var (
 
 // benchmarkResult stores the results of individual benchmark runs for statistical analysis
 type benchmarkResult struct {
+	region         string
 	instanceType   string
 	benchmarkSuite string
 	iteration      int
@@ -98,7 +103,7 @@ type GitDataProcessor struct {
 }
 
 type AggregateProcessor struct {
-	InputDir  string
+	Warehouse *warehouse.Warehouse
 	OutputDir string
 }
 
@@ -136,10 +141,31 @@ type StatisticalValidationResults struct {
 
 // Data processing implementation functions
 
-func retrieveRawResults(ctx context.Context, s3Storage *storage.S3Storage, date time.Time) ([]map[string]interface{}, error) {
-	// This would implement S3 retrieval logic
-	// For now, return placeholder
-	return []map[string]interface{}{}, nil
+func retrieveRawResults(ctx context.Context, store storage.ResultStore, date time.Time) ([]map[string]interface{}, error) {
+	// Query the results of benchmarks that ran on the given UTC day
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	results, err := store.GetResults(ctx, storage.ResultQuery{
+		DateRange: storage.DateRange{Start: day, End: day.AddDate(0, 0, 1)},
+		SortBy:    "timestamp",
+		OnSkip:    warnSkippedResult,
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	rawResults := make([]map[string]interface{}, 0, len(results))
+	for _, result := range results {
+		if data, ok := result.(map[string]interface{}); ok {
+			rawResults = append(rawResults, data)
+		}
+	}
+	return rawResults, nil
+}
+
+// warnSkippedResult warns about a stored file that is not a valid result
+// and was left out of a query.
+func warnSkippedResult(skipped storage.SkippedResult) {
+	fmt.Fprintf(os.Stderr, "⚠️  Skipping %s: %v\n", skipped.Key, skipped.Err)
 }
 
 func convertToStatisticalFormat(rawResults []map[string]interface{}, qualityThreshold float64) (*StatisticalDataSet, error) {
@@ -167,20 +193,48 @@ func saveStatisticalDataLocally(data *StatisticalDataSet, outputDir string) erro
 }
 
 func (ap *AggregateProcessor) GenerateFamilySummaries() error {
-	// This would implement family aggregation logic
-	fmt.Printf("   Generated family summaries for all instance families\n")
+	summaries := warehouse.Summarize(ap.Warehouse.Query(warehouse.Filter{}), func(r warehouse.Row) string {
+		return r.InstanceFamily
+	})
+	if err := ap.writeJSON("families.json", summaries); err != nil {
+		return err
+	}
+	fmt.Printf("   Generated %d family summaries\n", len(summaries))
 	return nil
 }
 
 func (ap *AggregateProcessor) GenerateArchitectureSummaries() error {
-	// This would implement architecture aggregation logic
-	fmt.Printf("   Generated architecture summaries for Intel, AMD, Graviton\n")
+	summaries := warehouse.Summarize(ap.Warehouse.Query(warehouse.Filter{}), func(r warehouse.Row) string {
+		return r.Architecture
+	})
+	if err := ap.writeJSON("architectures.json", summaries); err != nil {
+		return err
+	}
+	fmt.Printf("   Generated %d architecture summaries\n", len(summaries))
 	return nil
 }
 
 func (ap *AggregateProcessor) GeneratePerformanceIndices() error {
-	// This would implement performance index generation logic
-	fmt.Printf("   Generated performance indices and rankings\n")
+	indices := warehouse.PerformanceIndices(ap.Warehouse.Query(warehouse.Filter{}))
+	if err := ap.writeJSON("performance-indices.json", indices); err != nil {
+		return err
+	}
+	fmt.Printf("   Generated %d performance index entries\n", len(indices))
+	return nil
+}
+
+// writeJSON writes an aggregate to name in the output directory.
+func (ap *AggregateProcessor) writeJSON(name string, aggregate interface{}) error {
+	if err := os.MkdirAll(ap.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	data, err := json.MarshalIndent(aggregate, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", name, err)
+	}
+	if err := os.WriteFile(filepath.Join(ap.OutputDir, name), data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
 	return nil
 }
 
@@ -452,6 +506,7 @@ Supported cloud providers:
 
 The tool provides consistent benchmarking methodology across providers while
 capturing provider-specific optimizations and system characteristics.`,
+		Version: version,
 	}
 
 	var discoverCmd = &cobra.Command{
@@ -523,26 +578,213 @@ capturing provider-specific optimizations and system characteristics.`,
 	var maxConcurrency int
 	var iterations int
 	var s3Bucket string
+	var resultsDir string
 	var enableSystemProfiling bool
 	var configFileRun string
 	var environment string
+	var journalPath string
+	var resumePath string
+	var purchaseOption string
+	var spotMaxPrice string
+	var spotRetrySubnets []string
+	var spotFallbackOnDemand bool
+	var availabilityZone string
+	var tenancy string
+	var hostID string
+	var placementGroup string
+	var capacityReservation string
+	var amiID string
+	var amiParameter string
+	var launchTemplate string
+	var launchTemplateVersion string
+	var regions []string
+	var showDashboard bool
+	var eventsPath string
+	var webhooks []string
 
 	var runProvider string
 	runCmd.Flags().StringVar(&runProvider, "provider", "aws", "Cloud provider (aws, gcp, azure, oci)")
 	runCmd.Flags().StringSliceVar(&instanceTypes, "instance-types", []string{"m7i.large"}, "Instance types to benchmark")
 	runCmd.Flags().StringVar(&region, "region", "us-east-1", "Cloud provider region")
+	runCmd.Flags().StringSliceVar(&regions, "regions", nil, "Regions to run in concurrently (overrides --region; region-specific flags accept region=value lists)")
 	runCmd.Flags().StringVar(&keyPair, "key-pair", "", "SSH key pair name (provider-specific)")
 	runCmd.Flags().StringVar(&securityGroup, "security-group", "", "Security group/firewall rule ID")
 	runCmd.Flags().StringVar(&subnet, "subnet", "", "Subnet/VPC subnet ID")
 	runCmd.Flags().BoolVar(&skipQuota, "skip-quota-check", false, "Skip quota validation before launching")
-	runCmd.Flags().StringSliceVar(&benchmarkSuites, "benchmarks", []string{"stream"}, "Benchmark suites to run (stream, hpl)")
-	runCmd.Flags().IntVar(&maxConcurrency, "max-concurrency", 5, "Maximum number of concurrent benchmarks")
+	runCmd.Flags().StringSliceVar(&benchmarkSuites, "benchmarks", []string{"stream"}, fmt.Sprintf("Benchmark suites to run (%s)", strings.Join(awspkg.RegisteredSuites(), ", ")))
+	runCmd.Flags().IntVar(&maxConcurrency, "max-concurrency", 5, "Maximum number of concurrent benchmarks per region")
 	runCmd.Flags().IntVar(&iterations, "iterations", 1, "Number of benchmark iterations for statistical validation")
 	runCmd.Flags().StringVar(&s3Bucket, "storage-bucket", "", "Cloud storage bucket for storing results")
 	runCmd.Flags().StringVar(&s3Bucket, "s3-bucket", "", "(Deprecated) Use --storage-bucket instead")
+	runCmd.Flags().StringVar(&resultsDir, "results-dir", "", "Store results in this directory instead of the storage bucket")
 	runCmd.Flags().BoolVar(&enableSystemProfiling, "enable-system-profiling", false, "Enable comprehensive system topology discovery and profiling")
 	runCmd.Flags().StringVar(&configFileRun, "config", "", "Path to infrastructure config file (overrides individual flags)")
 	runCmd.Flags().StringVar(&environment, "environment", "", "Environment name from config file (e.g., us-west-2)")
+	runCmd.Flags().StringVar(&journalPath, "journal", "", "Path of the run journal (default: results/journals/run-<timestamp>.jsonl)")
+	runCmd.Flags().StringVar(&resumePath, "resume", "", "Resume an interrupted run from its journal")
+	runCmd.Flags().StringVar(&purchaseOption, "purchase-option", awspkg.PurchaseOnDemand, "Instance purchase option (on-demand, spot)")
+	runCmd.Flags().StringVar(&spotMaxPrice, "spot-max-price", "", "Maximum hourly spot price in USD (default: on-demand price)")
+	runCmd.Flags().StringSliceVar(&spotRetrySubnets, "spot-retry-subnets", nil, "Subnets in other availability zones to retry interrupted spot benchmarks in")
+	runCmd.Flags().BoolVar(&spotFallbackOnDemand, "spot-fallback-on-demand", false, "Retry interrupted spot benchmarks on-demand")
+	runCmd.Flags().StringVar(&availabilityZone, "availability-zone", "", "Pin instances to an availability zone (must match --subnet)")
+	runCmd.Flags().StringVar(&tenancy, "tenancy", "", "Instance tenancy (default, dedicated, host)")
+	runCmd.Flags().StringVar(&hostID, "host-id", "", "Dedicated host to launch on (requires --tenancy host)")
+	runCmd.Flags().StringVar(&placementGroup, "placement-group", "", "Placement group to launch instances into")
+	runCmd.Flags().StringVar(&capacityReservation, "capacity-reservation", "", "Capacity reservation ID to launch instances into")
+	runCmd.Flags().StringVar(&amiID, "ami", "", "AMI ID to launch instead of the latest Amazon Linux 2")
+	runCmd.Flags().StringVar(&amiParameter, "ami-parameter", "", "SSM parameter path that resolves to the AMI ID to launch")
+	runCmd.Flags().StringVar(&launchTemplate, "launch-template", "", "Launch template name to launch instances from")
+	runCmd.Flags().StringVar(&launchTemplateVersion, "launch-template-version", "", "Launch template version (number, $Latest or $Default)")
+	runCmd.Flags().BoolVar(&showDashboard, "dashboard", false, "Show live job progress (a table on terminals, one line per change otherwise)")
+	runCmd.Flags().StringVar(&eventsPath, "events", "", "Write job lifecycle events as newline-delimited JSON to this file (- for stdout)")
+	runCmd.Flags().StringSliceVar(&webhooks, "webhook", nil, "POST job lifecycle events as JSON to these URLs")
+
+	var cleanupCmd = &cobra.Command{
+		Use:   "cleanup",
+		Short: "Find and terminate orphaned benchmark instances",
+		Long: `Find instances launched by benchmark runs or async launches that have
+outlived their benchmark, report the cost they have accrued, and terminate them.
+
+Instances are found by the project's EC2 tags and matched against the local
+run journals and, when a storage bucket is given, async job metadata. Instances
+whose job finished, or that no record accounts for, are terminated; instances
+of jobs still in progress are kept unless --include-active is set.
+
+Example usage:
+  # Report orphaned instances older than two hours without terminating
+  ./aws-benchmark-collector cleanup --region us-east-1 --older-than 2h --dry-run
+
+  # Terminate them, including async jobs tracked in S3
+  ./aws-benchmark-collector cleanup --region us-east-1 --older-than 2h \
+    --storage-bucket aws-instance-benchmarks-data-us-east-1`,
+		RunE: runCleanupCmd,
+	}
+
+	var cleanupRegion string
+	var cleanupOlderThan time.Duration
+	var cleanupDryRun bool
+	var cleanupIncludeActive bool
+	var cleanupJournalDir string
+	var cleanupBucket string
+	var cleanupObjectStore string
+	var cleanupS3Endpoint string
+
+	cleanupCmd.Flags().StringVar(&cleanupRegion, "region", "us-east-1", "Cloud provider region")
+	cleanupCmd.Flags().DurationVar(&cleanupOlderThan, "older-than", 2*time.Hour, "Only terminate instances launched longer ago than this")
+	cleanupCmd.Flags().BoolVar(&cleanupDryRun, "dry-run", false, "Report orphaned instances without terminating them")
+	cleanupCmd.Flags().BoolVar(&cleanupIncludeActive, "include-active", false, "Also terminate instances of jobs that are still in progress")
+	cleanupCmd.Flags().StringVar(&cleanupJournalDir, "journal-dir", filepath.Join("results", "journals"), "Directory of run journals to match instances against")
+	cleanupCmd.Flags().StringVar(&cleanupBucket, "storage-bucket", "", "Cloud storage bucket with async job metadata")
+	cleanupCmd.Flags().StringVar(&cleanupObjectStore, "object-store", objectStoreS3, "Where async jobs are tracked: s3, or a local directory")
+	cleanupCmd.Flags().StringVar(&cleanupS3Endpoint, "s3-endpoint", "", "S3-compatible endpoint (e.g. MinIO) to track async jobs in instead of AWS S3")
+
+	var collectCmd = &cobra.Command{
+		Use:   "collect",
+		Short: "Check on async benchmark jobs and collect their results",
+		Long: `Check the status of async benchmark jobs tracked in a storage bucket and
+collect the results of completed jobs.
+
+With --watch the command keeps polling until every unfinished job has
+completed, failed or timed out. --dashboard shows each job's status,
+progress, elapsed time, estimated cost and latest log line as it changes.
+--events, --webhook and --cloudwatch stream the same job events to other
+tools.
+
+Example usage:
+  # Report the status of every job once
+  ./aws-benchmark-collector collect --storage-bucket aws-instance-benchmarks-data-us-east-1
+
+  # Follow unfinished jobs on a live dashboard
+  ./aws-benchmark-collector collect --storage-bucket aws-instance-benchmarks-data-us-east-1 \
+    --watch --dashboard
+
+  # Stream job events to a file and publish CloudWatch metrics as jobs finish
+  ./aws-benchmark-collector collect --storage-bucket aws-instance-benchmarks-data-us-east-1 \
+    --watch --events results/events.jsonl --cloudwatch
+
+  # Keep the results of completed jobs in a local directory
+  ./aws-benchmark-collector collect --storage-bucket aws-instance-benchmarks-data-us-east-1 \
+    --results-dir results`,
+		RunE: runCollectCmd,
+	}
+
+	var collectRegion string
+	var collectBucket string
+	var collectWatch bool
+	var collectInterval time.Duration
+	var collectDashboard bool
+	var collectEventsPath string
+	var collectWebhooks []string
+	var collectCloudWatch bool
+	var collectResultsDir string
+	var collectObjectStore string
+	var collectS3Endpoint string
+
+	collectCmd.Flags().StringVar(&collectRegion, "region", "us-east-1", "Cloud provider region")
+	collectCmd.Flags().StringVar(&collectBucket, "storage-bucket", "", "Cloud storage bucket with async job metadata (required)")
+	collectCmd.Flags().BoolVar(&collectWatch, "watch", false, "Keep checking until all unfinished jobs are done")
+	collectCmd.Flags().DurationVar(&collectInterval, "interval", time.Minute, "Time between checks with --watch")
+	collectCmd.Flags().BoolVar(&collectDashboard, "dashboard", false, "Show live job progress (a table on terminals, one line per change otherwise)")
+	collectCmd.Flags().StringVar(&collectEventsPath, "events", "", "Write job events as newline-delimited JSON to this file (- for stdout)")
+	collectCmd.Flags().StringSliceVar(&collectWebhooks, "webhook", nil, "POST job events as JSON to these URLs")
+	collectCmd.Flags().BoolVar(&collectCloudWatch, "cloudwatch", false, "Publish CloudWatch metrics for jobs that finish while collecting")
+	collectCmd.Flags().StringVar(&collectResultsDir, "results-dir", "", "Store the results of completed jobs in this directory")
+	collectCmd.Flags().StringVar(&collectObjectStore, "object-store", objectStoreS3, "Where async jobs are tracked: s3, or a local directory")
+	collectCmd.Flags().StringVar(&collectS3Endpoint, "s3-endpoint", "", "S3-compatible endpoint (e.g. MinIO) to track async jobs in instead of AWS S3")
+	collectCmd.MarkFlagRequired("storage-bucket")
+
+	var launchCmd = &cobra.Command{
+		Use:   "launch",
+		Short: "Launch async benchmark jobs that run without a connection",
+		Long: `Launch benchmark instances that run their benchmark from user data and
+upload the results to a storage bucket, then return without waiting. Use the
+collect command to follow the jobs and collect their results.
+
+--os-family selects the bootstrap script and, unless --ami, --ami-parameter or
+--launch-template is given, the default AMI: the latest Amazon Linux 2 or
+Ubuntu 24.04 LTS image for the instance architecture.
+
+Example usage:
+  # Launch STREAM on Intel and Graviton instances running Ubuntu
+  ./aws-benchmark-collector launch --instance-types m7i.large,m7g.large \
+    --os-family ubuntu --storage-bucket aws-instance-benchmarks-data-us-east-1`,
+		RunE: runLaunchCmd,
+	}
+
+	var launchRegion string
+	var launchInstanceTypes []string
+	var launchSuites []string
+	var launchBucket string
+	var launchKeyPair string
+	var launchSecurityGroup string
+	var launchSubnet string
+	var launchAMI string
+	var launchAMIParameter string
+	var launchTemplateName string
+	var launchTemplateVer string
+	var launchOSFamily string
+	var launchMaxRuntime time.Duration
+	var launchJobPrefix string
+	var launchObjectStore string
+	var launchS3Endpoint string
+
+	launchCmd.Flags().StringVar(&launchRegion, "region", "us-east-1", "Cloud provider region")
+	launchCmd.Flags().StringSliceVar(&launchInstanceTypes, "instance-types", []string{"m7i.large"}, "Instance types to benchmark")
+	launchCmd.Flags().StringSliceVar(&launchSuites, "benchmarks", []string{"stream"}, fmt.Sprintf("Benchmark suites to run (%s)", strings.Join(awspkg.RegisteredSuites(), ", ")))
+	launchCmd.Flags().StringVar(&launchBucket, "storage-bucket", "", "Cloud storage bucket to track jobs and upload results in (required)")
+	launchCmd.Flags().StringVar(&launchKeyPair, "key-pair", "", "SSH key pair name (provider-specific)")
+	launchCmd.Flags().StringVar(&launchSecurityGroup, "security-group", "", "Security group/firewall rule ID")
+	launchCmd.Flags().StringVar(&launchSubnet, "subnet", "", "Subnet/VPC subnet ID")
+	launchCmd.Flags().StringVar(&launchAMI, "ami", "", "AMI ID to launch instead of the default AMI of --os-family")
+	launchCmd.Flags().StringVar(&launchAMIParameter, "ami-parameter", "", "SSM parameter path that resolves to the AMI ID to launch")
+	launchCmd.Flags().StringVar(&launchTemplateName, "launch-template", "", "Launch template name to launch instances from")
+	launchCmd.Flags().StringVar(&launchTemplateVer, "launch-template-version", "", "Launch template version (number, $Latest or $Default)")
+	launchCmd.Flags().StringVar(&launchOSFamily, "os-family", awspkg.OSFamilyAmazonLinux, "OS family of the AMI (amazon-linux, ubuntu)")
+	launchCmd.Flags().DurationVar(&launchMaxRuntime, "max-runtime", 4*time.Hour, "Terminate each instance after this long")
+	launchCmd.Flags().StringVar(&launchJobPrefix, "job-name-prefix", "async", "Prefix of the job names")
+	launchCmd.Flags().StringVar(&launchObjectStore, "object-store", objectStoreS3, "Where async jobs are tracked: s3, or a local directory")
+	launchCmd.Flags().StringVar(&launchS3Endpoint, "s3-endpoint", "", "S3-compatible endpoint (e.g. MinIO) to track jobs in; instances upload there too")
+	launchCmd.MarkFlagRequired("storage-bucket")
 
 	var schemaCmd = &cobra.Command{
 		Use:   "schema",
@@ -577,8 +819,12 @@ capturing provider-specific optimizations and system characteristics.`,
 	var analyzeCmd = &cobra.Command{
 		Use:   "analyze [results-directory]",
 		Short: "Analyze benchmark results with price/performance calculations",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runAnalyze,
+		Long: `Analyze STREAM results with price/performance calculations.
+
+Results are read from the warehouse built by the ingest command, or from a
+results directory if one is given.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runAnalyze,
 	}
 
 	var baselineInstance string
@@ -588,6 +834,80 @@ capturing provider-specific optimizations and system characteristics.`,
 	analyzeCmd.Flags().StringVar(&baselineInstance, "baseline", "m7i.large", "Baseline instance for normalization")
 	analyzeCmd.Flags().StringVar(&outputFormat, "format", "table", "Output format: table, json, csv")
 	analyzeCmd.Flags().StringVar(&sortByMetric, "sort", "value_score", "Sort by: value_score, cost_efficiency, performance, price")
+	analyzeCmd.Flags().String("warehouse", warehouse.DefaultPath, "Results warehouse to analyze when no directory is given")
+
+	var ingestCmd = &cobra.Command{
+		Use:   "ingest [results-directory]",
+		Short: "Normalize benchmark results into the results warehouse",
+		Long: `Normalize every benchmark result, across all schema versions, into the
+columnar results warehouse queried by analyze and process aggregate.
+
+Results are read from a local directory or a storage bucket. Re-ingesting a
+result replaces its rows.
+
+Examples:
+  # Ingest results written with --results-dir
+  ./aws-benchmark-collector ingest results
+
+  # Ingest results from S3
+  ./aws-benchmark-collector ingest --storage-bucket my-benchmark-bucket`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runIngest,
+	}
+	ingestCmd.Flags().String("warehouse", warehouse.DefaultPath, "Results warehouse to update")
+	ingestCmd.Flags().String("storage-bucket", "", "Storage bucket to ingest results from instead of a directory")
+	ingestCmd.Flags().String("region", "us-east-1", "AWS region of the storage bucket")
+
+	var verifyCmd = &cobra.Command{
+		Use:   "verify [results-directory]",
+		Short: "Re-hash stored results and report tampering or corruption",
+		Long: `Re-hash every stored result and compare it with the content hash in its
+key and the benchmark data checksum it records.
+
+Results that no longer match are reported as tampered; results that cannot
+be read or decoded are reported as corrupt. Results stored before content
+hashing record no hash and are reported as unverified. The command fails if
+any result is tampered or corrupt.
+
+Examples:
+  # Verify results written with --results-dir
+  ./aws-benchmark-collector verify results
+
+  # Verify results in S3 as JSON
+  ./aws-benchmark-collector verify --storage-bucket my-benchmark-bucket --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runVerify,
+	}
+	verifyCmd.Flags().String("storage-bucket", "", "Storage bucket to verify instead of a directory")
+	verifyCmd.Flags().String("region", "us-east-1", "AWS region of the storage bucket")
+	verifyCmd.Flags().Bool("json", false, "Print the verification of every result as JSON")
+
+	var reparseCmd = &cobra.Command{
+		Use:   "reparse [results-directory]",
+		Short: "Re-run the current parsers over archived raw benchmark output",
+		Long: `Re-run the current output parsers over the raw stdout and stderr archived
+with every stored result, and write corrected results stamped with the
+current parser version to the output directory.
+
+Only results parsed by an older parser version are re-parsed unless --all
+is given. Results stored before raw output was archived are skipped. The
+corrected results keep the metadata of the originals and record the key
+they were re-parsed from.
+
+Examples:
+  # Re-parse results written with --results-dir
+  ./aws-benchmark-collector reparse results
+
+  # Re-parse STREAM results in S3 after a parser fix
+  ./aws-benchmark-collector reparse --storage-bucket my-benchmark-bucket --suite stream`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runReparse,
+	}
+	reparseCmd.Flags().String("storage-bucket", "", "Storage bucket to re-parse instead of a directory")
+	reparseCmd.Flags().String("region", "us-east-1", "AWS region of the storage bucket")
+	reparseCmd.Flags().String("output-dir", "results/reparsed", "Directory to write corrected results to")
+	reparseCmd.Flags().StringSlice("suite", nil, "Only re-parse results of these benchmark suites")
+	reparseCmd.Flags().Bool("all", false, "Re-parse results already stamped with the current parser version")
 
 	// Add schedule command with subcommands
 	var scheduleCmd = &cobra.Command{
@@ -691,6 +1011,12 @@ Example usage:
     --storage-bucket aws-instance-benchmarks-data-us-east-1 \
     --commit-to-git
 
+  # Process results stored in a local directory
+  ./cloud-benchmark-collector process daily \
+    --date 2025-06-29 \
+    --results-dir results \
+    --commit-to-git=false
+
   # Process Google Cloud Storage results
   ./cloud-benchmark-collector process daily \
     --provider gcp \
@@ -726,6 +1052,7 @@ Example usage:
 	// Daily processing flags
 	var processDate string
 	var s3BucketProcess string
+	var resultsDirProcess string
 	var commitToGit bool
 	var branchPrefix string
 	var qualityThreshold float64
@@ -735,6 +1062,7 @@ Example usage:
 	dailyCmd.Flags().StringVar(&processDate, "date", time.Now().Format("2006-01-02"), "Date to process (YYYY-MM-DD)")
 	dailyCmd.Flags().StringVar(&s3BucketProcess, "storage-bucket", "", "Cloud storage bucket containing raw results (S3, GCS, etc.)")
 	dailyCmd.Flags().StringVar(&s3BucketProcess, "s3-bucket", "", "(Deprecated) Use --storage-bucket instead")
+	dailyCmd.Flags().StringVar(&resultsDirProcess, "results-dir", "", "Read raw results from this directory instead of a storage bucket")
 	dailyCmd.Flags().BoolVar(&commitToGit, "commit-to-git", true, "Commit processed data to Git repository")
 	dailyCmd.Flags().StringVar(&branchPrefix, "branch-prefix", "data-collection-", "Prefix for Git branch names")
 	dailyCmd.Flags().Float64Var(&qualityThreshold, "quality-threshold", 0.95, "Minimum quality score for data inclusion")
@@ -751,6 +1079,7 @@ Example usage:
 	aggregateCmd.Flags().BoolVar(&regenerateIndices, "regenerate-indices", true, "Regenerate performance indices")
 	aggregateCmd.Flags().BoolVar(&crossProviderAnalysis, "cross-provider-analysis", false, "Generate cross-provider comparison analysis")
 	aggregateCmd.Flags().StringVar(&outputDir, "output-dir", "data/aggregated", "Output directory for aggregated data")
+	aggregateCmd.Flags().String("warehouse", warehouse.DefaultPath, "Results warehouse to aggregate")
 
 	// Validation flags
 	var validateStatistical bool
@@ -768,10 +1097,16 @@ Example usage:
 	rootCmd.AddCommand(discoverCmd)
 	rootCmd.AddCommand(buildCmd)
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(cleanupCmd)
+	rootCmd.AddCommand(collectCmd)
+	rootCmd.AddCommand(launchCmd)
 	rootCmd.AddCommand(scheduleCmd)
 	rootCmd.AddCommand(processCmd)
 	rootCmd.AddCommand(schemaCmd)
 	rootCmd.AddCommand(analyzeCmd)
+	rootCmd.AddCommand(ingestCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(reparseCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal(err)
@@ -880,26 +1215,89 @@ func runBenchmarkCmd(cmd *cobra.Command, _ []string) error {
 	// Check if config file is specified
 	configFileRun, _ := cmd.Flags().GetString("config")
 	environment, _ := cmd.Flags().GetString("environment")
+	journalPath, _ := cmd.Flags().GetString("journal")
+	resumePath, _ := cmd.Flags().GetString("resume")
+	purchaseOption, _ := cmd.Flags().GetString("purchase-option")
+	spotMaxPrice, _ := cmd.Flags().GetString("spot-max-price")
+	spotRetrySubnets, _ := cmd.Flags().GetStringSlice("spot-retry-subnets")
+	spotFallbackOnDemand, _ := cmd.Flags().GetBool("spot-fallback-on-demand")
+	availabilityZone, _ := cmd.Flags().GetString("availability-zone")
+	tenancy, _ := cmd.Flags().GetString("tenancy")
+	hostID, _ := cmd.Flags().GetString("host-id")
+	placementGroup, _ := cmd.Flags().GetString("placement-group")
+	capacityReservation, _ := cmd.Flags().GetString("capacity-reservation")
+	amiID, _ := cmd.Flags().GetString("ami")
+	amiParameter, _ := cmd.Flags().GetString("ami-parameter")
+	launchTemplate, _ := cmd.Flags().GetString("launch-template")
+	launchTemplateVersion, _ := cmd.Flags().GetString("launch-template-version")
+	regions, _ := cmd.Flags().GetStringSlice("regions")
+	showDashboard, _ := cmd.Flags().GetBool("dashboard")
+	eventsPath, _ := cmd.Flags().GetString("events")
+	webhooks, _ := cmd.Flags().GetStringSlice("webhook")
+	resultsDir, _ := cmd.Flags().GetString("results-dir")
 	
 	var instanceTypes []string
-	var region string
-	var keyPair string
-	var securityGroup string
-	var subnet string
+	var targets []regionTarget
 	var skipQuota bool
 	var benchmarkSuites []string
 	var maxConcurrency int
 	var iterations int
 	var s3Bucket string
 	var enableSystemProfiling bool
+	var journal *awspkg.Journal
 	
-	if configFileRun != "" {
+	if resumePath != "" {
+		// Resume the job plan recorded in the journal; only execution
+		// settings are taken from the command line
+		fmt.Printf("📓 Resuming run from journal %s...\n", resumePath)
+		
+		var err error
+		journal, err = awspkg.OpenJournal(resumePath)
+		if err != nil {
+			return fmt.Errorf("failed to open run journal: %w", err)
+		}
+		defer journal.Close()
+		
+		states := journal.JobStates()
+		if len(states) == 0 {
+			return fmt.Errorf("run journal %s contains no jobs", resumePath)
+		}
+		// Each region keeps the profile its jobs were planned with
+		var journalRegions []string
+		profiles := make(map[string]string)
+		for _, state := range states {
+			journalRegions = appendUnique(journalRegions, state.Config.Region)
+			if state.Config.Profile != "" {
+				profiles[state.Config.Region] = state.Config.Profile
+			}
+			instanceTypes = appendUnique(instanceTypes, state.Config.InstanceType)
+			benchmarkSuites = appendUnique(benchmarkSuites, state.Config.BenchmarkSuite)
+			if iteration := jobIteration(state.ID); iteration > iterations {
+				iterations = iteration
+			}
+		}
+		for _, region := range journalRegions {
+			targets = append(targets, regionTarget{region: region, profile: profiles[region]})
+		}
+		maxConcurrency, _ = cmd.Flags().GetInt("max-concurrency")
+		s3Bucket, _ = cmd.Flags().GetString("s3-bucket")
+		enableSystemProfiling, _ = cmd.Flags().GetBool("enable-system-profiling")
+		
+		if err := journal.Record(awspkg.JournalEntry{Event: awspkg.JournalRunResumed}); err != nil {
+			return fmt.Errorf("failed to update run journal: %w", err)
+		}
+		
+	} else if configFileRun != "" {
 		// Load from config file
-		if environment == "" {
-			return fmt.Errorf("--environment is required when using --config")
+		if environment == "" && len(regions) == 0 {
+			return fmt.Errorf("--environment or --regions is required when using --config")
 		}
 		
-		fmt.Printf("📋 Loading configuration from %s (environment: %s)...\n", configFileRun, environment)
+		if len(regions) > 0 {
+			fmt.Printf("📋 Loading configuration from %s (regions: %s)...\n", configFileRun, strings.Join(regions, ", "))
+		} else {
+			fmt.Printf("📋 Loading configuration from %s (environment: %s)...\n", configFileRun, environment)
+		}
 		
 		configData, err := os.ReadFile(configFileRun)
 		if err != nil {
@@ -911,20 +1309,37 @@ func runBenchmarkCmd(cmd *cobra.Command, _ []string) error {
 			return fmt.Errorf("failed to parse config file: %w", err)
 		}
 		
-		envConfig, exists := infraConfig.Environments[environment]
-		if !exists {
-			return fmt.Errorf("environment '%s' not found in config file", environment)
+		// Fan out to the environment of each requested region
+		var environments []*EnvironmentConfig
+		if len(regions) > 0 {
+			for _, region := range regions {
+				envConfig, err := environmentForRegion(infraConfig, region)
+				if err != nil {
+					return err
+				}
+				environments = append(environments, envConfig)
+			}
+		} else {
+			envConfig, exists := infraConfig.Environments[environment]
+			if !exists {
+				return fmt.Errorf("environment '%s' not found in config file", environment)
+			}
+			environments = append(environments, envConfig)
 		}
 		
-		// Set AWS profile from config
-		os.Setenv("AWS_PROFILE", envConfig.Profile)
-		
 		// Load values from config
-		region = envConfig.Region
-		keyPair = envConfig.Compute.KeyPairName
-		securityGroup = envConfig.Networking.SecurityGroupID
-		subnet = envConfig.Networking.SubnetID
-		s3Bucket = envConfig.Storage.S3Bucket
+		for _, envConfig := range environments {
+			targets = append(targets, regionTarget{
+				region:        envConfig.Region,
+				profile:       envConfig.Profile,
+				keyPair:       envConfig.Compute.KeyPairName,
+				securityGroup: envConfig.Networking.SecurityGroupID,
+				subnet:        envConfig.Networking.SubnetID,
+				bucket:        envConfig.Storage.S3Bucket,
+			})
+			fmt.Printf("   ✅ Region: %s, VPC: %s, Subnet: %s\n", envConfig.Region, envConfig.VPC.VPCID, envConfig.Networking.SubnetID)
+			fmt.Printf("   ✅ S3 Bucket: %s, Key Pair: %s\n", envConfig.Storage.S3Bucket, envConfig.Compute.KeyPairName)
+		}
 		
 		// Load defaults
 		if infraConfig.BenchmarkDefaults != nil {
@@ -953,64 +1368,113 @@ func runBenchmarkCmd(cmd *cobra.Command, _ []string) error {
 			enableSystemProfiling = flagValue
 		}
 		
-		fmt.Printf("   ✅ Region: %s, VPC: %s, Subnet: %s\n", region, envConfig.VPC.VPCID, subnet)
-		fmt.Printf("   ✅ S3 Bucket: %s, Key Pair: %s\n", s3Bucket, keyPair)
-		
 	} else {
 		// Load from command-line flags (existing behavior)
 		instanceTypes, _ = cmd.Flags().GetStringSlice("instance-types")
-		region, _ = cmd.Flags().GetString("region")
-		keyPair, _ = cmd.Flags().GetString("key-pair")
-		securityGroup, _ = cmd.Flags().GetString("security-group")
-		subnet, _ = cmd.Flags().GetString("subnet")
+		region, _ := cmd.Flags().GetString("region")
+		keyPair, _ := cmd.Flags().GetString("key-pair")
+		securityGroup, _ := cmd.Flags().GetString("security-group")
+		subnet, _ := cmd.Flags().GetString("subnet")
 		skipQuota, _ = cmd.Flags().GetBool("skip-quota-check")
 		benchmarkSuites, _ = cmd.Flags().GetStringSlice("benchmarks")
 		maxConcurrency, _ = cmd.Flags().GetInt("max-concurrency")
 		iterations, _ = cmd.Flags().GetInt("iterations")
 		s3Bucket, _ = cmd.Flags().GetString("s3-bucket")
 		enableSystemProfiling, _ = cmd.Flags().GetBool("enable-system-profiling")
+		
+		// Region-scoped resources may be given per region as region=value lists
+		if len(regions) == 0 {
+			regions = []string{region}
+		}
+		for _, region := range regions {
+			targets = append(targets, regionTarget{
+				region:        region,
+				keyPair:       regionalValue(keyPair, region),
+				securityGroup: regionalValue(securityGroup, region),
+				subnet:        regionalValue(subnet, region),
+			})
+		}
 	}
 
-	// Validate required parameters
-	if keyPair == "" {
-		return ErrKeyPairRequired
-	}
-	if securityGroup == "" {
-		return ErrSecurityGroupRequired
+	// Validate required parameters; a resumed plan was validated when it started
+	if journal == nil {
+		for _, target := range targets {
+			if target.keyPair == "" {
+				return fmt.Errorf("%w for region %s", ErrKeyPairRequired, target.region)
+			}
+			if target.securityGroup == "" {
+				return fmt.Errorf("%w for region %s", ErrSecurityGroupRequired, target.region)
+			}
+			if target.subnet == "" {
+				return fmt.Errorf("%w for region %s", ErrSubnetRequired, target.region)
+			}
+		}
+		if purchaseOption != awspkg.PurchaseOnDemand && purchaseOption != awspkg.PurchaseSpot {
+			return fmt.Errorf("invalid --purchase-option %q (use %s or %s)", purchaseOption, awspkg.PurchaseOnDemand, awspkg.PurchaseSpot)
+		}
 	}
-	if subnet == "" {
-		return ErrSubnetRequired
+	for _, benchmarkSuite := range benchmarkSuites {
+		if _, err := awspkg.LookupSuite(benchmarkSuite); err != nil {
+			return fmt.Errorf("%w (registered suites: %s)", err, strings.Join(awspkg.RegisteredSuites(), ", "))
+		}
 	}
 
-	orchestrator, err := awspkg.NewOrchestrator(region)
-	if err != nil {
-		return fmt.Errorf("failed to create orchestrator: %w", err)
+	// Each region gets its own orchestrator, results bucket and concurrency
+	// limit, so AMI lookups and quota checks run against that region
+	type regionRuntime struct {
+		orchestrator *awspkg.Orchestrator
+		storage      storage.ResultStore
+		semaphore    chan struct{}
 	}
+	runtimes := make(map[string]*regionRuntime, len(targets))
+	var regionNames []string
+	for _, target := range targets {
+		orchestrator, err := awspkg.NewOrchestratorWithProfile(target.region, target.profile)
+		if err != nil {
+			return fmt.Errorf("failed to create orchestrator for %s: %w", target.region, err)
+		}
 
-	// Initialize S3 storage for results
-	bucketName := s3Bucket
-	if bucketName == "" {
-		bucketName = fmt.Sprintf("aws-instance-benchmarks-data-%s", region)
-	}
-	
-	storageConfig := storage.Config{
-		BucketName:         bucketName,
-		KeyPrefix:          "instance-benchmarks/",
-		EnableCompression:  false,
-		EnableVersioning:   false,
-		RetryAttempts:      3,
-		UploadTimeout:      5 * time.Minute,
-		BatchSize:          1,
-		StorageClass:       "STANDARD",
-		DataVersion:        "1.0",
-	}
-	s3Storage, err := storage.NewS3Storage(ctx, storageConfig, region)
-	if err != nil {
-		return fmt.Errorf("failed to initialize S3 storage: %w", err)
+		// Initialize S3 storage for results, or a local directory that
+		// every region shares
+		runtime := &regionRuntime{
+			orchestrator: orchestrator,
+			semaphore:    make(chan struct{}, maxConcurrency),
+		}
+		runtimes[target.region] = runtime
+		regionNames = append(regionNames, target.region)
+		if resultsDir != "" {
+			runtime.storage = storage.NewLocalStorage(resultsDir, storage.Config{KeyPrefix: "instance-benchmarks/"})
+			continue
+		}
+		
+		bucketName := target.bucket
+		if bucketName == "" {
+			bucketName = regionalValue(s3Bucket, target.region)
+		}
+		if bucketName == "" {
+			bucketName = fmt.Sprintf("aws-instance-benchmarks-data-%s", target.region)
+		}
+		
+		storageConfig := storage.Config{
+			BucketName:         bucketName,
+			KeyPrefix:          "instance-benchmarks/",
+			EnableCompression:  false,
+			EnableVersioning:   false,
+			RetryAttempts:      3,
+			UploadTimeout:      5 * time.Minute,
+			BatchSize:          1,
+			StorageClass:       "STANDARD",
+			DataVersion:        "1.0",
+			Profile:            target.profile,
+		}
+		runtime.storage, err = storage.NewS3Storage(ctx, storageConfig, target.region)
+		if err != nil {
+			return fmt.Errorf("failed to initialize S3 storage for %s: %w", target.region, err)
+		}
 	}
 
-	// Initialize CloudWatch metrics collector
-	metricsCollector, err := monitoring.NewMetricsCollector(region)
+	// Initialize CloudWatch metrics collector; metrics carry their own region
+	metricsCollector, err := monitoring.NewMetricsCollector(targets[0].region)
 	if err != nil {
 		fmt.Printf("⚠️  Failed to initialize CloudWatch metrics: %v\n", err)
 		fmt.Println("   Continuing without metrics collection...")
@@ -1034,49 +1498,131 @@ func runBenchmarkCmd(cmd *cobra.Command, _ []string) error {
 		benchmarkSuite string
 		iteration      int
 		config         awspkg.BenchmarkConfig
+		resume         *awspkg.JobState
 	}
 
 	var jobs []benchmarkJob
-	for _, instanceType := range instanceTypes {
-		for _, benchmarkSuite := range benchmarkSuites {
-			for iteration := 1; iteration <= iterations; iteration++ {
-				containerImage := fmt.Sprintf("%s/%s:%s-%s", registry, namespace, benchmarkSuite, 
-					getContainerTagForInstance(instanceType))
-
-				config := awspkg.BenchmarkConfig{
-					InstanceType:    instanceType,
-					ContainerImage:  containerImage,
-					BenchmarkSuite:  benchmarkSuite,
-					Region:          region,
-					KeyPairName:     keyPair,
-					SecurityGroupID: securityGroup,
-					SubnetID:        subnet,
-					SkipQuotaCheck:  skipQuota,
-					MaxRetries:      3,
-					Timeout:         10 * time.Minute,
+	if journal != nil {
+		for _, state := range journal.JobStates() {
+			jobs = append(jobs, benchmarkJob{
+				instanceType:   state.Config.InstanceType,
+				benchmarkSuite: state.Config.BenchmarkSuite,
+				iteration:      jobIteration(state.ID),
+				config:         state.Config,
+				resume:         state,
+			})
+		}
+	}
+	for _, target := range targets {
+		if journal != nil {
+			break
+		}
+		for _, instanceType := range instanceTypes {
+			for _, benchmarkSuite := range benchmarkSuites {
+				for iteration := 1; iteration <= iterations; iteration++ {
+					containerImage := fmt.Sprintf("%s/%s:%s-%s", registry, namespace, benchmarkSuite, 
+						getContainerTagForInstance(instanceType))
+
+					config := awspkg.BenchmarkConfig{
+						InstanceType:    instanceType,
+						ContainerImage:  containerImage,
+						BenchmarkSuite:  benchmarkSuite,
+						Region:          target.region,
+						Profile:         target.profile,
+						KeyPairName:     target.keyPair,
+						SecurityGroupID: target.securityGroup,
+						SubnetID:        target.subnet,
+						SkipQuotaCheck:  skipQuota,
+						MaxRetries:      3,
+						Timeout:         10 * time.Minute,
+						JobID:           benchmarkJobID(target.region, instanceType, benchmarkSuite, iteration),
+						
+						PurchaseOption:       purchaseOption,
+						SpotMaxPrice:         spotMaxPrice,
+						SpotRetrySubnetIDs:   regionalValues(spotRetrySubnets, target.region),
+						SpotFallbackOnDemand: spotFallbackOnDemand,
+						
+						AvailabilityZone:      regionalValue(availabilityZone, target.region),
+						Tenancy:               tenancy,
+						HostID:                regionalValue(hostID, target.region),
+						PlacementGroup:        regionalValue(placementGroup, target.region),
+						CapacityReservationID: regionalValue(capacityReservation, target.region),
+						
+						AMIID:                 regionalValue(amiID, target.region),
+						AMIParameter:          amiParameter,
+						LaunchTemplateName:    regionalValue(launchTemplate, target.region),
+						LaunchTemplateVersion: launchTemplateVersion,
+					}
+					
+					jobs = append(jobs, benchmarkJob{
+						instanceType:   instanceType,
+						benchmarkSuite: benchmarkSuite,
+						iteration:      iteration,
+						config:         config,
+					})
 				}
-				
-				jobs = append(jobs, benchmarkJob{
-					instanceType:   instanceType,
-					benchmarkSuite: benchmarkSuite,
-					iteration:      iteration,
-					config:         config,
-				})
 			}
 		}
 	}
 
-	fmt.Printf("Starting parallel benchmark run for %d jobs (%d instance types, %d iterations) in region %s\n", 
-		len(jobs), len(instanceTypes), iterations, region)
-	fmt.Printf("Max concurrency: %d\n", maxConcurrency)
+	// Journal every launch and command so an interrupted run can be resumed
+	if journal == nil {
+		if journalPath == "" {
+			journalPath = filepath.Join("results", "journals", fmt.Sprintf("run-%s.jsonl", time.Now().UTC().Format("20060102-150405")))
+		}
+		configs := make([]awspkg.BenchmarkConfig, len(jobs))
+		for i, job := range jobs {
+			configs[i] = job.config
+		}
+		journal, err = awspkg.CreateJournal(journalPath, configs)
+		if err != nil {
+			return fmt.Errorf("failed to create run journal: %w", err)
+		}
+		defer journal.Close()
+	}
+	events := awspkg.NewEventBus()
+	for _, runtime := range runtimes {
+		runtime.orchestrator.WithJournal(journal).WithEvents(events)
+	}
+	fmt.Printf("📓 Run journal: %s\n", journal.Path())
+
+	// CloudWatch metrics are published per job below, so they are not
+	// subscribed to the event stream as well
+	stopEventSinks, err := startEventSinks(events, eventsPath, webhooks, nil)
+	if err != nil {
+		return err
+	}
+	
+	stopDashboard := func() {}
+	if showDashboard {
+		logPath := strings.TrimSuffix(journal.Path(), filepath.Ext(journal.Path())) + ".log"
+		stopDashboard, err = startDashboard(events, logPath)
+		if err != nil {
+			stopEventSinks()
+			return err
+		}
+	}
+
+	if len(regionNames) > 1 {
+		fmt.Printf("Starting parallel benchmark run for %d jobs (%d instance types, %d iterations) in %d regions: %s\n", 
+			len(jobs), len(instanceTypes), iterations, len(regionNames), strings.Join(regionNames, ", "))
+	} else {
+		fmt.Printf("Starting parallel benchmark run for %d jobs (%d instance types, %d iterations) in region %s\n", 
+			len(jobs), len(instanceTypes), iterations, regionNames[0])
+	}
+	fmt.Printf("Max concurrency: %d per region\n", maxConcurrency)
 
-	// Create semaphore to limit concurrency
-	semaphore := make(chan struct{}, maxConcurrency)
 	var wg sync.WaitGroup
 	var resultsMutex sync.Mutex
 	
 	successCount := 0
 	failureCount := 0
+	storedCount := 0
+	regionJobs := make(map[string]int)
+	regionFailures := make(map[string]int)
+	for _, job := range jobs {
+		regionJobs[job.config.Region]++
+	}
 	startTime := time.Now()
 	
 	// Collect all results for statistical analysis
@@ -1088,25 +1634,51 @@ func runBenchmarkCmd(cmd *cobra.Command, _ []string) error {
 		go func(j benchmarkJob) {
 			defer wg.Done()
 			
-			// Acquire semaphore
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
+			// Acquire the region's concurrency slot
+			runtime := runtimes[j.config.Region]
+			runtime.semaphore <- struct{}{}
+			defer func() { <-runtime.semaphore }()
+			
+			// Jobs whose results were stored before the interruption only
+			// need their leaked instances cleaned up
+			if j.resume != nil && j.resume.Status == awspkg.JournalStatusStored {
+				if err := runtime.orchestrator.TerminateLeakedInstances(ctx, j.resume); err != nil {
+					fmt.Printf("⚠️  %v\n", err)
+				}
+				fmt.Printf("⏭️  Skipping %s benchmark on %s in %s (iteration %d): results already stored\n", j.benchmarkSuite, j.instanceType, j.config.Region, j.iteration)
+				
+				resultsMutex.Lock()
+				allResults = append(allResults, benchmarkResult{
+					region:         j.config.Region,
+					instanceType:   j.instanceType,
+					benchmarkSuite: j.benchmarkSuite,
+					iteration:      j.iteration,
+					success:        true,
+					result:         j.resume.Result,
+				})
+				storedCount++
+				resultsMutex.Unlock()
+				return
+			}
 			
 			if iterations > 1 {
-				fmt.Printf("🚀 Starting %s benchmark on %s (iteration %d/%d)...\n", j.benchmarkSuite, j.instanceType, j.iteration, iterations)
+				fmt.Printf("🚀 Starting %s benchmark on %s in %s (iteration %d/%d)...\n", j.benchmarkSuite, j.instanceType, j.config.Region, j.iteration, iterations)
 			} else {
-				fmt.Printf("🚀 Starting %s benchmark on %s...\n", j.benchmarkSuite, j.instanceType)
+				fmt.Printf("🚀 Starting %s benchmark on %s in %s...\n", j.benchmarkSuite, j.instanceType, j.config.Region)
 			}
 			
 			benchmarkStartTime := time.Now()
 			var result *awspkg.InstanceResult
 			var err error
 			
-			// Use system profiling if enabled
-			if enableSystemProfiling {
-				result, err = orchestrator.RunBenchmarkWithProfiling(ctx, j.config)
-			} else {
-				result, err = orchestrator.RunBenchmark(ctx, j.config)
+			// Resume journaled jobs, using system profiling for fresh runs if enabled
+			switch {
+			case j.resume != nil:
+				result, err = runtime.orchestrator.ResumeBenchmark(ctx, j.resume)
+			case enableSystemProfiling:
+				result, err = runtime.orchestrator.RunBenchmarkWithProfiling(ctx, j.config)
+			default:
+				result, err = runtime.orchestrator.RunBenchmark(ctx, j.config)
 			}
 			benchmarkEndTime := time.Now()
 			
@@ -1115,7 +1687,7 @@ func runBenchmarkCmd(cmd *cobra.Command, _ []string) error {
 				InstanceType:       j.instanceType,
 				InstanceFamily:     extractInstanceFamily(j.instanceType),
 				BenchmarkSuite:     j.benchmarkSuite,
-				Region:            region,
+				Region:            j.config.Region,
 				Success:           err == nil,
 				ExecutionDuration: benchmarkEndTime.Sub(benchmarkStartTime).Seconds(),
 				Timestamp:         benchmarkEndTime,
@@ -1124,12 +1696,16 @@ func runBenchmarkCmd(cmd *cobra.Command, _ []string) error {
 			if err != nil {
 				resultsMutex.Lock()
 				failureCount++
+				regionFailures[j.config.Region]++
 				resultsMutex.Unlock()
 				
 				// Categorize error for metrics
 				if quotaErr, ok := err.(*awspkg.QuotaError); ok {
 					benchmarkMetrics.ErrorCategory = "quota"
 					fmt.Printf("⚠️  Skipped %s due to quota: %s\n", j.instanceType, quotaErr.Message)
+				} else if errors.Is(err, awspkg.ErrSpotInterrupted) {
+					benchmarkMetrics.ErrorCategory = "spot_interruption"
+					fmt.Printf("⚡ Interrupted %s benchmark on %s: %v\n", j.benchmarkSuite, j.instanceType, err)
 				} else {
 					benchmarkMetrics.ErrorCategory = "infrastructure"
 					fmt.Printf("❌ Failed %s benchmark on %s: %v\n", j.benchmarkSuite, j.instanceType, err)
@@ -1145,6 +1721,7 @@ func runBenchmarkCmd(cmd *cobra.Command, _ []string) error {
 				// Store failed result for analysis
 				resultsMutex.Lock()
 				allResults = append(allResults, benchmarkResult{
+					region:         j.config.Region,
 					instanceType:   j.instanceType,
 					benchmarkSuite: j.benchmarkSuite,
 					iteration:      j.iteration,
@@ -1161,69 +1738,28 @@ func runBenchmarkCmd(cmd *cobra.Command, _ []string) error {
 			
 			// Extract performance metrics from benchmark results
 			if result.BenchmarkData != nil {
-				benchmarkMetrics.PerformanceMetrics = make(map[string]float64)
-				
 				// Extract benchmark-specific performance data
-				switch j.benchmarkSuite {
-				case "stream":
-					streamData := result.BenchmarkData
-					if triad, exists := streamData["triad_bandwidth"]; exists {
-						if triadVal, ok := triad.(float64); ok {
-							benchmarkMetrics.PerformanceMetrics["triad_bandwidth"] = triadVal
-						}
-					}
-					if copy, exists := streamData["copy_bandwidth"]; exists {
-						if copyVal, ok := copy.(float64); ok {
-							benchmarkMetrics.PerformanceMetrics["copy_bandwidth"] = copyVal
-						}
-					}
-					if scale, exists := streamData["scale_bandwidth"]; exists {
-						if scaleVal, ok := scale.(float64); ok {
-							benchmarkMetrics.PerformanceMetrics["scale_bandwidth"] = scaleVal
-						}
-					}
-					if add, exists := streamData["add_bandwidth"]; exists {
-						if addVal, ok := add.(float64); ok {
-							benchmarkMetrics.PerformanceMetrics["add_bandwidth"] = addVal
-						}
-					}
-				case "hpl":
-					hplData := result.BenchmarkData
-					if gflops, exists := hplData["gflops"]; exists {
-						if gflopsVal, ok := gflops.(float64); ok {
-							benchmarkMetrics.PerformanceMetrics["gflops"] = gflopsVal
-						}
-					}
-					if efficiency, exists := hplData["efficiency"]; exists {
-						if efficiencyVal, ok := efficiency.(float64); ok {
-							benchmarkMetrics.PerformanceMetrics["efficiency"] = efficiencyVal
-						}
-					}
-					if executionTime, exists := hplData["execution_time"]; exists {
-						if executionTimeVal, ok := executionTime.(float64); ok {
-							benchmarkMetrics.PerformanceMetrics["execution_time"] = executionTimeVal
-						}
-					}
-					if residual, exists := hplData["residual"]; exists {
-						if residualVal, ok := residual.(float64); ok {
-							benchmarkMetrics.PerformanceMetrics["residual"] = residualVal
-						}
-					}
-				}
+				benchmarkMetrics.PerformanceMetrics = monitoring.ExtractPerformanceMetrics(result.BenchmarkData)
 				
 				// Calculate quality score based on performance stability
 				benchmarkMetrics.QualityScore = calculateQualityScore(result.BenchmarkData)
 			}
 
-			fmt.Printf("✅ Completed %s benchmark on %s (took %v)\n", 
-				j.benchmarkSuite, j.instanceType, result.EndTime.Sub(result.StartTime))
-			fmt.Printf("   Instance: %s, Public IP: %s\n", result.InstanceID, result.PublicIP)
+			fmt.Printf("✅ Completed %s benchmark on %s in %s (took %v)\n", 
+				j.benchmarkSuite, j.instanceType, j.config.Region, result.EndTime.Sub(result.StartTime))
+			fmt.Printf("   Instance: %s (%s), Public IP: %s\n", result.InstanceID, result.PurchaseOption, result.PublicIP)
+			if len(result.Interruptions) > 0 {
+				fmt.Printf("   ⚡ Completed after %d spot interruption(s)\n", len(result.Interruptions))
+			}
 
 			// Store results to S3 and locally
-			if err := storeResults(ctx, s3Storage, result, j.benchmarkSuite, region); err != nil {
+			if err := storeResults(ctx, runtime.storage, result, j.benchmarkSuite, j.config.Region); err != nil {
 				fmt.Printf("⚠️  Failed to store results for %s: %v\n", j.instanceType, err)
 			} else {
 				fmt.Printf("   Results stored successfully for %s\n", j.instanceType)
+				if err := journal.Record(awspkg.JournalEntry{Event: awspkg.JournalResultStored, JobID: j.config.JobID}); err != nil {
+					fmt.Printf("   ⚠️  Failed to update run journal: %v\n", err)
+				}
 			}
 			
 			// Publish success metrics to CloudWatch
@@ -1238,6 +1774,7 @@ func runBenchmarkCmd(cmd *cobra.Command, _ []string) error {
 			// Store successful result for analysis
 			resultsMutex.Lock()
 			allResults = append(allResults, benchmarkResult{
+				region:         j.config.Region,
 				instanceType:   j.instanceType,
 				benchmarkSuite: j.benchmarkSuite,
 				iteration:      j.iteration,
@@ -1253,6 +1790,8 @@ func runBenchmarkCmd(cmd *cobra.Command, _ []string) error {
 	// Wait for all benchmarks to complete
 	wg.Wait()
 	totalTime := time.Since(startTime)
+	stopDashboard()
+	stopEventSinks()
 
 	// Perform statistical analysis if multiple iterations
 	if iterations > 1 {
@@ -1265,6 +1804,14 @@ func runBenchmarkCmd(cmd *cobra.Command, _ []string) error {
 	fmt.Printf("   Total jobs: %d\n", len(jobs))
 	fmt.Printf("   Successful: %d\n", successCount)
 	fmt.Printf("   Failed: %d\n", failureCount)
+	if storedCount > 0 {
+		fmt.Printf("   Already stored: %d\n", storedCount)
+	}
+	if len(regionNames) > 1 {
+		for _, region := range regionNames {
+			fmt.Printf("   %s: %d jobs, %d failed\n", region, regionJobs[region], regionFailures[region])
+		}
+	}
 	fmt.Printf("   Total time: %v\n", totalTime)
 	fmt.Printf("   Average time per job: %v\n", totalTime/time.Duration(len(jobs)))
 	
@@ -1276,27 +1823,312 @@ func runBenchmarkCmd(cmd *cobra.Command, _ []string) error {
 			float64(sequentialTime)/float64(totalTime), efficiency)
 	}
 
-	// Publish operational metrics to CloudWatch
-	if metricsCollector != nil {
+	// Publish operational metrics to CloudWatch for each region
+	for _, region := range regionNames {
+		if metricsCollector == nil || regionJobs[region] == 0 {
+			continue
+		}
 		operationalMetrics := monitoring.OperationalMetrics{
 			InstanceLaunchDuration: totalTime.Seconds() / float64(len(jobs)), // Average launch time
 			ActiveInstances:        0, // All instances terminated after benchmarks
-			FailureRate:           float64(failureCount) / float64(len(jobs)) * 100,
+			FailureRate:           float64(regionFailures[region]) / float64(regionJobs[region]) * 100,
 			Region:               region,
 			Timestamp:            time.Now(),
 		}
 		
 		if publishErr := metricsCollector.PublishOperationalMetrics(ctx, operationalMetrics); publishErr != nil {
-			fmt.Printf("⚠️  Failed to publish operational metrics: %v\n", publishErr)
+			fmt.Printf("⚠️  Failed to publish operational metrics for %s: %v\n", region, publishErr)
 		} else {
-			fmt.Printf("📈 Operational metrics published to CloudWatch\n")
+			fmt.Printf("📈 Operational metrics for %s published to CloudWatch\n", region)
 		}
 	}
 
+	if failureCount > 0 {
+		fmt.Printf("\n📓 Retry failed jobs with: --resume %s\n", journal.Path())
+	}
+
 	fmt.Println("\n✅ Parallel benchmark execution completed!")
 	return nil
 }
 
+func runCleanupCmd(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	region, _ := cmd.Flags().GetString("region")
+	olderThan, _ := cmd.Flags().GetDuration("older-than")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	includeActive, _ := cmd.Flags().GetBool("include-active")
+	journalDir, _ := cmd.Flags().GetString("journal-dir")
+	bucket, _ := cmd.Flags().GetString("storage-bucket")
+
+	orchestrator, err := awspkg.NewOrchestrator(region)
+	if err != nil {
+		return fmt.Errorf("failed to create orchestrator: %w", err)
+	}
+
+	config := awspkg.ReaperConfig{
+		OlderThan:     olderThan,
+		IncludeActive: includeActive,
+		DryRun:        dryRun,
+	}
+
+	// Load run journals so orchestrated instances can be matched to their jobs
+	journalPaths, err := filepath.Glob(filepath.Join(journalDir, "*.jsonl"))
+	if err != nil {
+		return fmt.Errorf("failed to list run journals: %w", err)
+	}
+	for _, path := range journalPaths {
+		journal, err := awspkg.OpenJournal(path)
+		if err != nil {
+			fmt.Printf("⚠️  Skipping run journal %s: %v\n", path, err)
+			continue
+		}
+		defer journal.Close()
+		config.Journals = append(config.Journals, journal)
+	}
+	fmt.Printf("📓 Loaded %d run journals from %s\n", len(config.Journals), journalDir)
+
+	if bucket != "" {
+		objectStore, _ := cmd.Flags().GetString("object-store")
+		endpoint, _ := cmd.Flags().GetString("s3-endpoint")
+		collector, err := newAsyncCollector(region, objectStore, endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to create async collector: %w", err)
+		}
+		config.AsyncJobs, err = collector.ListJobs(ctx, bucket)
+		if err != nil {
+			return fmt.Errorf("failed to load async job metadata: %w", err)
+		}
+		fmt.Printf("📦 Loaded %d async jobs from s3://%s\n", len(config.AsyncJobs), bucket)
+	}
+
+	pricingService := pricing.NewPricingService()
+	config.HourlyPrice = func(instanceType string) (float64, bool) {
+		price, err := pricingService.GetInstancePricing(ctx, instanceType, region)
+		if err != nil {
+			return 0, false
+		}
+		return price.OnDemand, true
+	}
+
+	report, err := orchestrator.ReapOrphanedInstances(ctx, config)
+	if err != nil {
+		return fmt.Errorf("cleanup failed: %w", err)
+	}
+
+	if len(report.Instances) == 0 {
+		fmt.Printf("✅ No benchmark instances running in %s\n", region)
+		return nil
+	}
+
+	fmt.Printf("\n%-20s %-14s %-10s %-12s %10s %10s  %s\n", "INSTANCE", "TYPE", "STATE", "OWNER", "AGE", "COST", "STATUS")
+	for _, instance := range report.Instances {
+		cost := "unknown"
+		if instance.HourlyPrice > 0 {
+			cost = fmt.Sprintf("$%.2f", instance.AccruedCost)
+		}
+
+		action := "keep"
+		switch {
+		case instance.Error != nil:
+			action = fmt.Sprintf("❌ %v", instance.Error)
+		case instance.Terminated:
+			action = "🧹 terminated"
+		case instance.Reap:
+			action = "🧹 would terminate"
+		}
+
+		fmt.Printf("%-20s %-14s %-10s %-12s %10s %10s  %s: %s\n",
+			instance.InstanceID, instance.InstanceType, instance.State, instance.Owner,
+			instance.Age.Round(time.Minute), cost, action, instance.Reason)
+	}
+
+	fmt.Printf("\n📊 Cleanup Summary:\n")
+	fmt.Printf("   Benchmark instances: %d ($%.2f accrued)\n", len(report.Instances), report.TotalCost)
+	if dryRun {
+		fmt.Printf("   Would terminate: %d ($%.2f accrued)\n", report.Reaped, report.ReapedCost)
+		fmt.Println("\n💡 Run without --dry-run to terminate them")
+	} else {
+		fmt.Printf("   Terminated: %d ($%.2f accrued)\n", report.Reaped, report.ReapedCost)
+	}
+	return nil
+}
+
+func runCollectCmd(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	region, _ := cmd.Flags().GetString("region")
+	bucket, _ := cmd.Flags().GetString("storage-bucket")
+	watch, _ := cmd.Flags().GetBool("watch")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	showDashboard, _ := cmd.Flags().GetBool("dashboard")
+	eventsPath, _ := cmd.Flags().GetString("events")
+	webhooks, _ := cmd.Flags().GetStringSlice("webhook")
+	publishMetrics, _ := cmd.Flags().GetBool("cloudwatch")
+	resultsDir, _ := cmd.Flags().GetString("results-dir")
+	objectStore, _ := cmd.Flags().GetString("object-store")
+	endpoint, _ := cmd.Flags().GetString("s3-endpoint")
+
+	collector, err := newAsyncCollector(region, objectStore, endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to create async collector: %w", err)
+	}
+	if resultsDir != "" {
+		collector.WithResultStore(storage.NewLocalStorage(resultsDir, storage.Config{KeyPrefix: "instance-benchmarks/"}))
+	}
+	collector.WithProvenance(buildProvenance())
+	events := awspkg.NewEventBus()
+	collector.WithEvents(events)
+
+	var metricsCollector *monitoring.MetricsCollector
+	if publishMetrics {
+		metricsCollector, err = monitoring.NewMetricsCollector(region)
+		if err != nil {
+			return fmt.Errorf("failed to create metrics collector: %w", err)
+		}
+	}
+	stopEventSinks, err := startEventSinks(events, eventsPath, webhooks, metricsCollector)
+	if err != nil {
+		return err
+	}
+	defer stopEventSinks()
+
+	if showDashboard {
+		logPath := filepath.Join("results", "logs", fmt.Sprintf("collect-%s.log", time.Now().UTC().Format("20060102-150405")))
+		stopDashboard, err := startDashboard(events, logPath)
+		if err != nil {
+			return err
+		}
+		defer stopDashboard()
+	}
+
+	if !watch {
+		if _, err := collector.CheckAllBenchmarks(ctx, bucket); err != nil {
+			return fmt.Errorf("collection failed: %w", err)
+		}
+		return nil
+	}
+
+	// Only unfinished jobs are worth waiting for
+	jobs, err := collector.ListJobs(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	var pending []*awspkg.AsyncBenchmarkJob
+	for _, job := range jobs {
+		switch job.Status {
+		case awspkg.JobStatusLaunched, awspkg.JobStatusRunning, awspkg.JobStatusStalled:
+			pending = append(pending, job)
+		}
+	}
+	if len(pending) == 0 {
+		fmt.Printf("✅ No unfinished jobs in s3://%s\n", bucket)
+		return nil
+	}
+
+	result, err := collector.WaitForCompletion(ctx, pending, interval)
+	if err != nil {
+		return fmt.Errorf("collection failed: %w", err)
+	}
+	fmt.Printf("\n📊 Collection Summary:\n")
+	fmt.Printf("   Completed: %d\n", result.Summary.CompletedJobs)
+	fmt.Printf("   Failed: %d\n", result.Summary.FailedJobs)
+	fmt.Printf("   Timed out: %d\n", result.Summary.TimedOutJobs)
+	fmt.Printf("   Total cost: $%.4f\n", result.Summary.TotalCost)
+	return nil
+}
+
+func runLaunchCmd(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	region, _ := cmd.Flags().GetString("region")
+	instanceTypes, _ := cmd.Flags().GetStringSlice("instance-types")
+	benchmarkSuites, _ := cmd.Flags().GetStringSlice("benchmarks")
+	bucket, _ := cmd.Flags().GetString("storage-bucket")
+	keyPair, _ := cmd.Flags().GetString("key-pair")
+	securityGroup, _ := cmd.Flags().GetString("security-group")
+	subnet, _ := cmd.Flags().GetString("subnet")
+	amiID, _ := cmd.Flags().GetString("ami")
+	amiParameter, _ := cmd.Flags().GetString("ami-parameter")
+	launchTemplate, _ := cmd.Flags().GetString("launch-template")
+	launchTemplateVersion, _ := cmd.Flags().GetString("launch-template-version")
+	osFamily, _ := cmd.Flags().GetString("os-family")
+	maxRuntime, _ := cmd.Flags().GetDuration("max-runtime")
+	jobPrefix, _ := cmd.Flags().GetString("job-name-prefix")
+	objectStore, _ := cmd.Flags().GetString("object-store")
+	endpoint, _ := cmd.Flags().GetString("s3-endpoint")
+
+	for _, benchmarkSuite := range benchmarkSuites {
+		if _, err := awspkg.LookupSuite(benchmarkSuite); err != nil {
+			return fmt.Errorf("%w (registered suites: %s)", err, strings.Join(awspkg.RegisteredSuites(), ", "))
+		}
+	}
+
+	launcher, err := newAsyncLauncher(region, objectStore, endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to create async launcher: %w", err)
+	}
+
+	request := &awspkg.LaunchRequest{
+		S3Bucket:      bucket,
+		JobNamePrefix: jobPrefix,
+		MaxRuntime:    maxRuntime,
+	}
+	for _, instanceType := range instanceTypes {
+		for _, benchmarkSuite := range benchmarkSuites {
+			request.Configs = append(request.Configs, awspkg.BenchmarkConfig{
+				InstanceType:          instanceType,
+				BenchmarkSuite:        benchmarkSuite,
+				Region:                region,
+				KeyPairName:           keyPair,
+				SecurityGroupID:       securityGroup,
+				SubnetID:              subnet,
+				AMIID:                 amiID,
+				AMIParameter:          amiParameter,
+				LaunchTemplateName:    launchTemplate,
+				LaunchTemplateVersion: launchTemplateVersion,
+				OSFamily:              osFamily,
+			})
+		}
+	}
+
+	response, err := launcher.LaunchBenchmarks(ctx, request)
+	if err != nil {
+		return fmt.Errorf("launch failed: %w", err)
+	}
+	if response.LaunchedCount == 0 {
+		return fmt.Errorf("no benchmarks launched: %s", strings.Join(response.Errors, "; "))
+	}
+	fmt.Printf("💡 Follow the jobs with: collect --region %s --storage-bucket %s --watch\n", region, bucket)
+	return nil
+}
+
+// benchmarkJobID identifies one benchmark iteration in the run journal.
+func benchmarkJobID(region, instanceType, benchmarkSuite string, iteration int) string {
+	return fmt.Sprintf("%s/%s/%s/%d", region, instanceType, benchmarkSuite, iteration)
+}
+
+// jobIteration extracts the iteration number from a journal job ID, or
+// returns 1 for IDs not created by benchmarkJobID.
+func jobIteration(jobID string) int {
+	if idx := strings.LastIndex(jobID, "/"); idx >= 0 {
+		if iteration, err := strconv.Atoi(jobID[idx+1:]); err == nil && iteration > 0 {
+			return iteration
+		}
+	}
+	return 1
+}
+
+// appendUnique appends value to values unless it is already present.
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
 func getContainerTagForInstance(instanceType string) string {
 	// Extract family for precise container selection
 	family := extractInstanceFamily(instanceType) // e.g., "c7a" from "c7a.large"
@@ -1337,7 +2169,14 @@ func getContainerTagForInstance(instanceType string) string {
 	return "intel-skylake" // Default fallback for older generations
 }
 
-func storeResults(ctx context.Context, s3Storage *storage.S3Storage, result *awspkg.InstanceResult, benchmarkSuite string, region string) error {
+func storeResults(ctx context.Context, store storage.ResultStore, result *awspkg.InstanceResult, benchmarkSuite string, region string) error {
+	// Place results in the schema performance section declared by the suite
+	category := awspkg.CategoryMemory
+	if suite, err := awspkg.LookupSuite(benchmarkSuite); err == nil && suite.Metadata().Category != "" {
+		category = suite.Metadata().Category
+	}
+	containerImage := getContainerImageForInstance(result.InstanceType, benchmarkSuite)
+
 	// Create comprehensive result structure for JSON storage following ComputeCompass integration format
 	resultData := map[string]interface{}{
 		"schema_version": "1.0.0",
@@ -1352,14 +2191,21 @@ func storeResults(ctx context.Context, s3Storage *storage.S3Storage, result *aws
 			"benchmark_suite":  benchmarkSuite,
 			"duration_seconds": result.EndTime.Sub(result.StartTime).Seconds(),
 			"collection_method": "automated",
+			"purchase_option":  result.PurchaseOption,
+			"availability_zone": result.AvailabilityZone,
+			"tenancy":          result.Tenancy,
+			"ami_id":           result.AMIID,
+			"kernel_version":   result.KernelVersion,
+			"os_release":       result.OSRelease,
+			"parser_version":   awspkg.ParserVersion,
 			"environment": map[string]interface{}{
-				"containerImage": getContainerImageForInstance(result.InstanceType, benchmarkSuite),
+				"containerImage": containerImage,
 				"timestamp":     result.StartTime.UTC().Format(time.RFC3339),
 				"duration":      result.EndTime.Sub(result.StartTime).Seconds(),
 			},
 		},
 		"performance": map[string]interface{}{
-			"memory": result.BenchmarkData,
+			category: result.BenchmarkData,
 		},
 		"validation": map[string]interface{}{
 			"checksums": map[string]interface{}{
@@ -1383,6 +2229,7 @@ func storeResults(ctx context.Context, s3Storage *storage.S3Storage, result *aws
 			"benchmark_version": "latest",
 			"compiler_optimizations": getCompilerOptimizations(result.InstanceType),
 		},
+		"provenance": resultProvenance(result, containerImage),
 	}
 	
 	// Include system topology if available from profiling
@@ -1391,6 +2238,26 @@ func storeResults(ctx context.Context, s3Storage *storage.S3Storage, result *aws
 		// Update schema version to indicate enhanced data
 		resultData["schema_version"] = "2.0.0"
 	}
+	
+	// Record dedicated hardware and co-location targets for reproducibility
+	placement := map[string]interface{}{}
+	if result.HostID != "" {
+		placement["host_id"] = result.HostID
+	}
+	if result.PlacementGroup != "" {
+		placement["placement_group"] = result.PlacementGroup
+	}
+	if result.CapacityReservationID != "" {
+		placement["capacity_reservation_id"] = result.CapacityReservationID
+	}
+	if len(placement) > 0 {
+		resultData["metadata"].(map[string]interface{})["placement"] = placement
+	}
+	
+	// Record spot interruptions of earlier attempts
+	if len(result.Interruptions) > 0 {
+		resultData["spot_interruptions"] = result.Interruptions
+	}
 
 	// Convert to JSON
 	jsonData, err := json.MarshalIndent(resultData, "", "  ")
@@ -1415,28 +2282,45 @@ func storeResults(ctx context.Context, s3Storage *storage.S3Storage, result *aws
 		}
 	}
 
-	// Generate filename with timestamp
-	timestamp := result.StartTime.UTC().Format("20060102-150405")
-	filename := fmt.Sprintf("%s-%s-%s.json", result.InstanceType, benchmarkSuite, timestamp)
-	
-	// Store locally
-	localDir := filepath.Join("results", result.StartTime.UTC().Format("2006-01-02"))
-	if err := os.MkdirAll(localDir, 0755); err != nil {
-		return fmt.Errorf("failed to create local directory: %w", err)
+	// Keep a local copy next to S3 results. A --results-dir store already
+	// writes local files, and queries of it would find both copies
+	if _, local := store.(*storage.LocalStorage); !local {
+		// Generate filename with region and timestamp, so runs of the same
+		// instance type in several regions do not overwrite each other
+		timestamp := result.StartTime.UTC().Format("20060102-150405")
+		filename := fmt.Sprintf("%s-%s-%s-%s.json", result.InstanceType, benchmarkSuite, region, timestamp)
+		
+		localDir := filepath.Join("results", result.StartTime.UTC().Format("2006-01-02"))
+		if err := os.MkdirAll(localDir, 0755); err != nil {
+			return fmt.Errorf("failed to create local directory: %w", err)
+		}
+		
+		localPath := filepath.Join(localDir, filename)
+		if err := os.WriteFile(localPath, jsonData, 0644); err != nil {
+			return fmt.Errorf("failed to write local file: %w", err)
+		}
+		fmt.Printf("   Local:  %s\n", localPath)
 	}
-	
-	localPath := filepath.Join(localDir, filename)
-	if err := os.WriteFile(localPath, jsonData, 0644); err != nil {
-		return fmt.Errorf("failed to write local file: %w", err)
+
+	// Archive the raw output before the result, so every stored result can
+	// be re-parsed with fixed parsers
+	if len(result.RawOutputs) > 0 {
+		if _, err := store.ArchiveRawOutput(ctx, resultData, awspkg.NewRawOutput(benchmarkSuite, result.RawOutputs)); err != nil {
+			return fmt.Errorf("failed to archive raw output: %w", err)
+		}
 	}
 
-	// Store to S3
-	if err := s3Storage.StoreResult(ctx, resultData); err != nil {
-		return fmt.Errorf("failed to store to S3: %w", err)
+	// Store to S3 or the --results-dir directory
+	if err := store.StoreResult(ctx, resultData); err != nil {
+		var duplicate *storage.DuplicateResultError
+		if errors.As(err, &duplicate) {
+			fmt.Printf("   Store:  Already stored as %s\n", duplicate.Key)
+			return nil
+		}
+		return fmt.Errorf("failed to store to result store: %w", err)
 	}
 
-	fmt.Printf("   Local:  %s\n", localPath)
-	fmt.Printf("   S3:     Stored to S3 with structured key\n")
+	fmt.Printf("   Store:  Stored with structured key\n")
 	
 	return nil
 }
@@ -1482,34 +2366,36 @@ func getCompilerOptimizations(instanceType string) string {
 	}
 }
 
-func calculateQualityScore(benchmarkData interface{}) float64 {
+func calculateQualityScore(benchmarkData *awspkg.BenchmarkResults) float64 {
 	// Default quality score for successful benchmarks
 	if benchmarkData == nil {
 		return 0.5
 	}
 	
-	if data, ok := benchmarkData.(map[string]interface{}); ok {
-		// Check if this is STREAM data
-		if _, hasTriad := data["triad_bandwidth"]; hasTriad {
-			return calculateSTREAMQualityScore(data)
-		}
-		
-		// Check if this is HPL data
-		if _, hasGFLOPS := data["gflops"]; hasGFLOPS {
-			return calculateHPLQualityScore(data)
-		}
+	if benchmarkData.STREAM != nil {
+		return calculateSTREAMQualityScore(benchmarkData.STREAM)
+	}
+	
+	if benchmarkData.HPL != nil {
+		return calculateHPLQualityScore(benchmarkData.HPL)
 	}
 	
 	return 0.7 // Default score for other benchmark types
 }
 
 func performStatisticalAnalysis(allResults []benchmarkResult, iterations int) {
-	// Group results by instance type and benchmark suite
-	grouped := make(map[string][]benchmarkResult)
+	// Group results by region, instance type and benchmark suite so that
+	// regional variance is reported rather than averaged away
+	type groupKey struct {
+		region         string
+		instanceType   string
+		benchmarkSuite string
+	}
+	grouped := make(map[groupKey][]benchmarkResult)
 	
 	for _, result := range allResults {
 		if result.success {
-			key := fmt.Sprintf("%s-%s", result.instanceType, result.benchmarkSuite)
+			key := groupKey{result.region, result.instanceType, result.benchmarkSuite}
 			grouped[key] = append(grouped[key], result)
 		}
 	}
@@ -1520,15 +2406,11 @@ func performStatisticalAnalysis(allResults []benchmarkResult, iterations int) {
 			continue // Need at least 2 results for statistical analysis
 		}
 		
-		parts := strings.Split(key, "-")
-		instanceType := parts[0]
-		benchmarkSuite := parts[1]
+		fmt.Printf("\n   %s on %s in %s (%d successful runs):\n", key.benchmarkSuite, key.instanceType, key.region, len(results))
 		
-		fmt.Printf("\n   %s on %s (%d successful runs):\n", benchmarkSuite, instanceType, len(results))
-		
-		if benchmarkSuite == "stream" {
+		if key.benchmarkSuite == "stream" {
 			analyzeSTREAMResults(results)
-		} else if benchmarkSuite == "hpl" {
+		} else if key.benchmarkSuite == "hpl" {
 			analyzeHPLResults(results)
 		}
 	}
@@ -1542,76 +2424,24 @@ func analyzeSTREAMResults(results []benchmarkResult) {
 	
 	// Extract bandwidth values
 	for _, result := range results {
-		if result.result != nil && result.result.BenchmarkData != nil {
-			data := result.result.BenchmarkData
-			
-			// Check for nested STREAM data structure
-			if streamData, exists := data["stream"]; exists {
-				if streamMap, ok := streamData.(map[string]interface{}); ok {
-					// Extract triad bandwidth
-					if triad, exists := streamMap["triad"]; exists {
-						if triadMap, ok := triad.(map[string]interface{}); ok {
-							if bandwidth, exists := triadMap["bandwidth"]; exists {
-								if floatVal, ok := bandwidth.(float64); ok {
-									triadValues = append(triadValues, floatVal)
-								}
-							}
-						}
-					}
-					// Extract copy bandwidth  
-					if copy, exists := streamMap["copy"]; exists {
-						if copyMap, ok := copy.(map[string]interface{}); ok {
-							if bandwidth, exists := copyMap["bandwidth"]; exists {
-								if floatVal, ok := bandwidth.(float64); ok {
-									copyValues = append(copyValues, floatVal)
-								}
-							}
-						}
-					}
-					// Extract scale bandwidth
-					if scale, exists := streamMap["scale"]; exists {
-						if scaleMap, ok := scale.(map[string]interface{}); ok {
-							if bandwidth, exists := scaleMap["bandwidth"]; exists {
-								if floatVal, ok := bandwidth.(float64); ok {
-									scaleValues = append(scaleValues, floatVal)
-								}
-							}
-						}
-					}
-					// Extract add bandwidth
-					if add, exists := streamMap["add"]; exists {
-						if addMap, ok := add.(map[string]interface{}); ok {
-							if bandwidth, exists := addMap["bandwidth"]; exists {
-								if floatVal, ok := bandwidth.(float64); ok {
-									addValues = append(addValues, floatVal)
-								}
-							}
-						}
-					}
-				}
-			}
-			
-			// Also check for flat structure (legacy support)
-			if val, exists := data["triad_bandwidth"]; exists {
-				if floatVal, ok := val.(float64); ok {
-					triadValues = append(triadValues, floatVal)
-				}
-			}
-			if val, exists := data["copy_bandwidth"]; exists {
-				if floatVal, ok := val.(float64); ok {
-					copyValues = append(copyValues, floatVal)
-				}
-			}
-			if val, exists := data["scale_bandwidth"]; exists {
-				if floatVal, ok := val.(float64); ok {
-					scaleValues = append(scaleValues, floatVal)
-				}
-			}
-			if val, exists := data["add_bandwidth"]; exists {
-				if floatVal, ok := val.(float64); ok {
-					addValues = append(addValues, floatVal)
-				}
-			}
+		if result.result == nil || result.result.BenchmarkData == nil {
+			continue
+		}
+		stream := result.result.BenchmarkData.STREAM
+		if stream == nil {
+			continue
+		}
+		if bw := stream.Bandwidth("triad"); bw > 0 {
+			triadValues = append(triadValues, bw)
+		}
+		if bw := stream.Bandwidth("copy"); bw > 0 {
+			copyValues = append(copyValues, bw)
+		}
+		if bw := stream.Bandwidth("scale"); bw > 0 {
+			scaleValues = append(scaleValues, bw)
+		}
+		if bw := stream.Bandwidth("add"); bw > 0 {
+			addValues = append(addValues, bw)
 		}
 	}
 	
@@ -1652,23 +2482,21 @@ func analyzeHPLResults(results []benchmarkResult) {
 	
 	// Extract performance values
 	for _, result := range results {
-		if result.result != nil && result.result.BenchmarkData != nil {
-			data := result.result.BenchmarkData
-			if val, exists := data["gflops"]; exists {
-				if floatVal, ok := val.(float64); ok {
-					gflopsValues = append(gflopsValues, floatVal)
-				}
-			}
-			if val, exists := data["efficiency"]; exists {
-				if floatVal, ok := val.(float64); ok {
-					efficiencyValues = append(efficiencyValues, floatVal)
-				}
-			}
-			if val, exists := data["execution_time"]; exists {
-				if floatVal, ok := val.(float64); ok {
-					executionTimeValues = append(executionTimeValues, floatVal)
-				}
-			}
+		if result.result == nil || result.result.BenchmarkData == nil {
+			continue
+		}
+		hpl := result.result.BenchmarkData.HPL
+		if hpl == nil {
+			continue
+		}
+		if hpl.GFLOPS > 0 {
+			gflopsValues = append(gflopsValues, hpl.GFLOPS)
+		}
+		if hpl.Efficiency > 0 {
+			efficiencyValues = append(efficiencyValues, hpl.Efficiency)
+		}
+		if hpl.ExecutionTime > 0 {
+			executionTimeValues = append(executionTimeValues, hpl.ExecutionTime)
 		}
 	}
 	
@@ -1755,15 +2583,13 @@ func calculateConfidenceInterval(values []float64, confidence float64) confidenc
 	}
 }
 
-func calculateSTREAMQualityScore(streamData map[string]interface{}) float64 {
+func calculateSTREAMQualityScore(streamData *awspkg.STREAMResult) float64 {
 	var bandwidths []float64
 	
 	// Collect bandwidth values
-	for _, key := range []string{"copy_bandwidth", "scale_bandwidth", "add_bandwidth", "triad_bandwidth"} {
-		if val, exists := streamData[key]; exists {
-			if floatVal, ok := val.(float64); ok && floatVal > 0 {
-				bandwidths = append(bandwidths, floatVal)
-			}
+	for _, operation := range []string{"copy", "scale", "add", "triad"} {
+		if bw := streamData.Bandwidth(operation); bw > 0 {
+			bandwidths = append(bandwidths, bw)
 		}
 	}
 	
@@ -1802,28 +2628,24 @@ func calculateSTREAMQualityScore(streamData map[string]interface{}) float64 {
 	return qualityScore
 }
 
-func calculateHPLQualityScore(hplData map[string]interface{}) float64 {
+func calculateHPLQualityScore(hplData *awspkg.HPLResult) float64 {
 	qualityScore := 1.0
 	
 	// Check efficiency
-	if effVal, exists := hplData["efficiency"]; exists {
-		if efficiency, ok := effVal.(float64); ok {
-			if efficiency < 0.5 {
-				qualityScore -= 0.4 // Penalize low efficiency heavily
-			} else if efficiency < 0.7 {
-				qualityScore -= 0.2 // Moderate penalty
-			}
+	if efficiency := hplData.Efficiency; efficiency > 0 {
+		if efficiency < 0.5 {
+			qualityScore -= 0.4 // Penalize low efficiency heavily
+		} else if efficiency < 0.7 {
+			qualityScore -= 0.2 // Moderate penalty
 		}
 	}
 	
 	// Check residual (numerical accuracy)
-	if residualVal, exists := hplData["residual"]; exists {
-		if residual, ok := residualVal.(float64); ok {
-			if residual > 1e-6 {
-				qualityScore -= 0.3 // Penalize poor numerical accuracy
-			} else if residual > 1e-9 {
-				qualityScore -= 0.1 // Small penalty for moderate accuracy
-			}
+	if residual := hplData.Residual; residual > 0 {
+		if residual > 1e-6 {
+			qualityScore -= 0.3 // Penalize poor numerical accuracy
+		} else if residual > 1e-9 {
+			qualityScore -= 0.1 // Small penalty for moderate accuracy
 		}
 	}
 	
@@ -1843,16 +2665,32 @@ func getContainerImageForInstance(instanceType, benchmarkSuite string) string {
 	return fmt.Sprintf("public.ecr.aws/aws-benchmarks/%s:%s", benchmarkSuite, containerTag)
 }
 
+// generateMD5Checksum returns the MD5 of the canonical JSON form of
+// benchmark data, as hashed by storage.ContentHash.
 func generateMD5Checksum(data interface{}) string {
-	// For simplicity, return a placeholder checksum
-	// In production, this should generate actual MD5 from the data
-	return "d41d8cd98f00b204e9800998ecf8427e"
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	var canonical interface{}
+	if err := json.Unmarshal(encoded, &canonical); err != nil {
+		return ""
+	}
+	if encoded, err = json.Marshal(canonical); err != nil {
+		return ""
+	}
+	sum := md5.Sum(encoded)
+	return hex.EncodeToString(sum[:])
 }
 
+// generateSHA256Checksum returns the content hash of benchmark data, which
+// the verify command checks stored results against.
 func generateSHA256Checksum(data interface{}) string {
-	// For simplicity, return a placeholder checksum
-	// In production, this should generate actual SHA256 from the data
-	return "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	hash, err := storage.ContentHash(data)
+	if err != nil {
+		return ""
+	}
+	return hash
 }
 
 func runSchemaValidate(cmd *cobra.Command, args []string) error {
@@ -2083,21 +2921,35 @@ func extractVersionFromFile(data map[string]interface{}) (schema.SchemaVersion,
 }
 
 func runAnalyze(cmd *cobra.Command, args []string) error {
-	resultsDir := args[0]
 	baselineInstance, _ := cmd.Flags().GetString("baseline")
 	outputFormat, _ := cmd.Flags().GetString("format")
 	sortByMetric, _ := cmd.Flags().GetString("sort")
+	warehousePath, _ := cmd.Flags().GetString("warehouse")
 
 	ctx := context.Background()
 
-	fmt.Printf("📊 Analyzing benchmark results in: %s\n", resultsDir)
+	// A results directory is ingested into a throwaway warehouse; without
+	// one the warehouse built by the ingest command is queried
+	var resultsWarehouse *warehouse.Warehouse
+	if len(args) > 0 {
+		fmt.Printf("📊 Analyzing benchmark results in: %s\n", args[0])
+		resultsWarehouse = warehouse.New()
+		store := storage.NewLocalStorage(args[0], storage.Config{KeyPrefix: "instance-benchmarks/"})
+		if _, err := resultsWarehouse.Ingest(ctx, store, storage.ResultQuery{OnSkip: warnSkippedResult}); err != nil {
+			return fmt.Errorf("failed to load results: %w", err)
+		}
+	} else {
+		fmt.Printf("📊 Analyzing benchmark results in warehouse: %s\n", warehousePath)
+		var err error
+		resultsWarehouse, err = warehouse.Open(warehousePath)
+		if err != nil {
+			return fmt.Errorf("failed to open warehouse: %w", err)
+		}
+	}
 	fmt.Printf("📏 Using baseline: %s\n", baselineInstance)
 
 	// Load all benchmark results
-	results, err := loadBenchmarkResults(resultsDir)
-	if err != nil {
-		return fmt.Errorf("failed to load results: %w", err)
-	}
+	results := loadBenchmarkResults(resultsWarehouse)
 
 	if len(results) == 0 {
 		fmt.Println("❌ No benchmark results found")
@@ -2129,132 +2981,182 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	return displayAnalysisResults(analysisResults, outputFormat)
 }
 
-func loadBenchmarkResults(resultsDir string) ([]benchmarkFileResult, error) {
-	var results []benchmarkFileResult
-
-	err := filepath.Walk(resultsDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		if !strings.HasSuffix(path, ".json") {
-			return nil
-		}
-
-		data, err := os.ReadFile(path)
-		if err != nil {
-			fmt.Printf("⚠️  Failed to read %s: %v\n", path, err)
-			return nil
-		}
-
-		var rawData map[string]interface{}
-		if err := json.Unmarshal(data, &rawData); err != nil {
-			fmt.Printf("⚠️  Failed to parse %s: %v\n", path, err)
-			return nil
-		}
-
-		result := extractBenchmarkData(rawData, path)
-		if result != nil {
-			results = append(results, *result)
-		}
-
-		return nil
-	})
+// runIngest normalizes the results in a directory or storage bucket into
+// the results warehouse.
+func runIngest(cmd *cobra.Command, args []string) error {
+	warehousePath, _ := cmd.Flags().GetString("warehouse")
+	bucket, _ := cmd.Flags().GetString("storage-bucket")
+	region, _ := cmd.Flags().GetString("region")
 
-	return results, err
-}
+	ctx := context.Background()
 
-type benchmarkFileResult struct {
-	FilePath     string
-	InstanceType string
-	Region       string
-	Timestamp    string
-	Metrics      *pricing.PerformanceMetrics
-}
+	store, err := openResultStore(ctx, args, bucket, region, "📥 Ingesting")
+	if err != nil {
+		return err
+	}
+	defer store.Close()
 
-func extractBenchmarkData(data map[string]interface{}, filePath string) *benchmarkFileResult {
-	// Extract metadata
-	metadata, _ := data["metadata"].(map[string]interface{})
-	performanceData, _ := data["performance_data"].(map[string]interface{})
+	resultsWarehouse, err := warehouse.Open(warehousePath)
+	if err != nil {
+		return fmt.Errorf("failed to open warehouse: %w", err)
+	}
+	report, err := resultsWarehouse.Ingest(ctx, store, storage.ResultQuery{OnSkip: warnSkippedResult})
+	if err != nil {
+		return fmt.Errorf("failed to ingest results: %w", err)
+	}
+	if err := resultsWarehouse.Save(); err != nil {
+		return fmt.Errorf("failed to save warehouse: %w", err)
+	}
 
-	if metadata == nil && performanceData == nil {
-		return nil
+	fmt.Printf("✅ Ingested %d results (%d rows) into %s\n", report.Results, report.Rows, warehousePath)
+	if report.Replaced > 0 {
+		fmt.Printf("   Replaced rows of %d previously ingested results\n", report.Replaced)
+	}
+	if len(report.Skipped) > 0 {
+		fmt.Printf("   Skipped %d documents without benchmark results\n", len(report.Skipped))
+	}
+	if len(report.Duplicates) > 0 {
+		fmt.Printf("   Skipped %d duplicate copies of results\n", len(report.Duplicates))
 	}
+	fmt.Printf("   Warehouse now holds %d rows\n", resultsWarehouse.Len())
+	return nil
+}
 
-	// Get instance type
-	instanceType := extractStringValue(metadata, "instance_type")
-	if instanceType == "" {
-		instanceType = extractStringValue(metadata, "instanceType")
+// openResultStore opens the result store named by a results directory
+// argument or a storage bucket, announcing it with the action taken.
+func openResultStore(ctx context.Context, args []string, bucket, region, action string) (storage.ResultStore, error) {
+	storageConfig := storage.Config{
+		BucketName:    bucket,
+		KeyPrefix:     "instance-benchmarks/",
+		RetryAttempts: 3,
 	}
-	if instanceType == "" {
-		// Try to extract from filename
-		parts := strings.Split(filepath.Base(filePath), "-")
-		if len(parts) >= 2 {
-			instanceType = parts[0]
+	switch {
+	case len(args) > 0 && bucket != "":
+		return nil, fmt.Errorf("specify either a results directory or --storage-bucket, not both")
+	case len(args) > 0:
+		fmt.Fprintf(os.Stderr, "%s results from: %s\n", action, args[0])
+		return storage.NewLocalStorage(args[0], storageConfig), nil
+	case bucket != "":
+		fmt.Fprintf(os.Stderr, "%s results from bucket: %s\n", action, bucket)
+		s3Storage, err := storage.NewS3Storage(ctx, storageConfig, region)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize S3 storage: %w", err)
 		}
+		return s3Storage, nil
+	default:
+		return nil, fmt.Errorf("a results directory or --storage-bucket is required")
 	}
+}
 
-	// Get region
-	region := extractStringValue(metadata, "region")
-	if region == "" {
-		region = "us-east-1" // Default
-	}
+// runVerify re-hashes stored results and reports those whose content no
+// longer matches the hashes recorded when they were stored.
+func runVerify(cmd *cobra.Command, args []string) error {
+	bucket, _ := cmd.Flags().GetString("storage-bucket")
+	region, _ := cmd.Flags().GetString("region")
+	asJSON, _ := cmd.Flags().GetBool("json")
 
-	// Get timestamp
-	timestamp := extractStringValue(metadata, "timestamp")
+	ctx := context.Background()
 
-	// Extract STREAM performance data
-	streamData, _ := performanceData["stream"].(map[string]interface{})
-	if streamData == nil {
-		return nil
+	store, err := openResultStore(ctx, args, bucket, region, "🔐 Verifying")
+	if err != nil {
+		return err
 	}
+	defer store.Close()
 
-	metrics := &pricing.PerformanceMetrics{
-		TriadBandwidth: extractBandwidthValue(streamData, "triad"),
-		CopyBandwidth:  extractBandwidthValue(streamData, "copy"),
-		ScaleBandwidth: extractBandwidthValue(streamData, "scale"),
-		AddBandwidth:   extractBandwidthValue(streamData, "add"),
+	verified, err := store.VerifyResults(ctx, storage.ResultQuery{})
+	if err != nil {
+		return fmt.Errorf("failed to verify results: %w", err)
 	}
 
-	// Skip if no valid metrics
-	if metrics.TriadBandwidth == 0 {
-		return nil
+	counts := make(map[storage.VerificationStatus]int)
+	var failed []storage.VerifiedResult
+	for _, result := range verified {
+		counts[result.Status]++
+		if result.Status == storage.VerificationTampered || result.Status == storage.VerificationCorrupt {
+			failed = append(failed, result)
+		}
 	}
 
-	return &benchmarkFileResult{
-		FilePath:     filePath,
-		InstanceType: instanceType,
-		Region:       region,
-		Timestamp:    timestamp,
-		Metrics:      metrics,
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(verified); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("\n📊 Verified %d results:\n", len(verified))
+		fmt.Printf("   OK: %d\n", counts[storage.VerificationOK])
+		fmt.Printf("   Tampered: %d\n", counts[storage.VerificationTampered])
+		fmt.Printf("   Corrupt: %d\n", counts[storage.VerificationCorrupt])
+		fmt.Printf("   Unverified (no recorded hash): %d\n", counts[storage.VerificationUnverified])
+		for _, result := range failed {
+			fmt.Printf("❌ %s: %s (%s)\n", result.Status, result.Key, result.Detail)
+			if result.ExpectedHash != "" {
+				fmt.Printf("   expected %s, got %s\n", result.ExpectedHash, result.ActualHash)
+			}
+		}
 	}
-}
 
-func extractStringValue(data map[string]interface{}, key string) string {
-	if data == nil {
-		return ""
-	}
-	if val, ok := data[key].(string); ok {
-		return val
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d results failed verification", len(failed), len(verified))
 	}
-	return ""
+	return nil
 }
 
-func extractBandwidthValue(streamData map[string]interface{}, test string) float64 {
-	if streamData == nil {
-		return 0
-	}
+// loadBenchmarkResults returns the STREAM bandwidth of every result in the
+// warehouse that measured the triad kernel.
+func loadBenchmarkResults(resultsWarehouse *warehouse.Warehouse) []benchmarkFileResult {
+	var results []benchmarkFileResult
+	index := make(map[string]int)
+	for _, row := range resultsWarehouse.Query(warehouse.Filter{Suites: []string{"stream"}}) {
+		i, ok := index[row.ResultKey]
+		if !ok {
+			result := benchmarkFileResult{
+				FilePath:     row.ResultKey,
+				InstanceType: row.InstanceType,
+				Region:       row.Region,
+				Metrics:      &pricing.PerformanceMetrics{},
+			}
+			if result.Region == "" {
+				result.Region = "us-east-1" // Default
+			}
+			if !row.Timestamp.IsZero() {
+				result.Timestamp = row.Timestamp.Format(time.RFC3339)
+			}
+			i = len(results)
+			index[row.ResultKey] = i
+			results = append(results, result)
+		}
 
-	testData, ok := streamData[test].(map[string]interface{})
-	if !ok {
-		return 0
+		metrics := results[i].Metrics
+		switch row.Metric {
+		case "triad.bandwidth":
+			metrics.TriadBandwidth = row.Value
+		case "copy.bandwidth":
+			metrics.CopyBandwidth = row.Value
+		case "scale.bandwidth":
+			metrics.ScaleBandwidth = row.Value
+		case "add.bandwidth":
+			metrics.AddBandwidth = row.Value
+		}
 	}
 
-	if bandwidth, ok := testData["bandwidth"].(float64); ok {
-		return bandwidth
+	// Skip results without valid metrics
+	valid := results[:0]
+	for _, result := range results {
+		if result.Metrics.TriadBandwidth > 0 {
+			valid = append(valid, result)
+		}
 	}
+	return valid
+}
 
-	return 0
+type benchmarkFileResult struct {
+	FilePath     string
+	InstanceType string
+	Region       string
+	Timestamp    string
+	Metrics      *pricing.PerformanceMetrics
 }
 
 func setupBaseline(ctx context.Context, baselineInstance string, results []benchmarkFileResult) (*pricing.PricePerformanceMetrics, error) {
@@ -2423,9 +3325,10 @@ func runDailyProcessing(cmd *cobra.Command, _ []string) error {
 	commitToGit, _ := cmd.Flags().GetBool("commit-to-git")
 	branchPrefix, _ := cmd.Flags().GetString("branch-prefix")
 	qualityThreshold, _ := cmd.Flags().GetFloat64("quality-threshold")
+	resultsDir, _ := cmd.Flags().GetString("results-dir")
 	
-	if s3Bucket == "" {
-		return fmt.Errorf("--s3-bucket is required")
+	if s3Bucket == "" && resultsDir == "" {
+		return fmt.Errorf("--storage-bucket or --results-dir is required")
 	}
 	
 	parsedDate, err := time.Parse("2006-01-02", processDate)
@@ -2434,23 +3337,34 @@ func runDailyProcessing(cmd *cobra.Command, _ []string) error {
 	}
 	
 	fmt.Printf("📊 Processing benchmark data for %s\n", processDate)
-	fmt.Printf("📁 S3 Bucket: %s\n", s3Bucket)
+	if resultsDir != "" {
+		fmt.Printf("📁 Results Directory: %s\n", resultsDir)
+	} else {
+		fmt.Printf("📁 S3 Bucket: %s\n", s3Bucket)
+	}
 	fmt.Printf("🎯 Quality Threshold: %.2f\n", qualityThreshold)
 	
-	// Initialize S3 storage for reading raw results
+	// Initialize S3 or local storage for reading raw results
 	storageConfig := storage.Config{
 		BucketName:    s3Bucket,
 		KeyPrefix:     "instance-benchmarks/",
 		RetryAttempts: 3,
 	}
-	s3Storage, err := storage.NewS3Storage(ctx, storageConfig, "us-east-1")
-	if err != nil {
-		return fmt.Errorf("failed to initialize S3 storage: %w", err)
+	var store storage.ResultStore
+	if resultsDir != "" {
+		store = storage.NewLocalStorage(resultsDir, storageConfig)
+	} else {
+		s3Storage, err := storage.NewS3Storage(ctx, storageConfig, "us-east-1")
+		if err != nil {
+			return fmt.Errorf("failed to initialize S3 storage: %w", err)
+		}
+		store = s3Storage
 	}
+	defer store.Close()
 	
-	// Retrieve raw results from S3 for the specified date
-	fmt.Printf("🔍 Retrieving raw results from S3...\n")
-	rawResults, err := retrieveRawResults(ctx, s3Storage, parsedDate)
+	// Retrieve raw results for the specified date
+	fmt.Printf("🔍 Retrieving raw results...\n")
+	rawResults, err := retrieveRawResults(ctx, store, parsedDate)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve raw results: %w", err)
 	}
@@ -2501,12 +3415,21 @@ func runAggregateProcessing(cmd *cobra.Command, _ []string) error {
 	regenerateArchitectures, _ := cmd.Flags().GetBool("regenerate-architectures")
 	regenerateIndices, _ := cmd.Flags().GetBool("regenerate-indices")
 	outputDir, _ := cmd.Flags().GetString("output-dir")
+	warehousePath, _ := cmd.Flags().GetString("warehouse")
 	
 	fmt.Printf("🔄 Generating aggregated summaries...\n")
 	fmt.Printf("📁 Output Directory: %s\n", outputDir)
 	
+	resultsWarehouse, err := warehouse.Open(warehousePath)
+	if err != nil {
+		return fmt.Errorf("failed to open warehouse: %w", err)
+	}
+	if resultsWarehouse.Len() == 0 {
+		return fmt.Errorf("warehouse %s is empty; run the ingest command first", warehousePath)
+	}
+	
 	processor := &AggregateProcessor{
-		InputDir:  "data/statistical",
+		Warehouse: resultsWarehouse,
 		OutputDir: outputDir,
 	}
 	
@@ -2917,6 +3840,11 @@ func (ce *CustomBenchmarkExecutor) ExecuteBenchmark(ctx context.Context, job *sc
 		MaxRetries:      3,
 		Timeout:         10 * time.Minute,
 	}
+	if job.PreferSpotInstance {
+		// Spot is a cost optimization; never lose the measurement to it
+		config.PurchaseOption = awspkg.PurchaseSpot
+		config.SpotFallbackOnDemand = true
+	}
 	
 	// Execute benchmark using existing orchestrator
 	result, err := ce.executor.orchestrator.RunBenchmark(ctx, config)
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	awspkg "github.com/scttfrdmn/aws-instance-benchmarks/pkg/aws"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/storage"
+)
+
+// chdir changes the working directory for the rest of the test.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	previous, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(previous) })
+}
+
+func TestStoreResultsToResultsDir(t *testing.T) {
+	chdir(t, t.TempDir())
+	ctx := context.Background()
+	store := storage.NewLocalStorage("results", storage.Config{KeyPrefix: "instance-benchmarks/"})
+
+	start := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	result := &awspkg.InstanceResult{
+		InstanceID:   "i-0123456789abcdef0",
+		InstanceType: "m7i.large",
+		Status:       "completed",
+		StartTime:    start,
+		EndTime:      start.Add(10 * time.Minute),
+		BenchmarkData: &awspkg.BenchmarkResults{
+			STREAM: &awspkg.STREAMResult{Triad: &awspkg.StreamOperation{Bandwidth: 42.5, Unit: "GB/s"}},
+		},
+	}
+	if err := storeResults(ctx, store, result, "stream", "us-east-1"); err != nil {
+		t.Fatalf("storeResults failed: %v", err)
+	}
+
+	results, err := store.GetResults(ctx, storage.ResultQuery{})
+	if err != nil {
+		t.Fatalf("GetResults failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Expected the result once in the results directory, got %d copies", len(results))
+	}
+}
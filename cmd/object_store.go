@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	awspkg "github.com/scttfrdmn/aws-instance-benchmarks/pkg/aws"
+)
+
+// objectStoreS3 is the --object-store value that tracks async jobs in S3
+// or, with --s3-endpoint, an S3-compatible service.
+const objectStoreS3 = "s3"
+
+// asyncObjectStore returns the object store async jobs are tracked in.
+// location is "s3" or a local directory; endpoint points S3 tracking at an
+// S3-compatible service such as MinIO instead of AWS S3.
+func asyncObjectStore(region, location, endpoint string) (awspkg.ObjectStore, error) {
+	if location != "" && location != objectStoreS3 {
+		if endpoint != "" {
+			return nil, fmt.Errorf("--s3-endpoint cannot be used with the local object store %s", location)
+		}
+		return awspkg.NewLocalObjectStore(location)
+	}
+
+	cfg, err := awspkg.LoadAWSConfig(region)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint != "" {
+		return awspkg.NewS3CompatibleObjectStore(cfg, endpoint), nil
+	}
+	return awspkg.NewS3ObjectStore(s3.NewFromConfig(cfg)), nil
+}
+
+// newAsyncLauncher creates a launcher that tracks jobs in the object store
+// selected by the --object-store and --s3-endpoint flags.
+func newAsyncLauncher(region, location, endpoint string) (*awspkg.AsyncLauncher, error) {
+	store, err := asyncObjectStore(region, location, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	orchestrator, err := awspkg.NewOrchestrator(region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create orchestrator: %w", err)
+	}
+	return awspkg.NewAsyncLauncherWithStore(orchestrator, store), nil
+}
+
+// newAsyncCollector creates a collector that reads jobs from the object
+// store selected by the --object-store and --s3-endpoint flags.
+func newAsyncCollector(region, location, endpoint string) (*awspkg.AsyncCollector, error) {
+	store, err := asyncObjectStore(region, location, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	orchestrator, err := awspkg.NewOrchestrator(region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create orchestrator: %w", err)
+	}
+	return awspkg.NewAsyncCollectorWithStore(orchestrator, store), nil
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	awspkg "github.com/scttfrdmn/aws-instance-benchmarks/pkg/aws"
+)
+
+func TestAsyncObjectStoreLocalDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "jobs")
+
+	store, err := asyncObjectStore("us-east-1", dir, "")
+	if err != nil {
+		t.Fatalf("asyncObjectStore failed: %v", err)
+	}
+	if _, ok := store.(*awspkg.LocalObjectStore); !ok {
+		t.Fatalf("expected a local object store, got %T", store)
+	}
+
+	if _, err := asyncObjectStore("us-east-1", dir, "http://minio.internal:9000"); err == nil {
+		t.Error("expected an error for a local object store with an S3 endpoint")
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"runtime/debug"
+
+	awspkg "github.com/scttfrdmn/aws-instance-benchmarks/pkg/aws"
+)
+
+// Build identification, set at link time:
+//   go build -ldflags "-X main.version=v1.2.3 -X main.gitCommit=abc1234" ./cmd
+var (
+	version   = "dev"
+	gitCommit = ""
+)
+
+// buildProvenance returns the provenance of this binary. Without a commit
+// set at link time, the commit recorded by the Go toolchain is used.
+func buildProvenance() awspkg.Provenance {
+	provenance := awspkg.Provenance{OrchestratorVersion: version, GitCommit: gitCommit}
+	if provenance.GitCommit == "" {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			for _, setting := range info.Settings {
+				if setting.Key == "vcs.revision" {
+					provenance.GitCommit = setting.Value
+				}
+			}
+		}
+	}
+	return provenance
+}
+
+// resultProvenance returns the provenance of a result measured with a
+// container image. The image digest is the one the instance reported, if
+// any.
+func resultProvenance(result *awspkg.InstanceResult, image string) awspkg.Provenance {
+	provenance := buildProvenance()
+	provenance.ContainerImage = image
+	provenance.ContainerImageDigest = awspkg.ContainerImageDigest(image, result.ContainerImageDigest)
+	provenance.AMIID = result.AMIID
+	provenance.RawOutputSHA256 = result.RawOutputSHA256
+	return provenance
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// regionTarget holds the region-scoped resources a run launches into.
+// An empty profile uses the default 'aws' profile.
+type regionTarget struct {
+	region        string
+	profile       string
+	keyPair       string
+	securityGroup string
+	subnet        string
+	bucket        string
+}
+
+// regionalValues selects the entries of a region-qualified list that apply
+// to a region. Entries of the form "region=value" apply only to that region;
+// plain entries apply to every region.
+//
+// Example:
+//   regionalValues([]string{"us-east-1=subnet-a", "eu-west-1=subnet-b"}, "eu-west-1")
+//   // returns []string{"subnet-b"}
+func regionalValues(values []string, region string) []string {
+	var selected []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if scope, scoped, found := strings.Cut(value, "="); found {
+			if scope == region {
+				selected = append(selected, scoped)
+			}
+			continue
+		}
+		if value != "" {
+			selected = append(selected, value)
+		}
+	}
+	return selected
+}
+
+// regionalValue resolves a comma-separated, region-qualified flag value
+// such as "us-east-1=subnet-a,eu-west-1=subnet-b" for one region. A value
+// scoped to the region takes precedence over an unscoped default.
+func regionalValue(value, region string) string {
+	var fallback string
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if scope, scoped, found := strings.Cut(entry, "="); found {
+			if scope == region {
+				return scoped
+			}
+			continue
+		}
+		if fallback == "" {
+			fallback = entry
+		}
+	}
+	return fallback
+}
+
+// environmentForRegion finds the config file environment for a region,
+// matching the environment name first and then its configured region.
+func environmentForRegion(config InfrastructureConfig, region string) (*EnvironmentConfig, error) {
+	if env, ok := config.Environments[region]; ok && (env.Region == "" || env.Region == region) {
+		return env, nil
+	}
+	for _, env := range config.Environments {
+		if env.Region == region {
+			return env, nil
+		}
+	}
+	return nil, fmt.Errorf("no environment for region %s in config file", region)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegionalValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		region string
+		want   string
+	}{
+		{name: "scoped value", value: "us-east-1=subnet-a,eu-west-1=subnet-b", region: "eu-west-1", want: "subnet-b"},
+		{name: "scoped value wins over default", value: "subnet-default,eu-west-1=subnet-b", region: "eu-west-1", want: "subnet-b"},
+		{name: "default for other regions", value: "subnet-default, eu-west-1=subnet-b", region: "us-east-1", want: "subnet-default"},
+		{name: "first default", value: "subnet-a,subnet-b", region: "us-east-1", want: "subnet-a"},
+		{name: "no match", value: "us-east-1=subnet-a", region: "eu-west-1", want: ""},
+		{name: "empty", value: "", region: "us-east-1", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := regionalValue(tt.value, tt.region); got != tt.want {
+				t.Errorf("regionalValue(%q, %q) = %q, want %q", tt.value, tt.region, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegionalValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		region string
+		want   []string
+	}{
+		{name: "scoped values", values: []string{"us-east-1=sg-a", "eu-west-1=sg-b", "eu-west-1=sg-c"}, region: "eu-west-1", want: []string{"sg-b", "sg-c"}},
+		{name: "plain values apply everywhere", values: []string{"sg-shared", "us-east-1=sg-a"}, region: "us-east-1", want: []string{"sg-shared", "sg-a"}},
+		{name: "plain values only", values: []string{"sg-shared", " "}, region: "eu-west-1", want: []string{"sg-shared"}},
+		{name: "no match", values: []string{"us-east-1=sg-a"}, region: "eu-west-1", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := regionalValues(tt.values, tt.region); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("regionalValues(%q, %q) = %q, want %q", tt.values, tt.region, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvironmentForRegion(t *testing.T) {
+	config := InfrastructureConfig{
+		Environments: map[string]*EnvironmentConfig{
+			"us-east-1":  {Region: "us-east-1", Profile: "east"},
+			"production": {Region: "eu-west-1", Profile: "europe"},
+			"us-west-2":  {Region: "ap-northeast-1", Profile: "tokyo"},
+		},
+	}
+
+	tests := []struct {
+		region      string
+		wantProfile string
+		wantErr     bool
+	}{
+		{region: "us-east-1", wantProfile: "east"},
+		{region: "eu-west-1", wantProfile: "europe"},
+		{region: "ap-northeast-1", wantProfile: "tokyo"},
+		{region: "us-west-2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			env, err := environmentForRegion(config, tt.region)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got environment %+v", env)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("environmentForRegion failed: %v", err)
+			}
+			if env.Profile != tt.wantProfile {
+				t.Errorf("Profile = %q, want %q", env.Profile, tt.wantProfile)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	awspkg "github.com/scttfrdmn/aws-instance-benchmarks/pkg/aws"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+// runReparse re-runs the current parsers over the raw output archived with
+// stored results and writes the corrected results, stamped with the current
+// parser version, to the output directory.
+func runReparse(cmd *cobra.Command, args []string) error {
+	bucket, _ := cmd.Flags().GetString("storage-bucket")
+	region, _ := cmd.Flags().GetString("region")
+	outputDir, _ := cmd.Flags().GetString("output-dir")
+	suites, _ := cmd.Flags().GetStringSlice("suite")
+	all, _ := cmd.Flags().GetBool("all")
+
+	ctx := context.Background()
+
+	store, err := openResultStore(ctx, args, bucket, region, "🔁 Reparsing")
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	corrected := storage.NewLocalStorage(outputDir, storage.Config{KeyPrefix: "instance-benchmarks/"})
+
+	results := store.QueryResults(ctx, storage.ResultQuery{BenchmarkSuites: suites, OnSkip: warnSkippedResult})
+	defer results.Close()
+
+	var reparsed, current, unarchived, failed int
+	for results.Next() {
+		stored := results.Result()
+		metadata, _ := stored.Data["metadata"].(map[string]interface{})
+		if version, _ := metadata["parser_version"].(string); version == awspkg.ParserVersion && !all {
+			current++
+			continue
+		}
+
+		err := reparseResult(ctx, store, corrected, stored)
+		switch {
+		case errors.Is(err, storage.ErrRawOutputNotFound):
+			unarchived++
+		case err != nil:
+			failed++
+			fmt.Printf("❌ %s: %v\n", stored.Key, err)
+		default:
+			reparsed++
+			fmt.Printf("✅ %s\n", stored.Key)
+		}
+	}
+	if err := results.Err(); err != nil {
+		return fmt.Errorf("failed to query results: %w", err)
+	}
+
+	fmt.Printf("\n📊 Reparse Summary (parser version %s):\n", awspkg.ParserVersion)
+	fmt.Printf("   Reparsed: %d (written to %s)\n", reparsed, outputDir)
+	fmt.Printf("   Already current: %d\n", current)
+	fmt.Printf("   Without archived output: %d\n", unarchived)
+	fmt.Printf("   Failed: %d\n", failed)
+	if skipped := len(results.Skipped()); skipped > 0 {
+		fmt.Printf("   Skipped invalid files: %d\n", skipped)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d results could not be re-parsed", failed)
+	}
+	return nil
+}
+
+// reparseResult re-parses the raw output archived for a stored result and
+// stores the corrected result, with its updated raw output, in corrected.
+// Re-parsing a result twice with the same parsers is not an error.
+func reparseResult(ctx context.Context, store, corrected storage.ResultStore, stored storage.StoredResult) error {
+	var raw awspkg.RawOutput
+	if err := store.LoadRawOutput(ctx, stored.Key, &raw); err != nil {
+		return err
+	}
+	provenance, _ := stored.Data["provenance"].(map[string]interface{})
+	if expected, _ := provenance["raw_output_sha256"].(string); expected != "" && expected != raw.Hash() {
+		return fmt.Errorf("archived raw output does not match raw output hash %s", expected)
+	}
+
+	benchmarkData, reparsed, err := raw.Reparse()
+	if err != nil {
+		return err
+	}
+	resultData, err := correctedResult(stored, benchmarkData, reparsed)
+	if err != nil {
+		return err
+	}
+
+	if _, err := corrected.ArchiveRawOutput(ctx, resultData, reparsed); err != nil {
+		return fmt.Errorf("failed to archive raw output: %w", err)
+	}
+	if err := corrected.StoreResult(ctx, resultData); err != nil && !errors.Is(err, storage.ErrDuplicateResult) {
+		return fmt.Errorf("failed to store corrected result: %w", err)
+	}
+	return nil
+}
+
+// correctedResult returns a copy of a stored result with its benchmark data
+// replaced by re-parsed results, its checksums recomputed and the current
+// parser version stamped on it.
+func correctedResult(stored storage.StoredResult, benchmarkData *awspkg.BenchmarkResults, reparsed *awspkg.RawOutput) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(stored.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy result: %w", err)
+	}
+	var resultData map[string]interface{}
+	if err := json.Unmarshal(encoded, &resultData); err != nil {
+		return nil, fmt.Errorf("failed to copy result: %w", err)
+	}
+
+	// Results hold the data of one category, e.g. "memory", under
+	// "performance", or under "benchmark_data" if collected asynchronously
+	performance, _ := resultData["performance"].(map[string]interface{})
+	switch {
+	case len(performance) == 1:
+		for category := range performance {
+			performance[category] = benchmarkData
+		}
+	case resultData["benchmark_data"] != nil:
+		resultData["benchmark_data"] = benchmarkData
+	default:
+		return nil, fmt.Errorf("result has no single performance category to correct")
+	}
+
+	metadata, ok := resultData["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = map[string]interface{}{}
+		resultData["metadata"] = metadata
+	}
+	metadata["parser_version"] = awspkg.ParserVersion
+	metadata["reparsed_from"] = stored.Key
+
+	validation, ok := resultData["validation"].(map[string]interface{})
+	if !ok {
+		validation = map[string]interface{}{}
+		resultData["validation"] = validation
+	}
+	validation["checksums"] = map[string]interface{}{
+		"md5":    generateMD5Checksum(benchmarkData),
+		"sha256": generateSHA256Checksum(benchmarkData),
+	}
+
+	if provenance, ok := resultData["provenance"].(map[string]interface{}); ok {
+		provenance["raw_output_sha256"] = reparsed.Hash()
+	}
+	return resultData, nil
+}
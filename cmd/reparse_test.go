@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	awspkg "github.com/scttfrdmn/aws-instance-benchmarks/pkg/aws"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/fakecloud"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/storage"
+)
+
+const streamOutput = `-------------------------------------------------------------
+Function    Best Rate MB/s  Avg time     Min time     Max time
+Copy:           45234.2     0.000354     0.000354     0.000355
+Scale:          44876.1     0.000357     0.000356     0.000358
+Add:            42105.3     0.000571     0.000570     0.000572
+Triad:          41932.8     0.000573     0.000572     0.000574
+-------------------------------------------------------------`
+
+func TestReparseAsyncResult(t *testing.T) {
+	ctx := context.Background()
+	cloud := fakecloud.New("us-east-1")
+	orchestrator := awspkg.NewOrchestratorWithClients(cloud.Region(), cloud.EC2(), cloud.SSM())
+	objects := awspkg.NewMemoryObjectStore()
+	results := storage.NewLocalStorage(t.TempDir(), storage.Config{})
+	launcher := awspkg.NewAsyncLauncherWithStore(orchestrator, objects)
+	collector := awspkg.NewAsyncCollectorWithStore(orchestrator, objects).WithResultStore(results)
+
+	job, err := launcher.LaunchSingleBenchmark(ctx, awspkg.BenchmarkConfig{
+		InstanceType:    "m7i.large",
+		BenchmarkSuite:  "stream",
+		Region:          "us-east-1",
+		KeyPairName:     "test-key",
+		SecurityGroupID: "sg-12345678",
+		SubnetID:        "subnet-12345678",
+	}, "results", "reparse", time.Hour)
+	if err != nil {
+		t.Fatalf("LaunchSingleBenchmark failed: %v", err)
+	}
+
+	// The instance side: the benchmark output and the results the script
+	// extracted from it, with a parser bug
+	sentinels := awspkg.NewS3SentinelFiles(job.S3Prefix)
+	for key, body := range map[string]string{
+		sentinels.BenchmarkOutput:  streamOutput,
+		sentinels.BenchmarkResults: `{"benchmark_suite": "stream", "results": {"triad_bandwidth_mbps": 0}}`,
+		sentinels.StatusCompleted:  "COMPLETED",
+	} {
+		if err := objects.PutObject(ctx, job.S3Bucket, key, []byte(body), "text/plain"); err != nil {
+			t.Fatalf("PutObject failed: %v", err)
+		}
+	}
+	if _, err := collector.CheckAllBenchmarks(ctx, "results"); err != nil {
+		t.Fatalf("CheckAllBenchmarks failed: %v", err)
+	}
+	stored, err := results.GetResults(ctx, storage.ResultQuery{})
+	if err != nil || len(stored) != 1 {
+		t.Fatalf("Expected one collected result, got %d (%v)", len(stored), err)
+	}
+
+	corrected := storage.NewLocalStorage(t.TempDir(), storage.Config{})
+	if err := reparseResult(ctx, results, corrected, stored[0].(storage.StoredResult)); err != nil {
+		t.Fatalf("reparseResult failed: %v", err)
+	}
+
+	reparsed, err := corrected.GetResults(ctx, storage.ResultQuery{})
+	if err != nil || len(reparsed) != 1 {
+		t.Fatalf("Expected one corrected result, got %d (%v)", len(reparsed), err)
+	}
+	result := reparsed[0].(storage.StoredResult)
+	metadata, _ := result.Data["metadata"].(map[string]interface{})
+	if metadata["parser_version"] != awspkg.ParserVersion || metadata["reparsed_from"] != stored[0].(storage.StoredResult).Key {
+		t.Errorf("Expected the corrected result to record the reparse, got %v", metadata)
+	}
+	if _, ok := result.Data["performance"]; ok {
+		t.Error("Expected the async document shape to be kept")
+	}
+	benchmarkData, _ := result.Data["benchmark_data"].(map[string]interface{})
+	stream, _ := benchmarkData["stream"].(map[string]interface{})
+	triad, _ := stream["triad"].(map[string]interface{})
+	if bandwidth, _ := triad["bandwidth"].(float64); bandwidth <= 0 {
+		t.Errorf("Expected the re-parsed triad bandwidth, got %v", benchmarkData)
+	}
+	if validation, _ := result.Data["validation"].(map[string]interface{}); validation["checksums"] == nil {
+		t.Errorf("Expected recomputed checksums, got %v", result.Data["validation"])
+	}
+}
@@ -99,7 +99,8 @@ type DataAggregator struct {
 // and analysis operations including grouping dimensions and statistical parameters.
 type AggregationConfig struct {
 	// GroupingDimensions specifies the dimensions for data aggregation.
-	// Common values: ["instance_type"], ["instance_family", "region"], ["benchmark_suite"]
+	// Common values: ["instance_type"], ["instance_family", "region"], ["benchmark_suite"],
+	// ["instance_type", "tenancy"], ["instance_type", "availability_zone"]
 	GroupingDimensions []string
 	
 	// TimeWindow defines the time range for analysis.
@@ -180,6 +181,12 @@ type ResultMetadata struct {
 	// Region is the AWS region where the benchmark was executed.
 	Region string
 	
+	// AvailabilityZone is the zone the benchmark instance ran in.
+	AvailabilityZone string
+	
+	// Tenancy is the instance tenancy ("default", "dedicated", "host").
+	Tenancy string
+	
 	// Timestamp is when the benchmark was executed.
 	Timestamp time.Time
 	
@@ -678,6 +685,10 @@ func (da *DataAggregator) createAggregationKey(metadata ResultMetadata) Aggregat
 			dimensions["benchmark_suite"] = metadata.BenchmarkSuite
 		case "region":
 			dimensions["region"] = metadata.Region
+		case "availability_zone":
+			dimensions["availability_zone"] = metadata.AvailabilityZone
+		case "tenancy":
+			dimensions["tenancy"] = metadata.Tenancy
 		}
 	}
 
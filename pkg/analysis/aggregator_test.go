@@ -193,6 +193,37 @@ func TestCreateAggregationKey(t *testing.T) {
 	}
 }
 
+func TestCreateAggregationKeyByPlacement(t *testing.T) {
+	config := AggregationConfig{
+		GroupingDimensions: []string{"instance_type", "tenancy", "availability_zone"},
+		StatisticalConfig: StatisticalConfig{
+			ConfidenceLevel: 0.95,
+			MinSampleSize:   3,
+		},
+	}
+	aggregator, err := NewDataAggregator(config, NewMockDataSource())
+	if err != nil {
+		t.Fatalf("Failed to create aggregator: %v", err)
+	}
+
+	shared := aggregator.createAggregationKey(ResultMetadata{
+		InstanceType: "m7i.large", Tenancy: "default", AvailabilityZone: "us-east-1a",
+	})
+	dedicated := aggregator.createAggregationKey(ResultMetadata{
+		InstanceType: "m7i.large", Tenancy: "dedicated", AvailabilityZone: "us-east-1a",
+	})
+	otherZone := aggregator.createAggregationKey(ResultMetadata{
+		InstanceType: "m7i.large", Tenancy: "default", AvailabilityZone: "us-east-1b",
+	})
+
+	if dedicated.Dimensions["tenancy"] != "dedicated" || otherZone.Dimensions["availability_zone"] != "us-east-1b" {
+		t.Errorf("Unexpected dimensions: %v, %v", dedicated.Dimensions, otherZone.Dimensions)
+	}
+	if shared.Hash == dedicated.Hash || shared.Hash == otherZone.Hash {
+		t.Error("Expected tenancy and availability zone to split aggregation groups")
+	}
+}
+
 func TestFilterByQuality(t *testing.T) {
 	config := AggregationConfig{
 		GroupingDimensions: []string{"instance_type"},
@@ -0,0 +1,150 @@
+package aws
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// Output prefixes of osInfoCommand.
+const (
+	kernelVersionPrefix = "KERNEL_VERSION="
+	osReleasePrefix     = "OS_RELEASE="
+	imageDigestPrefix   = "IMAGE_DIGEST="
+)
+
+// osInfoCommand prints the kernel release and OS name of an instance, and
+// the digest of every container image it has pulled.
+const osInfoCommand = `echo "` + kernelVersionPrefix + `$(uname -r)"
+. /etc/os-release 2>/dev/null
+echo "` + osReleasePrefix + `${PRETTY_NAME:-unknown}"
+command -v docker >/dev/null 2>&1 && docker images --digests --format '` + imageDigestPrefix + `{{.Repository}}:{{.Tag}} {{.Digest}}' 2>/dev/null || true`
+
+// ubuntuAMIParameter returns Canonical's public SSM parameter holding the
+// current Ubuntu 24.04 LTS AMI for the architecture of an instance type.
+func ubuntuAMIParameter(instanceType string) string {
+	architecture := "amd64"
+	if instanceArchitecture(instanceType) == "arm64" {
+		architecture = "arm64"
+	}
+	return "/aws/service/canonical/ubuntu/server/24.04/stable/current/" + architecture + "/hvm/ebs-gp3/ami-id"
+}
+
+// resolveAMI returns the AMI to launch for a config: AMIID as given, the
+// value of AMIParameter, or the latest AMI of the OS family: Amazon Linux 2
+// or Canonical's Ubuntu 24.04 LTS. It returns an empty ID when a launch
+// template supplies the AMI.
+func (o *Orchestrator) resolveAMI(ctx context.Context, config BenchmarkConfig) (string, error) {
+	if config.AMIID != "" && config.AMIParameter != "" {
+		return "", fmt.Errorf("AMI %s and AMI parameter %s are mutually exclusive", config.AMIID, config.AMIParameter)
+	}
+	if config.LaunchTemplateName == "" && config.LaunchTemplateVersion != "" {
+		return "", fmt.Errorf("launch template version %s requires a launch template name", config.LaunchTemplateVersion)
+	}
+
+	switch {
+	case config.AMIID != "":
+		return config.AMIID, nil
+	case config.AMIParameter != "":
+		return o.parameterAMI(ctx, config.AMIParameter)
+	case config.LaunchTemplateName != "":
+		return "", nil
+	}
+
+	switch config.OSFamily {
+	case "", OSFamilyAmazonLinux:
+		return o.getLatestAMI(ctx, config.InstanceType)
+	case OSFamilyUbuntu:
+		return o.parameterAMI(ctx, ubuntuAMIParameter(config.InstanceType))
+	}
+	return "", fmt.Errorf("%w: no default AMI for OS family %s; set an AMI ID, AMI parameter or launch template", ErrNoSuitableAMI, config.OSFamily)
+}
+
+// parameterAMI returns the AMI ID stored in an SSM parameter.
+func (o *Orchestrator) parameterAMI(ctx context.Context, name string) (string, error) {
+	resp, err := o.ssmClient.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String(name)})
+	if err != nil {
+		return "", fmt.Errorf("failed to read AMI parameter %s: %w", name, err)
+	}
+	if resp.Parameter == nil || aws.ToString(resp.Parameter.Value) == "" {
+		return "", fmt.Errorf("%w: parameter %s is empty", ErrNoSuitableAMI, name)
+	}
+	return aws.ToString(resp.Parameter.Value), nil
+}
+
+// imageID returns the RunInstances ImageId for a resolved AMI, leaving it
+// unset so that a launch template's AMI applies.
+func imageID(amiID string) *string {
+	if amiID == "" {
+		return nil
+	}
+	return aws.String(amiID)
+}
+
+// launchTemplateSpec returns the RunInstances launch template for a config,
+// or nil when none is configured.
+func launchTemplateSpec(config BenchmarkConfig) *types.LaunchTemplateSpecification {
+	if config.LaunchTemplateName == "" {
+		return nil
+	}
+	spec := &types.LaunchTemplateSpecification{LaunchTemplateName: aws.String(config.LaunchTemplateName)}
+	if config.LaunchTemplateVersion != "" {
+		spec.Version = aws.String(config.LaunchTemplateVersion)
+	}
+	return spec
+}
+
+// captureOSInfo records the kernel version and OS release of the instance
+// a result runs on, and the digest image resolved to there.
+func (o *Orchestrator) captureOSInfo(ctx context.Context, result *InstanceResult, image string) error {
+	output, err := o.executeSSMCommand(ctx, result.InstanceID, osInfoCommand)
+	if err != nil {
+		return err
+	}
+	result.KernelVersion, result.OSRelease = parseOSInfo(output)
+	result.ContainerImageDigest = ContainerImageDigest(image, parseImageDigest(output, image))
+	return nil
+}
+
+// parseOSInfo extracts the kernel version and OS release from the output
+// of osInfoCommand.
+func parseOSInfo(output string) (kernelVersion, osRelease string) {
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if value, ok := strings.CutPrefix(line, kernelVersionPrefix); ok {
+			kernelVersion = value
+		} else if value, ok := strings.CutPrefix(line, osReleasePrefix); ok {
+			osRelease = value
+		}
+	}
+	return kernelVersion, osRelease
+}
+
+// parseImageDigest returns the digest of image from the output of
+// osInfoCommand, or "" if the instance has not pulled it. An image without
+// a tag is matched as ":latest".
+func parseImageDigest(output, image string) string {
+	if image == "" {
+		return ""
+	}
+	if !strings.Contains(image[strings.LastIndex(image, "/")+1:], ":") {
+		image += ":latest"
+	}
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		value, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), imageDigestPrefix)
+		if !ok {
+			continue
+		}
+		if ref, digest, ok := strings.Cut(value, " "); ok && ref == image && strings.HasPrefix(digest, "sha256:") {
+			return digest
+		}
+	}
+	return ""
+}
@@ -0,0 +1,154 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/fakecloud"
+)
+
+// newAMICloud returns a cloud whose instances report an Ubuntu kernel and
+// run STREAM.
+func newAMICloud() *fakecloud.Cloud {
+	cloud := fakecloud.New("us-east-1")
+	cloud.HandleCommands(func(instanceID, command string) fakecloud.CommandResult {
+		if command == osInfoCommand {
+			return fakecloud.CommandResult{
+				Status: ssmtypes.CommandInvocationStatusSuccess,
+				Stdout: "KERNEL_VERSION=6.8.0-1021-aws\nOS_RELEASE=Ubuntu 24.04.1 LTS\n",
+			}
+		}
+		return fakecloud.CommandResult{Status: ssmtypes.CommandInvocationStatusSuccess, Stdout: fakeSTREAMOutput}
+	})
+	return cloud
+}
+
+func TestRunBenchmarkWithCustomAMI(t *testing.T) {
+	cloud := newAMICloud()
+	amiID := cloud.AddImage("ubuntu-24.04-benchmarks", types.ArchitectureValuesX8664, "2025-03-01T00:00:00.000Z")
+
+	config := fakeBenchmarkConfig("m7i.large", "stream")
+	config.AMIID = amiID
+	result, err := newFakeOrchestrator(cloud).RunBenchmark(context.Background(), config)
+	if err != nil {
+		t.Fatalf("RunBenchmark failed: %v", err)
+	}
+
+	if result.AMIID != amiID {
+		t.Errorf("AMIID = %q, want %s", result.AMIID, amiID)
+	}
+	if result.KernelVersion != "6.8.0-1021-aws" || result.OSRelease != "Ubuntu 24.04.1 LTS" {
+		t.Errorf("Expected Ubuntu kernel and release, got %q and %q", result.KernelVersion, result.OSRelease)
+	}
+}
+
+func TestRunBenchmarkWithAMIParameter(t *testing.T) {
+	cloud := newAMICloud()
+	amiID := cloud.AddImage("al2023-ami-2023.6", types.ArchitectureValuesX8664, "2025-03-01T00:00:00.000Z")
+	cloud.SetParameter("/aws/service/ami-amazon-linux-latest/al2023-ami-kernel-default-x86_64", amiID)
+
+	config := fakeBenchmarkConfig("m7i.large", "stream")
+	config.AMIParameter = "/aws/service/ami-amazon-linux-latest/al2023-ami-kernel-default-x86_64"
+	result, err := newFakeOrchestrator(cloud).RunBenchmark(context.Background(), config)
+	if err != nil {
+		t.Fatalf("RunBenchmark failed: %v", err)
+	}
+	if result.AMIID != amiID {
+		t.Errorf("AMIID = %q, want %s", result.AMIID, amiID)
+	}
+}
+
+func TestResolveAMIForOSFamily(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	x86 := cloud.AddImage("ubuntu-noble-24.04-amd64", types.ArchitectureValuesX8664, "2025-03-01T00:00:00.000Z")
+	arm := cloud.AddImage("ubuntu-noble-24.04-arm64", types.ArchitectureValuesArm64, "2025-03-01T00:00:00.000Z")
+	cloud.SetParameter("/aws/service/canonical/ubuntu/server/24.04/stable/current/amd64/hvm/ebs-gp3/ami-id", x86)
+	cloud.SetParameter("/aws/service/canonical/ubuntu/server/24.04/stable/current/arm64/hvm/ebs-gp3/ami-id", arm)
+	orchestrator := newFakeOrchestrator(cloud)
+
+	for instanceType, want := range map[string]string{"m7i.large": x86, "c7g.large": arm} {
+		config := fakeBenchmarkConfig(instanceType, "stream")
+		config.OSFamily = OSFamilyUbuntu
+		if amiID, err := orchestrator.resolveAMI(context.Background(), config); err != nil || amiID != want {
+			t.Errorf("%s: resolveAMI = %q, %v; want %s", instanceType, amiID, err, want)
+		}
+	}
+
+	config := fakeBenchmarkConfig("m7i.large", "stream")
+	config.OSFamily = "rhel"
+	if _, err := orchestrator.resolveAMI(context.Background(), config); !errors.Is(err, ErrNoSuitableAMI) {
+		t.Errorf("Expected ErrNoSuitableAMI for an OS family without a default AMI, got %v", err)
+	}
+}
+
+func TestRunBenchmarkWithLaunchTemplate(t *testing.T) {
+	cloud := newAMICloud()
+	first := cloud.AddImage("benchmarks-v1", types.ArchitectureValuesX8664, "2025-01-01T00:00:00.000Z")
+	second := cloud.AddImage("benchmarks-v2", types.ArchitectureValuesX8664, "2025-02-01T00:00:00.000Z")
+	cloud.AddLaunchTemplateVersion("benchmarks", first)
+	cloud.AddLaunchTemplateVersion("benchmarks", second)
+
+	config := fakeBenchmarkConfig("m7i.large", "stream")
+	config.LaunchTemplateName = "benchmarks"
+	config.LaunchTemplateVersion = "$Latest"
+	result, err := newFakeOrchestrator(cloud).RunBenchmark(context.Background(), config)
+	if err != nil {
+		t.Fatalf("RunBenchmark failed: %v", err)
+	}
+	if result.AMIID != second {
+		t.Errorf("AMIID = %q, want the latest template version's %s", result.AMIID, second)
+	}
+	if inst, _ := cloud.Instance(result.InstanceID); inst.LaunchTemplate != "benchmarks" {
+		t.Errorf("Expected launch from template benchmarks, got %q", inst.LaunchTemplate)
+	}
+}
+
+func TestResolveAMIValidation(t *testing.T) {
+	orchestrator := newFakeOrchestrator(fakecloud.New("us-east-1"))
+
+	config := fakeBenchmarkConfig("m7i.large", "stream")
+	config.AMIID = "ami-0123456789abcdef0"
+	config.AMIParameter = "/benchmarks/ami"
+	if _, err := orchestrator.resolveAMI(context.Background(), config); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("Expected mutually exclusive error, got %v", err)
+	}
+
+	config = fakeBenchmarkConfig("m7i.large", "stream")
+	config.AMIParameter = "/benchmarks/missing"
+	if _, err := orchestrator.resolveAMI(context.Background(), config); err == nil {
+		t.Error("Expected error for a missing AMI parameter")
+	}
+}
+
+func TestParseOSInfo(t *testing.T) {
+	kernel, release := parseOSInfo("KERNEL_VERSION=5.10.228-219.884.amzn2.x86_64\r\nOS_RELEASE=Amazon Linux 2\n")
+	if kernel != "5.10.228-219.884.amzn2.x86_64" || release != "Amazon Linux 2" {
+		t.Errorf("parseOSInfo = %q, %q", kernel, release)
+	}
+}
+
+func TestParseImageDigest(t *testing.T) {
+	output := "KERNEL_VERSION=6.8.0-1021-aws\n" +
+		"IMAGE_DIGEST=public.ecr.aws/aws-benchmarks/stream:intel-icelake sha256:1111\n" +
+		"IMAGE_DIGEST=public.ecr.aws/aws-benchmarks/hpl:latest sha256:2222\n" +
+		"IMAGE_DIGEST=localhost:5000/coremark:<none> <none>\n"
+
+	tests := []struct {
+		image, want string
+	}{
+		{"public.ecr.aws/aws-benchmarks/stream:intel-icelake", "sha256:1111"},
+		{"public.ecr.aws/aws-benchmarks/hpl", "sha256:2222"},
+		{"public.ecr.aws/aws-benchmarks/stream:graviton3", ""},
+		{"localhost:5000/coremark", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := parseImageDigest(output, tt.image); got != tt.want {
+			t.Errorf("parseImageDigest(%q) = %q, want %q", tt.image, got, tt.want)
+		}
+	}
+}
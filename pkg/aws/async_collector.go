@@ -3,32 +3,102 @@ package aws
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"strings"
 	"time"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/storage"
 )
 
+// stallDiagnosticsTimeout bounds how long the collector waits for
+// diagnostics from a stalled instance.
+const stallDiagnosticsTimeout = 5 * time.Minute
+
+// stallDiagnosticsCommand captures the state of a stalled instance.
+const stallDiagnosticsCommand = `echo "== uptime"; uptime
+echo "== memory"; free -m
+echo "== disk"; df -h / /tmp
+echo "== processes"; ps aux --sort=-%cpu | head -n 25
+echo "== benchmark log"; tail -n 100 /tmp/benchmark.log
+echo "== kernel"; dmesg | tail -n 50`
+
+// errAsyncJobUnfinished is the cause given to spot interruption detection
+// for an async job that stopped without a final sentinel.
+var errAsyncJobUnfinished = errors.New("async job stopped without a final status")
+
 // AsyncCollector checks for completed benchmarks and collects results
 type AsyncCollector struct {
-	s3Client *s3.Client
-	region   string
+	store        ObjectStore
+	region       string
+	orchestrator *Orchestrator
+	stallPolicy  StallPolicy
+	retryPolicy  RetryPolicy
+	relauncher   *AsyncLauncher
+	events       *EventBus
+	results      storage.ResultStore
+	provenance   Provenance
+
+	// collected holds the result of each job collected so far, by
+	// benchmark ID, so that checking a job again does not collect it twice
+	collected map[string]*AsyncBenchmarkResult
 }
 
 // NewAsyncCollector creates a new benchmark result collector
 func NewAsyncCollector(region string) (*AsyncCollector, error) {
-	cfg, err := LoadAWSConfig(region)
+	orchestrator, err := NewOrchestrator(region)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		return nil, fmt.Errorf("failed to create orchestrator: %w", err)
 	}
 
+	return NewAsyncCollectorWithStore(orchestrator, NewS3ObjectStore(s3.NewFromConfig(orchestrator.cfg))), nil
+}
+
+// NewAsyncCollectorWithStore creates a collector that reads job tracking
+// files from the given object store, e.g. a LocalObjectStore to run the
+// sentinel protocol without S3.
+func NewAsyncCollectorWithStore(orchestrator *Orchestrator, store ObjectStore) *AsyncCollector {
 	return &AsyncCollector{
-		s3Client: s3.NewFromConfig(cfg),
-		region:   region,
-	}, nil
+		store:        store,
+		region:       orchestrator.region,
+		orchestrator: orchestrator,
+		stallPolicy:  DefaultStallPolicy(),
+		collected:    make(map[string]*AsyncBenchmarkResult),
+	}
+}
+
+// WithStallPolicy sets how stalled jobs are detected and handled.
+// Relaunching requires a launcher; without one stalled jobs are terminated
+// but not relaunched.
+func (c *AsyncCollector) WithStallPolicy(policy StallPolicy, launcher *AsyncLauncher) *AsyncCollector {
+	c.stallPolicy = policy
+	c.relauncher = launcher
+	return c
+}
+
+// WithRetryPolicy relaunches failed and timed-out jobs through launcher as
+// the policy allows. Without a retry policy failures are only recorded.
+func (c *AsyncCollector) WithRetryPolicy(policy RetryPolicy, launcher *AsyncLauncher) *AsyncCollector {
+	c.retryPolicy = policy
+	c.relauncher = launcher
+	return c
+}
+
+// WithResultStore stores the results of every completed job in the given
+// result store, e.g. a storage.LocalStorage to keep them offline. Storage
+// failures are reported but do not fail collection.
+func (c *AsyncCollector) WithResultStore(store storage.ResultStore) *AsyncCollector {
+	c.results = store
+	return c
+}
+
+// WithProvenance sets the orchestrator version and commit recorded in the
+// provenance of stored results. The image, AMI and output hash are filled
+// in per job.
+func (c *AsyncCollector) WithProvenance(provenance Provenance) *AsyncCollector {
+	c.provenance = provenance
+	return c
 }
 
 // CollectionResult represents the result of a collection cycle
@@ -37,6 +107,9 @@ type CollectionResult struct {
 	Failed      []*AsyncBenchmarkResult `json:"failed"`
 	InProgress  []*AsyncBenchmarkJob    `json:"in_progress"`
 	TimedOut    []*AsyncBenchmarkJob    `json:"timed_out"`
+	Stalled     []*AsyncBenchmarkJob    `json:"stalled"`
+	Relaunched  []*AsyncBenchmarkJob    `json:"relaunched,omitempty"`
+	Retried     []*AsyncBenchmarkJob    `json:"retried,omitempty"`
 	Summary     CollectionSummary       `json:"summary"`
 }
 
@@ -47,8 +120,17 @@ type CollectionSummary struct {
 	FailedJobs      int     `json:"failed_jobs"`
 	InProgressJobs  int     `json:"in_progress_jobs"`
 	TimedOutJobs    int     `json:"timed_out_jobs"`
+	StalledJobs     int     `json:"stalled_jobs"`
+	RelaunchedJobs  int     `json:"relaunched_jobs"`
+	RetriedJobs     int     `json:"retried_jobs"`
 	TotalCost       float64 `json:"total_cost"`
 	SuccessRate     float64 `json:"success_rate"`
+	
+	// Benchmarks counts distinct benchmarks, each of which may have run
+	// several attempts; the rates below are per benchmark
+	Benchmarks              int     `json:"benchmarks"`
+	FirstAttemptSuccessRate float64 `json:"first_attempt_success_rate"`
+	EventualSuccessRate     float64 `json:"eventual_success_rate"`
 }
 
 // CheckAllBenchmarks scans S3 for all benchmark jobs and returns status
@@ -64,6 +146,7 @@ func (c *AsyncCollector) CheckAllBenchmarks(ctx context.Context, s3Bucket string
 		Failed:     make([]*AsyncBenchmarkResult, 0),
 		InProgress: make([]*AsyncBenchmarkJob, 0),
 		TimedOut:   make([]*AsyncBenchmarkJob, 0),
+		Stalled:    make([]*AsyncBenchmarkJob, 0),
 	}
 
 	// List all benchmark jobs in S3
@@ -86,9 +169,12 @@ func (c *AsyncCollector) CheckAllBenchmarks(ctx context.Context, s3Bucket string
 
 		switch status {
 		case JobStatusCompleted:
-			benchmarkResult, err := c.collectCompletedJob(ctx, job)
-			if err != nil {
-				fmt.Printf("   ❌ Failed to collect results: %v\n", err)
+			benchmarkResult := c.collectResults(ctx, job)
+			if !benchmarkResult.Success {
+				result.Failed = append(result.Failed, benchmarkResult)
+				fmt.Printf("   ❌ Unreadable results: %s on %s (%s)\n",
+					job.BenchmarkConfig.BenchmarkSuite, job.BenchmarkConfig.InstanceType, job.FailureReason)
+				c.retryFailedJob(ctx, job, status, result)
 				continue
 			}
 			result.Completed = append(result.Completed, benchmarkResult)
@@ -96,30 +182,46 @@ func (c *AsyncCollector) CheckAllBenchmarks(ctx context.Context, s3Bucket string
 				job.BenchmarkConfig.BenchmarkSuite, job.BenchmarkConfig.InstanceType)
 
 		case JobStatusFailed:
+			c.classifyFailure(ctx, job, status)
 			benchmarkResult, err := c.collectFailedJob(ctx, job)
 			if err != nil {
 				fmt.Printf("   ⚠️  Failed to collect failure details: %v\n", err)
 				continue
 			}
 			result.Failed = append(result.Failed, benchmarkResult)
-			fmt.Printf("   ❌ Failed: %s on %s\n", 
-				job.BenchmarkConfig.BenchmarkSuite, job.BenchmarkConfig.InstanceType)
+			fmt.Printf("   ❌ Failed: %s on %s (%s)\n", 
+				job.BenchmarkConfig.BenchmarkSuite, job.BenchmarkConfig.InstanceType, job.FailureCategory)
+			c.retryFailedJob(ctx, job, status, result)
 
 		case JobStatusTimedOut:
 			result.TimedOut = append(result.TimedOut, job)
 			fmt.Printf("   ⏰ Timed out: %s on %s\n", 
 				job.BenchmarkConfig.BenchmarkSuite, job.BenchmarkConfig.InstanceType)
+			c.retryFailedJob(ctx, job, status, result)
 
 		case JobStatusEmergencyStop:
 			result.TimedOut = append(result.TimedOut, job) // Treat as timeout for grouping
 			fmt.Printf("   🚨 Emergency stop: %s on %s (failsafe triggered)\n", 
 				job.BenchmarkConfig.BenchmarkSuite, job.BenchmarkConfig.InstanceType)
+			c.retryFailedJob(ctx, job, status, result)
 
 		case JobStatusRunning, JobStatusLaunched:
 			result.InProgress = append(result.InProgress, job)
 			fmt.Printf("   🔄 In progress: %s on %s\n", 
 				job.BenchmarkConfig.BenchmarkSuite, job.BenchmarkConfig.InstanceType)
 
+		case JobStatusStalled:
+			result.Stalled = append(result.Stalled, job)
+			fmt.Printf("   🐢 Stalled: %s on %s (%s)\n",
+				job.BenchmarkConfig.BenchmarkSuite, job.BenchmarkConfig.InstanceType, job.StallReason)
+			relaunched, err := c.handleStalledJob(ctx, job)
+			if err != nil {
+				fmt.Printf("   ⚠️  Failed to handle stall: %v\n", err)
+			}
+			if relaunched != nil {
+				result.Relaunched = append(result.Relaunched, relaunched)
+			}
+
 		default:
 			fmt.Printf("   ❓ Unknown status: %s\n", status)
 		}
@@ -137,7 +239,11 @@ func (c *AsyncCollector) CheckAllBenchmarks(ctx context.Context, s3Bucket string
 		float64(result.Summary.FailedJobs)/float64(result.Summary.TotalJobs)*100)
 	fmt.Printf("   In Progress: %d\n", result.Summary.InProgressJobs)
 	fmt.Printf("   Timed Out: %d\n", result.Summary.TimedOutJobs)
+	fmt.Printf("   Stalled: %d (%d relaunched)\n", result.Summary.StalledJobs, result.Summary.RelaunchedJobs)
+	fmt.Printf("   Retried: %d\n", result.Summary.RetriedJobs)
 	fmt.Printf("   Success Rate: %.1f%%\n", result.Summary.SuccessRate)
+	fmt.Printf("   Benchmarks: %d (%.1f%% first attempt, %.1f%% eventual success)\n", result.Summary.Benchmarks,
+		result.Summary.FirstAttemptSuccessRate, result.Summary.EventualSuccessRate)
 	fmt.Printf("   Total Cost: $%.4f\n", result.Summary.TotalCost)
 
 	return result, nil
@@ -169,10 +275,47 @@ func (c *AsyncCollector) WaitForCompletion(ctx context.Context, jobs []*AsyncBen
 				continue
 			}
 			
+			// Unreadable results fail the attempt
+			if status == JobStatusCompleted && !c.collectResults(ctx, job).Success {
+				status = JobStatusFailed
+			}
+			
 			switch status {
-			case JobStatusCompleted, JobStatusFailed, JobStatusTimedOut:
+			case JobStatusCompleted:
 				completedJobs[job.BenchmarkID] = true
 				fmt.Printf("🎯 Job completed: %s (%s)\n", job.BenchmarkID, status)
+			case JobStatusFailed, JobStatusTimedOut, JobStatusEmergencyStop:
+				c.classifyFailure(ctx, job, status)
+				retried, err := c.retryJob(ctx, job)
+				if err != nil {
+					fmt.Printf("⚠️  Failed to retry %s: %v\n", job.BenchmarkID, err)
+				}
+				if retried != nil {
+					fmt.Printf("🔁 %s: %s (%s), retrying as %s (attempt %d)\n",
+						job.BenchmarkID, status, job.FailureCategory, retried.BenchmarkID, retried.AttemptNumber())
+					jobs = append(jobs, retried)
+				}
+				if c.awaitingRetry(job) {
+					allComplete = false
+				} else {
+					completedJobs[job.BenchmarkID] = true
+					fmt.Printf("🎯 Job completed: %s (%s)\n", job.BenchmarkID, status)
+				}
+			case JobStatusStalled:
+				fmt.Printf("🐢 %s: stalled (%s)\n", job.BenchmarkID, job.StallReason)
+				relaunched, err := c.handleStalledJob(ctx, job)
+				if err != nil {
+					fmt.Printf("⚠️  Failed to handle stall of %s: %v\n", job.BenchmarkID, err)
+				}
+				if c.stallPolicy.Action == StallActionRelaunch && err == nil {
+					completedJobs[job.BenchmarkID] = true
+				} else {
+					allComplete = false
+				}
+				if relaunched != nil {
+					jobs = append(jobs, relaunched)
+					allComplete = false
+				}
 			case JobStatusRunning, JobStatusLaunched:
 				allComplete = false
 				
@@ -207,6 +350,7 @@ func (c *AsyncCollector) CheckSpecificJobs(ctx context.Context, jobs []*AsyncBen
 		Failed:     make([]*AsyncBenchmarkResult, 0),
 		InProgress: make([]*AsyncBenchmarkJob, 0),
 		TimedOut:   make([]*AsyncBenchmarkJob, 0),
+		Stalled:    make([]*AsyncBenchmarkJob, 0),
 	}
 
 	for _, job := range jobs {
@@ -217,15 +361,21 @@ func (c *AsyncCollector) CheckSpecificJobs(ctx context.Context, jobs []*AsyncBen
 
 		switch status {
 		case JobStatusCompleted:
-			if benchmarkResult, err := c.collectCompletedJob(ctx, job); err == nil {
+			if benchmarkResult := c.collectResults(ctx, job); benchmarkResult.Success {
 				result.Completed = append(result.Completed, benchmarkResult)
+			} else {
+				result.Failed = append(result.Failed, benchmarkResult)
 			}
 		case JobStatusFailed:
+			c.classifyFailure(ctx, job, status)
 			if benchmarkResult, err := c.collectFailedJob(ctx, job); err == nil {
 				result.Failed = append(result.Failed, benchmarkResult)
 			}
-		case JobStatusTimedOut:
+		case JobStatusTimedOut, JobStatusEmergencyStop:
+			c.classifyFailure(ctx, job, status)
 			result.TimedOut = append(result.TimedOut, job)
+		case JobStatusStalled:
+			result.Stalled = append(result.Stalled, job)
 		default:
 			result.InProgress = append(result.InProgress, job)
 		}
@@ -235,50 +385,52 @@ func (c *AsyncCollector) CheckSpecificJobs(ctx context.Context, jobs []*AsyncBen
 	return result, nil
 }
 
-// listAllJobs finds all benchmark jobs in S3
+// ListJobs returns every async job recorded in the bucket with its Status
+// set from the job's sentinel files.
+func (c *AsyncCollector) ListJobs(ctx context.Context, s3Bucket string) ([]*AsyncBenchmarkJob, error) {
+	jobs, err := c.listAllJobs(ctx, s3Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	for _, job := range jobs {
+		status, err := c.checkJobStatus(ctx, job)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check status of job %s: %w", job.BenchmarkID, err)
+		}
+		job.Status = status
+	}
+	return jobs, nil
+}
+
+// listAllJobs finds all benchmark jobs in the object store
 func (c *AsyncCollector) listAllJobs(ctx context.Context, s3Bucket string) ([]*AsyncBenchmarkJob, error) {
 	var jobs []*AsyncBenchmarkJob
 
 	// List all objects under benchmarks/ prefix
-	paginator := s3.NewListObjectsV2Paginator(c.s3Client, &s3.ListObjectsV2Input{
-		Bucket: aws.String(s3Bucket),
-		Prefix: aws.String("benchmarks/"),
-	})
-
-	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list S3 objects: %w", err)
-		}
+	keys, err := c.store.ListObjects(ctx, s3Bucket, "benchmarks/")
+	if err != nil {
+		return nil, err
+	}
 
-		for _, obj := range page.Contents {
-			// Look for job-metadata.json files
-			if strings.HasSuffix(*obj.Key, "job-metadata.json") {
-				job, err := c.loadJobMetadata(ctx, s3Bucket, *obj.Key)
-				if err != nil {
-					fmt.Printf("⚠️  Failed to load job metadata from %s: %v\n", *obj.Key, err)
-					continue
-				}
-				jobs = append(jobs, job)
+	for _, key := range keys {
+		// Look for job-metadata.json files
+		if strings.HasSuffix(key, "job-metadata.json") {
+			job, err := c.loadJobMetadata(ctx, s3Bucket, key)
+			if err != nil {
+				fmt.Printf("⚠️  Failed to load job metadata from %s: %v\n", key, err)
+				continue
 			}
+			jobs = append(jobs, job)
 		}
 	}
 
 	return jobs, nil
 }
 
-// loadJobMetadata loads job metadata from S3
+// loadJobMetadata loads job metadata from the object store
 func (c *AsyncCollector) loadJobMetadata(ctx context.Context, bucket, key string) (*AsyncBenchmarkJob, error) {
-	resp, err := c.s3Client.GetObject(ctx, &s3.GetObjectInput{
-		Bucket: aws.String(bucket),
-		Key:    aws.String(key),
-	})
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	data, err := io.ReadAll(resp.Body)
+	data, err := c.store.GetObject(ctx, bucket, key)
 	if err != nil {
 		return nil, err
 	}
@@ -291,8 +443,18 @@ func (c *AsyncCollector) loadJobMetadata(ctx context.Context, bucket, key string
 	return &job, nil
 }
 
-// checkJobStatus determines the current status of a job
+// checkJobStatus determines the current status of a job and publishes it
+// to the event bus
 func (c *AsyncCollector) checkJobStatus(ctx context.Context, job *AsyncBenchmarkJob) (JobStatus, error) {
+	status, err := c.observeJobStatus(ctx, job)
+	if err == nil {
+		c.publishJobStatus(ctx, job, status)
+	}
+	return status, err
+}
+
+// observeJobStatus reads the status of a job from its sentinel files
+func (c *AsyncCollector) observeJobStatus(ctx context.Context, job *AsyncBenchmarkJob) (JobStatus, error) {
 	sentinels := NewS3SentinelFiles(job.S3Prefix)
 
 	// Check sentinels in order of preference
@@ -308,39 +470,45 @@ func (c *AsyncCollector) checkJobStatus(ctx context.Context, job *AsyncBenchmark
 	if c.objectExists(ctx, job.S3Bucket, sentinels.StatusTimedOut) {
 		return JobStatusTimedOut, nil
 	}
+	if c.objectExists(ctx, job.S3Bucket, sentinels.StatusStalled) {
+		return JobStatusStalled, nil
+	}
+
+	// A reclaimed spot instance stops without a final sentinel
+	if interruption := c.spotInterruption(ctx, job); interruption != nil {
+		job.FailureCategory = FailureCategorySpotInterruption
+		job.FailureReason = interruption.Error()
+		return JobStatusFailed, nil
+	}
+
+	status := JobStatusLaunched // Default status
 	if c.objectExists(ctx, job.S3Bucket, sentinels.StatusRunning) {
-		return JobStatusRunning, nil
+		status = JobStatusRunning
 	}
-	if c.objectExists(ctx, job.S3Bucket, sentinels.StatusLaunched) {
-		return JobStatusLaunched, nil
+
+	// Heartbeats distinguish a slow benchmark from a hung instance; jobs
+	// from scripts without heartbeats have no progress file
+	if progress, err := c.getJobProgress(ctx, job); err == nil {
+		if reason := c.stallPolicy.StallReason(progress, time.Now()); reason != "" {
+			job.StallReason = reason
+			return JobStatusStalled, nil
+		}
 	}
 
-	return JobStatusLaunched, nil // Default status
+	return status, nil
 }
 
-// objectExists checks if an S3 object exists
+// objectExists checks if an object exists, treating store errors as absent
 func (c *AsyncCollector) objectExists(ctx context.Context, bucket, key string) bool {
-	_, err := c.s3Client.HeadObject(ctx, &s3.HeadObjectInput{
-		Bucket: aws.String(bucket),
-		Key:    aws.String(key),
-	})
-	return err == nil
+	exists, err := c.store.ObjectExists(ctx, bucket, key)
+	return err == nil && exists
 }
 
 // getJobProgress retrieves job progress information
 func (c *AsyncCollector) getJobProgress(ctx context.Context, job *AsyncBenchmarkJob) (*BenchmarkProgress, error) {
 	sentinels := NewS3SentinelFiles(job.S3Prefix)
 	
-	resp, err := c.s3Client.GetObject(ctx, &s3.GetObjectInput{
-		Bucket: aws.String(job.S3Bucket),
-		Key:    aws.String(sentinels.StatusProgress),
-	})
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	data, err := io.ReadAll(resp.Body)
+	data, err := c.store.GetObject(ctx, job.S3Bucket, sentinels.StatusProgress)
 	if err != nil {
 		return nil, err
 	}
@@ -353,40 +521,217 @@ func (c *AsyncCollector) getJobProgress(ctx context.Context, job *AsyncBenchmark
 	return &progress, nil
 }
 
-// collectCompletedJob collects results from a completed job
-func (c *AsyncCollector) collectCompletedJob(ctx context.Context, job *AsyncBenchmarkJob) (*AsyncBenchmarkResult, error) {
+// handleStalledJob applies the stall policy to a stalled job. It returns
+// the relaunched job, if any.
+func (c *AsyncCollector) handleStalledJob(ctx context.Context, job *AsyncBenchmarkJob) (*AsyncBenchmarkJob, error) {
+	if c.stallPolicy.Action == StallActionWait {
+		return nil, nil
+	}
+
 	sentinels := NewS3SentinelFiles(job.S3Prefix)
+	if c.objectExists(ctx, job.S3Bucket, sentinels.StatusStalled) {
+		return nil, nil // Already handled
+	}
 
-	// Load benchmark results
-	resultsResp, err := c.s3Client.GetObject(ctx, &s3.GetObjectInput{
-		Bucket: aws.String(job.S3Bucket),
-		Key:    aws.String(sentinels.BenchmarkResults),
-	})
+	// Capture diagnostics once per stall
+	if !c.objectExists(ctx, job.S3Bucket, sentinels.Diagnostics) {
+		diagnostics := c.stallDiagnostics(ctx, job)
+		if err := c.putObject(ctx, job.S3Bucket, sentinels.Diagnostics, diagnostics, "text/plain"); err != nil {
+			return nil, fmt.Errorf("failed to upload diagnostics: %w", err)
+		}
+		fmt.Printf("   🩺 Diagnostics captured: s3://%s/%s\n", job.S3Bucket, sentinels.Diagnostics)
+	}
+
+	if c.stallPolicy.Action != StallActionRelaunch {
+		return nil, nil
+	}
+
+	if job.InstanceID != "" {
+		if err := c.orchestrator.terminateInstance(ctx, job.InstanceID); err != nil {
+			fmt.Printf("   ⚠️  Failed to terminate stalled instance %s: %v\n", job.InstanceID, err)
+		} else {
+			terminated := asyncJobEvent(job, EventInstanceTerminated)
+			terminated.Message = "stalled instance terminated"
+			c.events.Publish(terminated)
+		}
+	}
+	sentinel := fmt.Sprintf("%s: %s (%s)", time.Now().Format(time.RFC3339), JobStatusStalled, job.StallReason)
+	if err := c.putObject(ctx, job.S3Bucket, sentinels.StatusStalled, sentinel, "text/plain"); err != nil {
+		return nil, fmt.Errorf("failed to upload stalled sentinel: %w", err)
+	}
+
+	if c.relauncher == nil {
+		return nil, nil
+	}
+	relaunched, err := c.relaunch(ctx, job)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load benchmark results: %w", err)
+		return relaunched, fmt.Errorf("failed to relaunch stalled job: %w", err)
+	}
+	fmt.Printf("   🔁 Relaunched as %s\n", relaunched.BenchmarkID)
+	return relaunched, nil
+}
+
+// classifyFailure records why a job ended in a failed or timed-out status,
+// keeping a category recorded earlier by the launcher or a status check.
+func (c *AsyncCollector) classifyFailure(ctx context.Context, job *AsyncBenchmarkJob, status JobStatus) {
+	if job.FailureCategory != "" {
+		return
+	}
+	if status == JobStatusTimedOut || status == JobStatusEmergencyStop {
+		job.FailureCategory = FailureCategoryTimeout
+		job.FailureReason = fmt.Sprintf("exceeded maximum runtime (%s)", status)
+		return
+	}
+	// An interruption can kill the benchmark before the instance stops
+	if interruption := c.spotInterruption(ctx, job); interruption != nil {
+		job.FailureCategory = FailureCategorySpotInterruption
+		job.FailureReason = interruption.Error()
+		return
+	}
+	job.FailureCategory = FailureCategoryBenchmark
+	job.FailureReason = "benchmark exited with an error"
+}
+
+// spotInterruption returns the interruption of a spot job's instance, or
+// nil for on-demand jobs and instances that were not reclaimed.
+func (c *AsyncCollector) spotInterruption(ctx context.Context, job *AsyncBenchmarkJob) *SpotInterruption {
+	if job.InstanceID == "" || c.orchestrator == nil {
+		return nil
 	}
-	defer resultsResp.Body.Close()
+	return c.orchestrator.detectSpotInterruption(ctx, job.BenchmarkConfig, job.InstanceID, errAsyncJobUnfinished)
+}
 
-	resultsData, err := io.ReadAll(resultsResp.Body)
+// retryFailedJob classifies a failed job and retries it as the retry
+// policy allows, recording a new attempt in result.
+func (c *AsyncCollector) retryFailedJob(ctx context.Context, job *AsyncBenchmarkJob, status JobStatus, result *CollectionResult) {
+	c.classifyFailure(ctx, job, status)
+	retried, err := c.retryJob(ctx, job)
 	if err != nil {
+		fmt.Printf("   ⚠️  Failed to retry: %v\n", err)
+	}
+	if retried != nil {
+		result.Retried = append(result.Retried, retried)
+		fmt.Printf("   🔁 Retrying as %s (attempt %d)\n", retried.BenchmarkID, retried.AttemptNumber())
+	} else if c.awaitingRetry(job) {
+		fmt.Printf("   ⏳ Retry after %s\n", job.RetryAfter.Format(time.RFC3339))
+	}
+}
+
+// awaitingRetry reports whether the retry policy will relaunch a failed job.
+func (c *AsyncCollector) awaitingRetry(job *AsyncBenchmarkJob) bool {
+	return c.relauncher != nil && job.RetriedAs == "" && c.retryPolicy.ShouldRetry(job)
+}
+
+// retryJob relaunches a classified failed job once its backoff has
+// passed, if the retry policy allows another attempt. It returns the new
+// attempt, if any.
+func (c *AsyncCollector) retryJob(ctx context.Context, job *AsyncBenchmarkJob) (*AsyncBenchmarkJob, error) {
+	if !c.awaitingRetry(job) {
+		return nil, nil
+	}
+
+	now := time.Now()
+	if job.RetryAfter == nil {
+		retryAfter := now.Add(c.retryPolicy.BackoffAfter(job.AttemptNumber()))
+		job.RetryAfter = &retryAfter
+		if err := putJobMetadata(ctx, c.store, job); err != nil {
+			return nil, fmt.Errorf("failed to schedule retry: %w", err)
+		}
+	}
+	if now.Before(*job.RetryAfter) {
+		return nil, nil
+	}
+
+	return c.relaunch(ctx, job)
+}
+
+// relaunch starts the next attempt of a job and links the job to it. A
+// launch that fails after the attempt was recorded returns both.
+func (c *AsyncCollector) relaunch(ctx context.Context, job *AsyncBenchmarkJob) (*AsyncBenchmarkJob, error) {
+	next, err := c.relauncher.relaunch(ctx, job)
+	if next == nil {
 		return nil, err
 	}
 
-	var benchmarkData map[string]interface{}
+	job.RetriedAs = next.BenchmarkID
+	if err := putJobMetadata(ctx, c.store, job); err != nil {
+		fmt.Printf("   ⚠️  Failed to link %s to its next attempt: %v\n", job.BenchmarkID, err)
+	}
+	return next, err
+}
+
+// stallDiagnostics runs stallDiagnosticsCommand on a stalled job's instance
+// and returns its output, or why it could not be captured.
+func (c *AsyncCollector) stallDiagnostics(ctx context.Context, job *AsyncBenchmarkJob) string {
+	header := fmt.Sprintf("job %s stalled: %s\ncaptured %s\n\n", job.BenchmarkID, job.StallReason, time.Now().Format(time.RFC3339))
+	if job.InstanceID == "" {
+		return header + "no instance to diagnose\n"
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, stallDiagnosticsTimeout)
+	defer cancel()
+
+	output, err := c.orchestrator.executeSSMCommand(ctx, job.InstanceID, stallDiagnosticsCommand)
+	if err != nil {
+		return header + fmt.Sprintf("diagnostics unavailable from %s: %v\n%s", job.InstanceID, err, output)
+	}
+	return header + output
+}
+
+// putObject uploads a small text object to the object store
+func (c *AsyncCollector) putObject(ctx context.Context, bucket, key, body, contentType string) error {
+	return c.store.PutObject(ctx, bucket, key, []byte(body), contentType)
+}
+
+// collectResults collects a completed job. Results that are missing or
+// unreadable fail the attempt with FailureCategoryParse. Each job is
+// collected once; checking it again returns the result collected first.
+func (c *AsyncCollector) collectResults(ctx context.Context, job *AsyncBenchmarkJob) *AsyncBenchmarkResult {
+	if collected, ok := c.collected[job.BenchmarkID]; ok {
+		if !collected.Success {
+			job.FailureCategory = collected.Job.FailureCategory
+			job.FailureReason = collected.Job.FailureReason
+		}
+		return collected
+	}
+
+	benchmarkResult, err := c.collectCompletedJob(ctx, job)
+	if err == nil {
+		parsed := asyncJobEvent(job, EventResultParsed)
+		parsed.Results = benchmarkResult.BenchmarkData
+		c.events.Publish(parsed)
+		if err := c.storeResult(ctx, benchmarkResult); err != nil && !errors.Is(err, storage.ErrDuplicateResult) {
+			fmt.Printf("   ⚠️  Failed to store results: %v\n", err)
+		}
+	} else {
+		job.FailureCategory = FailureCategoryParse
+		job.FailureReason = err.Error()
+		c.publishJobStatus(ctx, job, JobStatusFailed)
+		benchmarkResult, _ = c.collectFailedJob(ctx, job)
+	}
+	c.collected[job.BenchmarkID] = benchmarkResult
+	return benchmarkResult
+}
+
+// collectCompletedJob collects results from a completed job
+func (c *AsyncCollector) collectCompletedJob(ctx context.Context, job *AsyncBenchmarkJob) (*AsyncBenchmarkResult, error) {
+	sentinels := NewS3SentinelFiles(job.S3Prefix)
+
+	// Load benchmark results
+	resultsData, err := c.store.GetObject(ctx, job.S3Bucket, sentinels.BenchmarkResults)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load benchmark results: %w", err)
+	}
+
+	var benchmarkData BenchmarkResults
 	if err := json.Unmarshal(resultsData, &benchmarkData); err != nil {
 		return nil, err
 	}
 
 	// Load system info
 	systemInfo := make(map[string]interface{})
-	if systemResp, err := c.s3Client.GetObject(ctx, &s3.GetObjectInput{
-		Bucket: aws.String(job.S3Bucket),
-		Key:    aws.String(sentinels.SystemInfo),
-	}); err == nil {
-		defer systemResp.Body.Close()
-		if systemData, err := io.ReadAll(systemResp.Body); err == nil {
-			json.Unmarshal(systemData, &systemInfo)
-		}
+	if systemData, err := c.store.GetObject(ctx, job.S3Bucket, sentinels.SystemInfo); err == nil {
+		json.Unmarshal(systemData, &systemInfo)
 	}
 
 	// Calculate execution time
@@ -395,13 +740,81 @@ func (c *AsyncCollector) collectCompletedJob(ctx context.Context, job *AsyncBenc
 		executionTime = job.CompletedAt.Sub(*job.StartedAt)
 	}
 
-	return &AsyncBenchmarkResult{
+	result := &AsyncBenchmarkResult{
 		Job:           job,
-		BenchmarkData: benchmarkData,
+		BenchmarkData: &benchmarkData,
 		SystemInfo:    systemInfo,
 		ExecutionTime: executionTime,
 		Success:       true,
-	}, nil
+	}
+
+	// Load the raw output of the benchmark, falling back to the full log
+	// of instances that did not upload it separately
+	outputData, err := c.store.GetObject(ctx, job.S3Bucket, sentinels.BenchmarkOutput)
+	if err != nil {
+		outputData, err = c.store.GetObject(ctx, job.S3Bucket, sentinels.BenchmarkLogs)
+	}
+	if err == nil {
+		result.RawOutput = newAsyncRawOutput(job.BenchmarkConfig.BenchmarkSuite, string(outputData))
+		result.RawOutputSHA256 = result.RawOutput.Hash()
+	}
+	return result, nil
+}
+
+// storeResult stores the results of a completed job in the result store,
+// if one is configured. Results are keyed by content, so a job collected
+// again by another collector, e.g. a later collect run, returns
+// storage.ErrDuplicateResult rather than being stored twice.
+func (c *AsyncCollector) storeResult(ctx context.Context, result *AsyncBenchmarkResult) error {
+	if c.results == nil {
+		return nil
+	}
+
+	job := result.Job
+	timestamp := job.LaunchedAt
+	if job.CompletedAt != nil {
+		timestamp = *job.CompletedAt
+	} else if job.StartedAt != nil {
+		timestamp = *job.StartedAt
+	}
+	region := job.Region
+	if region == "" {
+		region = c.region
+	}
+	provenance := c.provenance
+	provenance.ContainerImage = job.BenchmarkConfig.ContainerImage
+	reported, _ := result.SystemInfo["container_image_digest"].(string)
+	provenance.ContainerImageDigest = ContainerImageDigest(provenance.ContainerImage, reported)
+	provenance.AMIID = job.AMIID
+	provenance.RawOutputSHA256 = result.RawOutputSHA256
+
+	resultData := map[string]interface{}{
+		"schema_version": "1.0.0",
+		"metadata": map[string]interface{}{
+			"instanceType":      job.BenchmarkConfig.InstanceType,
+			"region":            region,
+			"benchmark_suite":   job.BenchmarkConfig.BenchmarkSuite,
+			"timestamp":         timestamp.UTC().Format(time.RFC3339),
+			"instance_id":       job.InstanceID,
+			"benchmark_id":      job.BenchmarkID,
+			"attempt":           job.AttemptNumber(),
+			"duration_seconds":  result.ExecutionTime.Seconds(),
+			"collection_method": "async",
+			"parser_version":    ParserVersion,
+		},
+		"benchmark_data": result.BenchmarkData,
+		"system_info":    result.SystemInfo,
+		"provenance":     provenance,
+	}
+
+	// Archive the raw output before the result, so every stored result can
+	// be re-parsed with fixed parsers
+	if result.RawOutput != nil {
+		if _, err := c.results.ArchiveRawOutput(ctx, resultData, result.RawOutput); err != nil {
+			return fmt.Errorf("failed to archive raw output: %w", err)
+		}
+	}
+	return c.results.StoreResult(ctx, resultData)
 }
 
 // collectFailedJob collects details from a failed job
@@ -409,22 +822,18 @@ func (c *AsyncCollector) collectFailedJob(ctx context.Context, job *AsyncBenchma
 	// Try to get whatever results we can
 	result := &AsyncBenchmarkResult{
 		Job:           job,
-		BenchmarkData: make(map[string]interface{}),
 		SystemInfo:    make(map[string]interface{}),
 		Success:       false,
 		Error:         "Benchmark execution failed",
 	}
+	if job.FailureReason != "" {
+		result.Error = fmt.Sprintf("%s: %s", job.FailureCategory, job.FailureReason)
+	}
 
 	// Try to load logs for error details
 	sentinels := NewS3SentinelFiles(job.S3Prefix)
-	if logResp, err := c.s3Client.GetObject(ctx, &s3.GetObjectInput{
-		Bucket: aws.String(job.S3Bucket),
-		Key:    aws.String(sentinels.BenchmarkLogs),
-	}); err == nil {
-		defer logResp.Body.Close()
-		if logData, err := io.ReadAll(logResp.Body); err == nil {
-			result.BenchmarkData["error_logs"] = string(logData)
-		}
+	if logData, err := c.store.GetObject(ctx, job.S3Bucket, sentinels.BenchmarkLogs); err == nil {
+		result.ErrorLogs = string(logData)
 	}
 
 	return result, nil
@@ -432,7 +841,7 @@ func (c *AsyncCollector) collectFailedJob(ctx context.Context, job *AsyncBenchma
 
 // calculateSummary computes aggregate statistics
 func (c *AsyncCollector) calculateSummary(result *CollectionResult) CollectionSummary {
-	total := len(result.Completed) + len(result.Failed) + len(result.InProgress) + len(result.TimedOut)
+	total := len(result.Completed) + len(result.Failed) + len(result.InProgress) + len(result.TimedOut) + len(result.Stalled)
 	completed := len(result.Completed)
 	failed := len(result.Failed)
 	
@@ -449,13 +858,53 @@ func (c *AsyncCollector) calculateSummary(result *CollectionResult) CollectionSu
 		totalCost += job.Job.EstimatedCost
 	}
 
+	// Attempts of one benchmark share a chain; a chain succeeds once any
+	// of its attempts completes
+	chains := make(map[string]bool)
+	addAttempt := func(job *AsyncBenchmarkJob, succeeded bool) {
+		chains[job.ChainID()] = chains[job.ChainID()] || succeeded
+	}
+	for _, jobs := range [][]*AsyncBenchmarkJob{result.InProgress, result.TimedOut, result.Stalled} {
+		for _, job := range jobs {
+			addAttempt(job, false)
+		}
+	}
+	for _, failed := range result.Failed {
+		addAttempt(failed.Job, false)
+	}
+	firstAttemptSuccesses := 0
+	for _, completed := range result.Completed {
+		addAttempt(completed.Job, true)
+		if completed.Job.AttemptNumber() == 1 {
+			firstAttemptSuccesses++
+		}
+	}
+	eventualSuccesses := 0
+	for _, succeeded := range chains {
+		if succeeded {
+			eventualSuccesses++
+		}
+	}
+	var firstAttemptRate, eventualRate float64
+	if len(chains) > 0 {
+		firstAttemptRate = float64(firstAttemptSuccesses) / float64(len(chains)) * 100
+		eventualRate = float64(eventualSuccesses) / float64(len(chains)) * 100
+	}
+
 	return CollectionSummary{
 		TotalJobs:      total,
 		CompletedJobs:  completed,
 		FailedJobs:     failed,
 		InProgressJobs: len(result.InProgress),
 		TimedOutJobs:   len(result.TimedOut),
+		StalledJobs:    len(result.Stalled),
+		RelaunchedJobs: len(result.Relaunched),
+		RetriedJobs:    len(result.Retried),
 		TotalCost:      totalCost,
 		SuccessRate:    successRate,
+		
+		Benchmarks:              len(chains),
+		FirstAttemptSuccessRate: firstAttemptRate,
+		EventualSuccessRate:     eventualRate,
 	}
 }
\ No newline at end of file
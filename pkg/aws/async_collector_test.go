@@ -0,0 +1,510 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/fakecloud"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/storage"
+)
+
+func TestBenchmarkProgressHeartbeatJSON(t *testing.T) {
+	// Written by write_heartbeat in the bootstrap script
+	raw := `{
+    "current_iteration": 2,
+    "total_iterations": 5,
+    "last_update": "2025-06-30T12:00:00+00:00",
+    "message": "iteration 2",
+    "percent_complete": 40,
+    "heartbeat": "2025-06-30T12:04:00+00:00",
+    "heartbeat_interval_seconds": 60,
+    "last_activity": "2025-06-30T12:03:30+00:00"
+}`
+
+	var progress BenchmarkProgress
+	if err := json.Unmarshal([]byte(raw), &progress); err != nil {
+		t.Fatalf("failed to parse heartbeat: %v", err)
+	}
+	if want := time.Date(2025, 6, 30, 12, 4, 0, 0, time.UTC); !progress.LastSeen().Equal(want) {
+		t.Errorf("LastSeen = %v, want %v", progress.LastSeen(), want)
+	}
+	if progress.IsStale(time.Date(2025, 6, 30, 12, 5, 0, 0, time.UTC), 5*time.Minute) {
+		t.Error("Expected a heartbeat one minute old to be fresh")
+	}
+	if !progress.IsStale(time.Date(2025, 6, 30, 12, 10, 0, 0, time.UTC), 5*time.Minute) {
+		t.Error("Expected a heartbeat six minutes old to be stale")
+	}
+}
+
+func TestStallPolicyStallReason(t *testing.T) {
+	now := time.Date(2025, 6, 30, 13, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		policy   StallPolicy
+		progress *BenchmarkProgress
+		want     string
+	}{
+		{
+			name:     "no progress file",
+			policy:   DefaultStallPolicy(),
+			progress: nil,
+		},
+		{
+			name:     "fresh heartbeat",
+			policy:   DefaultStallPolicy(),
+			progress: &BenchmarkProgress{Heartbeat: now.Add(-time.Minute)},
+		},
+		{
+			name:     "missed heartbeats",
+			policy:   DefaultStallPolicy(),
+			progress: &BenchmarkProgress{Heartbeat: now.Add(-10 * time.Minute)},
+			want:     "no heartbeat for 10m0s",
+		},
+		{
+			name:     "legacy progress without heartbeat",
+			policy:   DefaultStallPolicy(),
+			progress: &BenchmarkProgress{LastUpdate: now.Add(-time.Hour)},
+			want:     "no heartbeat for 1h0m0s",
+		},
+		{
+			name:     "silent benchmark with activity check disabled",
+			policy:   DefaultStallPolicy(),
+			progress: &BenchmarkProgress{Heartbeat: now, LastActivity: now.Add(-2 * time.Hour)},
+		},
+		{
+			name:     "silent benchmark",
+			policy:   StallPolicy{HeartbeatTimeout: 5 * time.Minute, ActivityTimeout: time.Hour},
+			progress: &BenchmarkProgress{Heartbeat: now, LastActivity: now.Add(-2 * time.Hour)},
+			want:     "no benchmark output for 2h0m0s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.StallReason(tt.progress, now); got != tt.want {
+				t.Errorf("StallReason = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStallDiagnostics(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	cloud.HandleCommands(func(instanceID, command string) fakecloud.CommandResult {
+		if command != stallDiagnosticsCommand {
+			t.Errorf("unexpected command %q", command)
+		}
+		return fakecloud.CommandResult{Status: ssmtypes.CommandInvocationStatusSuccess, Stdout: "== uptime\n 13:00:00 up 2:00, load average: 0.00"}
+	})
+	instanceID := cloud.AddInstance(fakecloud.Instance{InstanceType: "m7i.large"})
+	collector := &AsyncCollector{orchestrator: newFakeOrchestrator(cloud), stallPolicy: DefaultStallPolicy()}
+
+	job := &AsyncBenchmarkJob{BenchmarkID: "bench-1", InstanceID: instanceID, StallReason: "no heartbeat for 10m0s"}
+	diagnostics := collector.stallDiagnostics(context.Background(), job)
+	if !strings.Contains(diagnostics, "no heartbeat for 10m0s") || !strings.Contains(diagnostics, "load average") {
+		t.Errorf("Unexpected diagnostics:\n%s", diagnostics)
+	}
+
+	// A dead instance still yields a diagnostics report
+	if err := cloud.SetInstanceState(instanceID, types.InstanceStateNameTerminated); err != nil {
+		t.Fatalf("SetInstanceState failed: %v", err)
+	}
+	diagnostics = collector.stallDiagnostics(context.Background(), job)
+	if !strings.Contains(diagnostics, "diagnostics unavailable") {
+		t.Errorf("Expected unavailable diagnostics for a terminated instance, got:\n%s", diagnostics)
+	}
+}
+
+func TestBootstrapWritesHeartbeats(t *testing.T) {
+	data := goldenBootstrapData(OSFamilyAmazonLinux, "x86_64")
+	data.HeartbeatInterval = 30 * time.Second
+	script, err := NewBootstrapRenderer().Render(data)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	for _, want := range []string{"HEARTBEAT_INTERVAL=30", `"heartbeat": "$(date -Iseconds)"`, "status-progress.json"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("Expected script to contain %q", want)
+		}
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	for _, tt := range []struct {
+		job  AsyncBenchmarkJob
+		want bool
+	}{
+		{AsyncBenchmarkJob{FailureCategory: FailureCategoryCapacity}, true},
+		{AsyncBenchmarkJob{FailureCategory: FailureCategorySpotInterruption, Attempt: 2}, true},
+		{AsyncBenchmarkJob{FailureCategory: FailureCategoryParse, Attempt: 3}, false},
+		{AsyncBenchmarkJob{FailureCategory: FailureCategoryTimeout}, true},
+		{AsyncBenchmarkJob{FailureCategory: FailureCategoryBenchmark}, false},
+		{AsyncBenchmarkJob{FailureCategory: FailureCategoryLaunch}, false},
+	} {
+		if got := policy.ShouldRetry(&tt.job); got != tt.want {
+			t.Errorf("ShouldRetry(%s, attempt %d) = %v, want %v", tt.job.FailureCategory, tt.job.AttemptNumber(), got, tt.want)
+		}
+	}
+	if (RetryPolicy{}).ShouldRetry(&AsyncBenchmarkJob{FailureCategory: FailureCategoryCapacity}) {
+		t.Error("Expected the zero policy not to retry")
+	}
+
+	for attempt, want := range map[int]time.Duration{1: time.Minute, 2: 2 * time.Minute, 4: 8 * time.Minute, 6: 15 * time.Minute} {
+		if got := policy.BackoffAfter(attempt); got != want {
+			t.Errorf("BackoffAfter(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
+
+// newRetryingCollector wires a launcher and a collector that retries
+// immediately to an in-memory cloud and object store.
+func newRetryingCollector(cloud *fakecloud.Cloud, policy RetryPolicy) (*AsyncLauncher, *AsyncCollector, ObjectStore) {
+	store := NewMemoryObjectStore()
+	orchestrator := newFakeOrchestrator(cloud)
+	launcher := NewAsyncLauncherWithStore(orchestrator, store)
+	collector := NewAsyncCollectorWithStore(orchestrator, store).WithRetryPolicy(policy, launcher)
+	return launcher, collector, store
+}
+
+// fakeScriptResults is the results.json the async benchmark script writes
+// for fakeSTREAMOutput.
+const fakeScriptResults = `{
+    "benchmark_suite": "stream",
+    "results": {
+        "copy_bandwidth_mbps": 45234.2,
+        "scale_bandwidth_mbps": 44876.1,
+        "add_bandwidth_mbps": 42105.3,
+        "triad_bandwidth_mbps": 41932.8
+    },
+    "success": true,
+    "exit_code": 0,
+    "timestamp": "2025-06-30T12:00:00+00:00"
+}`
+
+func completeAsyncJob(t *testing.T, store ObjectStore, job *AsyncBenchmarkJob, results string) {
+	t.Helper()
+	sentinels := NewS3SentinelFiles(job.S3Prefix)
+	if results != "" {
+		if err := store.PutObject(context.Background(), job.S3Bucket, sentinels.BenchmarkResults, []byte(results), "application/json"); err != nil {
+			t.Fatalf("PutObject failed: %v", err)
+		}
+	}
+	if err := store.PutObject(context.Background(), job.S3Bucket, sentinels.StatusCompleted, []byte("COMPLETED"), "text/plain"); err != nil {
+		t.Fatalf("PutObject failed: %v", err)
+	}
+}
+
+func loadAsyncJob(t *testing.T, collector *AsyncCollector, job *AsyncBenchmarkJob) *AsyncBenchmarkJob {
+	t.Helper()
+	loaded, err := collector.loadJobMetadata(context.Background(), job.S3Bucket, NewS3SentinelFiles(job.S3Prefix).JobMetadata)
+	if err != nil {
+		t.Fatalf("loadJobMetadata failed: %v", err)
+	}
+	return loaded
+}
+
+func TestCollectorRetriesSpotInterruption(t *testing.T) {
+	ctx := context.Background()
+	cloud := fakecloud.New("us-east-1")
+	policy := DefaultRetryPolicy()
+	policy.Backoff = 0
+	launcher, collector, store := newRetryingCollector(cloud, policy)
+
+	config := fakeBenchmarkConfig("m7i.large", "stream")
+	config.PurchaseOption = PurchaseSpot
+	first, err := launcher.LaunchSingleBenchmark(ctx, config, "results", "retry", time.Hour)
+	if err != nil {
+		t.Fatalf("LaunchSingleBenchmark failed: %v", err)
+	}
+	if err := cloud.InterruptSpot(first.InstanceID); err != nil {
+		t.Fatalf("InterruptSpot failed: %v", err)
+	}
+
+	result, err := collector.CheckAllBenchmarks(ctx, "results")
+	if err != nil {
+		t.Fatalf("CheckAllBenchmarks failed: %v", err)
+	}
+	if len(result.Failed) != 1 || len(result.Retried) != 1 {
+		t.Fatalf("Expected the interrupted job to fail and be retried, got %+v", result.Summary)
+	}
+	if failed := result.Failed[0].Job; failed.FailureCategory != FailureCategorySpotInterruption {
+		t.Errorf("FailureCategory = %q, want %q", failed.FailureCategory, FailureCategorySpotInterruption)
+	}
+
+	second := result.Retried[0]
+	if second.Attempt != 2 || second.FirstAttemptID != first.BenchmarkID || second.PreviousAttemptID != first.BenchmarkID {
+		t.Errorf("Retry not linked to the first attempt: %+v", second)
+	}
+	if !reflect.DeepEqual(second.BenchmarkConfig, config) {
+		t.Errorf("Retry config = %+v, want %+v", second.BenchmarkConfig, config)
+	}
+	if linked := loadAsyncJob(t, collector, first); linked.RetriedAs != second.BenchmarkID {
+		t.Errorf("First attempt RetriedAs = %q, want %q", linked.RetriedAs, second.BenchmarkID)
+	}
+
+	completeAsyncJob(t, store, second, fakeScriptResults)
+	result, err = collector.CheckAllBenchmarks(ctx, "results")
+	if err != nil {
+		t.Fatalf("CheckAllBenchmarks failed: %v", err)
+	}
+	if len(result.Retried) != 0 {
+		t.Errorf("Expected a retried attempt not to be retried again, got %d retries", len(result.Retried))
+	}
+	summary := result.Summary
+	if summary.TotalJobs != 2 || summary.Benchmarks != 1 || summary.FirstAttemptSuccessRate != 0 || summary.EventualSuccessRate != 100 {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+}
+
+func TestCollectorRetriesCapacityAndParseFailures(t *testing.T) {
+	ctx := context.Background()
+	cloud := fakecloud.New("us-east-1")
+	policy := DefaultRetryPolicy()
+	policy.Backoff = 0
+	launcher, collector, store := newRetryingCollector(cloud, policy)
+
+	// No capacity: the launch fails but the attempt is recorded
+	cloud.SetCapacity("c7i.large", 0)
+	if _, err := launcher.LaunchSingleBenchmark(ctx, fakeBenchmarkConfig("c7i.large", "stream"), "results", "retry", time.Hour); err == nil {
+		t.Fatal("Expected LaunchSingleBenchmark to fail without capacity")
+	}
+	parsed, err := launcher.LaunchSingleBenchmark(ctx, fakeBenchmarkConfig("m7i.large", "stream"), "results", "retry", time.Hour)
+	if err != nil {
+		t.Fatalf("LaunchSingleBenchmark failed: %v", err)
+	}
+	completeAsyncJob(t, store, parsed, "Triad: not json")
+	cloud.SetCapacity("c7i.large", 1)
+
+	result, err := collector.CheckAllBenchmarks(ctx, "results")
+	if err != nil {
+		t.Fatalf("CheckAllBenchmarks failed: %v", err)
+	}
+	if len(result.Completed) != 0 || len(result.Failed) != 2 || len(result.Retried) != 2 {
+		t.Fatalf("Expected two failed and retried jobs, got %+v", result.Summary)
+	}
+	categories := map[FailureCategory]bool{}
+	for _, failed := range result.Failed {
+		categories[failed.Job.FailureCategory] = true
+	}
+	if !categories[FailureCategoryCapacity] || !categories[FailureCategoryParse] {
+		t.Errorf("Expected capacity and parse failures, got %v", categories)
+	}
+
+	for _, retried := range result.Retried {
+		if retried.InstanceID == "" || retried.Attempt != 2 {
+			t.Errorf("Expected a launched second attempt, got %+v", retried)
+		}
+		completeAsyncJob(t, store, retried, fakeScriptResults)
+	}
+	result, err = collector.CheckAllBenchmarks(ctx, "results")
+	if err != nil {
+		t.Fatalf("CheckAllBenchmarks failed: %v", err)
+	}
+	if summary := result.Summary; summary.Benchmarks != 2 || summary.CompletedJobs != 2 || summary.EventualSuccessRate != 100 {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+}
+
+func TestCollectorRetryBackoffAndLimits(t *testing.T) {
+	ctx := context.Background()
+	cloud := fakecloud.New("us-east-1")
+	launcher, collector, store := newRetryingCollector(cloud, DefaultRetryPolicy())
+
+	job, err := launcher.LaunchSingleBenchmark(ctx, fakeBenchmarkConfig("m7i.large", "stream"), "results", "retry", time.Hour)
+	if err != nil {
+		t.Fatalf("LaunchSingleBenchmark failed: %v", err)
+	}
+	if err := store.PutObject(ctx, "results", NewS3SentinelFiles(job.S3Prefix).StatusTimedOut, []byte("TIMED_OUT"), "text/plain"); err != nil {
+		t.Fatalf("PutObject failed: %v", err)
+	}
+
+	// The retry waits out its backoff, which is recorded in the metadata
+	result, err := collector.CheckAllBenchmarks(ctx, "results")
+	if err != nil {
+		t.Fatalf("CheckAllBenchmarks failed: %v", err)
+	}
+	if len(result.TimedOut) != 1 || len(result.Retried) != 0 {
+		t.Fatalf("Expected a timed-out job waiting for its retry, got %+v", result.Summary)
+	}
+	scheduled := loadAsyncJob(t, collector, job)
+	if scheduled.FailureCategory != FailureCategoryTimeout || scheduled.RetryAfter == nil || time.Until(*scheduled.RetryAfter) < 50*time.Second {
+		t.Errorf("Expected a retry scheduled about a minute out, got %+v", scheduled)
+	}
+
+	// Benchmark errors are not retried, and neither is the last attempt
+	failed := &AsyncBenchmarkJob{FailureCategory: FailureCategoryBenchmark}
+	last := &AsyncBenchmarkJob{FailureCategory: FailureCategoryTimeout, Attempt: 3}
+	for _, job := range []*AsyncBenchmarkJob{failed, last} {
+		if retried, err := collector.retryJob(ctx, job); retried != nil || err != nil || collector.awaitingRetry(job) {
+			t.Errorf("Expected no retry of %s attempt %d, got %v, %v", job.FailureCategory, job.AttemptNumber(), retried, err)
+		}
+	}
+}
+
+func TestCollectorStoresResults(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryObjectStore()
+	orchestrator := newFakeOrchestrator(fakecloud.New("us-east-1"))
+	launcher := NewAsyncLauncherWithStore(orchestrator, store)
+	results := storage.NewLocalStorage(t.TempDir(), storage.Config{})
+	collector := NewAsyncCollectorWithStore(orchestrator, store).WithResultStore(results).
+		WithProvenance(Provenance{OrchestratorVersion: "v1.2.3", GitCommit: "abc1234"})
+
+	config := fakeBenchmarkConfig("m7i.large", "stream")
+	config.ContainerImage = "public.ecr.aws/aws-benchmarks/stream:intel-icelake"
+	job, err := launcher.LaunchSingleBenchmark(ctx, config, "results", "store", time.Hour)
+	if err != nil {
+		t.Fatalf("LaunchSingleBenchmark failed: %v", err)
+	}
+	systemInfo := `{"container_image": "public.ecr.aws/aws-benchmarks/stream:intel-icelake", "container_image_digest": "sha256:1111"}`
+	if err := store.PutObject(ctx, job.S3Bucket, NewS3SentinelFiles(job.S3Prefix).SystemInfo, []byte(systemInfo), "application/json"); err != nil {
+		t.Fatalf("PutObject failed: %v", err)
+	}
+	if err := store.PutObject(ctx, job.S3Bucket, NewS3SentinelFiles(job.S3Prefix).BenchmarkOutput, []byte(fakeSTREAMOutput), "text/plain"); err != nil {
+		t.Fatalf("PutObject failed: %v", err)
+	}
+	completeAsyncJob(t, store, job, fakeScriptResults)
+
+	// Collecting twice stores the result once
+	for i := 0; i < 2; i++ {
+		if _, err := collector.CheckAllBenchmarks(ctx, "results"); err != nil {
+			t.Fatalf("CheckAllBenchmarks failed: %v", err)
+		}
+	}
+
+	stored, err := results.GetResults(ctx, storage.ResultQuery{
+		InstanceTypes:   []string{"m7i.large"},
+		Regions:         []string{"us-east-1"},
+		BenchmarkSuites: []string{"stream"},
+		IncludeMetadata: true,
+	})
+	if err != nil {
+		t.Fatalf("GetResults failed: %v", err)
+	}
+	if len(stored) != 1 {
+		t.Fatalf("Expected one stored result, got %d", len(stored))
+	}
+	data := stored[0].(storage.StoredResult).Data
+	if metadata, _ := data["metadata"].(map[string]interface{}); metadata["benchmark_id"] != job.BenchmarkID {
+		t.Errorf("Expected the stored result to identify job %s, got %v", job.BenchmarkID, data["metadata"])
+	}
+	provenance, _ := data["provenance"].(map[string]interface{})
+	if provenance["orchestrator_version"] != "v1.2.3" || provenance["ami_id"] != job.AMIID || job.AMIID == "" {
+		t.Errorf("Expected provenance with the orchestrator version and AMI %q, got %v", job.AMIID, provenance)
+	}
+	if hash, _ := provenance["raw_output_sha256"].(string); len(hash) != 64 {
+		t.Errorf("Expected the raw output hash in the provenance, got %v", provenance)
+	}
+	if provenance["container_image_digest"] != "sha256:1111" {
+		t.Errorf("Expected the image digest reported by the instance, got %v", provenance)
+	}
+}
+
+// countingResultStore counts the results stored through it.
+type countingResultStore struct {
+	storage.ResultStore
+	stored int
+}
+
+func (s *countingResultStore) StoreResult(ctx context.Context, result interface{}) error {
+	s.stored++
+	return s.ResultStore.StoreResult(ctx, result)
+}
+
+func TestWaitForCompletionCollectsJobsOnce(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryObjectStore()
+	orchestrator := newFakeOrchestrator(fakecloud.New("us-east-1"))
+	results := &countingResultStore{ResultStore: storage.NewLocalStorage(t.TempDir(), storage.Config{})}
+	recorder := &eventRecorder{}
+	bus := NewEventBus()
+	bus.Subscribe(recorder)
+	collector := NewAsyncCollectorWithStore(orchestrator, store).WithResultStore(results).WithEvents(bus)
+
+	job, err := NewAsyncLauncherWithStore(orchestrator, store).
+		LaunchSingleBenchmark(ctx, fakeBenchmarkConfig("m7i.large", "stream"), "results", "once", time.Hour)
+	if err != nil {
+		t.Fatalf("LaunchSingleBenchmark failed: %v", err)
+	}
+	completeAsyncJob(t, store, job, fakeScriptResults)
+
+	result, err := collector.WaitForCompletion(ctx, []*AsyncBenchmarkJob{job}, time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitForCompletion failed: %v", err)
+	}
+	if len(result.Completed) != 1 || !result.Completed[0].Success {
+		t.Fatalf("Expected one completed job, got %+v", result)
+	}
+	if results.stored != 1 {
+		t.Errorf("Expected the result to be stored once, got %d", results.stored)
+	}
+	parsed := 0
+	for _, event := range recorder.events {
+		if event.Type == EventResultParsed {
+			parsed++
+		}
+	}
+	if parsed != 1 {
+		t.Errorf("Expected one result_parsed event, got %d", parsed)
+	}
+}
+
+func TestCollectorArchivesRawOutput(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryObjectStore()
+	orchestrator := newFakeOrchestrator(fakecloud.New("us-east-1"))
+	launcher := NewAsyncLauncherWithStore(orchestrator, store)
+	results := storage.NewLocalStorage(t.TempDir(), storage.Config{})
+	collector := NewAsyncCollectorWithStore(orchestrator, store).WithResultStore(results)
+
+	job, err := launcher.LaunchSingleBenchmark(ctx, fakeBenchmarkConfig("m7i.large", "stream"), "results", "archive", time.Hour)
+	if err != nil {
+		t.Fatalf("LaunchSingleBenchmark failed: %v", err)
+	}
+	if err := store.PutObject(ctx, job.S3Bucket, NewS3SentinelFiles(job.S3Prefix).BenchmarkOutput, []byte(fakeSTREAMOutput), "text/plain"); err != nil {
+		t.Fatalf("PutObject failed: %v", err)
+	}
+	completeAsyncJob(t, store, job, `{"benchmark_suite": "stream", "results": {"triad_bandwidth_mbps": 1}, "success": true, "exit_code": 0}`)
+
+	if _, err := collector.CheckAllBenchmarks(ctx, "results"); err != nil {
+		t.Fatalf("CheckAllBenchmarks failed: %v", err)
+	}
+	stored, err := results.GetResults(ctx, storage.ResultQuery{IncludeMetadata: true})
+	if err != nil {
+		t.Fatalf("GetResults failed: %v", err)
+	}
+	if len(stored) != 1 {
+		t.Fatalf("Expected one stored result, got %d", len(stored))
+	}
+	result := stored[0].(storage.StoredResult)
+	if metadata, _ := result.Data["metadata"].(map[string]interface{}); metadata["parser_version"] != ParserVersion {
+		t.Errorf("Expected parser version %s, got %v", ParserVersion, result.Data["metadata"])
+	}
+
+	var raw RawOutput
+	if err := results.LoadRawOutput(ctx, result.Key, &raw); err != nil {
+		t.Fatalf("LoadRawOutput failed: %v", err)
+	}
+	if len(raw.Iterations) != 1 || raw.Iterations[0].Stdout != fakeSTREAMOutput || !raw.Iterations[0].Parsed {
+		t.Fatalf("Expected the benchmark output archived as a parsed iteration, got %+v", raw.Iterations)
+	}
+	if provenance, _ := result.Data["provenance"].(map[string]interface{}); provenance["raw_output_sha256"] != raw.Hash() {
+		t.Errorf("Expected the provenance to record the archive hash %s, got %v", raw.Hash(), provenance)
+	}
+
+	benchmarkData, _, err := raw.Reparse()
+	if err != nil {
+		t.Fatalf("Reparse failed: %v", err)
+	}
+	if benchmarkData.STREAM == nil || benchmarkData.STREAM.Triad == nil || benchmarkData.STREAM.Triad.Bandwidth <= 1 {
+		t.Errorf("Expected STREAM results re-parsed from the raw output, got %+v", benchmarkData.STREAM)
+	}
+}
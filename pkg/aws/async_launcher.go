@@ -2,6 +2,7 @@ package aws
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,14 +10,21 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/google/uuid"
 )
 
+// defaultAsyncMaxRuntime is the runtime limit of relaunched jobs whose
+// metadata predates MaxRuntime.
+const defaultAsyncMaxRuntime = 4 * time.Hour
+
 // AsyncLauncher handles fire-and-forget benchmark execution
 type AsyncLauncher struct {
 	orchestrator *Orchestrator
-	s3Client     *s3.Client
+	store        ObjectStore
+	bootstrap    *BootstrapRenderer
+	events       *EventBus
 }
 
 // NewAsyncLauncher creates a new async benchmark launcher
@@ -26,12 +34,30 @@ func NewAsyncLauncher(region string) (*AsyncLauncher, error) {
 		return nil, fmt.Errorf("failed to create orchestrator: %w", err)
 	}
 
-	s3Client := s3.NewFromConfig(orchestrator.cfg)
+	return NewAsyncLauncherWithStore(orchestrator, NewS3ObjectStore(s3.NewFromConfig(orchestrator.cfg))), nil
+}
 
+// NewAsyncLauncherWithStore creates a launcher that writes job tracking
+// files to the given object store instead of S3.
+//
+// Example:
+//   cloud := fakecloud.New("us-east-1")
+//   orchestrator := aws.NewOrchestratorWithClients("us-east-1", cloud.EC2(), cloud.SSM())
+//   store, _ := aws.NewLocalObjectStore("/tmp/benchmarks")
+//   launcher := aws.NewAsyncLauncherWithStore(orchestrator, store)
+func NewAsyncLauncherWithStore(orchestrator *Orchestrator, store ObjectStore) *AsyncLauncher {
 	return &AsyncLauncher{
 		orchestrator: orchestrator,
-		s3Client:     s3Client,
-	}, nil
+		store:        store,
+		bootstrap:    NewBootstrapRenderer(),
+	}
+}
+
+// WithBootstrapRenderer renders instance user data from a custom template
+// set instead of the embedded templates.
+func (l *AsyncLauncher) WithBootstrapRenderer(renderer *BootstrapRenderer) *AsyncLauncher {
+	l.bootstrap = renderer
+	return l
 }
 
 // LaunchBenchmarks launches multiple benchmarks asynchronously
@@ -78,6 +104,38 @@ func (l *AsyncLauncher) LaunchBenchmarks(ctx context.Context, request *LaunchReq
 func (l *AsyncLauncher) LaunchSingleBenchmark(ctx context.Context, config BenchmarkConfig, 
 	s3Bucket, jobName string, maxRuntime time.Duration) (*AsyncBenchmarkJob, error) {
 
+	job := newAsyncJob(config, s3Bucket, jobName, maxRuntime)
+	if err := l.launchJob(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// relaunch launches the next attempt of a job with the same configuration,
+// linked to it through the attempt fields. When the instance launch fails
+// the attempt is still recorded, as FAILED, and returned with the error.
+func (l *AsyncLauncher) relaunch(ctx context.Context, previous *AsyncBenchmarkJob) (*AsyncBenchmarkJob, error) {
+	maxRuntime := previous.MaxRuntime
+	if maxRuntime <= 0 {
+		maxRuntime = defaultAsyncMaxRuntime
+	}
+
+	job := newAsyncJob(previous.BenchmarkConfig, previous.S3Bucket, previous.JobName, maxRuntime)
+	job.Attempt = previous.AttemptNumber() + 1
+	job.FirstAttemptID = previous.ChainID()
+	job.PreviousAttemptID = previous.BenchmarkID
+
+	if err := l.launchJob(ctx, job); err != nil {
+		if job.Status != JobStatusFailed {
+			return nil, err
+		}
+		return job, err
+	}
+	return job, nil
+}
+
+// newAsyncJob creates the metadata of a first attempt of a benchmark.
+func newAsyncJob(config BenchmarkConfig, s3Bucket, jobName string, maxRuntime time.Duration) *AsyncBenchmarkJob {
 	// Generate unique benchmark ID
 	benchmarkID := fmt.Sprintf("bench-%s-%s", 
 		strings.ReplaceAll(time.Now().Format("20060102-150405"), "-", ""),
@@ -88,7 +146,7 @@ func (l *AsyncLauncher) LaunchSingleBenchmark(ctx context.Context, config Benchm
 		benchmarkID, config.InstanceType, config.BenchmarkSuite)
 
 	// Create job metadata
-	job := &AsyncBenchmarkJob{
+	return &AsyncBenchmarkJob{
 		BenchmarkID:     benchmarkID,
 		JobName:         jobName,
 		BenchmarkConfig: config,
@@ -97,21 +155,42 @@ func (l *AsyncLauncher) LaunchSingleBenchmark(ctx context.Context, config Benchm
 		Status:          JobStatusLaunched,
 		LaunchedAt:      time.Now(),
 		Region:          config.Region,
+		MaxRuntime:      maxRuntime,
+		Attempt:         1,
 	}
+}
 
+// launchJob records a job and launches its instance.
+func (l *AsyncLauncher) launchJob(ctx context.Context, job *AsyncBenchmarkJob) error {
 	// Upload job metadata to S3 first
 	if err := l.uploadJobMetadata(ctx, job); err != nil {
-		return nil, fmt.Errorf("failed to upload job metadata: %w", err)
+		return fmt.Errorf("failed to upload job metadata: %w", err)
 	}
 
 	// Launch EC2 instance with self-contained benchmark execution
-	instanceID, err := l.launchBenchmarkInstance(ctx, job, maxRuntime)
+	instanceID, err := l.launchBenchmarkInstance(ctx, job, job.MaxRuntime)
 	if err != nil {
-		return nil, fmt.Errorf("failed to launch instance: %w", err)
+		// Record the failed launch so the collector can report and retry it
+		job.Status = JobStatusFailed
+		job.FailureCategory = FailureCategoryLaunch
+		if isCapacityError(err) {
+			job.FailureCategory = FailureCategoryCapacity
+		}
+		job.FailureReason = err.Error()
+		if err := l.uploadJobMetadata(ctx, job); err != nil {
+			fmt.Printf("⚠️  Warning: failed to record failed launch: %v\n", err)
+		}
+		if err := l.uploadSentinel(ctx, job, JobStatusFailed); err != nil {
+			fmt.Printf("⚠️  Warning: failed to upload failed sentinel: %v\n", err)
+		}
+		failed := asyncJobEvent(job, EventJobStatus)
+		failed.Status, failed.Message, failed.Error, failed.FailureCategory = JobStatusFailed, job.FailureReason, job.FailureReason, job.FailureCategory
+		l.events.Publish(failed)
+		return fmt.Errorf("failed to launch instance: %w", err)
 	}
 
 	job.InstanceID = instanceID
-	job.EstimatedCost = l.estimateJobCost(config.InstanceType, maxRuntime)
+	job.EstimatedCost = l.estimateJobCost(job.BenchmarkConfig.InstanceType, job.MaxRuntime)
 
 	// Update job metadata with instance ID
 	if err := l.uploadJobMetadata(ctx, job); err != nil {
@@ -123,7 +202,11 @@ func (l *AsyncLauncher) LaunchSingleBenchmark(ctx context.Context, config Benchm
 		fmt.Printf("⚠️  Warning: failed to upload launched sentinel: %v\n", err)
 	}
 
-	return job, nil
+	launched := asyncJobEvent(job, EventInstanceLaunched)
+	launched.Status, launched.Message = JobStatusLaunched, "instance launched"
+	l.events.Publish(launched)
+
+	return nil
 }
 
 // launchBenchmarkInstance launches an EC2 instance with self-contained benchmark execution
@@ -133,42 +216,58 @@ func (l *AsyncLauncher) launchBenchmarkInstance(ctx context.Context, job *AsyncB
 	config := job.BenchmarkConfig
 
 	// Generate comprehensive user data script
-	userData := l.generateAsyncUserDataScript(job, maxRuntime)
+	userData, err := l.generateAsyncUserDataScript(job, maxRuntime)
+	if err != nil {
+		return "", err
+	}
 	userDataEncoded := base64.StdEncoding.EncodeToString([]byte(userData))
 
-	// Get AMI ID for the instance type
-	amiID, err := l.orchestrator.getOptimalAMI(ctx, config.InstanceType)
+	// Resolve the configured AMI, or the latest Amazon Linux 2 AMI
+	amiID, err := l.orchestrator.resolveAMI(ctx, config)
 	if err != nil {
 		return "", fmt.Errorf("failed to get AMI: %w", err)
 	}
 
+	marketOptions, err := spotMarketOptions(config)
+	if err != nil {
+		return "", err
+	}
+	placement, reservation, err := placementOptions(config)
+	if err != nil {
+		return "", err
+	}
+
 	// Configure instance
 	runInput := &ec2.RunInstancesInput{
-		ImageId:      aws.String(amiID),
-		InstanceType: ec2.InstanceType(config.InstanceType),
+		ImageId:        imageID(amiID),
+		LaunchTemplate: launchTemplateSpec(config),
+		InstanceType: types.InstanceType(config.InstanceType),
 		MinCount:     aws.Int32(1),
 		MaxCount:     aws.Int32(1),
 		UserData:     aws.String(userDataEncoded),
+		InstanceMarketOptions: marketOptions,
+		Placement:             placement,
+		CapacityReservationSpecification: reservation,
 		
 		// Networking
 		SecurityGroupIds: []string{config.SecurityGroupID},
 		SubnetId:         aws.String(config.SubnetID),
 		
 		// Instance profile for S3 access
-		IamInstanceProfile: &ec2.IamInstanceProfileSpecification{
+		IamInstanceProfile: &types.IamInstanceProfileSpecification{
 			Name: aws.String("EC2-S3-BenchmarkAccess"), // Needs to be created
 		},
 		
 		// Tagging
-		TagSpecifications: []ec2.TagSpecification{
+		TagSpecifications: []types.TagSpecification{
 			{
-				ResourceType: ec2.ResourceTypeInstance,
-				Tags: []ec2.Tag{
+				ResourceType: types.ResourceTypeInstance,
+				Tags: []types.Tag{
 					{Key: aws.String("Name"), Value: aws.String(fmt.Sprintf("AsyncBenchmark-%s", job.BenchmarkID))},
-					{Key: aws.String("BenchmarkID"), Value: aws.String(job.BenchmarkID)},
+					{Key: aws.String(TagBenchmarkID), Value: aws.String(job.BenchmarkID)},
 					{Key: aws.String("BenchmarkSuite"), Value: aws.String(config.BenchmarkSuite)},
 					{Key: aws.String("InstanceType"), Value: aws.String(config.InstanceType)},
-					{Key: aws.String("LaunchedBy"), Value: aws.String("AsyncBenchmarkLauncher")},
+					{Key: aws.String(TagLaunchedBy), Value: aws.String(LaunchedByAsync)},
 					{Key: aws.String("S3Bucket"), Value: aws.String(job.S3Bucket)},
 					{Key: aws.String("AutoTerminate"), Value: aws.String("true")},
 				},
@@ -192,6 +291,7 @@ func (l *AsyncLauncher) launchBenchmarkInstance(ctx context.Context, job *AsyncB
 	}
 
 	instanceID := *result.Instances[0].InstanceId
+	job.AMIID = aws.ToString(result.Instances[0].ImageId)
 	
 	fmt.Printf("   🚀 Instance launched: %s\n", instanceID)
 	fmt.Printf("   📊 Benchmark: %s on %s\n", config.BenchmarkSuite, config.InstanceType)
@@ -202,205 +302,36 @@ func (l *AsyncLauncher) launchBenchmarkInstance(ctx context.Context, job *AsyncB
 	return instanceID, nil
 }
 
-// generateAsyncUserDataScript creates a comprehensive self-contained benchmark script
-func (l *AsyncLauncher) generateAsyncUserDataScript(job *AsyncBenchmarkJob, maxRuntime time.Duration) string {
+// generateAsyncUserDataScript renders the self-contained benchmark script
+// for the job's architecture and OS family.
+func (l *AsyncLauncher) generateAsyncUserDataScript(job *AsyncBenchmarkJob, maxRuntime time.Duration) (string, error) {
 	config := job.BenchmarkConfig
-	sentinels := NewS3SentinelFiles(job.S3Prefix)
-
-	return fmt.Sprintf(`#!/bin/bash
-set -e
-
-# AWS Instance Async Benchmark Execution
-# Benchmark ID: %s
-# Instance Type: %s
-# Benchmark Suite: %s
-# S3 Bucket: %s
-# Max Runtime: %v
-
-echo "🚀 Starting async benchmark execution at $(date)"
-
-# Install dependencies
-yum update -y
-yum install -y gcc gcc-c++ make wget unzip git htop iostat
-
-# Install AWS CLI v2
-curl "https://awscli.amazonaws.com/awscli-exe-linux-x86_64.zip" -o "awscliv2.zip"
-unzip awscliv2.zip
-./aws/install
-
-# Set up logging
-LOGFILE="/tmp/benchmark.log"
-exec 1> >(tee -a "$LOGFILE")
-exec 2> >(tee -a "$LOGFILE" >&2)
-
-# Function to upload to S3 with retry
-upload_to_s3() {
-    local file="$1"
-    local s3_path="$2"
-    local retries=3
-    
-    for i in $(seq 1 $retries); do
-        if aws s3 cp "$file" "$s3_path"; then
-            echo "✅ Uploaded $file to $s3_path"
-            return 0
-        else
-            echo "⚠️  Upload attempt $i failed, retrying..."
-            sleep 5
-        fi
-    done
-    echo "❌ Failed to upload $file after $retries attempts"
-    return 1
-}
-
-# Function to upload sentinel
-upload_sentinel() {
-    local status="$1"
-    echo "$(date): $status" > "/tmp/status-$status.sentinel"
-    upload_to_s3 "/tmp/status-$status.sentinel" "s3://%s/%sstatus-$status.sentinel"
-}
-
-# Function to upload progress
-upload_progress() {
-    local iteration="$1"
-    local total="$2"
-    local message="$3"
-    
-    cat > /tmp/status-progress.json <<EOF
-{
-    "current_iteration": $iteration,
-    "total_iterations": $total,
-    "last_update": "$(date -Iseconds)",
-    "message": "$message",
-    "percent_complete": $(( iteration * 100 / total ))
-}
-EOF
-    upload_to_s3 "/tmp/status-progress.json" "s3://%s/%sstatus-progress.json"
-}
-
-# Function to collect system info
-collect_system_info() {
-    cat > /tmp/system-info.json <<EOF
-{
-    "instance_id": "$(curl -s http://169.254.169.254/latest/meta-data/instance-id)",
-    "instance_type": "$(curl -s http://169.254.169.254/latest/meta-data/instance-type)",
-    "availability_zone": "$(curl -s http://169.254.169.254/latest/meta-data/placement/availability-zone)",
-    "cpu_info": "$(lscpu | grep 'Model name' | cut -d: -f2 | xargs)",
-    "memory_gb": $(free -g | awk '/^Mem:/{print $2}'),
-    "architecture": "$(uname -m)",
-    "kernel": "$(uname -r)",
-    "timestamp": "$(date -Iseconds)"
-}
-EOF
-    upload_to_s3 "/tmp/system-info.json" "s3://%s/%ssystem-info.json"
-}
-
-# Function to handle cleanup and termination
-cleanup_and_terminate() {
-    echo "🧹 Performing cleanup..."
-    
-    # Upload final logs
-    upload_to_s3 "$LOGFILE" "s3://%s/%sbenchmark.log"
-    
-    # Self-terminate instance
-    INSTANCE_ID=$(curl -s http://169.254.169.254/latest/meta-data/instance-id)
-    echo "🔚 Self-terminating instance $INSTANCE_ID"
-    aws ec2 terminate-instances --region %s --instance-ids "$INSTANCE_ID"
-}
-
-# Set up signal handlers for cleanup
-trap cleanup_and_terminate EXIT
-trap cleanup_and_terminate SIGTERM
-trap cleanup_and_terminate SIGINT
-
-# Set maximum runtime enforcement with failsafe
-timeout_seconds=%d
-failsafe_timeout_seconds=$((timeout_seconds + 3600))  # Add 1 hour failsafe buffer
-
-# Primary timeout - graceful termination
-if [ $timeout_seconds -gt 0 ]; then
-    (
-        sleep $timeout_seconds
-        echo "⏰ Maximum runtime exceeded, attempting graceful termination"
-        upload_sentinel "TIMED_OUT"
-        
-        # Try to terminate gracefully first
-        kill -TERM $$
-        
-        # Wait 10 minutes for graceful termination
-        sleep 600
-        
-        # Force kill if still running
-        echo "🚨 Graceful termination failed, forcing kill"
-        kill -KILL $$
-    ) &
-    TIMEOUT_PID=$!
-fi
-
-# Failsafe timeout - absolute maximum runtime (prevents runaway instances)
-if [ $failsafe_timeout_seconds -gt 0 ]; then
-    (
-        sleep $failsafe_timeout_seconds
-        echo "🚨 FAILSAFE TIMEOUT: Instance has exceeded absolute maximum runtime"
-        echo "🚨 This indicates a serious problem - forcing immediate termination"
-        
-        # Upload emergency sentinel
-        echo "$(date): EMERGENCY_TIMEOUT - Instance exceeded failsafe limit" > "/tmp/status-emergency.sentinel"
-        upload_to_s3 "/tmp/status-emergency.sentinel" "s3://%s/%sstatus-emergency.sentinel"
-        
-        # Force immediate instance termination
-        INSTANCE_ID=$(curl -s http://169.254.169.254/latest/meta-data/instance-id)
-        aws ec2 terminate-instances --region %s --instance-ids "$INSTANCE_ID" || true
-        
-        # If EC2 termination fails, try shutdown
-        sudo shutdown -h now
-        
-        # Ultimate fallback - kernel panic to force termination
-        echo 1 > /proc/sys/kernel/sysrq
-        echo c > /proc/sysrq-trigger
-    ) &
-    FAILSAFE_PID=$!
-fi
-
-echo "📋 Collecting system information..."
-collect_system_info
-
-echo "🏁 Starting benchmark execution..."
-upload_sentinel "RUNNING"
-
-# Generate and execute benchmark
-%s
 
-echo "✅ Benchmark execution completed at $(date)"
-upload_sentinel "COMPLETED"
-
-# Kill timeout processes if still running
-if [ ! -z "$TIMEOUT_PID" ]; then
-    kill $TIMEOUT_PID 2>/dev/null || true
-fi
-if [ ! -z "$FAILSAFE_PID" ]; then
-    kill $FAILSAFE_PID 2>/dev/null || true
-fi
+	// Instances upload to the same S3-compatible endpoint as the launcher
+	var endpoint string
+	if s3Store, ok := l.store.(*S3ObjectStore); ok {
+		endpoint = s3Store.Endpoint()
+	}
 
-echo "🎉 Async benchmark completed successfully!"
-`,
-		job.BenchmarkID,
-		config.InstanceType, 
-		config.BenchmarkSuite,
-		job.S3Bucket,
-		maxRuntime,
-		job.S3Bucket, job.S3Prefix,
-		job.S3Bucket, job.S3Prefix,
-		job.S3Bucket, job.S3Prefix,
-		job.S3Bucket, job.S3Prefix,
-		config.Region,
-		int(maxRuntime.Seconds()),
-		job.S3Bucket, job.S3Prefix,
-		config.Region,
-		l.generateBenchmarkExecutionScript(config))
+	return l.bootstrap.Render(BootstrapData{
+		BenchmarkID:     job.BenchmarkID,
+		InstanceType:    config.InstanceType,
+		BenchmarkSuite:  config.BenchmarkSuite,
+		Bucket:          job.S3Bucket,
+		Prefix:          job.S3Prefix,
+		Region:          config.Region,
+		S3Endpoint:      endpoint,
+		MaxRuntime:      maxRuntime,
+		OSFamily:        config.OSFamily,
+		ContainerImage:  config.ContainerImage,
+		BenchmarkScript: l.generateBenchmarkExecutionScript(job),
+	})
 }
 
-// generateBenchmarkExecutionScript creates the actual benchmark execution code
-func (l *AsyncLauncher) generateBenchmarkExecutionScript(config BenchmarkConfig) string {
+// generateBenchmarkExecutionScript creates the actual benchmark execution code,
+// uploading the raw output and results under the job's S3 prefix
+func (l *AsyncLauncher) generateBenchmarkExecutionScript(job *AsyncBenchmarkJob) string {
+	config := job.BenchmarkConfig
 	// Use the existing benchmark command generation from orchestrator
 	baseCommand := l.orchestrator.generateBenchmarkCommand(config)
 	
@@ -413,14 +344,19 @@ echo "🔬 Executing %s benchmark..."
 BENCHMARK_OUTPUT_FILE="/tmp/benchmark_output.txt"
 RESULTS_FILE="/tmp/results.json"
 
-# Execute the actual benchmark
-%s 2>&1 | tee "$BENCHMARK_OUTPUT_FILE"
+# Execute the actual benchmark, reporting progress around it
+upload_progress 0 1 "running %[1]s benchmark" || true
+%[2]s 2>&1 | tee "$BENCHMARK_OUTPUT_FILE"
 BENCHMARK_EXIT_CODE=${PIPESTATUS[0]}
+upload_progress 1 1 "%[1]s benchmark finished with exit code $BENCHMARK_EXIT_CODE" || true
+
+# Upload the raw output so the results can be re-parsed by the collector
+upload_to_s3 "$BENCHMARK_OUTPUT_FILE" "s3://%[5]s/%[6]sbenchmark-output.txt"
 
 echo "📊 Processing benchmark results..."
 
 # Parse results based on benchmark type
-case "%s" in
+case "%[3]s" in
     "stream")
         # Parse STREAM results
         COPY_BANDWIDTH=$(grep "Copy:" "$BENCHMARK_OUTPUT_FILE" | awk '{print $2}' | tail -1)
@@ -463,7 +399,7 @@ EOF
         # Generic result format
         cat > "$RESULTS_FILE" <<EOF
 {
-    "benchmark_suite": "%s",
+    "benchmark_suite": "%[4]s",
     "results": {
         "raw_output": "$(cat "$BENCHMARK_OUTPUT_FILE" | base64 -w 0)"
     },
@@ -476,7 +412,7 @@ EOF
 esac
 
 # Upload results to S3
-upload_to_s3 "$RESULTS_FILE" "s3://%s/%sresults.json"
+upload_to_s3 "$RESULTS_FILE" "s3://%[5]s/%[6]sresults.json"
 
 if [ $BENCHMARK_EXIT_CODE -eq 0 ]; then
     echo "✅ Benchmark completed successfully"
@@ -485,11 +421,16 @@ else
     upload_sentinel "FAILED"
     exit $BENCHMARK_EXIT_CODE
 fi
-`, config.BenchmarkSuite, baseCommand, config.BenchmarkSuite, config.BenchmarkSuite, config.BenchmarkSuite, config.BenchmarkSuite)
+`, config.BenchmarkSuite, baseCommand, config.BenchmarkSuite, config.BenchmarkSuite, job.S3Bucket, job.S3Prefix)
 }
 
-// uploadJobMetadata uploads job metadata to S3
+// uploadJobMetadata uploads job metadata to the object store
 func (l *AsyncLauncher) uploadJobMetadata(ctx context.Context, job *AsyncBenchmarkJob) error {
+	return putJobMetadata(ctx, l.store, job)
+}
+
+// putJobMetadata writes a job's job-metadata.json.
+func putJobMetadata(ctx context.Context, store ObjectStore, job *AsyncBenchmarkJob) error {
 	metadata, err := json.MarshalIndent(job, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal job metadata: %w", err)
@@ -497,29 +438,15 @@ func (l *AsyncLauncher) uploadJobMetadata(ctx context.Context, job *AsyncBenchma
 
 	sentinels := NewS3SentinelFiles(job.S3Prefix)
 	
-	_, err = l.s3Client.PutObject(ctx, &s3.PutObjectInput{
-		Bucket: aws.String(job.S3Bucket),
-		Key:    aws.String(sentinels.JobMetadata),
-		Body:   strings.NewReader(string(metadata)),
-		ContentType: aws.String("application/json"),
-	})
-
-	return err
+	return store.PutObject(ctx, job.S3Bucket, sentinels.JobMetadata, metadata, "application/json")
 }
 
-// uploadSentinel uploads a status sentinel file to S3
+// uploadSentinel uploads a status sentinel file to the object store
 func (l *AsyncLauncher) uploadSentinel(ctx context.Context, job *AsyncBenchmarkJob, status JobStatus) error {
 	sentinelContent := fmt.Sprintf("%s: %s", time.Now().Format(time.RFC3339), status)
 	sentinelKey := fmt.Sprintf("%sstatus-%s.sentinel", job.S3Prefix, strings.ToLower(string(status)))
 	
-	_, err := l.s3Client.PutObject(ctx, &s3.PutObjectInput{
-		Bucket: aws.String(job.S3Bucket),
-		Key:    aws.String(sentinelKey),
-		Body:   strings.NewReader(sentinelContent),
-		ContentType: aws.String("text/plain"),
-	})
-
-	return err
+	return l.store.PutObject(ctx, job.S3Bucket, sentinelKey, []byte(sentinelContent), "text/plain")
 }
 
 // estimateJobCost estimates the cost of running a benchmark job
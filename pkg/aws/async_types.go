@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -11,6 +12,9 @@ type AsyncBenchmarkJob struct {
 	InstanceID   string `json:"instance_id"`
 	JobName      string `json:"job_name"`
 	
+	// AMIID is the AMI the instance was launched from.
+	AMIID        string `json:"ami_id,omitempty"`
+	
 	// Configuration
 	BenchmarkConfig BenchmarkConfig `json:"benchmark_config"`
 	
@@ -27,6 +31,40 @@ type AsyncBenchmarkJob struct {
 	// Cost tracking
 	EstimatedCost float64 `json:"estimated_cost"`
 	Region       string  `json:"region"`
+	
+	// MaxRuntime is the runtime limit the instance enforces on itself
+	MaxRuntime   time.Duration `json:"max_runtime,omitempty"`
+
+	// StallReason explains why the job was last found STALLED
+	StallReason  string `json:"stall_reason,omitempty"`
+	
+	// Retry tracking: every attempt of a benchmark shares FirstAttemptID,
+	// and each attempt links to the ones before and after it
+	Attempt           int             `json:"attempt,omitempty"`
+	FirstAttemptID    string          `json:"first_attempt_id,omitempty"`
+	PreviousAttemptID string          `json:"previous_attempt_id,omitempty"`
+	RetriedAs         string          `json:"retried_as,omitempty"`
+	RetryAfter        *time.Time      `json:"retry_after,omitempty"`
+	FailureCategory   FailureCategory `json:"failure_category,omitempty"`
+	FailureReason     string          `json:"failure_reason,omitempty"`
+}
+
+// AttemptNumber returns the 1-based attempt of the job. Jobs launched
+// before attempts were tracked count as first attempts.
+func (j *AsyncBenchmarkJob) AttemptNumber() int {
+	if j.Attempt < 1 {
+		return 1
+	}
+	return j.Attempt
+}
+
+// ChainID identifies the benchmark a job is an attempt of: the
+// BenchmarkID of its first attempt.
+func (j *AsyncBenchmarkJob) ChainID() string {
+	if j.FirstAttemptID != "" {
+		return j.FirstAttemptID
+	}
+	return j.BenchmarkID
 }
 
 // JobStatus represents the current state of an async benchmark job
@@ -39,6 +77,7 @@ const (
 	JobStatusFailed         JobStatus = "FAILED"           // Benchmark failed
 	JobStatusTimedOut       JobStatus = "TIMED_OUT"        // Benchmark exceeded maximum runtime
 	JobStatusEmergencyStop  JobStatus = "EMERGENCY_STOP"   // Failsafe timeout triggered
+	JobStatusStalled        JobStatus = "STALLED"          // Heartbeats or benchmark output stopped
 	JobStatusTerminated     JobStatus = "TERMINATED"       // Instance terminated
 )
 
@@ -50,10 +89,14 @@ type S3SentinelFiles struct {
 	StatusProgress    string // status-progress.json
 	StatusCompleted   string // status-completed.sentinel
 	StatusFailed      string // status-failed.sentinel
+	StatusTimedOut    string // status-timed_out.sentinel
 	StatusEmergency   string // status-emergency.sentinel
+	StatusStalled     string // status-stalled.sentinel
 	BenchmarkResults  string // results.json
+	BenchmarkOutput   string // benchmark-output.txt
 	BenchmarkLogs     string // benchmark.log
 	SystemInfo        string // system-info.json
+	Diagnostics       string // diagnostics.txt
 }
 
 // NewS3SentinelFiles creates the S3 file paths for a job
@@ -65,10 +108,14 @@ func NewS3SentinelFiles(s3Prefix string) *S3SentinelFiles {
 		StatusProgress:   s3Prefix + "status-progress.json",
 		StatusCompleted:  s3Prefix + "status-completed.sentinel",
 		StatusFailed:     s3Prefix + "status-failed.sentinel",
+		StatusTimedOut:   s3Prefix + "status-timed_out.sentinel",
 		StatusEmergency:  s3Prefix + "status-emergency.sentinel",
+		StatusStalled:    s3Prefix + "status-stalled.sentinel",
 		BenchmarkResults: s3Prefix + "results.json",
+		BenchmarkOutput:  s3Prefix + "benchmark-output.txt",
 		BenchmarkLogs:    s3Prefix + "benchmark.log",
 		SystemInfo:       s3Prefix + "system-info.json",
+		Diagnostics:      s3Prefix + "diagnostics.txt",
 	}
 }
 
@@ -79,16 +126,172 @@ type BenchmarkProgress struct {
 	LastUpdate       time.Time `json:"last_update"`
 	Message          string    `json:"message"`
 	PercentComplete  float64   `json:"percent_complete"`
+	
+	// Heartbeat is refreshed every HeartbeatIntervalSeconds while the
+	// instance script is alive.
+	Heartbeat                time.Time `json:"heartbeat"`
+	HeartbeatIntervalSeconds int       `json:"heartbeat_interval_seconds"`
+	
+	// LastActivity is when the benchmark last wrote output.
+	LastActivity time.Time `json:"last_activity"`
+}
+
+// LastSeen returns when the instance script last reported in.
+func (p *BenchmarkProgress) LastSeen() time.Time {
+	if p.Heartbeat.After(p.LastUpdate) {
+		return p.Heartbeat
+	}
+	return p.LastUpdate
+}
+
+// IsStale reports whether the instance script has not reported in for
+// longer than maxAge.
+func (p *BenchmarkProgress) IsStale(now time.Time, maxAge time.Duration) bool {
+	return now.Sub(p.LastSeen()) > maxAge
+}
+
+// StallAction is what the collector does about a stalled job.
+type StallAction string
+
+const (
+	StallActionWait     StallAction = "wait"     // Report the stall and keep waiting
+	StallActionDiagnose StallAction = "diagnose" // Capture diagnostics from the instance, then keep waiting
+	StallActionRelaunch StallAction = "relaunch" // Capture diagnostics, terminate and relaunch the job
+)
+
+// StallPolicy decides when a running job counts as stalled and what the
+// collector does about it.
+type StallPolicy struct {
+	// HeartbeatTimeout is how long a job may go without a heartbeat.
+	HeartbeatTimeout time.Duration
+	
+	// ActivityTimeout is how long a job's benchmark may go without writing
+	// output. Zero disables the check, since some benchmarks only print
+	// when they finish.
+	ActivityTimeout time.Duration
+	
+	// Action is taken once a job is stalled.
+	Action StallAction
+}
+
+// DefaultStallPolicy captures diagnostics from jobs that miss five minutes
+// of heartbeats (five default heartbeat intervals).
+func DefaultStallPolicy() StallPolicy {
+	return StallPolicy{
+		HeartbeatTimeout: 5 * time.Minute,
+		Action:           StallActionDiagnose,
+	}
+}
+
+// StallReason returns why a job with the given progress is stalled, or an
+// empty string when it is healthy.
+func (p StallPolicy) StallReason(progress *BenchmarkProgress, now time.Time) string {
+	if progress == nil {
+		return ""
+	}
+	if p.HeartbeatTimeout > 0 && progress.IsStale(now, p.HeartbeatTimeout) {
+		return fmt.Sprintf("no heartbeat for %v", now.Sub(progress.LastSeen()).Round(time.Second))
+	}
+	if p.ActivityTimeout > 0 && !progress.LastActivity.IsZero() && now.Sub(progress.LastActivity) > p.ActivityTimeout {
+		return fmt.Sprintf("no benchmark output for %v", now.Sub(progress.LastActivity).Round(time.Second))
+	}
+	return ""
+}
+
+// FailureCategory classifies why an async job attempt failed.
+type FailureCategory string
+
+const (
+	FailureCategoryCapacity         FailureCategory = "capacity"          // EC2 had no capacity or quota for the instance
+	FailureCategorySpotInterruption FailureCategory = "spot_interruption" // The spot instance was reclaimed
+	FailureCategoryParse            FailureCategory = "parse"             // Results were missing or unreadable
+	FailureCategoryTimeout          FailureCategory = "timeout"           // The job exceeded its maximum runtime
+	FailureCategoryLaunch           FailureCategory = "launch"            // The instance could not be launched
+	FailureCategoryBenchmark        FailureCategory = "benchmark"         // The benchmark exited with an error
+)
+
+// RetryPolicy decides which failed or timed-out async jobs the collector
+// relaunches, and when.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per benchmark, including
+	// the first. Values below 2 disable retries.
+	MaxAttempts int
+	
+	// Backoff is the delay before the second attempt. Each further attempt
+	// waits BackoffMultiplier times longer, up to MaxBackoff.
+	Backoff           time.Duration
+	BackoffMultiplier float64
+	MaxBackoff        time.Duration
+	
+	// RetryOn lists the failure categories that are retried.
+	RetryOn []FailureCategory
+}
+
+// DefaultRetryPolicy retries capacity shortfalls, spot interruptions,
+// unreadable results and timeouts up to three attempts, backing off from
+// one minute. Launch and benchmark errors are usually deterministic and
+// are not retried.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:       3,
+		Backoff:           time.Minute,
+		BackoffMultiplier: 2,
+		MaxBackoff:        15 * time.Minute,
+		RetryOn: []FailureCategory{
+			FailureCategoryCapacity,
+			FailureCategorySpotInterruption,
+			FailureCategoryParse,
+			FailureCategoryTimeout,
+		},
+	}
+}
+
+// ShouldRetry reports whether a failed job gets another attempt.
+func (p RetryPolicy) ShouldRetry(job *AsyncBenchmarkJob) bool {
+	if job.AttemptNumber() >= p.MaxAttempts {
+		return false
+	}
+	for _, category := range p.RetryOn {
+		if category == job.FailureCategory {
+			return true
+		}
+	}
+	return false
+}
+
+// BackoffAfter returns how long to wait before retrying the given attempt.
+func (p RetryPolicy) BackoffAfter(attempt int) time.Duration {
+	backoff := p.Backoff
+	multiplier := p.BackoffMultiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	for i := 1; i < attempt; i++ {
+		backoff = time.Duration(float64(backoff) * multiplier)
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return backoff
 }
 
 // AsyncBenchmarkResult contains the final results from an async benchmark
 type AsyncBenchmarkResult struct {
 	Job           *AsyncBenchmarkJob `json:"job"`
-	BenchmarkData map[string]interface{} `json:"benchmark_data"`
+	BenchmarkData *BenchmarkResults `json:"benchmark_data"`
 	SystemInfo    map[string]interface{} `json:"system_info"`
 	ExecutionTime time.Duration `json:"execution_time"`
 	Success       bool `json:"success"`
 	Error         string `json:"error,omitempty"`
+	ErrorLogs     string `json:"error_logs,omitempty"`
+	
+	// RawOutput is the raw output of the benchmark command, archived next
+	// to the stored result, and RawOutputSHA256 its Hash.
+	RawOutput       *RawOutput `json:"-"`
+	RawOutputSHA256 string     `json:"raw_output_sha256,omitempty"`
 }
 
 // LaunchRequest contains parameters for launching async benchmarks
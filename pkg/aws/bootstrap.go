@@ -0,0 +1,276 @@
+package aws
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Operating system families supported by the async bootstrap templates.
+const (
+	OSFamilyAmazonLinux = "amazon-linux"
+	OSFamilyUbuntu      = "ubuntu"
+)
+
+// BootstrapTemplateVersion is the version of the embedded bootstrap templates.
+const BootstrapTemplateVersion = "v1"
+
+// DefaultHeartbeatInterval is how often async instances report liveness.
+const DefaultHeartbeatInterval = time.Minute
+
+// MaxUserDataSize is the EC2 limit on user data before base64 encoding.
+const MaxUserDataSize = 16 * 1024
+
+// Bootstrap rendering errors.
+var (
+	ErrUserDataTooLarge    = errors.New("user data exceeds the EC2 limit")
+	ErrInvalidBootstrap    = errors.New("bootstrap script failed validation")
+	ErrUnsupportedOSFamily = errors.New("unsupported OS family")
+)
+
+//go:embed templates/bootstrap
+var bootstrapTemplates embed.FS
+
+// BootstrapData is the input of a bootstrap template.
+type BootstrapData struct {
+	BenchmarkID    string
+	InstanceType   string
+	BenchmarkSuite string
+	Bucket         string
+	Prefix         string
+	Region         string
+	MaxRuntime     time.Duration
+
+	// S3Endpoint is the endpoint of an S3-compatible service such as MinIO
+	// that the instance uploads to. Empty means AWS S3.
+	S3Endpoint string
+
+	// HeartbeatInterval is how often the instance refreshes
+	// status-progress.json. Defaults to DefaultHeartbeatInterval.
+	HeartbeatInterval time.Duration
+
+	// Architecture is the EC2 architecture, "x86_64" or "arm64".
+	// Defaults to the architecture of InstanceType.
+	Architecture string
+
+	// OSFamily selects the package manager setup, e.g. "amazon-linux".
+	// Defaults to Amazon Linux.
+	OSFamily string
+
+	// ContainerImage is the benchmark's container image. If set, the
+	// instance reports the digest it resolved to in system-info.json.
+	ContainerImage string
+
+	// BenchmarkScript is the shell code that runs the benchmark and
+	// uploads its results.
+	BenchmarkScript string
+
+	// Version is set by the renderer to the template version used.
+	Version string
+}
+
+// TimeoutSeconds returns MaxRuntime in whole seconds for the template.
+func (d BootstrapData) TimeoutSeconds() int {
+	return int(d.MaxRuntime.Seconds())
+}
+
+// HeartbeatSeconds returns HeartbeatInterval in whole seconds for the template.
+func (d BootstrapData) HeartbeatSeconds() int {
+	if d.HeartbeatInterval <= 0 {
+		return int(DefaultHeartbeatInterval.Seconds())
+	}
+	return int(d.HeartbeatInterval.Seconds())
+}
+
+// BootstrapRenderer renders async user-data scripts from versioned templates.
+//
+// A template set is a directory per version holding bootstrap.sh.tmpl, which
+// includes the "install_packages" template from os/<family>.sh.tmpl and the
+// "arch_env" template from arch/<architecture>.sh.tmpl. Every rendered script
+// is statically validated and checked against MaxUserDataSize.
+type BootstrapRenderer struct {
+	templates fs.FS
+	version   string
+}
+
+// NewBootstrapRenderer returns a renderer for the embedded templates.
+func NewBootstrapRenderer() *BootstrapRenderer {
+	templates, _ := fs.Sub(bootstrapTemplates, "templates/bootstrap")
+	return NewBootstrapRendererFS(templates, BootstrapTemplateVersion)
+}
+
+// NewBootstrapRendererFS returns a renderer for the given version of a
+// custom template set laid out like templates/bootstrap.
+func NewBootstrapRendererFS(templates fs.FS, version string) *BootstrapRenderer {
+	return &BootstrapRenderer{templates: templates, version: version}
+}
+
+// Render renders, validates and size-checks a bootstrap script.
+func (r *BootstrapRenderer) Render(data BootstrapData) (string, error) {
+	if data.Architecture == "" {
+		data.Architecture = instanceArchitecture(data.InstanceType)
+	}
+	if data.OSFamily == "" {
+		data.OSFamily = OSFamilyAmazonLinux
+	}
+	data.Version = r.version
+
+	osTemplate := path.Join(r.version, "os", data.OSFamily+".sh.tmpl")
+	if _, err := fs.Stat(r.templates, osTemplate); err != nil {
+		return "", fmt.Errorf("%w: %s (bootstrap %s)", ErrUnsupportedOSFamily, data.OSFamily, r.version)
+	}
+	archTemplate := path.Join(r.version, "arch", data.Architecture+".sh.tmpl")
+	if _, err := fs.Stat(r.templates, archTemplate); err != nil {
+		return "", fmt.Errorf("no bootstrap %s template for architecture %s", r.version, data.Architecture)
+	}
+
+	tmpl, err := template.New("bootstrap.sh.tmpl").Option("missingkey=error").
+		ParseFS(r.templates, path.Join(r.version, "bootstrap.sh.tmpl"), osTemplate, archTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse bootstrap %s templates: %w", r.version, err)
+	}
+
+	var script strings.Builder
+	if err := tmpl.Execute(&script, data); err != nil {
+		return "", fmt.Errorf("failed to render bootstrap script: %w", err)
+	}
+
+	if err := ValidateBootstrapScript(script.String()); err != nil {
+		return "", err
+	}
+	if size := script.Len(); size > MaxUserDataSize {
+		return "", fmt.Errorf("%w: %d bytes for %s on %s (limit %d)", ErrUserDataTooLarge, size, data.BenchmarkSuite, data.InstanceType, MaxUserDataSize)
+	}
+	return script.String(), nil
+}
+
+// heredocPattern matches a here-document redirection and captures its
+// delimiter, e.g. <<EOF, << 'EOF' or <<-"END". Here-strings (<<<) are
+// excluded.
+var heredocPattern = regexp.MustCompile(`(?:^|[^<])<<-?\s*['"]?([A-Za-z_][A-Za-z0-9_]*)['"]?`)
+
+// shellBlocks maps the keywords that open a compound command to the keyword
+// that closes it.
+var shellBlocks = map[string]string{"if": "fi", "case": "esac", "for": "done", "while": "done", "until": "done"}
+
+// ValidateBootstrapScript performs shellcheck-style static checks on a
+// rendered script: a bash shebang, no unrendered template output, terminated
+// here-documents and balanced if/fi, case/esac and loop/done blocks.
+func ValidateBootstrapScript(script string) error {
+	var findings []string
+	report := func(line int, format string, args ...interface{}) {
+		findings = append(findings, fmt.Sprintf("line %d: %s", line, fmt.Sprintf(format, args...)))
+	}
+
+	if !strings.HasPrefix(script, "#!/bin/bash\n") {
+		report(1, "missing #!/bin/bash shebang")
+	}
+
+	type block struct {
+		keyword string
+		line    int
+	}
+	var open []block
+	var heredoc string
+	heredocLine := 0
+
+	scanner := bufio.NewScanner(strings.NewReader(script))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		if strings.Contains(line, "<no value>") {
+			report(lineNo, "unrendered template output")
+		}
+
+		if heredoc != "" {
+			if strings.TrimLeft(line, "\t") == heredoc {
+				heredoc = ""
+			}
+			continue
+		}
+
+		code := strings.TrimSpace(line)
+		if code == "" || strings.HasPrefix(code, "#") {
+			continue
+		}
+		if match := heredocPattern.FindStringSubmatch(code); match != nil && !strings.Contains(code, "<<<") {
+			heredoc, heredocLine = match[1], lineNo
+		}
+
+		for _, word := range commandWords(code) {
+			if _, opens := shellBlocks[word]; opens {
+				open = append(open, block{keyword: word, line: lineNo})
+				continue
+			}
+			if word != "fi" && word != "esac" && word != "done" {
+				continue
+			}
+			if len(open) == 0 || shellBlocks[open[len(open)-1].keyword] != word {
+				report(lineNo, "unexpected %q", word)
+				continue
+			}
+			open = open[:len(open)-1]
+		}
+	}
+
+	if heredoc != "" {
+		report(heredocLine, "here-document is not terminated by %q", heredoc)
+	}
+	for _, b := range open {
+		report(b.line, "%q is never closed with %q", b.keyword, shellBlocks[b.keyword])
+	}
+
+	if len(findings) > 0 {
+		return fmt.Errorf("%w:\n  %s", ErrInvalidBootstrap, strings.Join(findings, "\n  "))
+	}
+	return nil
+}
+
+// commandWords returns the first word of each command on a line of shell,
+// splitting on command separators outside of quotes.
+func commandWords(line string) []string {
+	var words []string
+	var current strings.Builder
+	var quote rune
+	atCommand := true
+
+	flush := func() {
+		if atCommand && current.Len() > 0 {
+			words = append(words, current.String())
+			atCommand = false
+		}
+		current.Reset()
+	}
+
+	for _, c := range line {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+			atCommand = false
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+			flush()
+			atCommand = false
+		case c == '#' && current.Len() == 0:
+			return words
+		case c == ';' || c == '&' || c == '|' || c == '(' || c == ')':
+			flush()
+			atCommand = true
+		case c == ' ' || c == '\t':
+			flush()
+		default:
+			current.WriteRune(c)
+		}
+	}
+	flush()
+	return words
+}
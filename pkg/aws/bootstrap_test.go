@@ -0,0 +1,264 @@
+package aws
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/fakecloud"
+)
+
+// bootstrapGoldenDir holds one rendered script per OS family and
+// architecture of the embedded templates:
+//
+//   go test ./pkg/aws -run TestBootstrapGolden -update
+const bootstrapGoldenDir = "testdata/bootstrap"
+
+func goldenBootstrapData(osFamily, architecture string) BootstrapData {
+	instanceType := "m7i.large"
+	if architecture == "arm64" {
+		instanceType = "c7g.large"
+	}
+	return BootstrapData{
+		BenchmarkID:     "bench-20250630-abcdef12",
+		InstanceType:    instanceType,
+		BenchmarkSuite:  "stream",
+		Bucket:          "benchmark-results",
+		Prefix:          "benchmarks/bench-20250630-abcdef12/" + instanceType + "/stream/",
+		Region:          "us-east-1",
+		MaxRuntime:      2 * time.Hour,
+		Architecture:    architecture,
+		OSFamily:        osFamily,
+		BenchmarkScript: "./run-benchmark.sh",
+	}
+}
+
+func TestBootstrapGolden(t *testing.T) {
+	for _, osFamily := range []string{OSFamilyAmazonLinux, OSFamilyUbuntu} {
+		for _, architecture := range []string{"x86_64", "arm64"} {
+			name := osFamily + "_" + architecture
+			t.Run(name, func(t *testing.T) {
+				script, err := NewBootstrapRenderer().Render(goldenBootstrapData(osFamily, architecture))
+				if err != nil {
+					t.Fatalf("Render failed: %v", err)
+				}
+				checkShellSyntax(t, script)
+
+				goldenPath := filepath.Join(bootstrapGoldenDir, BootstrapTemplateVersion, name+".sh.golden")
+				if *updateGolden {
+					if err := os.MkdirAll(filepath.Dir(goldenPath), 0755); err != nil {
+						t.Fatalf("failed to create golden dir: %v", err)
+					}
+					if err := os.WriteFile(goldenPath, []byte(script), 0644); err != nil {
+						t.Fatalf("failed to write golden: %v", err)
+					}
+					return
+				}
+				want, err := os.ReadFile(goldenPath)
+				if err != nil {
+					t.Fatalf("failed to read golden (run with -update to create it): %v", err)
+				}
+				if script != string(want) {
+					t.Errorf("rendered script differs from %s (run with -update after reviewing the change)", goldenPath)
+				}
+			})
+		}
+	}
+}
+
+// checkShellSyntax parses a script with bash -n when bash is available.
+func checkShellSyntax(t *testing.T, script string) {
+	t.Helper()
+
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		return
+	}
+	cmd := exec.Command(bash, "-n")
+	cmd.Stdin = strings.NewReader(script)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("bash -n failed: %v\n%s", err, out)
+	}
+}
+
+func TestBootstrapSelectsPackagesAndAWSCLI(t *testing.T) {
+	tests := []struct {
+		osFamily, instanceType string
+		want, notWant          []string
+	}{
+		{OSFamilyAmazonLinux, "m7i.large", []string{"yum install", "AWSCLI_ARCH=x86_64"}, []string{"apt-get"}},
+		{OSFamilyAmazonLinux, "c7g.large", []string{"yum install", "AWSCLI_ARCH=aarch64"}, []string{"apt-get"}},
+		{OSFamilyUbuntu, "m7i.large", []string{"apt-get install", "AWSCLI_ARCH=x86_64"}, []string{"yum"}},
+		{OSFamilyUbuntu, "c7g.large", []string{"apt-get install", "AWSCLI_ARCH=aarch64"}, []string{"yum"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.osFamily+"/"+tt.instanceType, func(t *testing.T) {
+			data := goldenBootstrapData(tt.osFamily, "")
+			data.InstanceType = tt.instanceType
+			script, err := NewBootstrapRenderer().Render(data)
+			if err != nil {
+				t.Fatalf("Render failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(script, want) {
+					t.Errorf("expected script to contain %q", want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(script, notWant) {
+					t.Errorf("expected script not to contain %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestBootstrapReportsContainerImageDigest(t *testing.T) {
+	data := goldenBootstrapData(OSFamilyAmazonLinux, "x86_64")
+	script, err := NewBootstrapRenderer().Render(data)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if strings.Contains(script, "container_image") {
+		t.Error("Expected no container image fields without an image")
+	}
+
+	data.ContainerImage = "public.ecr.aws/aws-benchmarks/stream:intel-icelake"
+	script, err = NewBootstrapRenderer().Render(data)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	for _, want := range []string{
+		`docker image inspect --format '{{index .RepoDigests 0}}' "public.ecr.aws/aws-benchmarks/stream:intel-icelake"`,
+		`"container_image": "public.ecr.aws/aws-benchmarks/stream:intel-icelake",`,
+		`"container_image_digest": "${image_digest#*@}",`,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("expected script to contain %q", want)
+		}
+	}
+	checkShellSyntax(t, script)
+}
+
+func TestAsyncUserDataForSuites(t *testing.T) {
+	launcher := &AsyncLauncher{orchestrator: newFakeOrchestrator(fakecloud.New("us-east-1")), bootstrap: NewBootstrapRenderer()}
+
+	for _, suite := range []string{"stream", "hpl", "coremark"} {
+		for _, instanceType := range []string{"m7i.large", "c7g.large"} {
+			job := &AsyncBenchmarkJob{
+				BenchmarkID:     "bench-test",
+				BenchmarkConfig: fakeBenchmarkConfig(instanceType, suite),
+				S3Bucket:        "benchmark-results",
+				S3Prefix:        "benchmarks/bench-test/",
+			}
+			script, err := launcher.generateAsyncUserDataScript(job, time.Hour)
+			if err != nil {
+				t.Errorf("%s on %s: %v", suite, instanceType, err)
+				continue
+			}
+			for _, want := range []string{`upload_progress 0 1 "running ` + suite + ` benchmark"`, `upload_progress 1 1 "` + suite + ` benchmark finished`} {
+				if !strings.Contains(script, want) {
+					t.Errorf("%s on %s: expected script to contain %q", suite, instanceType, want)
+				}
+			}
+			checkShellSyntax(t, script)
+		}
+	}
+}
+
+func TestBootstrapUserDataLimit(t *testing.T) {
+	data := goldenBootstrapData(OSFamilyAmazonLinux, "x86_64")
+	data.BenchmarkScript = "# " + strings.Repeat("x", MaxUserDataSize)
+
+	if _, err := NewBootstrapRenderer().Render(data); !errors.Is(err, ErrUserDataTooLarge) {
+		t.Errorf("Expected ErrUserDataTooLarge, got %v", err)
+	}
+}
+
+func TestBootstrapUnsupportedOSFamily(t *testing.T) {
+	if _, err := NewBootstrapRenderer().Render(goldenBootstrapData("windows", "x86_64")); !errors.Is(err, ErrUnsupportedOSFamily) {
+		t.Errorf("Expected ErrUnsupportedOSFamily, got %v", err)
+	}
+}
+
+func TestBootstrapRendererFS(t *testing.T) {
+	templates := fstest.MapFS{
+		"v2/bootstrap.sh.tmpl":       {Data: []byte("#!/bin/bash\n{{template \"install_packages\" .}}\n{{template \"arch_env\" .}}\n# {{.Version}}\n")},
+		"v2/os/amazon-linux.sh.tmpl": {Data: []byte(`{{define "install_packages"}}dnf install -y gcc{{end}}`)},
+		"v2/arch/arm64.sh.tmpl":      {Data: []byte(`{{define "arch_env"}}AWSCLI_ARCH=aarch64{{end}}`)},
+	}
+
+	data := goldenBootstrapData(OSFamilyAmazonLinux, "")
+	data.InstanceType = "c7g.large"
+	script, err := NewBootstrapRendererFS(templates, "v2").Render(data)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if want := "#!/bin/bash\ndnf install -y gcc\nAWSCLI_ARCH=aarch64\n# v2\n"; script != want {
+		t.Errorf("Render = %q, want %q", script, want)
+	}
+
+	data.InstanceType = "m7i.large"
+	if _, err := NewBootstrapRendererFS(templates, "v2").Render(data); err == nil {
+		t.Error("Expected error for an architecture without a template")
+	}
+}
+
+func TestValidateBootstrapScript(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			script: "#!/bin/bash\nfor i in 1 2; do\n  if [ $i -gt 1 ]; then echo done; fi\ndone\ncase \"$x\" in\n  a) echo a ;;\nesac\ncat <<'EOF'\nif\nEOF\n",
+		},
+		{
+			name:    "missing shebang",
+			script:  "echo hello\n",
+			wantErr: "shebang",
+		},
+		{
+			name:    "unterminated heredoc",
+			script:  "#!/bin/bash\ncat > /tmp/out <<EOF\n{}\n",
+			wantErr: `not terminated by "EOF"`,
+		},
+		{
+			name:    "unclosed if",
+			script:  "#!/bin/bash\nif true; then\n  echo yes\n",
+			wantErr: `"if" is never closed`,
+		},
+		{
+			name:    "mismatched done",
+			script:  "#!/bin/bash\nif true; then\n  echo yes\ndone\n",
+			wantErr: `unexpected "done"`,
+		},
+		{
+			name:    "unrendered value",
+			script:  "#!/bin/bash\naws s3 cp x s3://<no value>/\n",
+			wantErr: "unrendered template output",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBootstrapScript(tt.script)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected valid script, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidBootstrap) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
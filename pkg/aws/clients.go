@@ -0,0 +1,142 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// EC2API defines the subset of the EC2 API used by the Orchestrator.
+//
+// The interface is satisfied by *ec2.Client for production use and by the
+// in-memory fake in pkg/fakecloud for offline testing. Keeping the surface
+// narrow makes it explicit which EC2 operations benchmark orchestration
+// depends on and keeps fakes small.
+type EC2API interface {
+	RunInstances(ctx context.Context, params *ec2.RunInstancesInput, optFns ...func(*ec2.Options)) (*ec2.RunInstancesOutput, error)
+	DescribeInstances(ctx context.Context, params *ec2.DescribeInstancesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error)
+	DescribeImages(ctx context.Context, params *ec2.DescribeImagesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error)
+	TerminateInstances(ctx context.Context, params *ec2.TerminateInstancesInput, optFns ...func(*ec2.Options)) (*ec2.TerminateInstancesOutput, error)
+}
+
+// SSMAPI defines the subset of the Systems Manager API used by the
+// Orchestrator for remote command execution on benchmark instances.
+type SSMAPI interface {
+	SendCommand(ctx context.Context, params *ssm.SendCommandInput, optFns ...func(*ssm.Options)) (*ssm.SendCommandOutput, error)
+	GetCommandInvocation(ctx context.Context, params *ssm.GetCommandInvocationInput, optFns ...func(*ssm.Options)) (*ssm.GetCommandInvocationOutput, error)
+	GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+}
+
+// Compile-time checks that the SDK clients satisfy the orchestration interfaces.
+var (
+	_ EC2API = (*ec2.Client)(nil)
+	_ SSMAPI = (*ssm.Client)(nil)
+)
+
+// PollingConfig controls how often the Orchestrator polls AWS while waiting
+// for instances and commands to progress.
+//
+// The defaults are tuned for real EC2 latencies. Tests running against an
+// in-memory backend should use much shorter intervals so that complete
+// orchestration flows finish in milliseconds.
+type PollingConfig struct {
+	// InstanceStateInterval is the delay between instance state checks.
+	InstanceStateInterval time.Duration
+
+	// UserDataSettleTime is the grace period after an instance reaches the
+	// running state before benchmark commands are sent.
+	UserDataSettleTime time.Duration
+
+	// BenchmarkPollInterval is the delay between result retrieval attempts.
+	BenchmarkPollInterval time.Duration
+
+	// BenchmarkMaxWait bounds the total time spent waiting for results.
+	BenchmarkMaxWait time.Duration
+
+	// CommandPollInterval is the delay between SSM command status checks.
+	CommandPollInterval time.Duration
+}
+
+// DefaultPollingConfig returns polling intervals suitable for real AWS accounts.
+func DefaultPollingConfig() PollingConfig {
+	return PollingConfig{
+		InstanceStateInterval: 15 * time.Second,
+		UserDataSettleTime:    60 * time.Second,
+		BenchmarkPollInterval: 30 * time.Second,
+		BenchmarkMaxWait:      10 * time.Minute,
+		CommandPollInterval:   60 * time.Second,
+	}
+}
+
+// LoadAWSConfig loads the shared AWS configuration for the given region using
+// the 'aws' profile, matching the credential setup used across the project.
+func LoadAWSConfig(region string) (aws.Config, error) {
+	return LoadAWSConfigWithProfile(region, "aws")
+}
+
+// LoadAWSConfigWithProfile loads the shared AWS configuration for the given
+// region using a named profile, so each region of a run can use its own
+// credentials.
+func LoadAWSConfigWithProfile(region, profile string) (aws.Config, error) {
+	cfg, err := config.LoadDefaultConfig(context.TODO(),
+		config.WithRegion(region),
+		config.WithSharedConfigProfile(profile),
+	)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// NewOrchestratorWithClients creates an orchestrator backed by the supplied
+// EC2 and SSM implementations instead of clients built from the shared AWS
+// configuration.
+//
+// This constructor is the injection point for alternative backends such as
+// the in-memory fake cloud used to exercise the full orchestration flow
+// offline and in CI.
+//
+// Example:
+//   cloud := fakecloud.New("us-east-1")
+//   orchestrator := aws.NewOrchestratorWithClients("us-east-1", cloud.EC2(), cloud.SSM()).
+//       WithPolling(aws.PollingConfig{BenchmarkMaxWait: time.Second})
+func NewOrchestratorWithClients(region string, ec2Client EC2API, ssmClient SSMAPI) *Orchestrator {
+	return &Orchestrator{
+		ec2Client: ec2Client,
+		ssmClient: ssmClient,
+		region:    region,
+		polling:   DefaultPollingConfig(),
+	}
+}
+
+// WithPolling overrides the orchestrator's polling intervals.
+//
+// Returns:
+//   - *Orchestrator: The same orchestrator instance for method chaining
+func (o *Orchestrator) WithPolling(polling PollingConfig) *Orchestrator {
+	o.polling = polling
+	return o
+}
+
+// sleep waits for the given duration or until the context is cancelled.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,177 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Defaults for delivering events to webhooks.
+const (
+	defaultWebhookTimeout    = 10 * time.Second
+	defaultWebhookAttempts   = 3
+	defaultWebhookRetryDelay = time.Second
+
+	// DefaultEventBufferSize is the number of events a BufferedSink holds
+	// before it starts dropping them.
+	DefaultEventBufferSize = 1024
+)
+
+// NDJSONSink writes each event as one line of JSON, e.g. to a file that
+// other tools tail. It is safe for concurrent use.
+type NDJSONSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewNDJSONSink returns a sink writing newline-delimited JSON events to w.
+func NewNDJSONSink(w io.Writer) *NDJSONSink {
+	return &NDJSONSink{encoder: json.NewEncoder(w)}
+}
+
+// HandleEvent writes the event as a line of JSON. A failed write is
+// reported but does not interrupt the benchmark.
+func (s *NDJSONSink) HandleEvent(event Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.encoder.Encode(event); err != nil {
+		fmt.Printf("   ⚠️  Failed to write event: %v\n", err)
+	}
+}
+
+// WebhookSink POSTs each event as JSON to a URL, retrying failed
+// deliveries. Deliveries are synchronous, so wrap the sink in a
+// BufferedSink before subscribing it to an event bus.
+type WebhookSink struct {
+	url        string
+	client     *http.Client
+	attempts   int
+	retryDelay time.Duration
+}
+
+// NewWebhookSink returns a sink posting events to url.
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url:        url,
+		client:     &http.Client{Timeout: defaultWebhookTimeout},
+		attempts:   defaultWebhookAttempts,
+		retryDelay: defaultWebhookRetryDelay,
+	}
+}
+
+// HandleEvent posts the event. An event that still cannot be delivered
+// after the last attempt is reported and dropped.
+func (s *WebhookSink) HandleEvent(event Event) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		fmt.Printf("   ⚠️  Failed to encode event: %v\n", err)
+		return
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = s.post(body)
+		if err == nil {
+			return
+		}
+		if attempt >= s.attempts {
+			break
+		}
+		time.Sleep(s.retryDelay * time.Duration(attempt))
+	}
+	fmt.Printf("   ⚠️  Failed to deliver %s event to %s: %v\n", event.Type, s.url, err)
+}
+
+// post sends one delivery attempt. Responses other than 2xx are errors, so
+// they are retried.
+func (s *WebhookSink) post(body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultWebhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// BufferedSink delivers events to a slow sink, e.g. a webhook or
+// CloudWatch, from its own goroutine so that publishing never waits on
+// the network. When its buffer is full it drops events rather than stall
+// the benchmarks; Close reports how many were dropped.
+type BufferedSink struct {
+	sink    EventSink
+	events  chan Event
+	done    chan struct{}
+	mu      sync.RWMutex
+	closed  bool
+	dropped atomic.Int64
+}
+
+// NewBufferedSink starts delivering events to sink, buffering up to size
+// of them.
+func NewBufferedSink(sink EventSink, size int) *BufferedSink {
+	if size <= 0 {
+		size = DefaultEventBufferSize
+	}
+	b := &BufferedSink{
+		sink:   sink,
+		events: make(chan Event, size),
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(b.done)
+		for event := range b.events {
+			b.sink.HandleEvent(event)
+		}
+	}()
+	return b
+}
+
+// HandleEvent queues the event for delivery. Events handled after Close
+// are dropped.
+func (b *BufferedSink) HandleEvent(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return
+	}
+	select {
+	case b.events <- event:
+	default:
+		b.dropped.Add(1)
+	}
+}
+
+// Close waits until every queued event has been delivered.
+//
+// Returns:
+//   - int: The number of events dropped because the buffer was full
+func (b *BufferedSink) Close() int {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.events)
+	}
+	b.mu.Unlock()
+
+	<-b.done
+	return int(b.dropped.Load())
+}
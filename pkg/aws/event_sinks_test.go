@@ -0,0 +1,97 @@
+package aws
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNDJSONSinkWritesOneEventPerLine(t *testing.T) {
+	var out bytes.Buffer
+	sink := NewNDJSONSink(&out)
+	sink.HandleEvent(Event{Type: EventCommandSent, JobID: "a", CommandID: "cmd-1", Iteration: 2})
+	sink.HandleEvent(Event{Type: EventJobStatus, JobID: "a", Status: JobStatusFailed, FailureCategory: FailureCategoryCapacity})
+
+	var events []Event
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("Line is not a JSON event: %q: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(events))
+	}
+	if events[0].CommandID != "cmd-1" || events[0].Iteration != 2 {
+		t.Errorf("Unexpected command event %+v", events[0])
+	}
+	if events[1].FailureCategory != FailureCategoryCapacity {
+		t.Errorf("Unexpected status event %+v", events[1])
+	}
+}
+
+func TestWebhookSinkRetriesFailedDeliveries(t *testing.T) {
+	var mu sync.Mutex
+	var received []Event
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		if requests == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("Request body is not a JSON event: %v", err)
+		}
+		received = append(received, event)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL)
+	sink.retryDelay = time.Millisecond
+	buffered := NewBufferedSink(sink, 0)
+	buffered.HandleEvent(Event{Type: EventInstanceLaunched, JobID: "a", InstanceID: "i-1"})
+	buffered.HandleEvent(Event{Type: EventInstanceTerminated, JobID: "a", InstanceID: "i-1"})
+	if dropped := buffered.Close(); dropped != 0 {
+		t.Errorf("Expected no dropped events, got %d", dropped)
+	}
+	buffered.HandleEvent(Event{JobID: "ignored after close"})
+
+	if requests != 3 || len(received) != 2 {
+		t.Fatalf("Expected 2 events delivered in 3 requests, got %d in %d", len(received), requests)
+	}
+	if received[0].Type != EventInstanceLaunched || received[1].Type != EventInstanceTerminated {
+		t.Errorf("Expected events in publish order, got %+v", received)
+	}
+}
+
+func TestBufferedSinkDropsWhenFull(t *testing.T) {
+	release := make(chan struct{})
+	delivered := 0
+	buffered := NewBufferedSink(EventSinkFunc(func(Event) {
+		<-release
+		delivered++
+	}), 1)
+
+	for i := 0; i < 5; i++ {
+		buffered.HandleEvent(Event{JobID: "a"})
+	}
+	close(release)
+	dropped := buffered.Close()
+	if delivered+dropped != 5 || dropped < 3 {
+		t.Errorf("Expected at least 3 of 5 events dropped, got %d delivered and %d dropped", delivered, dropped)
+	}
+}
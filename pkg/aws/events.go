@@ -0,0 +1,241 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventType identifies the kind of a benchmark event.
+type EventType string
+
+// Benchmark events published to an EventBus, in the order they normally
+// occur for a job.
+const (
+	// EventInstanceLaunched reports the instance launched for a job.
+	EventInstanceLaunched EventType = "instance_launched"
+
+	// EventJobStatus reports a change of a job's status, including its
+	// final COMPLETED or FAILED status.
+	EventJobStatus EventType = "job_status"
+
+	// EventJobProgress reports the progress of a running job.
+	EventJobProgress EventType = "job_progress"
+
+	// EventCommandSent reports the SSM command of a benchmark iteration.
+	EventCommandSent EventType = "command_sent"
+
+	// EventIterationCompleted reports the end of a benchmark iteration;
+	// Error is set if it failed.
+	EventIterationCompleted EventType = "iteration_completed"
+
+	// EventResultParsed carries the parsed, aggregated results of a job.
+	EventResultParsed EventType = "result_parsed"
+
+	// EventInstanceTerminated reports a successful termination request.
+	EventInstanceTerminated EventType = "instance_terminated"
+)
+
+// Event is a lifecycle change of a benchmark job. The Orchestrator
+// publishes events as it runs a job, and the AsyncCollector publishes them
+// as it observes async jobs through their sentinel files.
+type Event struct {
+	Type EventType `json:"type"`
+	Time time.Time `json:"time"`
+
+	// JobID is the run journal job ID, or the BenchmarkID of an async job
+	JobID          string `json:"job_id"`
+	InstanceType   string `json:"instance_type,omitempty"`
+	BenchmarkSuite string `json:"benchmark_suite,omitempty"`
+	Region         string `json:"region,omitempty"`
+	InstanceID     string `json:"instance_id,omitempty"`
+
+	// PurchaseOption is PurchaseSpot for spot jobs and PurchaseOnDemand or
+	// empty otherwise
+	PurchaseOption string `json:"purchase_option,omitempty"`
+
+	// Status uses the async job statuses for both kinds of jobs. Events
+	// that do not change the status leave it empty.
+	Status JobStatus `json:"status,omitempty"`
+
+	// StartTime is when the job's instance was launched, if known
+	StartTime time.Time `json:"start_time,omitempty"`
+
+	PercentComplete float64 `json:"percent_complete,omitempty"`
+
+	// CommandID and Iteration identify the SSM command of an iteration
+	CommandID string `json:"command_id,omitempty"`
+	Iteration int    `json:"iteration,omitempty"`
+
+	// Results is set on EventResultParsed
+	Results *BenchmarkResults `json:"results,omitempty"`
+
+	// Message is the latest human-readable log line of the job
+	Message string `json:"message,omitempty"`
+	Error   string `json:"error,omitempty"`
+
+	// FailureCategory classifies the error of a failed job
+	FailureCategory FailureCategory `json:"failure_category,omitempty"`
+}
+
+// Final reports whether the event's status ends the job.
+func (e Event) Final() bool {
+	switch e.Status {
+	case JobStatusCompleted, JobStatusFailed, JobStatusTimedOut, JobStatusEmergencyStop, JobStatusTerminated:
+		return true
+	}
+	return false
+}
+
+// EventSink receives published events. Sinks are called synchronously by
+// EventBus.Publish, possibly from several goroutines, and must not block.
+type EventSink interface {
+	HandleEvent(event Event)
+}
+
+// EventSinkFunc adapts a function to an EventSink.
+type EventSinkFunc func(event Event)
+
+// HandleEvent calls f(event).
+func (f EventSinkFunc) HandleEvent(event Event) {
+	f(event)
+}
+
+// EventBus fans events out to its subscribed sinks. It is safe for
+// concurrent use; a nil *EventBus discards events.
+type EventBus struct {
+	mu    sync.RWMutex
+	sinks []EventSink
+}
+
+// NewEventBus returns an event bus without subscribers.
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+// Subscribe adds a sink for all subsequently published events.
+func (b *EventBus) Subscribe(sink EventSink) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sinks = append(b.sinks, sink)
+}
+
+// Publish delivers an event to every sink, stamping it with the current
+// time if it has none.
+func (b *EventBus) Publish(event Event) {
+	if b == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, sink := range b.sinks {
+		sink.HandleEvent(event)
+	}
+}
+
+// WithEvents publishes the lifecycle events of the orchestrator's jobs to
+// the given event bus.
+//
+// Returns:
+//   - *Orchestrator: The same orchestrator instance for method chaining
+func (o *Orchestrator) WithEvents(events *EventBus) *Orchestrator {
+	o.events = events
+	return o
+}
+
+// publish fills in the job fields of an event from config and publishes it,
+// if an event bus is configured.
+func (o *Orchestrator) publish(config BenchmarkConfig, event Event) {
+	if o.events == nil {
+		return
+	}
+	event.JobID = journalJobID(config)
+	event.InstanceType = config.InstanceType
+	event.BenchmarkSuite = config.BenchmarkSuite
+	event.Region = config.Region
+	event.PurchaseOption = config.PurchaseOption
+	if event.Region == "" {
+		event.Region = o.region
+	}
+	o.events.Publish(event)
+}
+
+// failureCategory classifies the error of a failed orchestrated job.
+func failureCategory(err error) FailureCategory {
+	var quotaErr *QuotaError
+	switch {
+	case errors.As(err, &quotaErr) || isCapacityError(err):
+		return FailureCategoryCapacity
+	case errors.Is(err, ErrSpotInterrupted):
+		return FailureCategorySpotInterruption
+	case errors.Is(err, context.DeadlineExceeded) || strings.Contains(err.Error(), "timed out"):
+		return FailureCategoryTimeout
+	default:
+		return FailureCategoryBenchmark
+	}
+}
+
+// asyncJobEvent returns an event of the given type attributed to an async job.
+func asyncJobEvent(job *AsyncBenchmarkJob, eventType EventType) Event {
+	region := job.Region
+	if region == "" {
+		region = job.BenchmarkConfig.Region
+	}
+	return Event{
+		Type:           eventType,
+		JobID:          job.BenchmarkID,
+		InstanceType:   job.BenchmarkConfig.InstanceType,
+		BenchmarkSuite: job.BenchmarkConfig.BenchmarkSuite,
+		Region:         region,
+		InstanceID:     job.InstanceID,
+		PurchaseOption: job.BenchmarkConfig.PurchaseOption,
+		StartTime:      job.LaunchedAt,
+	}
+}
+
+// WithEvents publishes the launch of each async job to the given event bus.
+func (l *AsyncLauncher) WithEvents(events *EventBus) *AsyncLauncher {
+	l.events = events
+	return l
+}
+
+// WithEvents publishes the status and progress of every job the collector
+// checks, the results it parses and the instances it terminates to the
+// given event bus.
+func (c *AsyncCollector) WithEvents(events *EventBus) *AsyncCollector {
+	c.events = events
+	return c
+}
+
+// publishJobStatus publishes the status of an async job just checked, with
+// its progress while it is in flight.
+func (c *AsyncCollector) publishJobStatus(ctx context.Context, job *AsyncBenchmarkJob, status JobStatus) {
+	if c.events == nil {
+		return
+	}
+	event := asyncJobEvent(job, EventJobStatus)
+	event.Status = status
+	event.Message = job.StallReason
+	if event.Region == "" {
+		event.Region = c.region
+	}
+	if job.FailureReason != "" {
+		event.Message = job.FailureReason
+		event.Error = job.FailureReason
+		event.FailureCategory = job.FailureCategory
+	}
+	if status == JobStatusRunning || status == JobStatusLaunched {
+		if progress, err := c.getJobProgress(ctx, job); err == nil {
+			event.Type = EventJobProgress
+			event.PercentComplete = progress.PercentComplete
+			event.Message = progress.Message
+		}
+	}
+	c.events.Publish(event)
+}
@@ -0,0 +1,207 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/fakecloud"
+)
+
+// eventRecorder collects published events.
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *eventRecorder) HandleEvent(event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *eventRecorder) statuses() []JobStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var statuses []JobStatus
+	for _, event := range r.events {
+		if event.Status == "" {
+			continue
+		}
+		if len(statuses) == 0 || statuses[len(statuses)-1] != event.Status {
+			statuses = append(statuses, event.Status)
+		}
+	}
+	return statuses
+}
+
+func TestEventBusFanOut(t *testing.T) {
+	var nilBus *EventBus
+	nilBus.Publish(Event{JobID: "ignored"})
+
+	bus := NewEventBus()
+	first, second := &eventRecorder{}, &eventRecorder{}
+	bus.Subscribe(first)
+	bus.Subscribe(EventSinkFunc(second.HandleEvent))
+
+	stamped := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	bus.Publish(Event{JobID: "a", Time: stamped})
+	bus.Publish(Event{JobID: "b"})
+
+	for _, recorder := range []*eventRecorder{first, second} {
+		if len(recorder.events) != 2 {
+			t.Fatalf("Expected 2 events per sink, got %d", len(recorder.events))
+		}
+		if !recorder.events[0].Time.Equal(stamped) {
+			t.Errorf("Expected the event time to be kept, got %v", recorder.events[0].Time)
+		}
+		if recorder.events[1].Time.IsZero() {
+			t.Error("Expected an unstamped event to get the current time")
+		}
+	}
+}
+
+func TestOrchestratorPublishesJobEvents(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	streamCommands(cloud)
+	recorder := &eventRecorder{}
+	bus := NewEventBus()
+	bus.Subscribe(recorder)
+
+	config := fakeBenchmarkConfig("m7i.large", "stream")
+	config.JobID = "us-east-1/m7i.large/stream/1"
+	result, err := newFakeOrchestrator(cloud).WithEvents(bus).RunBenchmark(context.Background(), config)
+	if err != nil {
+		t.Fatalf("RunBenchmark failed: %v", err)
+	}
+
+	want := []JobStatus{JobStatusLaunched, JobStatusRunning, JobStatusCompleted}
+	if got := recorder.statuses(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Event statuses = %v, want %v", got, want)
+	}
+
+	var progress []float64
+	for _, event := range recorder.events {
+		if event.JobID != config.JobID || event.InstanceType != "m7i.large" || event.Region != "us-east-1" {
+			t.Errorf("Event not attributed to the job: %+v", event)
+		}
+		if event.InstanceID != result.InstanceID {
+			t.Errorf("Event instance = %q, want %q", event.InstanceID, result.InstanceID)
+		}
+		if event.Type == EventJobProgress {
+			progress = append(progress, event.PercentComplete)
+		}
+	}
+	if len(progress) != 5 || progress[0] != 0 || progress[4] != 80 {
+		t.Errorf("Expected progress for 5 iterations, got %v", progress)
+	}
+	if last := recorder.events[len(recorder.events)-1]; !last.Final() || last.PercentComplete != 100 {
+		t.Errorf("Expected a final completed event, got %+v", last)
+	}
+}
+
+func TestCollectorPublishesJobEvents(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryObjectStore()
+	orchestrator := newFakeOrchestrator(fakecloud.New("us-east-1"))
+	recorder := &eventRecorder{}
+	bus := NewEventBus()
+	bus.Subscribe(recorder)
+	collector := NewAsyncCollectorWithStore(orchestrator, store).WithEvents(bus)
+
+	job, err := NewAsyncLauncherWithStore(orchestrator, store).
+		LaunchSingleBenchmark(ctx, fakeBenchmarkConfig("m7i.large", "stream"), "results", "events", time.Hour)
+	if err != nil {
+		t.Fatalf("LaunchSingleBenchmark failed: %v", err)
+	}
+	files := NewS3SentinelFiles(job.S3Prefix)
+	store.PutObject(ctx, "results", files.StatusRunning, []byte("RUNNING"), "text/plain")
+	progress := `{"current_iteration": 2, "total_iterations": 5, "message": "iteration 2", "percent_complete": 40, "heartbeat": "` +
+		time.Now().Format(time.RFC3339) + `"}`
+	store.PutObject(ctx, "results", files.StatusProgress, []byte(progress), "application/json")
+
+	if _, err := collector.CheckSpecificJobs(ctx, []*AsyncBenchmarkJob{job}); err != nil {
+		t.Fatalf("CheckSpecificJobs failed: %v", err)
+	}
+	completeAsyncJob(t, store, job, fakeScriptResults)
+	if _, err := collector.CheckSpecificJobs(ctx, []*AsyncBenchmarkJob{job}); err != nil {
+		t.Fatalf("CheckSpecificJobs failed: %v", err)
+	}
+
+	if len(recorder.events) != 3 {
+		t.Fatalf("Expected an event per check and the parsed results, got %+v", recorder.events)
+	}
+	running := recorder.events[0]
+	if running.Type != EventJobProgress || running.Status != JobStatusRunning || running.PercentComplete != 40 || running.Message != "iteration 2" {
+		t.Errorf("Unexpected progress event %+v", running)
+	}
+	if running.JobID != job.BenchmarkID || running.InstanceID != job.InstanceID || !running.StartTime.Equal(job.LaunchedAt) {
+		t.Errorf("Progress event not attributed to the job: %+v", running)
+	}
+	if completed := recorder.events[1]; completed.Status != JobStatusCompleted || !completed.Final() {
+		t.Errorf("Unexpected completion event %+v", completed)
+	}
+	if parsed := recorder.events[2]; parsed.Type != EventResultParsed || parsed.Results == nil || parsed.JobID != job.BenchmarkID {
+		t.Errorf("Unexpected result event %+v", parsed)
+	}
+}
+
+func TestOrchestratorPublishesLifecycleEvents(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	streamCommands(cloud)
+	recorder := &eventRecorder{}
+	bus := NewEventBus()
+	bus.Subscribe(recorder)
+
+	if _, err := newFakeOrchestrator(cloud).WithEvents(bus).RunBenchmark(context.Background(), fakeBenchmarkConfig("m7i.large", "stream")); err != nil {
+		t.Fatalf("RunBenchmark failed: %v", err)
+	}
+
+	var types []EventType
+	commands := make(map[int]string)
+	for _, event := range recorder.events {
+		if event.Type == EventJobProgress {
+			continue
+		}
+		if len(types) == 0 || types[len(types)-1] != event.Type {
+			types = append(types, event.Type)
+		}
+		if event.Type == EventCommandSent {
+			commands[event.Iteration] = event.CommandID
+		}
+		if event.Type == EventResultParsed && event.Results == nil {
+			t.Error("Expected the parsed results on the result event")
+		}
+	}
+	want := []EventType{
+		EventInstanceLaunched, EventJobStatus,
+		EventCommandSent, EventIterationCompleted, EventCommandSent, EventIterationCompleted,
+		EventCommandSent, EventIterationCompleted, EventCommandSent, EventIterationCompleted,
+		EventCommandSent, EventIterationCompleted,
+		EventResultParsed, EventInstanceTerminated, EventJobStatus,
+	}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("Event types = %v, want %v", types, want)
+	}
+	if len(commands) != 5 || commands[1] == "" || commands[1] == commands[5] {
+		t.Errorf("Expected a distinct command per iteration, got %v", commands)
+	}
+}
+
+func TestFailedJobEventCategory(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	cloud.SetCapacity("m7i.large", 0)
+	recorder := &eventRecorder{}
+	bus := NewEventBus()
+	bus.Subscribe(recorder)
+
+	if _, err := newFakeOrchestrator(cloud).WithEvents(bus).RunBenchmark(context.Background(), fakeBenchmarkConfig("m7i.large", "stream")); err == nil {
+		t.Fatal("Expected the launch to fail without capacity")
+	}
+	last := recorder.events[len(recorder.events)-1]
+	if last.Status != JobStatusFailed || last.FailureCategory != FailureCategoryCapacity || last.Error == "" {
+		t.Errorf("Unexpected failure event %+v", last)
+	}
+}
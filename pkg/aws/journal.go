@@ -0,0 +1,581 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// ErrJournalExists is returned when creating a journal at a path that is
+// already in use, which would otherwise mix two runs in one file.
+var ErrJournalExists = errors.New("run journal already exists")
+
+// JournalEvent identifies the kind of a journal entry.
+type JournalEvent string
+
+// Journal events, in the order they normally occur for a job.
+const (
+	// JournalRunStarted records the full job plan of a run.
+	JournalRunStarted JournalEvent = "run_started"
+
+	// JournalRunResumed marks the point where a run was resumed.
+	JournalRunResumed JournalEvent = "run_resumed"
+
+	// JournalInstanceLaunched records an instance launched for a job.
+	JournalInstanceLaunched JournalEvent = "instance_launched"
+
+	// JournalCommandSent records the SSM command ID of a benchmark iteration.
+	JournalCommandSent JournalEvent = "command_sent"
+
+	// JournalCommandCompleted records the output or error of an iteration.
+	JournalCommandCompleted JournalEvent = "command_completed"
+
+	// JournalInstanceTerminated records a successful termination request.
+	JournalInstanceTerminated JournalEvent = "instance_terminated"
+
+	// JournalJobCompleted records the aggregated result of a job.
+	JournalJobCompleted JournalEvent = "job_completed"
+
+	// JournalJobFailed records a job that ended with an error.
+	JournalJobFailed JournalEvent = "job_failed"
+
+	// JournalResultStored records that a job's result has been persisted.
+	JournalResultStored JournalEvent = "result_stored"
+)
+
+// JournalEntry is a single line of a run journal.
+type JournalEntry struct {
+	Time       time.Time         `json:"time"`
+	Event      JournalEvent      `json:"event"`
+	JobID      string            `json:"job_id,omitempty"`
+	InstanceID string            `json:"instance_id,omitempty"`
+	CommandID  string            `json:"command_id,omitempty"`
+	Iteration  int               `json:"iteration,omitempty"`
+	Output     string            `json:"output,omitempty"`
+	Stderr     string            `json:"stderr,omitempty"`
+	Error      string            `json:"error,omitempty"`
+	Result     *JournalResult    `json:"result,omitempty"`
+	Jobs       []BenchmarkConfig `json:"jobs,omitempty"`
+}
+
+// JournalResult is the serializable part of an InstanceResult.
+type JournalResult struct {
+	InstanceID    string            `json:"instance_id"`
+	InstanceType  string            `json:"instance_type"`
+	PublicIP      string            `json:"public_ip,omitempty"`
+	PrivateIP     string            `json:"private_ip,omitempty"`
+	Status        string            `json:"status,omitempty"`
+	BenchmarkData *BenchmarkResults `json:"benchmark_data,omitempty"`
+	StartTime     time.Time         `json:"start_time"`
+	EndTime       time.Time         `json:"end_time"`
+
+	PurchaseOption string             `json:"purchase_option,omitempty"`
+	Interruptions  []SpotInterruption `json:"interruptions,omitempty"`
+
+	AvailabilityZone      string `json:"availability_zone,omitempty"`
+	Tenancy               string `json:"tenancy,omitempty"`
+	HostID                string `json:"host_id,omitempty"`
+	PlacementGroup        string `json:"placement_group,omitempty"`
+	CapacityReservationID string `json:"capacity_reservation_id,omitempty"`
+
+	AMIID                string `json:"ami_id,omitempty"`
+	KernelVersion        string `json:"kernel_version,omitempty"`
+	OSRelease            string `json:"os_release,omitempty"`
+	ContainerImageDigest string `json:"container_image_digest,omitempty"`
+
+	RawOutputSHA256 string            `json:"raw_output_sha256,omitempty"`
+	RawOutputs      []IterationOutput `json:"raw_outputs,omitempty"`
+}
+
+// InstanceResult converts the journaled result back into an InstanceResult.
+func (r *JournalResult) InstanceResult() *InstanceResult {
+	if r == nil {
+		return nil
+	}
+	return &InstanceResult{
+		InstanceID:    r.InstanceID,
+		InstanceType:  r.InstanceType,
+		PublicIP:      r.PublicIP,
+		PrivateIP:     r.PrivateIP,
+		Status:        r.Status,
+		BenchmarkData: r.BenchmarkData,
+		StartTime:     r.StartTime,
+		EndTime:       r.EndTime,
+
+		PurchaseOption: r.PurchaseOption,
+		Interruptions:  r.Interruptions,
+
+		AvailabilityZone:      r.AvailabilityZone,
+		Tenancy:               r.Tenancy,
+		HostID:                r.HostID,
+		PlacementGroup:        r.PlacementGroup,
+		CapacityReservationID: r.CapacityReservationID,
+
+		AMIID:                r.AMIID,
+		KernelVersion:        r.KernelVersion,
+		OSRelease:            r.OSRelease,
+		ContainerImageDigest: r.ContainerImageDigest,
+
+		RawOutputSHA256: r.RawOutputSHA256,
+		RawOutputs:      r.RawOutputs,
+	}
+}
+
+func newJournalResult(result *InstanceResult) *JournalResult {
+	return &JournalResult{
+		InstanceID:    result.InstanceID,
+		InstanceType:  result.InstanceType,
+		PublicIP:      result.PublicIP,
+		PrivateIP:     result.PrivateIP,
+		Status:        result.Status,
+		BenchmarkData: result.BenchmarkData,
+		StartTime:     result.StartTime,
+		EndTime:       result.EndTime,
+
+		PurchaseOption: result.PurchaseOption,
+		Interruptions:  result.Interruptions,
+
+		AvailabilityZone:      result.AvailabilityZone,
+		Tenancy:               result.Tenancy,
+		HostID:                result.HostID,
+		PlacementGroup:        result.PlacementGroup,
+		CapacityReservationID: result.CapacityReservationID,
+
+		AMIID:                result.AMIID,
+		KernelVersion:        result.KernelVersion,
+		OSRelease:            result.OSRelease,
+		ContainerImageDigest: result.ContainerImageDigest,
+
+		RawOutputSHA256: result.RawOutputSHA256,
+		RawOutputs:      result.RawOutputs,
+	}
+}
+
+// Journal is a durable, append-only record of a benchmark run.
+//
+// Every instance launch, SSM command, termination and job outcome is written
+// to a JSON-lines file and synced to disk before orchestration continues, so
+// that an interrupted run can be resumed: completed jobs are skipped, live
+// instances are reattached and instances that are no longer needed are
+// terminated instead of being leaked.
+//
+// Usage:
+//   journal, err := aws.CreateJournal("results/journals/run.jsonl", configs)
+//   orchestrator.WithJournal(journal)
+//   ...
+//   // After an interruption
+//   journal, err := aws.OpenJournal("results/journals/run.jsonl")
+//   for _, job := range journal.JobStates() {
+//       result, err := orchestrator.WithJournal(journal).ResumeBenchmark(ctx, job)
+//   }
+//
+// A Journal is safe for concurrent use.
+type Journal struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	entries []JournalEntry
+}
+
+// CreateJournal creates a new journal and records the run's job plan.
+//
+// Each config should carry a unique JobID; configs without one are assigned
+// the default "<instance type>/<suite>" identifier.
+//
+// Returns ErrJournalExists if a file already exists at path.
+func CreateJournal(path string, jobs []BenchmarkConfig) (*Journal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("%w: %s", ErrJournalExists, path)
+		}
+		return nil, fmt.Errorf("failed to create journal: %w", err)
+	}
+
+	plan := make([]BenchmarkConfig, len(jobs))
+	for i, job := range jobs {
+		job.JobID = journalJobID(job)
+		plan[i] = job
+	}
+
+	journal := &Journal{path: path, file: file}
+	if err := journal.Record(JournalEntry{Event: JournalRunStarted, Jobs: plan}); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return journal, nil
+}
+
+// OpenJournal loads an existing journal and reopens it for appending.
+//
+// A partially written final line, left behind when the process was killed
+// mid-write, is discarded so that new entries start on a clean line.
+func OpenJournal(path string) (*Journal, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+
+	var entries []JournalEntry
+	valid := 0
+	for offset, line := 0, 1; offset < len(data); line++ {
+		end := bytes.IndexByte(data[offset:], '\n')
+		if end < 0 {
+			// Unterminated final line: the write never completed
+			break
+		}
+		raw := data[offset : offset+end]
+		offset += end + 1
+
+		var entry JournalEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			if offset < len(data) {
+				return nil, fmt.Errorf("corrupt journal entry at %s:%d: %w", path, line, err)
+			}
+			break
+		}
+		entries = append(entries, entry)
+		valid = offset
+	}
+	if len(entries) == 0 || entries[0].Event != JournalRunStarted {
+		return nil, fmt.Errorf("journal %s does not start with a %s entry", path, JournalRunStarted)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal for appending: %w", err)
+	}
+	if valid < len(data) {
+		if err := file.Truncate(int64(valid)); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to discard partial journal entry: %w", err)
+		}
+	}
+	return &Journal{path: path, file: file, entries: entries}, nil
+}
+
+// Path returns the journal's file path.
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// Record appends an entry and syncs it to disk.
+func (j *Journal) Record(entry JournalEntry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode journal entry: %w", err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal entry: %w", err)
+	}
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal: %w", err)
+	}
+	j.entries = append(j.entries, entry)
+	return nil
+}
+
+// Close closes the journal file.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.file.Close()
+}
+
+// Entries returns a copy of all entries recorded so far.
+func (j *Journal) Entries() []JournalEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]JournalEntry(nil), j.entries...)
+}
+
+// JournalJobStatus is the state of a job replayed from a run journal.
+type JournalJobStatus string
+
+// Job states derived from the journal.
+const (
+	JournalStatusPending   JournalJobStatus = "pending"
+	JournalStatusRunning   JournalJobStatus = "running"
+	JournalStatusCompleted JournalJobStatus = "completed"
+	JournalStatusFailed    JournalJobStatus = "failed"
+	JournalStatusStored    JournalJobStatus = "stored"
+)
+
+// JobState is the state of one job reconstructed from a journal.
+type JobState struct {
+	// ID is the job identifier, BenchmarkConfig.JobID.
+	ID string
+
+	// Config is the job's benchmark configuration from the run plan.
+	Config BenchmarkConfig
+
+	// Status is the latest state of the job.
+	Status JournalJobStatus
+
+	// Instances lists every instance launched for the job, oldest first.
+	Instances []string
+
+	// LiveInstances lists launched instances with no recorded termination.
+	LiveInstances []string
+
+	// Result is the journaled result of a completed or stored job.
+	Result *InstanceResult
+
+	// Error is the last recorded failure of the job.
+	Error string
+
+	// instanceID is the most recently launched instance; only its
+	// iterations can be reused.
+	instanceID string
+	outputs    map[int]IterationOutput
+	pending    map[int]string
+}
+
+// JobStates replays the journal and returns the state of every job in the
+// order of the run plan. Jobs journaled without a plan entry follow.
+func (j *Journal) JobStates() []*JobState {
+	entries := j.Entries()
+
+	states := make(map[string]*JobState)
+	var order []string
+	state := func(id string) *JobState {
+		if s, ok := states[id]; ok {
+			return s
+		}
+		s := &JobState{ID: id, Status: JournalStatusPending, outputs: map[int]IterationOutput{}, pending: map[int]string{}}
+		states[id] = s
+		order = append(order, id)
+		return s
+	}
+
+	terminated := make(map[string]bool)
+	for _, entry := range entries {
+		switch entry.Event {
+		case JournalRunStarted:
+			for _, config := range entry.Jobs {
+				state(journalJobID(config)).Config = config
+			}
+		case JournalInstanceLaunched:
+			s := state(entry.JobID)
+			s.Status = JournalStatusRunning
+			s.Instances = append(s.Instances, entry.InstanceID)
+			s.instanceID = entry.InstanceID
+			s.outputs = map[int]IterationOutput{}
+			s.pending = map[int]string{}
+		case JournalCommandSent:
+			if s := state(entry.JobID); s.instanceID == entry.InstanceID {
+				s.pending[entry.Iteration] = entry.CommandID
+			}
+		case JournalCommandCompleted:
+			if s := state(entry.JobID); s.instanceID == entry.InstanceID {
+				delete(s.pending, entry.Iteration)
+				if entry.Error == "" {
+					s.outputs[entry.Iteration] = IterationOutput{Iteration: entry.Iteration, Stdout: entry.Output, Stderr: entry.Stderr}
+				}
+			}
+		case JournalInstanceTerminated:
+			terminated[entry.InstanceID] = true
+		case JournalJobCompleted:
+			s := state(entry.JobID)
+			s.Status = JournalStatusCompleted
+			s.Result = entry.Result.InstanceResult()
+			s.Error = ""
+		case JournalJobFailed:
+			s := state(entry.JobID)
+			s.Status = JournalStatusFailed
+			s.Error = entry.Error
+		case JournalResultStored:
+			state(entry.JobID).Status = JournalStatusStored
+		}
+	}
+
+	result := make([]*JobState, 0, len(order))
+	for _, id := range order {
+		s := states[id]
+		for _, instanceID := range s.Instances {
+			if !terminated[instanceID] {
+				s.LiveInstances = append(s.LiveInstances, instanceID)
+			}
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+// Done reports whether the job's result is already available.
+func (s *JobState) Done() bool {
+	return s.Status == JournalStatusCompleted || s.Status == JournalStatusStored
+}
+
+// reusableOutput returns the journaled output of an iteration that completed
+// on the given instance.
+func (s *JobState) reusableOutput(instanceID string, iteration int) (IterationOutput, bool) {
+	if s == nil || s.instanceID != instanceID {
+		return IterationOutput{}, false
+	}
+	output, ok := s.outputs[iteration]
+	return output, ok
+}
+
+// pendingCommand returns the SSM command ID of an iteration that was sent to
+// the given instance but never recorded as completed.
+func (s *JobState) pendingCommand(instanceID string, iteration int) (string, bool) {
+	if s == nil || s.instanceID != instanceID {
+		return "", false
+	}
+	commandID, ok := s.pending[iteration]
+	return commandID, ok
+}
+
+// journalJobID returns the job identifier used in the journal for a config.
+func journalJobID(config BenchmarkConfig) string {
+	if config.JobID != "" {
+		return config.JobID
+	}
+	return config.InstanceType + "/" + config.BenchmarkSuite
+}
+
+// WithJournal records the orchestrator's launches, SSM commands,
+// terminations and job outcomes in the given run journal.
+//
+// Returns:
+//   - *Orchestrator: The same orchestrator instance for method chaining
+func (o *Orchestrator) WithJournal(journal *Journal) *Orchestrator {
+	o.journal = journal
+	return o
+}
+
+// record appends an entry to the run journal, if one is configured. A failed
+// write is reported but does not interrupt the benchmark.
+func (o *Orchestrator) record(entry JournalEntry) {
+	if o.journal == nil {
+		return
+	}
+	if err := o.journal.Record(entry); err != nil {
+		fmt.Printf("   ⚠️  Failed to update run journal: %v\n", err)
+	}
+}
+
+// recordOutcome journals and publishes the final result or error of a job.
+func (o *Orchestrator) recordOutcome(config BenchmarkConfig, result *InstanceResult, err error) {
+	outcome := Event{Type: EventJobStatus, InstanceID: result.InstanceID, StartTime: result.StartTime}
+	if err != nil {
+		o.record(JournalEntry{Event: JournalJobFailed, JobID: journalJobID(config), InstanceID: result.InstanceID, Error: err.Error()})
+		outcome.Status, outcome.Message, outcome.Error = JobStatusFailed, err.Error(), err.Error()
+		outcome.FailureCategory = failureCategory(err)
+		o.publish(config, outcome)
+		return
+	}
+	o.record(JournalEntry{Event: JournalJobCompleted, JobID: journalJobID(config), InstanceID: result.InstanceID, Result: newJournalResult(result)})
+	outcome.Status, outcome.PercentComplete, outcome.Message = JobStatusCompleted, 100, "completed"
+	o.publish(config, outcome)
+}
+
+// ResumeBenchmark continues a job recorded in a run journal.
+//
+// Completed jobs return their journaled result without launching anything.
+// An in-flight job whose instance is still pending or running is reattached:
+// iterations with journaled output are reused and commands that were sent
+// but never collected are awaited instead of being run again. Every other
+// live instance recorded for the job is terminated, and the job is started
+// on a fresh instance when nothing can be reattached.
+//
+// Parameters:
+//   - ctx: Context for timeout control and cancellation
+//   - job: Job state replayed from the journal by Journal.JobStates
+//
+// Returns:
+//   - *InstanceResult: The journaled or newly collected benchmark result
+//   - error: Execution errors, as for RunBenchmark
+//
+// Example:
+//   journal, err := aws.OpenJournal(path)
+//   orchestrator.WithJournal(journal)
+//   for _, job := range journal.JobStates() {
+//       result, err := orchestrator.ResumeBenchmark(ctx, job)
+//   }
+func (o *Orchestrator) ResumeBenchmark(ctx context.Context, job *JobState) (*InstanceResult, error) {
+	config := job.Config
+	config.JobID = job.ID
+
+	if job.Done() {
+		if err := o.TerminateLeakedInstances(ctx, job); err != nil {
+			fmt.Printf("   ⚠️  %v\n", err)
+		}
+		return job.Result, nil
+	}
+
+	var resume *JobState
+	for _, instanceID := range job.LiveInstances {
+		if job.Status == JournalStatusRunning && instanceID == job.instanceID && o.instanceAlive(ctx, instanceID) {
+			resume = job
+			continue
+		}
+		fmt.Printf("   🧹 Terminating leaked instance %s of job %s\n", instanceID, job.ID)
+		if err := o.terminateJobInstance(ctx, config, instanceID); err != nil {
+			fmt.Printf("   ⚠️  Failed to terminate instance %s: %v\n", instanceID, err)
+		}
+	}
+
+	result, err := o.runWithSpotRetry(config, func(attempt BenchmarkConfig) (*InstanceResult, error) {
+		// Only the first attempt can reattach to the journaled instance
+		result, err := o.runBenchmark(ctx, attempt, resume)
+		resume = nil
+		return result, err
+	})
+	o.recordOutcome(config, result, err)
+	return result, err
+}
+
+// TerminateLeakedInstances terminates every instance the journal recorded for
+// a job without a matching termination.
+//
+// Returns:
+//   - error: The first termination failure; remaining instances are still attempted
+func (o *Orchestrator) TerminateLeakedInstances(ctx context.Context, job *JobState) error {
+	config := job.Config
+	config.JobID = job.ID
+
+	var firstErr error
+	for _, instanceID := range job.LiveInstances {
+		fmt.Printf("   🧹 Terminating leaked instance %s of job %s\n", instanceID, job.ID)
+		if err := o.terminateJobInstance(ctx, config, instanceID); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to terminate leaked instance %s: %w", instanceID, err)
+		}
+	}
+	return firstErr
+}
+
+// instanceAlive reports whether an instance exists and is pending or running.
+func (o *Orchestrator) instanceAlive(ctx context.Context, instanceID string) bool {
+	resp, err := o.ec2Client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+		InstanceIds: []string{instanceID},
+	})
+	if err != nil || len(resp.Reservations) == 0 || len(resp.Reservations[0].Instances) == 0 {
+		return false
+	}
+
+	state := resp.Reservations[0].Instances[0].State
+	return state != nil && (state.Name == types.InstanceStateNamePending || state.Name == types.InstanceStateNameRunning)
+}
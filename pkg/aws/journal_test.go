@@ -0,0 +1,241 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/fakecloud"
+)
+
+func newTestJournal(t *testing.T, jobs ...BenchmarkConfig) *Journal {
+	t.Helper()
+	journal, err := CreateJournal(filepath.Join(t.TempDir(), "run.jsonl"), jobs)
+	if err != nil {
+		t.Fatalf("CreateJournal failed: %v", err)
+	}
+	t.Cleanup(func() { journal.Close() })
+	return journal
+}
+
+func streamCommands(cloud *fakecloud.Cloud) {
+	cloud.HandleCommands(func(instanceID, command string) fakecloud.CommandResult {
+		return fakecloud.CommandResult{Status: ssmtypes.CommandInvocationStatusSuccess, Stdout: fakeSTREAMOutput}
+	})
+}
+
+func isTerminated(cloud *fakecloud.Cloud, instanceID string) bool {
+	inst, ok := cloud.Instance(instanceID)
+	return ok && (inst.State == types.InstanceStateNameShuttingDown || inst.State == types.InstanceStateNameTerminated)
+}
+
+func TestJournalRecordsCompletedRun(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	streamCommands(cloud)
+
+	config := fakeBenchmarkConfig("m7i.large", "stream")
+	config.JobID = "m7i.large/stream/1"
+	config.ContainerImage = "public.ecr.aws/aws-benchmarks/stream@sha256:1111"
+	config.Profile = "benchmarks"
+	journal := newTestJournal(t, config)
+
+	if _, err := newFakeOrchestrator(cloud).WithJournal(journal).RunBenchmark(context.Background(), config); err != nil {
+		t.Fatalf("RunBenchmark failed: %v", err)
+	}
+
+	reopened, err := OpenJournal(journal.Path())
+	if err != nil {
+		t.Fatalf("OpenJournal failed: %v", err)
+	}
+	defer reopened.Close()
+
+	states := reopened.JobStates()
+	if len(states) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(states))
+	}
+	job := states[0]
+	if job.ID != config.JobID || job.Status != JournalStatusCompleted {
+		t.Errorf("unexpected job state: id=%s status=%s", job.ID, job.Status)
+	}
+	if job.Config.Profile != "benchmarks" {
+		t.Errorf("expected the planned profile to be journaled, got %q", job.Config.Profile)
+	}
+	if len(job.Instances) != 1 || len(job.LiveInstances) != 0 {
+		t.Errorf("expected one terminated instance, got instances=%v live=%v", job.Instances, job.LiveInstances)
+	}
+	if job.Result == nil || job.Result.BenchmarkData.STREAM == nil {
+		t.Fatalf("expected journaled STREAM result, got %+v", job.Result)
+	}
+	if digest := job.Result.InstanceResult().ContainerImageDigest; digest != "sha256:1111" {
+		t.Errorf("expected journaled container image digest sha256:1111, got %q", digest)
+	}
+
+	sent := 0
+	for _, entry := range reopened.Entries() {
+		if entry.Event == JournalCommandSent {
+			sent++
+		}
+	}
+	if sent != 5 {
+		t.Errorf("expected 5 journaled commands, got %d", sent)
+	}
+}
+
+func TestResumeReattachesToLiveInstance(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	streamCommands(cloud)
+
+	config := fakeBenchmarkConfig("m7i.large", "stream")
+	journal := newTestJournal(t, config)
+	jobID := journalJobID(config)
+
+	// Simulate a run interrupted after two iterations, with a third in flight
+	instanceID := cloud.AddInstance(fakecloud.Instance{InstanceType: "m7i.large"})
+	sent, err := cloud.SSM().SendCommand(context.Background(), &ssm.SendCommandInput{
+		InstanceIds:  []string{instanceID},
+		DocumentName: aws.String("AWS-RunShellScript"),
+		Parameters:   map[string][]string{"commands": {"stream"}},
+	})
+	if err != nil {
+		t.Fatalf("SendCommand failed: %v", err)
+	}
+	for _, entry := range []JournalEntry{
+		{Event: JournalInstanceLaunched, JobID: jobID, InstanceID: instanceID},
+		{Event: JournalCommandCompleted, JobID: jobID, InstanceID: instanceID, Iteration: 1, Output: fakeSTREAMOutput},
+		{Event: JournalCommandCompleted, JobID: jobID, InstanceID: instanceID, Iteration: 2, Output: fakeSTREAMOutput},
+		{Event: JournalCommandSent, JobID: jobID, InstanceID: instanceID, Iteration: 3, CommandID: *sent.Command.CommandId},
+	} {
+		if err := journal.Record(entry); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	job := journal.JobStates()[0]
+	if job.Status != JournalStatusRunning {
+		t.Fatalf("expected running job, got %s", job.Status)
+	}
+
+	result, err := newFakeOrchestrator(cloud).WithJournal(journal).ResumeBenchmark(context.Background(), job)
+	if err != nil {
+		t.Fatalf("ResumeBenchmark failed: %v", err)
+	}
+	if result.InstanceID != instanceID {
+		t.Errorf("expected reattach to %s, got %s", instanceID, result.InstanceID)
+	}
+	if got := len(cloud.Instances()); got != 1 {
+		t.Errorf("expected no new launches, got %d instances", got)
+	}
+	if got := len(benchmarkInvocations(cloud)); got != 3 {
+		t.Errorf("expected only iterations 4 and 5 to be sent, got %d invocations in total", got)
+	}
+	if !isTerminated(cloud, instanceID) {
+		t.Error("expected reattached instance to be terminated after the benchmark")
+	}
+	if status := journal.JobStates()[0].Status; status != JournalStatusCompleted {
+		t.Errorf("expected journaled job to be completed, got %s", status)
+	}
+}
+
+func TestResumeTerminatesLeakedInstances(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	streamCommands(cloud)
+
+	done := fakeBenchmarkConfig("m7i.large", "stream")
+	stale := fakeBenchmarkConfig("c7g.large", "stream")
+	journal := newTestJournal(t, done, stale)
+
+	// The completed job's instance was never terminated, and the in-flight
+	// job's instance was stopped while the orchestrator was down
+	leaked := cloud.AddInstance(fakecloud.Instance{InstanceType: "m7i.large"})
+	stopped := cloud.AddInstance(fakecloud.Instance{InstanceType: "c7g.large", State: types.InstanceStateNameStopped})
+	for _, entry := range []JournalEntry{
+		{Event: JournalInstanceLaunched, JobID: journalJobID(done), InstanceID: leaked},
+		{Event: JournalJobCompleted, JobID: journalJobID(done), InstanceID: leaked, Result: &JournalResult{InstanceID: leaked, InstanceType: "m7i.large"}},
+		{Event: JournalInstanceLaunched, JobID: journalJobID(stale), InstanceID: stopped},
+	} {
+		if err := journal.Record(entry); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	orchestrator := newFakeOrchestrator(cloud).WithJournal(journal)
+	states := journal.JobStates()
+
+	result, err := orchestrator.ResumeBenchmark(context.Background(), states[0])
+	if err != nil || result.InstanceID != leaked {
+		t.Fatalf("expected journaled result for completed job, got %+v (%v)", result, err)
+	}
+	if !isTerminated(cloud, leaked) {
+		t.Error("expected leaked instance of completed job to be terminated")
+	}
+
+	result, err = orchestrator.ResumeBenchmark(context.Background(), states[1])
+	if err != nil {
+		t.Fatalf("ResumeBenchmark failed: %v", err)
+	}
+	if result.InstanceID == stopped {
+		t.Error("expected a fresh instance instead of the stopped one")
+	}
+	if !isTerminated(cloud, stopped) {
+		t.Error("expected stopped instance to be terminated")
+	}
+	if got := cloud.ActiveInstances(); got != 0 {
+		t.Errorf("expected no active instances after resume, got %d", got)
+	}
+
+	for _, job := range journal.JobStates() {
+		if len(job.LiveInstances) != 0 {
+			t.Errorf("job %s still has live instances %v", job.ID, job.LiveInstances)
+		}
+	}
+}
+
+func TestOpenJournalDiscardsPartialEntry(t *testing.T) {
+	journal := newTestJournal(t, fakeBenchmarkConfig("m7i.large", "stream"))
+	if err := journal.Record(JournalEntry{Event: JournalInstanceLaunched, JobID: "m7i.large/stream", InstanceID: "i-1"}); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	journal.Close()
+
+	// A crash mid-write leaves an unterminated line behind
+	file, err := os.OpenFile(journal.Path(), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"event":"instance_termin`)
+	file.Close()
+
+	reopened, err := OpenJournal(journal.Path())
+	if err != nil {
+		t.Fatalf("OpenJournal failed: %v", err)
+	}
+	if err := reopened.Record(JournalEntry{Event: JournalRunResumed}); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	reopened.Close()
+
+	again, err := OpenJournal(journal.Path())
+	if err != nil {
+		t.Fatalf("OpenJournal after resume failed: %v", err)
+	}
+	defer again.Close()
+	if got := len(again.Entries()); got != 3 {
+		t.Errorf("expected 3 entries, got %d", got)
+	}
+	if live := again.JobStates()[0].LiveInstances; len(live) != 1 || live[0] != "i-1" {
+		t.Errorf("expected i-1 to still be live, got %v", live)
+	}
+}
+
+func TestCreateJournalRefusesExistingFile(t *testing.T) {
+	journal := newTestJournal(t)
+	if _, err := CreateJournal(journal.Path(), nil); !errors.Is(err, ErrJournalExists) {
+		t.Errorf("expected ErrJournalExists, got %v", err)
+	}
+}
@@ -0,0 +1,333 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// ErrObjectNotFound is returned by an ObjectStore for a missing object.
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectStore is the storage behind async job tracking: job metadata,
+// sentinels, progress, results and logs laid out as in S3SentinelFiles.
+//
+// The interface is satisfied by S3ObjectStore for S3 and S3-compatible
+// services such as MinIO, by LocalObjectStore for a directory on disk and
+// by MemoryObjectStore for tests, so the sentinel protocol can run end to
+// end without AWS.
+type ObjectStore interface {
+	// PutObject writes an object, replacing any existing one.
+	PutObject(ctx context.Context, bucket, key string, body []byte, contentType string) error
+
+	// GetObject reads an object, returning ErrObjectNotFound if it is missing.
+	GetObject(ctx context.Context, bucket, key string) ([]byte, error)
+
+	// ObjectExists reports whether an object exists.
+	ObjectExists(ctx context.Context, bucket, key string) (bool, error)
+
+	// ListObjects returns the keys under prefix in lexical order.
+	ListObjects(ctx context.Context, bucket, prefix string) ([]string, error)
+}
+
+// S3API defines the subset of the S3 API used by S3ObjectStore.
+type S3API interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+}
+
+// Compile-time checks that the object stores satisfy ObjectStore.
+var (
+	_ S3API       = (*s3.Client)(nil)
+	_ ObjectStore = (*S3ObjectStore)(nil)
+	_ ObjectStore = (*LocalObjectStore)(nil)
+	_ ObjectStore = (*MemoryObjectStore)(nil)
+)
+
+// S3ObjectStore stores objects in S3 or an S3-compatible service.
+type S3ObjectStore struct {
+	client   S3API
+	endpoint string
+}
+
+// NewS3ObjectStore returns an object store backed by an S3 client.
+func NewS3ObjectStore(client S3API) *S3ObjectStore {
+	return &S3ObjectStore{client: client}
+}
+
+// NewS3CompatibleObjectStore returns an object store for an S3-compatible
+// service such as MinIO at endpoint, e.g. "http://minio.internal:9000".
+// Buckets are addressed path-style, which such services expect.
+//
+// Async instances upload to the same endpoint, so it must be reachable
+// from the benchmark subnet.
+func NewS3CompatibleObjectStore(cfg aws.Config, endpoint string) *S3ObjectStore {
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.BaseEndpoint = aws.String(endpoint)
+		o.UsePathStyle = true
+	})
+	return &S3ObjectStore{client: client, endpoint: endpoint}
+}
+
+// Endpoint returns the custom endpoint of an S3-compatible store, or "" for S3.
+func (s *S3ObjectStore) Endpoint() string {
+	return s.endpoint
+}
+
+// PutObject writes an object to S3.
+func (s *S3ObjectStore) PutObject(ctx context.Context, bucket, key string, body []byte, contentType string) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String(contentType),
+	})
+	return err
+}
+
+// GetObject reads an object from S3.
+func (s *S3ObjectStore) GetObject(ctx context.Context, bucket, key string) ([]byte, error) {
+	resp, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isS3NotFound(err) {
+			return nil, fmt.Errorf("%w: s3://%s/%s", ErrObjectNotFound, bucket, key)
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
+}
+
+// ObjectExists checks whether an S3 object exists.
+func (s *S3ObjectStore) ObjectExists(ctx context.Context, bucket, key string) (bool, error) {
+	_, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isS3NotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// ListObjects lists the S3 keys under prefix.
+func (s *S3ObjectStore) ListObjects(ctx context.Context, bucket, prefix string) ([]string, error) {
+	var keys []string
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list S3 objects: %w", err)
+		}
+		for _, obj := range page.Contents {
+			keys = append(keys, aws.ToString(obj.Key))
+		}
+	}
+	return keys, nil
+}
+
+// isS3NotFound reports whether err is S3's answer for a missing object.
+// HeadObject has no response body, so only the generic NotFound code is
+// available for it.
+func isS3NotFound(err error) bool {
+	var noSuchKey *s3types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return true
+	}
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "NotFound"
+}
+
+// LocalObjectStore stores objects as files under a root directory, one
+// subdirectory per bucket, e.g. <root>/<bucket>/benchmarks/<id>/...
+type LocalObjectStore struct {
+	root string
+}
+
+// NewLocalObjectStore returns an object store rooted at dir, creating it
+// if needed.
+func NewLocalObjectStore(dir string) (*LocalObjectStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create object store directory: %w", err)
+	}
+	return &LocalObjectStore{root: dir}, nil
+}
+
+// path returns the file holding an object, rejecting keys that would
+// escape the bucket directory.
+func (s *LocalObjectStore) path(bucket, key string) (string, error) {
+	if !fs.ValidPath(bucket) || strings.Contains(bucket, "/") || !fs.ValidPath(key) || key == "." {
+		return "", fmt.Errorf("invalid object %s/%s", bucket, key)
+	}
+	return filepath.Join(s.root, bucket, filepath.FromSlash(key)), nil
+}
+
+// PutObject writes an object to a file, atomically replacing any existing one.
+func (s *LocalObjectStore) PutObject(_ context.Context, bucket, key string, body []byte, _ string) error {
+	path, err := s.path(bucket, key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-"+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// GetObject reads an object from its file.
+func (s *LocalObjectStore) GetObject(_ context.Context, bucket, key string) ([]byte, error) {
+	path, err := s.path(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, path)
+	}
+	return data, err
+}
+
+// ObjectExists checks whether an object's file exists.
+func (s *LocalObjectStore) ObjectExists(_ context.Context, bucket, key string) (bool, error) {
+	path, err := s.path(bucket, key)
+	if err != nil {
+		return false, err
+	}
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !info.IsDir(), nil
+}
+
+// ListObjects lists the keys under prefix by walking the bucket directory.
+func (s *LocalObjectStore) ListObjects(_ context.Context, bucket, prefix string) ([]string, error) {
+	if !fs.ValidPath(bucket) || strings.Contains(bucket, "/") {
+		return nil, fmt.Errorf("invalid bucket %s", bucket)
+	}
+	bucketDir := filepath.Join(s.root, bucket)
+
+	var keys []string
+	err := filepath.WalkDir(bucketDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == bucketDir {
+				return filepath.SkipDir // Empty bucket
+			}
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(bucketDir, path)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", bucketDir, err)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// MemoryObjectStore keeps objects in memory. It is safe for concurrent use.
+type MemoryObjectStore struct {
+	mu      sync.RWMutex
+	objects map[string][]byte
+}
+
+// NewMemoryObjectStore returns an empty in-memory object store.
+func NewMemoryObjectStore() *MemoryObjectStore {
+	return &MemoryObjectStore{objects: make(map[string][]byte)}
+}
+
+// memoryKey joins a bucket and key into a map key.
+func memoryKey(bucket, key string) string {
+	return bucket + "/" + key
+}
+
+// PutObject stores a copy of body.
+func (s *MemoryObjectStore) PutObject(_ context.Context, bucket, key string, body []byte, _ string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[memoryKey(bucket, key)] = append([]byte(nil), body...)
+	return nil
+}
+
+// GetObject returns a copy of an object.
+func (s *MemoryObjectStore) GetObject(_ context.Context, bucket, key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	body, ok := s.objects[memoryKey(bucket, key)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s/%s", ErrObjectNotFound, bucket, key)
+	}
+	return append([]byte(nil), body...), nil
+}
+
+// ObjectExists checks whether an object is stored.
+func (s *MemoryObjectStore) ObjectExists(_ context.Context, bucket, key string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.objects[memoryKey(bucket, key)]
+	return ok, nil
+}
+
+// ListObjects lists the stored keys under prefix.
+func (s *MemoryObjectStore) ListObjects(_ context.Context, bucket, prefix string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var keys []string
+	for name := range s.objects {
+		if key, ok := strings.CutPrefix(name, bucket+"/"); ok && strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
@@ -0,0 +1,212 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/fakecloud"
+)
+
+func TestObjectStores(t *testing.T) {
+	local, err := NewLocalObjectStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalObjectStore failed: %v", err)
+	}
+	stores := map[string]ObjectStore{
+		"local":  local,
+		"memory": NewMemoryObjectStore(),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			if _, err := store.GetObject(ctx, "bucket", "benchmarks/a/results.json"); !errors.Is(err, ErrObjectNotFound) {
+				t.Errorf("Expected ErrObjectNotFound for a missing object, got %v", err)
+			}
+			if keys, err := store.ListObjects(ctx, "bucket", "benchmarks/"); err != nil || len(keys) != 0 {
+				t.Errorf("Expected an empty bucket, got %v (err %v)", keys, err)
+			}
+
+			for _, key := range []string{"benchmarks/b/job-metadata.json", "benchmarks/a/job-metadata.json", "other/readme.txt"} {
+				if err := store.PutObject(ctx, "bucket", key, []byte("v1"), "text/plain"); err != nil {
+					t.Fatalf("PutObject(%s) failed: %v", key, err)
+				}
+			}
+			if err := store.PutObject(ctx, "bucket", "benchmarks/a/job-metadata.json", []byte("v2"), "text/plain"); err != nil {
+				t.Fatalf("PutObject overwrite failed: %v", err)
+			}
+
+			data, err := store.GetObject(ctx, "bucket", "benchmarks/a/job-metadata.json")
+			if err != nil || string(data) != "v2" {
+				t.Errorf("GetObject = %q, %v; want the overwritten object", data, err)
+			}
+			if exists, err := store.ObjectExists(ctx, "bucket", "benchmarks/a/job-metadata.json"); err != nil || !exists {
+				t.Errorf("ObjectExists = %v, %v; want true", exists, err)
+			}
+			if exists, _ := store.ObjectExists(ctx, "bucket", "benchmarks/a"); exists {
+				t.Error("Expected a key prefix not to exist as an object")
+			}
+			if exists, _ := store.ObjectExists(ctx, "other-bucket", "benchmarks/a/job-metadata.json"); exists {
+				t.Error("Expected objects to be scoped to their bucket")
+			}
+
+			keys, err := store.ListObjects(ctx, "bucket", "benchmarks/")
+			if err != nil {
+				t.Fatalf("ListObjects failed: %v", err)
+			}
+			if want := []string{"benchmarks/a/job-metadata.json", "benchmarks/b/job-metadata.json"}; !reflect.DeepEqual(keys, want) {
+				t.Errorf("ListObjects = %v, want %v", keys, want)
+			}
+		})
+	}
+}
+
+func TestLocalObjectStoreRejectsEscapingKeys(t *testing.T) {
+	store, err := NewLocalObjectStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalObjectStore failed: %v", err)
+	}
+	for _, key := range []string{"../outside.txt", "/etc/passwd", "benchmarks/../../outside.txt"} {
+		if err := store.PutObject(context.Background(), "bucket", key, []byte("x"), "text/plain"); err == nil {
+			t.Errorf("Expected PutObject to reject key %q", key)
+		}
+	}
+	if err := store.PutObject(context.Background(), "../bucket", "key", []byte("x"), "text/plain"); err == nil {
+		t.Error("Expected PutObject to reject an escaping bucket")
+	}
+}
+
+// notFoundS3 is an S3API whose objects are all missing.
+type notFoundS3 struct {
+	S3API
+}
+
+func (notFoundS3) GetObject(context.Context, *s3.GetObjectInput, ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	return nil, &s3types.NoSuchKey{}
+}
+
+func (notFoundS3) HeadObject(context.Context, *s3.HeadObjectInput, ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	return nil, &smithy.GenericAPIError{Code: "NotFound"}
+}
+
+func TestS3ObjectStoreNotFound(t *testing.T) {
+	store := NewS3ObjectStore(notFoundS3{})
+	if _, err := store.GetObject(context.Background(), "bucket", "key"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Expected ErrObjectNotFound for NoSuchKey, got %v", err)
+	}
+	exists, err := store.ObjectExists(context.Background(), "bucket", "key")
+	if err != nil || exists {
+		t.Errorf("ObjectExists = %v, %v; want false without an error", exists, err)
+	}
+}
+
+func TestAsyncSentinelProtocolWithLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalObjectStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalObjectStore failed: %v", err)
+	}
+	orchestrator := newFakeOrchestrator(fakecloud.New("us-east-1"))
+	launcher := NewAsyncLauncherWithStore(orchestrator, store)
+	collector := NewAsyncCollectorWithStore(orchestrator, store)
+
+	stream, err := launcher.LaunchSingleBenchmark(ctx, fakeBenchmarkConfig("m7i.large", "stream"), "results", "local", time.Hour)
+	if err != nil {
+		t.Fatalf("LaunchSingleBenchmark failed: %v", err)
+	}
+	coremark, err := launcher.LaunchSingleBenchmark(ctx, fakeBenchmarkConfig("c7g.large", "coremark"), "results", "local", time.Hour)
+	if err != nil {
+		t.Fatalf("LaunchSingleBenchmark failed: %v", err)
+	}
+
+	jobs, err := collector.ListJobs(ctx, "results")
+	if err != nil {
+		t.Fatalf("ListJobs failed: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("Expected 2 jobs, got %d", len(jobs))
+	}
+	for _, job := range jobs {
+		if job.Status != JobStatusLaunched || job.InstanceID == "" {
+			t.Errorf("Job %s: status %s, instance %q; want a launched instance", job.BenchmarkID, job.Status, job.InstanceID)
+		}
+	}
+
+	// Play the instance side of the protocol: the STREAM job completes
+	// and the CoreMark job fails
+	put := func(key, body string) {
+		t.Helper()
+		if err := store.PutObject(ctx, "results", key, []byte(body), "text/plain"); err != nil {
+			t.Fatalf("PutObject(%s) failed: %v", key, err)
+		}
+	}
+	streamFiles := NewS3SentinelFiles(stream.S3Prefix)
+	put(streamFiles.StatusRunning, "RUNNING")
+	put(streamFiles.SystemInfo, `{"architecture": "x86_64"}`)
+	put(streamFiles.BenchmarkResults, fakeScriptResults)
+	put(streamFiles.StatusCompleted, "COMPLETED")
+
+	coremarkFiles := NewS3SentinelFiles(coremark.S3Prefix)
+	put(coremarkFiles.StatusRunning, "RUNNING")
+	put(coremarkFiles.BenchmarkLogs, "coremark: compilation failed")
+	put(coremarkFiles.StatusFailed, "FAILED")
+
+	result, err := collector.CheckAllBenchmarks(ctx, "results")
+	if err != nil {
+		t.Fatalf("CheckAllBenchmarks failed: %v", err)
+	}
+	if len(result.Completed) != 1 || len(result.Failed) != 1 || len(result.InProgress) != 0 {
+		t.Fatalf("Expected 1 completed and 1 failed job, got %+v", result.Summary)
+	}
+
+	completed := result.Completed[0]
+	if completed.Job.BenchmarkID != stream.BenchmarkID {
+		t.Errorf("Completed job = %s, want %s", completed.Job.BenchmarkID, stream.BenchmarkID)
+	}
+	if completed.BenchmarkData.STREAM == nil || completed.BenchmarkData.STREAM.Bandwidth("triad") < 41.9 {
+		t.Errorf("Unexpected STREAM results: %+v", completed.BenchmarkData.STREAM)
+	}
+	if completed.SystemInfo["architecture"] != "x86_64" {
+		t.Errorf("Unexpected system info: %v", completed.SystemInfo)
+	}
+	if failed := result.Failed[0]; failed.ErrorLogs != "coremark: compilation failed" {
+		t.Errorf("Unexpected failure logs %q", failed.ErrorLogs)
+	}
+}
+
+func TestAsyncUserDataUsesStoreEndpoint(t *testing.T) {
+	job := &AsyncBenchmarkJob{
+		BenchmarkID:     "bench-test",
+		BenchmarkConfig: fakeBenchmarkConfig("m7i.large", "stream"),
+		S3Bucket:        "benchmark-results",
+		S3Prefix:        "benchmarks/bench-test/",
+	}
+	orchestrator := newFakeOrchestrator(fakecloud.New("us-east-1"))
+
+	store := NewS3CompatibleObjectStore(aws.Config{Region: "us-east-1"}, "http://minio.internal:9000")
+	script, err := NewAsyncLauncherWithStore(orchestrator, store).generateAsyncUserDataScript(job, time.Hour)
+	if err != nil {
+		t.Fatalf("generateAsyncUserDataScript failed: %v", err)
+	}
+	if !strings.Contains(script, `export AWS_ENDPOINT_URL_S3="http://minio.internal:9000"`) {
+		t.Error("Expected instance uploads to use the MinIO endpoint")
+	}
+	checkShellSyntax(t, script)
+
+	script, err = NewAsyncLauncherWithStore(orchestrator, NewMemoryObjectStore()).generateAsyncUserDataScript(job, time.Hour)
+	if err != nil {
+		t.Fatalf("generateAsyncUserDataScript failed: %v", err)
+	}
+	if strings.Contains(script, "AWS_ENDPOINT_URL_S3") {
+		t.Error("Expected no endpoint override without an S3-compatible store")
+	}
+}
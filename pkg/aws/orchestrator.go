@@ -11,6 +11,7 @@
 //   - BenchmarkConfig: Configuration for benchmark execution parameters
 //   - InstanceResult: Comprehensive results and metadata from benchmark runs
 //   - QuotaError: Specialized error type for quota and capacity issues
+//   - BenchmarkSuite: Pluggable suite interface with a name-keyed registry
 //
 // Usage:
 //   orchestrator, err := aws.NewOrchestrator("us-east-1")
@@ -39,7 +40,7 @@
 //   - Concurrent instance launches for batch processing
 //   - Intelligent retry logic with exponential backoff
 //   - Regional optimization for network latency reduction
-//   - Spot instance support for cost-sensitive workloads (planned)
+//   - Spot instance support with interruption detection and retry
 package aws
 
 import (
@@ -53,7 +54,6 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
@@ -65,6 +65,7 @@ var (
 	ErrNoSuitableAMI          = errors.New("no suitable AMI found for architecture")
 	ErrInstanceNotFound       = errors.New("instance not found")
 	ErrUnsupportedBenchmark   = errors.New("unsupported benchmark suite")
+	ErrRegionMismatch         = errors.New("benchmark region does not match orchestrator region")
 )
 
 // Orchestrator manages the complete lifecycle of AWS EC2 benchmark execution.
@@ -79,17 +80,32 @@ var (
 //   Separate orchestrator instances can run benchmarks in parallel without
 //   interference, enabling efficient batch processing workflows.
 type Orchestrator struct {
-	// ec2Client is the configured AWS SDK v2 EC2 client for the target region.
-	// Includes automatic retry logic and regional endpoint optimization.
-	ec2Client *ec2.Client
+	// ec2Client is the EC2 backend for the target region. In production this
+	// is the AWS SDK v2 client; tests substitute an in-memory implementation.
+	ec2Client EC2API
 	
-	// ssmClient is the configured AWS Systems Manager client for command execution.
+	// ssmClient is the Systems Manager backend used for command execution.
 	// Used for secure command execution without SSH key management.
-	ssmClient *ssm.Client
+	ssmClient SSMAPI
+	
+	// cfg is the shared AWS configuration the SDK clients were built from.
+	// Zero-valued when the orchestrator was created with injected clients.
+	cfg aws.Config
 	
 	// region is the AWS region where benchmark instances will be launched.
 	// Used for AMI selection, capacity planning, and result storage.
 	region string
+	
+	// polling controls wait intervals for instance and command state checks.
+	polling PollingConfig
+	
+	// journal durably records launches, commands and outcomes so an
+	// interrupted run can be resumed. Nil disables journaling.
+	journal *Journal
+	
+	// events receives the status and progress of running jobs. Nil
+	// disables publishing.
+	events *EventBus
 }
 
 // BenchmarkConfig defines the complete configuration for a benchmark execution
@@ -114,9 +130,15 @@ type BenchmarkConfig struct {
 	BenchmarkSuite string
 	
 	// Region is the AWS region for instance launch and resource allocation.
-	// Must match the orchestrator's configured region.
+	// Must match the orchestrator's configured region; multi-region runs
+	// use one orchestrator per region.
 	Region string
 	
+	// Profile is the AWS shared config profile the region's orchestrator
+	// was created with. It is recorded in the run journal so that a resumed
+	// run uses the same credentials. Empty means the default 'aws' profile.
+	Profile string
+	
 	// KeyPairName is the EC2 key pair for SSH access to benchmark instances.
 	// Required for debugging and manual intervention scenarios.
 	KeyPairName string
@@ -140,6 +162,66 @@ type BenchmarkConfig struct {
 	// Timeout defines the maximum duration for benchmark execution.
 	// Includes instance launch, benchmark execution, and result collection time.
 	Timeout time.Duration
+	
+	// JobID identifies this execution in the run journal.
+	// Defaults to "<instance type>/<suite>" when empty.
+	JobID string
+	
+	// PurchaseOption selects on-demand or spot capacity.
+	// Values: "on-demand" (default), "spot"
+	PurchaseOption string
+	
+	// SpotMaxPrice caps the hourly spot price in USD, e.g. "0.05".
+	// Empty means the on-demand price.
+	SpotMaxPrice string
+	
+	// SpotRetrySubnetIDs are subnets, typically in other availability zones,
+	// in which an interrupted spot benchmark is retried in order.
+	SpotRetrySubnetIDs []string
+	
+	// SpotFallbackOnDemand retries an interrupted spot benchmark on-demand
+	// once SpotRetrySubnetIDs are exhausted.
+	SpotFallbackOnDemand bool
+	
+	// AvailabilityZone pins the instance to an availability zone.
+	// Must match the zone of SubnetID when both are set.
+	AvailabilityZone string
+	
+	// Tenancy selects shared or single-tenant hardware.
+	// Values: "default", "dedicated", "host"
+	Tenancy string
+	
+	// HostID targets a specific dedicated host. Requires "host" tenancy.
+	HostID string
+	
+	// PlacementGroup launches the instance into an existing placement group,
+	// e.g. a cluster group for co-located runs.
+	PlacementGroup string
+	
+	// CapacityReservationID launches the instance into a targeted on-demand
+	// capacity reservation.
+	CapacityReservationID string
+	
+	// AMIID launches a specific AMI instead of the latest Amazon Linux 2.
+	AMIID string
+	
+	// AMIParameter resolves the AMI from an SSM parameter, e.g.
+	// "/aws/service/ami-amazon-linux-latest/al2023-ami-kernel-default-x86_64".
+	// Mutually exclusive with AMIID.
+	AMIParameter string
+	
+	// LaunchTemplateName launches the instance from an EC2 launch template.
+	// The template's AMI is used unless AMIID or AMIParameter is also set.
+	LaunchTemplateName string
+	
+	// LaunchTemplateVersion selects the template version: a number,
+	// "$Latest" or "$Default". Empty means the default version.
+	LaunchTemplateVersion string
+	
+	// OSFamily is the OS family of the AMI, which selects the async
+	// bootstrap template and the default AMI.
+	// Values: "amazon-linux" (default), "ubuntu"
+	OSFamily string
 }
 
 // InstanceResult contains comprehensive execution results and metadata for a
@@ -164,14 +246,57 @@ type InstanceResult struct {
 	PrivateIP string
 	
 	// Status indicates the current state of benchmark execution.
-	// Values: "launching", "running", "completed", "failed"
+	// Values: "launching", "running", "completed", "failed", "interrupted"
 	Status string
 	
-	// BenchmarkData contains structured performance results from execution.
-	// Format varies by benchmark suite:
-	//   STREAM: {"copy": {"bandwidth": 45.2, "unit": "GB/s"}, ...}
-	//   HPL: {"gflops": 123.4, "efficiency": 0.85, ...}
-	BenchmarkData map[string]interface{}
+	// PurchaseOption is how the instance that produced the result was
+	// actually purchased: "on-demand" or "spot".
+	PurchaseOption string
+	
+	// Interruptions lists the spot interruptions of this benchmark,
+	// including those of attempts that were retried.
+	Interruptions []SpotInterruption
+	
+	// AvailabilityZone is the zone the instance actually ran in.
+	AvailabilityZone string
+	
+	// Tenancy is the instance's tenancy: "default", "dedicated" or "host".
+	Tenancy string
+	
+	// HostID is the dedicated host the instance ran on, if any.
+	HostID string
+	
+	// PlacementGroup is the placement group the instance ran in, if any.
+	PlacementGroup string
+	
+	// CapacityReservationID is the capacity reservation the instance used, if any.
+	CapacityReservationID string
+	
+	// AMIID is the AMI the instance was actually launched from.
+	AMIID string
+	
+	// KernelVersion is the instance's kernel release as reported by uname -r.
+	KernelVersion string
+	
+	// OSRelease is the PRETTY_NAME from the instance's /etc/os-release.
+	OSRelease string
+	
+	// ContainerImageDigest is the digest the benchmark's container image
+	// resolved to on the instance, if known.
+	ContainerImageDigest string
+	
+	// BenchmarkData contains typed performance results from execution.
+	// Only the field for the executed suite is populated, e.g.
+	// BenchmarkData.STREAM for "stream" or BenchmarkData.HPL for "hpl".
+	BenchmarkData *BenchmarkResults
+	
+	// RawOutputSHA256 is the RawOutputHash of the iteration outputs
+	// BenchmarkData was parsed from.
+	RawOutputSHA256 string
+	
+	// RawOutputs holds the stdout and stderr of every iteration whose
+	// command completed, for archiving next to the stored result.
+	RawOutputs []IterationOutput
 	
 	// SystemTopology contains comprehensive hardware topology and configuration
 	// discovered from the benchmark instance for performance analysis.
@@ -210,6 +335,27 @@ func (e *QuotaError) Error() string {
 	return fmt.Sprintf("quota exceeded for %s in %s: %s", e.InstanceType, e.Region, e.Message)
 }
 
+// isCapacityError reports whether a RunInstances error is a quota or
+// capacity limitation rather than a problem with the request.
+func isCapacityError(err error) bool {
+	var quotaErr *QuotaError
+	if errors.As(err, &quotaErr) {
+		return true
+	}
+	for _, code := range []string{
+		"InsufficientInstanceCapacity",
+		"InstanceLimitExceeded",
+		"MaxSpotInstanceCountExceeded",
+		"SpotMaxPriceTooLow",
+		"ReservationCapacityExceeded",
+	} {
+		if strings.Contains(err.Error(), code) {
+			return true
+		}
+	}
+	return false
+}
+
 // NewOrchestrator creates a new AWS EC2 benchmark orchestrator for the specified region.
 //
 // This function initializes a complete orchestration environment with AWS SDK v2
@@ -251,19 +397,31 @@ func (e *QuotaError) Error() string {
 //   - Invalid region specification
 //   - IAM permissions insufficient for EC2 operations
 func NewOrchestrator(region string) (*Orchestrator, error) {
-	cfg, err := config.LoadDefaultConfig(context.TODO(),
-		config.WithRegion(region),
-		config.WithSharedConfigProfile("aws"), // Use 'aws' profile as specified
-	)
+	cfg, err := LoadAWSConfig(region)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		return nil, err
 	}
 
-	return &Orchestrator{
-		ec2Client: ec2.NewFromConfig(cfg),
-		ssmClient: ssm.NewFromConfig(cfg),
-		region:    region,
-	}, nil
+	orchestrator := NewOrchestratorWithClients(region, ec2.NewFromConfig(cfg), ssm.NewFromConfig(cfg))
+	orchestrator.cfg = cfg
+	return orchestrator, nil
+}
+
+// NewOrchestratorWithProfile creates an orchestrator for the region whose
+// clients use the named shared AWS config profile instead of 'aws'. An
+// empty profile falls back to NewOrchestrator.
+func NewOrchestratorWithProfile(region, profile string) (*Orchestrator, error) {
+	if profile == "" {
+		return NewOrchestrator(region)
+	}
+	cfg, err := LoadAWSConfigWithProfile(region, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	orchestrator := NewOrchestratorWithClients(region, ec2.NewFromConfig(cfg), ssm.NewFromConfig(cfg))
+	orchestrator.cfg = cfg
+	return orchestrator, nil
 }
 
 // RunBenchmark executes a comprehensive benchmark on the specified AWS EC2 instance type.
@@ -280,32 +438,54 @@ func NewOrchestrator(region string) (*Orchestrator, error) {
 //   - *InstanceResult: Complete benchmark results with performance data and metadata
 //   - error: Execution errors, infrastructure failures, or configuration issues
 func (o *Orchestrator) RunBenchmark(ctx context.Context, config BenchmarkConfig) (*InstanceResult, error) {
+	result, err := o.runWithSpotRetry(config, func(attempt BenchmarkConfig) (*InstanceResult, error) {
+		return o.runBenchmark(ctx, attempt, nil)
+	})
+	o.recordOutcome(config, result, err)
+	return result, err
+}
+
+// runBenchmark implements RunBenchmark. When resume names a live instance
+// from the run journal, the launch is skipped and the benchmark continues on
+// that instance, reusing iterations the journal already holds.
+func (o *Orchestrator) runBenchmark(ctx context.Context, config BenchmarkConfig, resume *JobState) (*InstanceResult, error) {
 	result := &InstanceResult{
 		InstanceType: config.InstanceType,
 		StartTime:    time.Now(),
 	}
 
-	// Check quotas first if not skipped
-	if !config.SkipQuotaCheck {
-		if err := o.checkQuotas(ctx, config.InstanceType); err != nil {
-			result.Error = err
-			result.EndTime = time.Now()
-			return result, err
-		}
+	instanceID := ""
+	if resume != nil {
+		instanceID = resume.instanceID
 	}
 
-	// Launch instance
-	instanceID, err := o.launchInstance(ctx, config)
-	if err != nil {
-		result.Error = fmt.Errorf("failed to launch instance: %w", err)
-		result.EndTime = time.Now()
-		return result, result.Error
+	if instanceID != "" {
+		fmt.Printf("   🔗 Reattaching to instance %s from run journal...\n", instanceID)
+	} else {
+		// Check quotas first if not skipped
+		if !config.SkipQuotaCheck {
+			if err := o.checkQuotas(ctx, config.InstanceType); err != nil {
+				result.Error = err
+				result.EndTime = time.Now()
+				return result, err
+			}
+		}
+
+		// Launch instance
+		launchedID, err := o.launchInstance(ctx, config)
+		if err != nil {
+			result.Error = fmt.Errorf("failed to launch instance: %w", err)
+			result.EndTime = time.Now()
+			return result, result.Error
+		}
+		instanceID = launchedID
 	}
 	result.InstanceID = instanceID
 
 	// Wait for instance to be running
 	if err := o.waitForInstanceRunning(ctx, instanceID, config.Timeout); err != nil {
-		if terminateErr := o.terminateInstance(ctx, instanceID); terminateErr != nil {
+		err = o.interruptedError(ctx, config, instanceID, err)
+		if terminateErr := o.terminateJobInstance(ctx, config, instanceID); terminateErr != nil {
 			// Log termination failure but don't override the original error
 			_ = terminateErr
 		}
@@ -316,7 +496,7 @@ func (o *Orchestrator) RunBenchmark(ctx context.Context, config BenchmarkConfig)
 
 	// Get instance details
 	if err := o.updateInstanceDetails(ctx, result); err != nil {
-		if terminateErr := o.terminateInstance(ctx, instanceID); terminateErr != nil {
+		if terminateErr := o.terminateJobInstance(ctx, config, instanceID); terminateErr != nil {
 			// Log termination failure but don't override the original error
 			_ = terminateErr
 		}
@@ -326,9 +506,10 @@ func (o *Orchestrator) RunBenchmark(ctx context.Context, config BenchmarkConfig)
 	}
 
 	// Run benchmark via user data script
-	benchmarkData, err := o.runBenchmarkOnInstance(ctx, result, config)
+	benchmarkData, err := o.runBenchmarkOnInstance(ctx, result, config, resume)
 	if err != nil {
-		if terminateErr := o.terminateInstance(ctx, instanceID); terminateErr != nil {
+		err = o.interruptedError(ctx, config, instanceID, err)
+		if terminateErr := o.terminateJobInstance(ctx, config, instanceID); terminateErr != nil {
 			// Log termination failure but don't override the original error
 			_ = terminateErr
 		}
@@ -339,7 +520,7 @@ func (o *Orchestrator) RunBenchmark(ctx context.Context, config BenchmarkConfig)
 	result.BenchmarkData = benchmarkData
 
 	// Terminate instance
-	if err := o.terminateInstance(ctx, instanceID); err != nil {
+	if err := o.terminateJobInstance(ctx, config, instanceID); err != nil {
 		result.Error = fmt.Errorf("failed to terminate instance: %w", err)
 	}
 
@@ -362,6 +543,14 @@ func (o *Orchestrator) RunBenchmark(ctx context.Context, config BenchmarkConfig)
 //   - *InstanceResult: Complete benchmark results with performance data, metadata, and system topology
 //   - error: Execution errors, infrastructure failures, or configuration issues
 func (o *Orchestrator) RunBenchmarkWithProfiling(ctx context.Context, config BenchmarkConfig) (*InstanceResult, error) {
+	result, err := o.runWithSpotRetry(config, func(attempt BenchmarkConfig) (*InstanceResult, error) {
+		return o.runBenchmarkWithProfiling(ctx, attempt)
+	})
+	o.recordOutcome(config, result, err)
+	return result, err
+}
+
+func (o *Orchestrator) runBenchmarkWithProfiling(ctx context.Context, config BenchmarkConfig) (*InstanceResult, error) {
 	result := &InstanceResult{
 		InstanceType: config.InstanceType,
 		StartTime:    time.Now(),
@@ -387,7 +576,7 @@ func (o *Orchestrator) RunBenchmarkWithProfiling(ctx context.Context, config Ben
 
 	// Get instance details
 	if err := o.updateInstanceDetails(ctx, result); err != nil {
-		if terminateErr := o.terminateInstance(ctx, instanceID); terminateErr != nil {
+		if terminateErr := o.terminateJobInstance(ctx, config, instanceID); terminateErr != nil {
 			// Log termination failure but don't override the original error
 			_ = terminateErr
 		}
@@ -415,9 +604,10 @@ func (o *Orchestrator) RunBenchmarkWithProfiling(ctx context.Context, config Ben
 	}
 
 	// Run benchmark via user data script
-	benchmarkData, err := o.runBenchmarkOnInstance(ctx, result, config)
+	benchmarkData, err := o.runBenchmarkOnInstance(ctx, result, config, nil)
 	if err != nil {
-		if terminateErr := o.terminateInstance(ctx, instanceID); terminateErr != nil {
+		err = o.interruptedError(ctx, config, instanceID, err)
+		if terminateErr := o.terminateJobInstance(ctx, config, instanceID); terminateErr != nil {
 			// Log termination failure but don't override the original error
 			_ = terminateErr
 		}
@@ -428,7 +618,7 @@ func (o *Orchestrator) RunBenchmarkWithProfiling(ctx context.Context, config Ben
 	result.BenchmarkData = benchmarkData
 
 	// Terminate instance
-	if err := o.terminateInstance(ctx, instanceID); err != nil {
+	if err := o.terminateJobInstance(ctx, config, instanceID); err != nil {
 		result.Error = fmt.Errorf("failed to terminate instance: %w", err)
 	}
 
@@ -586,18 +776,35 @@ func (o *Orchestrator) checkQuotas(ctx context.Context, instanceType string) err
 }
 
 func (o *Orchestrator) launchInstance(ctx context.Context, config BenchmarkConfig) (string, error) {
+	// Orchestrators are bound to one region; multi-region runs use one each
+	if config.Region != "" && config.Region != o.region {
+		return "", fmt.Errorf("%w: %s vs %s", ErrRegionMismatch, config.Region, o.region)
+	}
+	
 	// Generate user data script for benchmark execution
 	userData := o.generateUserDataScript(config)
+	if config.PurchaseOption == PurchaseSpot {
+		userData = withSpotInterruptionWatcher(userData)
+	}
 	userDataEncoded := base64.StdEncoding.EncodeToString([]byte(userData))
 
-	// Get the latest Amazon Linux 2 AMI
-	amiID, err := o.getLatestAMI(ctx, config.InstanceType)
+	marketOptions, err := spotMarketOptions(config)
+	if err != nil {
+		return "", err
+	}
+	placement, reservation, err := placementOptions(config)
+	if err != nil {
+		return "", err
+	}
+
+	// Resolve the configured AMI, or the latest Amazon Linux 2 AMI
+	amiID, err := o.resolveAMI(ctx, config)
 	if err != nil {
 		return "", fmt.Errorf("failed to get AMI: %w", err)
 	}
 
 	input := &ec2.RunInstancesInput{
-		ImageId:      aws.String(amiID),
+		ImageId:      imageID(amiID),
 		InstanceType: types.InstanceType(config.InstanceType),
 		MinCount:     aws.Int32(1),
 		MaxCount:     aws.Int32(1),
@@ -616,8 +823,9 @@ func (o *Orchestrator) launchInstance(ctx context.Context, config BenchmarkConfi
 				ResourceType: types.ResourceTypeInstance,
 				Tags: []types.Tag{
 					{Key: aws.String("Name"), Value: aws.String(fmt.Sprintf("benchmark-%s-%d", config.InstanceType, time.Now().Unix()))},
-					{Key: aws.String("Purpose"), Value: aws.String("aws-instance-benchmarks")},
+					{Key: aws.String(TagPurpose), Value: aws.String(PurposeBenchmarks)},
 					{Key: aws.String("BenchmarkSuite"), Value: aws.String(config.BenchmarkSuite)},
+					{Key: aws.String(TagBenchmarkJobID), Value: aws.String(journalJobID(config))},
 					{Key: aws.String("AutoTerminate"), Value: aws.String("true")},
 				},
 			},
@@ -625,13 +833,16 @@ func (o *Orchestrator) launchInstance(ctx context.Context, config BenchmarkConfi
 		IamInstanceProfile: &types.IamInstanceProfileSpecification{
 			Name: aws.String("benchmark-instance-profile"), // IAM role for benchmark execution
 		},
+		InstanceMarketOptions:            marketOptions,
+		Placement:                        placement,
+		CapacityReservationSpecification: reservation,
+		LaunchTemplate:                   launchTemplateSpec(config),
 	}
 
 	resp, err := o.ec2Client.RunInstances(ctx, input)
 	if err != nil {
 		// Check if it's a quota/capacity error
-		if strings.Contains(err.Error(), "InsufficientInstanceCapacity") ||
-			strings.Contains(err.Error(), "InstanceLimitExceeded") {
+		if isCapacityError(err) {
 			return "", &QuotaError{
 				InstanceType: config.InstanceType,
 				Region:       o.region,
@@ -641,19 +852,28 @@ func (o *Orchestrator) launchInstance(ctx context.Context, config BenchmarkConfi
 		return "", err
 	}
 
-	return *resp.Instances[0].InstanceId, nil
+	instanceID := *resp.Instances[0].InstanceId
+	o.record(JournalEntry{Event: JournalInstanceLaunched, JobID: journalJobID(config), InstanceID: instanceID})
+	o.publish(config, Event{Type: EventInstanceLaunched, Status: JobStatusLaunched, InstanceID: instanceID, StartTime: time.Now(), Message: "instance launched"})
+	return instanceID, nil
 }
 
-func (o *Orchestrator) getLatestAMI(ctx context.Context, instanceType string) (string, error) {
-	// Determine architecture based on instance type
-	architecture := "x86_64"
+// instanceArchitecture returns the EC2 architecture of an instance type:
+// "arm64" for Graviton types and "x86_64" otherwise.
+func instanceArchitecture(instanceType string) string {
 	// Check for Graviton instances (end with 'g' after the size, e.g., m7g.large, c7g.xlarge)
 	if strings.Contains(instanceType, "g.") || strings.HasSuffix(instanceType, "g") {
 		if strings.HasPrefix(instanceType, "m") || strings.HasPrefix(instanceType, "c") || 
 			strings.HasPrefix(instanceType, "r") || strings.HasPrefix(instanceType, "t") {
-			architecture = "arm64" // Graviton instances
+			return "arm64" // Graviton instances
 		}
 	}
+	return "x86_64"
+}
+
+func (o *Orchestrator) getLatestAMI(ctx context.Context, instanceType string) (string, error) {
+	// Determine architecture based on instance type
+	architecture := instanceArchitecture(instanceType)
 
 	input := &ec2.DescribeImagesInput{
 		Owners: []string{"amazon"},
@@ -695,7 +915,11 @@ func (o *Orchestrator) getLatestAMI(ctx context.Context, instanceType string) (s
 }
 
 func (o *Orchestrator) waitForInstanceRunning(ctx context.Context, instanceID string, timeout time.Duration) error {
-	waiter := ec2.NewInstanceRunningWaiter(o.ec2Client)
+	waiter := ec2.NewInstanceRunningWaiter(o.ec2Client, func(opts *ec2.InstanceRunningWaiterOptions) {
+		if o.polling.InstanceStateInterval > 0 && o.polling.InstanceStateInterval < opts.MinDelay {
+			opts.MinDelay = o.polling.InstanceStateInterval
+		}
+	})
 	input := &ec2.DescribeInstancesInput{
 		InstanceIds: []string{instanceID},
 	}
@@ -724,22 +948,20 @@ func (o *Orchestrator) updateInstanceDetails(ctx context.Context, result *Instan
 	if instance.PrivateIpAddress != nil {
 		result.PrivateIP = *instance.PrivateIpAddress
 	}
+	result.PurchaseOption = PurchaseOnDemand
+	if instance.InstanceLifecycle == types.InstanceLifecycleTypeSpot {
+		result.PurchaseOption = PurchaseSpot
+	}
+	recordPlacement(result, instance)
+	result.AMIID = aws.ToString(instance.ImageId)
 
 	return nil
 }
 
-func (o *Orchestrator) runBenchmarkOnInstance(ctx context.Context, result *InstanceResult, config BenchmarkConfig) (map[string]interface{}, error) {
+func (o *Orchestrator) runBenchmarkOnInstance(ctx context.Context, result *InstanceResult, config BenchmarkConfig, resume *JobState) (*BenchmarkResults, error) {
 	// Validate benchmark suite
-	supportedBenchmarks := []string{"stream", "hpl", "dgemm", "coremark", "7zip", "sysbench", "cache"}
-	supported := false
-	for _, benchmark := range supportedBenchmarks {
-		if config.BenchmarkSuite == benchmark {
-			supported = true
-			break
-		}
-	}
-	if !supported {
-		return nil, fmt.Errorf("%w: %s", ErrUnsupportedBenchmark, config.BenchmarkSuite)
+	if _, err := LookupSuite(config.BenchmarkSuite); err != nil {
+		return nil, err
 	}
 	
 	fmt.Printf("   ⏳ Waiting for instance to be ready and user data script to complete...\n")
@@ -749,25 +971,39 @@ func (o *Orchestrator) runBenchmarkOnInstance(ctx context.Context, result *Insta
 		return nil, fmt.Errorf("instance failed to become ready: %w", err)
 	}
 	
+	// Record the kernel and OS the benchmark runs on; failure is not fatal
+	if err := o.captureOSInfo(ctx, result, config.ContainerImage); err != nil {
+		fmt.Printf("   ⚠️  Could not read kernel and OS release: %v\n", err)
+	}
+	
 	// Wait for benchmark execution (user data script)
 	// The user data script should complete within 5-10 minutes for typical benchmarks
 	fmt.Printf("   🏃 Executing %s benchmark via user data script...\n", config.BenchmarkSuite)
+	o.publish(config, Event{Type: EventJobStatus, Status: JobStatusRunning, InstanceID: result.InstanceID,
+		Message: fmt.Sprintf("executing %s benchmark", config.BenchmarkSuite)})
 	
 	// Poll for benchmark completion by checking for completion marker
-	maxWaitTime := 10 * time.Minute
-	pollInterval := 30 * time.Second
+	maxWaitTime := o.polling.BenchmarkMaxWait
+	pollInterval := o.polling.BenchmarkPollInterval
 	startTime := time.Now()
 	
 	for time.Since(startTime) < maxWaitTime {
 		// Check if benchmark completed by trying to retrieve results
-		benchmarkData, err := o.retrieveBenchmarkResults(ctx, result.InstanceID, config)
+		benchmarkData, outputs, err := o.retrieveBenchmarkResults(ctx, result.InstanceID, config, resume)
 		if err == nil {
 			fmt.Printf("   ✅ Benchmark completed successfully\n")
+			result.RawOutputs = outputs
+			result.RawOutputSHA256 = RawOutputHash(parsedOutputs(outputs))
 			return benchmarkData, nil
 		}
+		if errors.Is(err, ErrSpotInterrupted) {
+			return nil, err
+		}
 		
 		fmt.Printf("   ⏳ Benchmark still running... (elapsed: %v)\n", time.Since(startTime).Round(time.Second))
-		time.Sleep(pollInterval)
+		if err := sleep(ctx, pollInterval); err != nil {
+			return nil, err
+		}
 	}
 	
 	return nil, fmt.Errorf("benchmark execution timed out after %v", maxWaitTime)
@@ -776,7 +1012,7 @@ func (o *Orchestrator) runBenchmarkOnInstance(ctx context.Context, result *Insta
 func (o *Orchestrator) waitForInstanceReady(ctx context.Context, instanceID string) error {
 	// Wait for instance to be in "running" state
 	maxAttempts := 20
-	waitTime := 15 * time.Second
+	waitTime := o.polling.InstanceStateInterval
 	
 	for attempt := 0; attempt < maxAttempts; attempt++ {
 		input := &ec2.DescribeInstancesInput{
@@ -798,62 +1034,130 @@ func (o *Orchestrator) waitForInstanceReady(ctx context.Context, instanceID stri
 		if state == types.InstanceStateNameRunning {
 			// Instance is running, now wait a bit more for user data script to start
 			fmt.Printf("   ✅ Instance is running, waiting for user data script...\n")
-			time.Sleep(60 * time.Second) // Give user data script time to start
-			return nil
+			return sleep(ctx, o.polling.UserDataSettleTime) // Give user data script time to start
 		}
 		
-		if state == types.InstanceStateNameTerminated || state == types.InstanceStateNameStopping {
+		if state == types.InstanceStateNameTerminated || state == types.InstanceStateNameShuttingDown ||
+			state == types.InstanceStateNameStopping {
 			return fmt.Errorf("instance terminated unexpectedly (state: %s)", state)
 		}
 		
 		fmt.Printf("   ⏳ Instance state: %s, waiting...\n", state)
-		time.Sleep(waitTime)
+		if err := sleep(ctx, waitTime); err != nil {
+			return err
+		}
 	}
 	
 	return fmt.Errorf("instance failed to reach running state within timeout")
 }
 
-func (o *Orchestrator) retrieveBenchmarkResults(ctx context.Context, instanceID string, config BenchmarkConfig) (map[string]interface{}, error) {
+// retrieveBenchmarkResults runs the benchmark iterations and returns their
+// aggregated results and the raw output of each iteration whose command
+// completed, whether or not it could be parsed.
+func (o *Orchestrator) retrieveBenchmarkResults(ctx context.Context, instanceID string, config BenchmarkConfig, resume *JobState) (*BenchmarkResults, []IterationOutput, error) {
 	// Execute multiple benchmark iterations for statistical significance
 	iterations := 5 // Minimum for statistical analysis
 	
-	var allResults []map[string]interface{}
+	var allResults []*BenchmarkResults
+	var outputs []IterationOutput
 	
 	for i := 0; i < iterations; i++ {
 		fmt.Printf("   🔄 Running benchmark iteration %d/%d...\n", i+1, iterations)
+		o.publish(config, Event{Type: EventJobProgress, Status: JobStatusRunning, InstanceID: instanceID,
+			PercentComplete: float64(i*100) / float64(iterations), Message: fmt.Sprintf("running iteration %d/%d", i+1, iterations)})
 		
-		result, err := o.executeBenchmarkViaSSH(ctx, instanceID, config)
+		result, output, err := o.executeBenchmarkViaSSH(ctx, instanceID, config, i+1, resume)
+		if errors.Is(err, ErrSpotInterrupted) {
+			return nil, nil, err
+		}
+		if output.Stdout != "" || output.Stderr != "" {
+			outputs = append(outputs, output)
+		}
+		completed := Event{Type: EventIterationCompleted, Status: JobStatusRunning, InstanceID: instanceID, Iteration: i + 1,
+			PercentComplete: float64((i+1)*100) / float64(iterations)}
 		if err != nil {
 			fmt.Printf("   ⚠️  Iteration %d failed: %v\n", i+1, err)
+			completed.Message, completed.Error = fmt.Sprintf("iteration %d failed: %v", i+1, err), err.Error()
+			o.publish(config, completed)
 			continue
 		}
+		o.publish(config, completed)
 		
 		allResults = append(allResults, result)
 	}
 	
-	if len(allResults) < 3 {
-		return nil, fmt.Errorf("insufficient valid iterations: got %d, need at least 3", len(allResults))
+	if len(allResults) < minValidIterations {
+		return nil, nil, fmt.Errorf("insufficient valid iterations: got %d, need at least %d", len(allResults), minValidIterations)
 	}
 	
 	// Perform statistical analysis and return aggregated results
-	return o.aggregateBenchmarkResults(config.BenchmarkSuite, allResults)
+	aggregated, err := o.aggregateBenchmarkResults(config.BenchmarkSuite, allResults)
+	if err != nil {
+		return nil, nil, err
+	}
+	o.publish(config, Event{Type: EventResultParsed, InstanceID: instanceID, Results: aggregated})
+	return aggregated, outputs, nil
 }
 
-func (o *Orchestrator) executeBenchmarkViaSSH(ctx context.Context, instanceID string, config BenchmarkConfig) (map[string]interface{}, error) {
-	// Execute benchmark via SSM (Systems Manager) - no need for public IP or SSH keys
-	benchmarkCmd := o.generateBenchmarkCommand(config)
-	output, err := o.executeSSHCommand(ctx, instanceID, benchmarkCmd)
+// executeBenchmarkViaSSH runs one benchmark iteration and returns its
+// parsed results and raw output. The raw output is returned even if the
+// command failed or its output could not be parsed.
+func (o *Orchestrator) executeBenchmarkViaSSH(ctx context.Context, instanceID string, config BenchmarkConfig, iteration int, resume *JobState) (*BenchmarkResults, IterationOutput, error) {
+	// Reuse output the run journal captured before an interruption
+	if output, ok := resume.reusableOutput(instanceID, iteration); ok {
+		if benchmarkData, err := o.parseBenchmarkOutput(config.BenchmarkSuite, output.Stdout); err == nil {
+			fmt.Printf("   ♻️  Reusing journaled output for iteration %d\n", iteration)
+			output.Parsed = true
+			return benchmarkData, output, nil
+		}
+	}
+	output := IterationOutput{Iteration: iteration}
+	
+	jobID := journalJobID(config)
+	commandID, pending := resume.pendingCommand(instanceID, iteration)
+	if pending {
+		fmt.Printf("   🔗 Waiting for journaled command %s...\n", commandID)
+	} else {
+		// Execute benchmark via SSM (Systems Manager) - no need for public IP or SSH keys
+		benchmarkCmd := o.generateBenchmarkCommand(config)
+		if config.PurchaseOption == PurchaseSpot {
+			benchmarkCmd = wrapSpotCommand(benchmarkCmd)
+		}
+		sentID, err := o.sendSSMCommand(ctx, instanceID, benchmarkCmd)
+		if err != nil {
+			return nil, output, fmt.Errorf("failed to execute benchmark via SSM: %w", err)
+		}
+		commandID = sentID
+		o.record(JournalEntry{Event: JournalCommandSent, JobID: jobID, InstanceID: instanceID, CommandID: commandID, Iteration: iteration})
+		o.publish(config, Event{Type: EventCommandSent, InstanceID: instanceID, CommandID: commandID, Iteration: iteration})
+	}
+	
+	stdout, stderr, err := o.waitForSSMCommandOutput(ctx, instanceID, commandID)
+	output.Stdout, output.Stderr = stdout, stderr
+	completed := JournalEntry{Event: JournalCommandCompleted, JobID: jobID, InstanceID: instanceID, CommandID: commandID, Iteration: iteration,
+		Output: stdout, Stderr: stderr}
+	if err != nil {
+		completed.Error = err.Error()
+		output.CommandError = err.Error()
+	}
+	if ctx.Err() == nil {
+		// A cancelled wait leaves the command pending for a later resume
+		o.record(completed)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute benchmark via SSM: %w", err)
+		err = o.interruptedError(ctx, config, instanceID, err)
+		return nil, output, fmt.Errorf("failed to execute benchmark via SSM: %w", err)
 	}
 	
 	// Parse benchmark output
-	benchmarkData, err := o.parseBenchmarkOutput(config.BenchmarkSuite, output)
+	benchmarkData, err := o.parseBenchmarkOutput(config.BenchmarkSuite, stdout)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse benchmark output: %w", err)
+		output.ParseError = err.Error()
+		return nil, output, fmt.Errorf("failed to parse benchmark output: %w", err)
 	}
 	
-	return benchmarkData, nil
+	output.Parsed = true
+	return benchmarkData, output, nil
 }
 
 func (o *Orchestrator) getInstanceInfo(ctx context.Context, instanceID string) (*InstanceInfo, error) {
@@ -895,32 +1199,11 @@ type InstanceInfo struct {
 }
 
 func (o *Orchestrator) generateBenchmarkCommand(config BenchmarkConfig) string {
-	switch config.BenchmarkSuite {
-	case "stream":
-		return o.generateSTREAMCommand()
-	case "hpl":
-		return o.generateHPLCommand()
-	case "dgemm":
-		return o.generateDGEMMCommand()
-	case "fftw":
-		return o.generateFFTWCommand()
-	case "vector_ops":
-		return o.generateVectorOpsCommand()
-	case "mixed_precision":
-		return o.generateMixedPrecisionCommand()
-	case "compilation":
-		return o.generateCompilationCommand()
-	case "coremark":
-		return o.generateCoreMarkCommand()
-	case "7zip":
-		return o.generate7ZipCommand()
-	case "sysbench":
-		return o.generateSysbenchCommand()
-	case "cache":
-		return o.generateCacheCommand()
-	default:
+	suite, err := LookupSuite(config.BenchmarkSuite)
+	if err != nil {
 		return "echo 'Unsupported benchmark suite'"
 	}
+	return suite.GenerateCommand(config)
 }
 
 func (o *Orchestrator) generateSTREAMCommand() string {
@@ -1677,17 +1960,10 @@ echo "Compilation Benchmark Complete"
 }
 
 // Parsing functions for Phase 2 benchmarks
-func (o *Orchestrator) parseVectorOpsOutput(output string) (map[string]interface{}, error) {
+func (o *Orchestrator) parseVectorOpsOutput(output string) (*BenchmarkResults, error) {
 	lines := strings.Split(output, "\n")
 	
-	results := map[string]interface{}{
-		"vector_ops": map[string]interface{}{},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-		},
-	}
-	
-	vectorResults := make(map[string]interface{})
+	vectorResults := &VectorOpsResult{}
 	
 	// Parse vector operations output
 	for _, line := range lines {
@@ -1695,46 +1971,34 @@ func (o *Orchestrator) parseVectorOpsOutput(output string) (map[string]interface
 		
 		// Parse operation-specific results
 		if strings.Contains(line, "Average AXPY:") {
-			if gflops := o.extractGFLOPSFromLine(line); gflops > 0 {
-				vectorResults["avg_axpy_gflops"] = gflops
-			}
+			vectorResults.AvgAXPYGFLOPS = o.extractGFLOPSFromLine(line)
 		} else if strings.Contains(line, "Average DOT:") {
-			if gflops := o.extractGFLOPSFromLine(line); gflops > 0 {
-				vectorResults["avg_dot_gflops"] = gflops
-			}
+			vectorResults.AvgDotGFLOPS = o.extractGFLOPSFromLine(line)
 		} else if strings.Contains(line, "Average NORM:") {
-			if gflops := o.extractGFLOPSFromLine(line); gflops > 0 {
-				vectorResults["avg_norm_gflops"] = gflops
-			}
+			vectorResults.AvgNormGFLOPS = o.extractGFLOPSFromLine(line)
 		} else if strings.Contains(line, "Overall Average:") {
-			if gflops := o.extractGFLOPSFromLine(line); gflops > 0 {
-				vectorResults["overall_avg_gflops"] = gflops
-			}
+			vectorResults.OverallAvgGFLOPS = o.extractGFLOPSFromLine(line)
 		}
 	}
 	
-	vectorResults["unit"] = "GFLOPS"
-	vectorResults["benchmark_type"] = "blas_level1_vector_ops"
-	
-	if len(vectorResults) == 0 {
+	if vectorResults.AvgAXPYGFLOPS <= 0 && vectorResults.AvgDotGFLOPS <= 0 &&
+		vectorResults.AvgNormGFLOPS <= 0 && vectorResults.OverallAvgGFLOPS <= 0 {
 		return nil, fmt.Errorf("no vector operations results found in output")
 	}
 	
-	results["vector_ops"] = vectorResults
-	return results, nil
+	vectorResults.Unit = "GFLOPS"
+	vectorResults.BenchmarkType = "blas_level1_vector_ops"
+	
+	return &BenchmarkResults{
+		VectorOps: vectorResults,
+		Metadata:  newIterationMetadata(),
+	}, nil
 }
 
-func (o *Orchestrator) parseMixedPrecisionOutput(output string) (map[string]interface{}, error) {
+func (o *Orchestrator) parseMixedPrecisionOutput(output string) (*BenchmarkResults, error) {
 	lines := strings.Split(output, "\n")
 	
-	results := map[string]interface{}{
-		"mixed_precision": map[string]interface{}{},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-		},
-	}
-	
-	mixedResults := make(map[string]interface{})
+	mixedResults := &MixedPrecisionResult{}
 	
 	// Parse mixed precision output
 	for _, line := range lines {
@@ -1742,56 +2006,42 @@ func (o *Orchestrator) parseMixedPrecisionOutput(output string) (map[string]inte
 		
 		// Parse precision-specific results
 		if strings.Contains(line, "Peak FP16:") {
-			if gflops := o.extractGFLOPSFromLine(line); gflops > 0 {
-				mixedResults["peak_fp16_gflops"] = gflops
-			}
+			mixedResults.PeakFP16GFLOPS = o.extractGFLOPSFromLine(line)
 		} else if strings.Contains(line, "Peak FP32:") {
-			if gflops := o.extractGFLOPSFromLine(line); gflops > 0 {
-				mixedResults["peak_fp32_gflops"] = gflops
-			}
+			mixedResults.PeakFP32GFLOPS = o.extractGFLOPSFromLine(line)
 		} else if strings.Contains(line, "Peak FP64:") {
-			if gflops := o.extractGFLOPSFromLine(line); gflops > 0 {
-				mixedResults["peak_fp64_gflops"] = gflops
-			}
+			mixedResults.PeakFP64GFLOPS = o.extractGFLOPSFromLine(line)
 		}
 		
 		// Parse efficiency ratios
 		if strings.Contains(line, "FP16/FP32 ratio (large):") {
-			if ratio := o.extractFloatFromLine(line, "ratio"); ratio > 0 {
-				mixedResults["fp16_fp32_efficiency"] = ratio
-			}
+			mixedResults.FP16FP32Efficiency = o.extractFloatFromLine(line, "ratio")
 		} else if strings.Contains(line, "FP32/FP64 ratio (large):") {
-			if ratio := o.extractFloatFromLine(line, "ratio"); ratio > 0 {
-				mixedResults["fp32_fp64_efficiency"] = ratio
-			}
+			mixedResults.FP32FP64Efficiency = o.extractFloatFromLine(line, "ratio")
 		}
 	}
 	
+	if mixedResults.PeakFP16GFLOPS <= 0 && mixedResults.PeakFP32GFLOPS <= 0 &&
+		mixedResults.PeakFP64GFLOPS <= 0 && mixedResults.FP16FP32Efficiency <= 0 &&
+		mixedResults.FP32FP64Efficiency <= 0 {
+		return nil, fmt.Errorf("no mixed precision results found in output")
+	}
+	
 	// Calculate overall efficiency score
-	if fp16, ok := mixedResults["peak_fp16_gflops"].(float64); ok {
-		if fp32, ok := mixedResults["peak_fp32_gflops"].(float64); ok {
-			if fp64, ok := mixedResults["peak_fp64_gflops"].(float64); ok {
-				overallScore := (fp16 + fp32 + fp64) / 3.0
-				mixedResults["overall_mixed_precision_score"] = overallScore
-			}
-		}
+	if mixedResults.PeakFP16GFLOPS > 0 && mixedResults.PeakFP32GFLOPS > 0 && mixedResults.PeakFP64GFLOPS > 0 {
+		mixedResults.OverallMixedPrecisionScore = (mixedResults.PeakFP16GFLOPS + mixedResults.PeakFP32GFLOPS + mixedResults.PeakFP64GFLOPS) / 3.0
 	}
 	
-	results["mixed_precision"] = mixedResults
-	return results, nil
+	return &BenchmarkResults{
+		MixedPrecision: mixedResults,
+		Metadata:       newIterationMetadata(),
+	}, nil
 }
 
-func (o *Orchestrator) parseCompilationOutput(output string) (map[string]interface{}, error) {
+func (o *Orchestrator) parseCompilationOutput(output string) (*BenchmarkResults, error) {
 	lines := strings.Split(output, "\n")
 	
-	results := map[string]interface{}{
-		"compilation": map[string]interface{}{},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-		},
-	}
-	
-	compilationResults := make(map[string]interface{})
+	compilationResults := &CompilationResult{}
 	
 	// Parse compilation benchmark output
 	for _, line := range lines {
@@ -1799,89 +2049,67 @@ func (o *Orchestrator) parseCompilationOutput(output string) (map[string]interfa
 		
 		// Parse compilation times
 		if strings.Contains(line, "Single-threaded time:") {
-			if duration := o.extractFloatFromLine(line, "seconds"); duration > 0 {
-				compilationResults["single_threaded_time_seconds"] = duration
-			}
+			compilationResults.SingleThreadedTimeSeconds = o.extractFloatFromLine(line, "seconds")
 		} else if strings.Contains(line, "Multi-threaded time") {
-			if duration := o.extractFloatFromLine(line, "seconds"); duration > 0 {
-				compilationResults["multi_threaded_time_seconds"] = duration
-			}
+			compilationResults.MultiThreadedTimeSeconds = o.extractFloatFromLine(line, "seconds")
 		} else if strings.Contains(line, "Incremental build time:") {
-			if duration := o.extractFloatFromLine(line, "seconds"); duration > 0 {
-				compilationResults["incremental_build_time_seconds"] = duration
-			}
+			compilationResults.IncrementalBuildTimeSeconds = o.extractFloatFromLine(line, "seconds")
 		}
 		
 		// Parse performance metrics
 		if strings.Contains(line, "Parallel speedup:") {
-			if speedup := o.extractFloatFromLine(line, "x"); speedup > 0 {
-				compilationResults["parallel_speedup"] = speedup
-			}
+			compilationResults.ParallelSpeedup = o.extractFloatFromLine(line, "x")
 		} else if strings.Contains(line, "Parallel efficiency:") {
-			if efficiency := o.extractFloatFromLine(line, "%"); efficiency > 0 {
-				compilationResults["parallel_efficiency_percent"] = efficiency
-			}
+			compilationResults.ParallelEfficiencyPercent = o.extractFloatFromLine(line, "%")
 		} else if strings.Contains(line, "Compilation throughput:") {
-			if throughput := o.extractFloatFromLine(line, "builds/second"); throughput > 0 {
-				compilationResults["compilation_throughput"] = throughput
-			}
+			compilationResults.CompilationThroughput = o.extractFloatFromLine(line, "builds/second")
 		} else if strings.Contains(line, "Average CPU utilization:") {
-			if utilization := o.extractFloatFromLine(line, "%"); utilization > 0 {
-				compilationResults["avg_cpu_utilization_percent"] = utilization
-			}
+			compilationResults.AvgCPUUtilizationPercent = o.extractFloatFromLine(line, "%")
 		}
 		
 		// Parse development workload metrics
 		if strings.Contains(line, "Single-core performance:") {
-			if units := o.extractFloatFromLine(line, "units"); units > 0 {
-				compilationResults["single_core_performance_units"] = units
-			}
+			compilationResults.SingleCorePerformanceUnits = o.extractFloatFromLine(line, "units")
 		} else if strings.Contains(line, "Multi-core scaling:") {
-			if efficiency := o.extractFloatFromLine(line, "efficiency"); efficiency > 0 {
-				compilationResults["multicore_scaling_efficiency"] = efficiency
-			}
+			compilationResults.MulticoreScalingEfficiency = o.extractFloatFromLine(line, "efficiency")
 		} else if strings.Contains(line, "Memory pressure:") {
-			if pressure := o.extractFloatFromLine(line, "%"); pressure > 0 {
-				compilationResults["memory_pressure_percent"] = pressure
-			}
+			compilationResults.MemoryPressurePercent = o.extractFloatFromLine(line, "%")
 		}
 	}
 	
-	// Calculate overall compilation performance score
-	if singleTime, ok := compilationResults["single_threaded_time_seconds"].(float64); ok {
-		if multiTime, ok := compilationResults["multi_threaded_time_seconds"].(float64); ok {
-			if speedup, ok := compilationResults["parallel_speedup"].(float64); ok {
-				// Composite score: balance between absolute performance and scaling
-				// Factor in both single-core and multi-core performance
-				performanceScore := (1000.0 / singleTime) + (1000.0 / multiTime) + (speedup * 100.0)
-				compilationResults["overall_compilation_score"] = performanceScore
-			}
-		}
+	if compilationResults.SingleThreadedTimeSeconds <= 0 && compilationResults.MultiThreadedTimeSeconds <= 0 &&
+		compilationResults.IncrementalBuildTimeSeconds <= 0 && compilationResults.ParallelSpeedup <= 0 {
+		return nil, fmt.Errorf("no compilation results found in output")
 	}
 	
-	results["compilation"] = compilationResults
-	return results, nil
+	// Calculate overall compilation performance score
+	singleTime := compilationResults.SingleThreadedTimeSeconds
+	multiTime := compilationResults.MultiThreadedTimeSeconds
+	speedup := compilationResults.ParallelSpeedup
+	if singleTime > 0 && multiTime > 0 && speedup > 0 {
+		// Composite score: balance between absolute performance and scaling
+		// Factor in both single-core and multi-core performance
+		compilationResults.OverallCompilationScore = (1000.0 / singleTime) + (1000.0 / multiTime) + (speedup * 100.0)
+	}
+	
+	return &BenchmarkResults{
+		Compilation: compilationResults,
+		Metadata:    newIterationMetadata(),
+	}, nil
 }
 
 // Aggregation functions for Phase 2 benchmarks
-func (o *Orchestrator) aggregateVectorOpsResults(allResults []map[string]interface{}) (map[string]interface{}, error) {
+func (o *Orchestrator) aggregateVectorOpsResults(allResults []*BenchmarkResults) (*BenchmarkResults, error) {
 	var axpyValues, dotValues, normValues, overallValues []float64
 	
 	for _, result := range allResults {
-		if vectorData, ok := result["vector_ops"].(map[string]interface{}); ok {
-			if axpy, ok := vectorData["avg_axpy_gflops"].(float64); ok {
-				axpyValues = append(axpyValues, axpy)
-			}
-			if dot, ok := vectorData["avg_dot_gflops"].(float64); ok {
-				dotValues = append(dotValues, dot)
-			}
-			if norm, ok := vectorData["avg_norm_gflops"].(float64); ok {
-				normValues = append(normValues, norm)
-			}
-			if overall, ok := vectorData["overall_avg_gflops"].(float64); ok {
-				overallValues = append(overallValues, overall)
-			}
+		if result == nil || result.VectorOps == nil {
+			continue
 		}
+		axpyValues = appendMeasured(axpyValues, result.VectorOps.AvgAXPYGFLOPS)
+		dotValues = appendMeasured(dotValues, result.VectorOps.AvgDotGFLOPS)
+		normValues = appendMeasured(normValues, result.VectorOps.AvgNormGFLOPS)
+		overallValues = appendMeasured(overallValues, result.VectorOps.OverallAvgGFLOPS)
 	}
 	
 	axpyStats := o.calculateStatistics(axpyValues)
@@ -1889,95 +2117,79 @@ func (o *Orchestrator) aggregateVectorOpsResults(allResults []map[string]interfa
 	normStats := o.calculateStatistics(normValues)
 	overallStats := o.calculateStatistics(overallValues)
 	
-	return map[string]interface{}{
-		"vector_ops": map[string]interface{}{
-			"avg_axpy_gflops": axpyStats.Mean,
-			"axpy_std_dev": axpyStats.StdDev,
-			"avg_dot_gflops": dotStats.Mean,
-			"dot_std_dev": dotStats.StdDev,
-			"avg_norm_gflops": normStats.Mean,
-			"norm_std_dev": normStats.StdDev,
-			"overall_avg_gflops": overallStats.Mean,
-			"overall_std_dev": overallStats.StdDev,
-			"unit": "GFLOPS",
-			"benchmark_type": "blas_level1_vector_ops",
-		},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-			"iterations": len(allResults),
-			"statistical_confidence": "95%",
-			"operations": []string{"axpy", "dot", "norm"},
+	metadata := newAggregateMetadata(len(allResults))
+	metadata.Operations = []string{"axpy", "dot", "norm"}
+	
+	return &BenchmarkResults{
+		VectorOps: &VectorOpsResult{
+			AvgAXPYGFLOPS:    axpyStats.Mean,
+			AXPYStdDev:       axpyStats.StdDev,
+			AvgDotGFLOPS:     dotStats.Mean,
+			DotStdDev:        dotStats.StdDev,
+			AvgNormGFLOPS:    normStats.Mean,
+			NormStdDev:       normStats.StdDev,
+			OverallAvgGFLOPS: overallStats.Mean,
+			OverallStdDev:    overallStats.StdDev,
+			Unit:             "GFLOPS",
+			BenchmarkType:    "blas_level1_vector_ops",
 		},
+		Metadata: metadata,
 	}, nil
 }
 
-func (o *Orchestrator) aggregateMixedPrecisionResults(allResults []map[string]interface{}) (map[string]interface{}, error) {
+func (o *Orchestrator) aggregateMixedPrecisionResults(allResults []*BenchmarkResults) (*BenchmarkResults, error) {
 	var fp16Values, fp32Values, fp64Values, overallValues []float64
 	var fp16fp32RatioValues, fp32fp64RatioValues []float64
 	
 	for _, result := range allResults {
-		if mixedData, ok := result["mixed_precision"].(map[string]interface{}); ok {
-			if fp16, ok := mixedData["peak_fp16_gflops"].(float64); ok {
-				fp16Values = append(fp16Values, fp16)
-			}
-			if fp32, ok := mixedData["peak_fp32_gflops"].(float64); ok {
-				fp32Values = append(fp32Values, fp32)
-			}
-			if fp64, ok := mixedData["peak_fp64_gflops"].(float64); ok {
-				fp64Values = append(fp64Values, fp64)
-			}
-			if overall, ok := mixedData["overall_mixed_precision_score"].(float64); ok {
-				overallValues = append(overallValues, overall)
-			}
-			if ratio, ok := mixedData["fp16_fp32_efficiency"].(float64); ok {
-				fp16fp32RatioValues = append(fp16fp32RatioValues, ratio)
-			}
-			if ratio, ok := mixedData["fp32_fp64_efficiency"].(float64); ok {
-				fp32fp64RatioValues = append(fp32fp64RatioValues, ratio)
-			}
+		if result == nil || result.MixedPrecision == nil {
+			continue
 		}
+		mixedData := result.MixedPrecision
+		fp16Values = appendMeasured(fp16Values, mixedData.PeakFP16GFLOPS)
+		fp32Values = appendMeasured(fp32Values, mixedData.PeakFP32GFLOPS)
+		fp64Values = appendMeasured(fp64Values, mixedData.PeakFP64GFLOPS)
+		overallValues = appendMeasured(overallValues, mixedData.OverallMixedPrecisionScore)
+		fp16fp32RatioValues = appendMeasured(fp16fp32RatioValues, mixedData.FP16FP32Efficiency)
+		fp32fp64RatioValues = appendMeasured(fp32fp64RatioValues, mixedData.FP32FP64Efficiency)
 	}
 	
-	aggregated := map[string]interface{}{
-		"mixed_precision": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-			"iterations": len(allResults),
-		},
+	mixedResults := &MixedPrecisionResult{
+		Timestamp:  time.Now().Format(time.RFC3339),
+		Iterations: len(allResults),
 	}
 	
-	mixedResults := aggregated["mixed_precision"].(map[string]interface{})
-	
 	// Calculate statistics for each precision
 	if len(fp16Values) > 0 {
-		mixedResults["avg_fp16_gflops"] = o.calculateMean(fp16Values)
-		mixedResults["std_fp16_gflops"] = o.calculateStdDev(fp16Values)
-		mixedResults["peak_fp16_gflops"] = o.calculateMax(fp16Values)
+		mixedResults.AvgFP16GFLOPS = o.calculateMean(fp16Values)
+		mixedResults.StdFP16GFLOPS = o.calculateStdDev(fp16Values)
+		mixedResults.PeakFP16GFLOPS = o.calculateMax(fp16Values)
 	}
 	
 	if len(fp32Values) > 0 {
-		mixedResults["avg_fp32_gflops"] = o.calculateMean(fp32Values)
-		mixedResults["std_fp32_gflops"] = o.calculateStdDev(fp32Values)
-		mixedResults["peak_fp32_gflops"] = o.calculateMax(fp32Values)
+		mixedResults.AvgFP32GFLOPS = o.calculateMean(fp32Values)
+		mixedResults.StdFP32GFLOPS = o.calculateStdDev(fp32Values)
+		mixedResults.PeakFP32GFLOPS = o.calculateMax(fp32Values)
 	}
 	
 	if len(fp64Values) > 0 {
-		mixedResults["avg_fp64_gflops"] = o.calculateMean(fp64Values)
-		mixedResults["std_fp64_gflops"] = o.calculateStdDev(fp64Values)
-		mixedResults["peak_fp64_gflops"] = o.calculateMax(fp64Values)
+		mixedResults.AvgFP64GFLOPS = o.calculateMean(fp64Values)
+		mixedResults.StdFP64GFLOPS = o.calculateStdDev(fp64Values)
+		mixedResults.PeakFP64GFLOPS = o.calculateMax(fp64Values)
 	}
 	
 	if len(overallValues) > 0 {
-		mixedResults["avg_overall_score"] = o.calculateMean(overallValues)
-		mixedResults["std_overall_score"] = o.calculateStdDev(overallValues)
+		mixedResults.AvgOverallScore = o.calculateMean(overallValues)
+		mixedResults.StdOverallScore = o.calculateStdDev(overallValues)
 	}
 	
 	// Efficiency ratios
 	if len(fp16fp32RatioValues) > 0 {
-		mixedResults["avg_fp16_fp32_efficiency"] = o.calculateMean(fp16fp32RatioValues)
+		mixedResults.AvgFP16FP32Efficiency = o.calculateMean(fp16fp32RatioValues)
 	}
 	
 	if len(fp32fp64RatioValues) > 0 {
-		mixedResults["avg_fp32_fp64_efficiency"] = o.calculateMean(fp32fp64RatioValues)
+		mixedResults.AvgFP32FP64Efficiency = o.calculateMean(fp32fp64RatioValues)
 	}
 	
 	// Precision performance ranking
@@ -1986,17 +2198,17 @@ func (o *Orchestrator) aggregateMixedPrecisionResults(allResults []map[string]in
 		avgFp32 := o.calculateMean(fp32Values)
 		avgFp64 := o.calculateMean(fp64Values)
 		
-		mixedResults["precision_performance_summary"] = map[string]interface{}{
-			"best_precision": o.getBestPrecision(avgFp16, avgFp32, avgFp64),
-			"fp16_vs_fp32_speedup": avgFp16 / avgFp32,
-			"fp32_vs_fp64_speedup": avgFp32 / avgFp64,
+		mixedResults.Summary = &PrecisionPerformanceSummary{
+			BestPrecision:     o.getBestPrecision(avgFp16, avgFp32, avgFp64),
+			FP16VsFP32Speedup: avgFp16 / avgFp32,
+			FP32VsFP64Speedup: avgFp32 / avgFp64,
 		}
 	}
 	
-	return aggregated, nil
+	return &BenchmarkResults{MixedPrecision: mixedResults}, nil
 }
 
-func (o *Orchestrator) aggregateCompilationResults(allResults []map[string]interface{}) (map[string]interface{}, error) {
+func (o *Orchestrator) aggregateCompilationResults(allResults []*BenchmarkResults) (*BenchmarkResults, error) {
 	var singleThreadedTimes, multiThreadedTimes, incrementalTimes []float64
 	var speedupValues, efficiencyValues, throughputValues []float64
 	var cpuUtilizationValues, memoryPressureValues []float64
@@ -2004,110 +2216,86 @@ func (o *Orchestrator) aggregateCompilationResults(allResults []map[string]inter
 	var overallScoreValues []float64
 	
 	for _, result := range allResults {
-		if compData, ok := result["compilation"].(map[string]interface{}); ok {
-			if singleTime, ok := compData["single_threaded_time_seconds"].(float64); ok {
-				singleThreadedTimes = append(singleThreadedTimes, singleTime)
-			}
-			if multiTime, ok := compData["multi_threaded_time_seconds"].(float64); ok {
-				multiThreadedTimes = append(multiThreadedTimes, multiTime)
-			}
-			if incrTime, ok := compData["incremental_build_time_seconds"].(float64); ok {
-				incrementalTimes = append(incrementalTimes, incrTime)
-			}
-			if speedup, ok := compData["parallel_speedup"].(float64); ok {
-				speedupValues = append(speedupValues, speedup)
-			}
-			if efficiency, ok := compData["parallel_efficiency_percent"].(float64); ok {
-				efficiencyValues = append(efficiencyValues, efficiency)
-			}
-			if throughput, ok := compData["compilation_throughput"].(float64); ok {
-				throughputValues = append(throughputValues, throughput)
-			}
-			if cpuUtil, ok := compData["avg_cpu_utilization_percent"].(float64); ok {
-				cpuUtilizationValues = append(cpuUtilizationValues, cpuUtil)
-			}
-			if memPressure, ok := compData["memory_pressure_percent"].(float64); ok {
-				memoryPressureValues = append(memoryPressureValues, memPressure)
-			}
-			if singleCore, ok := compData["single_core_performance_units"].(float64); ok {
-				singleCorePerformanceValues = append(singleCorePerformanceValues, singleCore)
-			}
-			if multiScale, ok := compData["multicore_scaling_efficiency"].(float64); ok {
-				multiCoreScalingValues = append(multiCoreScalingValues, multiScale)
-			}
-			if overall, ok := compData["overall_compilation_score"].(float64); ok {
-				overallScoreValues = append(overallScoreValues, overall)
-			}
+		if result == nil || result.Compilation == nil {
+			continue
 		}
+		compData := result.Compilation
+		singleThreadedTimes = appendMeasured(singleThreadedTimes, compData.SingleThreadedTimeSeconds)
+		multiThreadedTimes = appendMeasured(multiThreadedTimes, compData.MultiThreadedTimeSeconds)
+		incrementalTimes = appendMeasured(incrementalTimes, compData.IncrementalBuildTimeSeconds)
+		speedupValues = appendMeasured(speedupValues, compData.ParallelSpeedup)
+		efficiencyValues = appendMeasured(efficiencyValues, compData.ParallelEfficiencyPercent)
+		throughputValues = appendMeasured(throughputValues, compData.CompilationThroughput)
+		cpuUtilizationValues = appendMeasured(cpuUtilizationValues, compData.AvgCPUUtilizationPercent)
+		memoryPressureValues = appendMeasured(memoryPressureValues, compData.MemoryPressurePercent)
+		singleCorePerformanceValues = appendMeasured(singleCorePerformanceValues, compData.SingleCorePerformanceUnits)
+		multiCoreScalingValues = appendMeasured(multiCoreScalingValues, compData.MulticoreScalingEfficiency)
+		overallScoreValues = appendMeasured(overallScoreValues, compData.OverallCompilationScore)
 	}
 	
-	aggregated := map[string]interface{}{
-		"compilation": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-			"iterations": len(allResults),
-		},
+	compResults := &CompilationResult{
+		Timestamp:  time.Now().Format(time.RFC3339),
+		Iterations: len(allResults),
 	}
 	
-	compResults := aggregated["compilation"].(map[string]interface{})
-	
 	// Aggregate timing metrics
 	if len(singleThreadedTimes) > 0 {
-		compResults["avg_single_threaded_time_seconds"] = o.calculateMean(singleThreadedTimes)
-		compResults["std_single_threaded_time_seconds"] = o.calculateStdDev(singleThreadedTimes)
-		compResults["min_single_threaded_time_seconds"] = o.calculateMin(singleThreadedTimes)
+		compResults.AvgSingleThreadedTimeSeconds = o.calculateMean(singleThreadedTimes)
+		compResults.StdSingleThreadedTimeSeconds = o.calculateStdDev(singleThreadedTimes)
+		compResults.MinSingleThreadedTimeSeconds = o.calculateMin(singleThreadedTimes)
 	}
 	
 	if len(multiThreadedTimes) > 0 {
-		compResults["avg_multi_threaded_time_seconds"] = o.calculateMean(multiThreadedTimes)
-		compResults["std_multi_threaded_time_seconds"] = o.calculateStdDev(multiThreadedTimes)
-		compResults["min_multi_threaded_time_seconds"] = o.calculateMin(multiThreadedTimes)
+		compResults.AvgMultiThreadedTimeSeconds = o.calculateMean(multiThreadedTimes)
+		compResults.StdMultiThreadedTimeSeconds = o.calculateStdDev(multiThreadedTimes)
+		compResults.MinMultiThreadedTimeSeconds = o.calculateMin(multiThreadedTimes)
 	}
 	
 	if len(incrementalTimes) > 0 {
-		compResults["avg_incremental_build_time_seconds"] = o.calculateMean(incrementalTimes)
-		compResults["std_incremental_build_time_seconds"] = o.calculateStdDev(incrementalTimes)
-		compResults["min_incremental_build_time_seconds"] = o.calculateMin(incrementalTimes)
+		compResults.AvgIncrementalBuildTimeSeconds = o.calculateMean(incrementalTimes)
+		compResults.StdIncrementalBuildTimeSeconds = o.calculateStdDev(incrementalTimes)
+		compResults.MinIncrementalBuildTimeSeconds = o.calculateMin(incrementalTimes)
 	}
 	
 	// Aggregate performance metrics
 	if len(speedupValues) > 0 {
-		compResults["avg_parallel_speedup"] = o.calculateMean(speedupValues)
-		compResults["std_parallel_speedup"] = o.calculateStdDev(speedupValues)
-		compResults["max_parallel_speedup"] = o.calculateMax(speedupValues)
+		compResults.AvgParallelSpeedup = o.calculateMean(speedupValues)
+		compResults.StdParallelSpeedup = o.calculateStdDev(speedupValues)
+		compResults.MaxParallelSpeedup = o.calculateMax(speedupValues)
 	}
 	
 	if len(efficiencyValues) > 0 {
-		compResults["avg_parallel_efficiency_percent"] = o.calculateMean(efficiencyValues)
-		compResults["std_parallel_efficiency_percent"] = o.calculateStdDev(efficiencyValues)
+		compResults.AvgParallelEfficiencyPercent = o.calculateMean(efficiencyValues)
+		compResults.StdParallelEfficiencyPercent = o.calculateStdDev(efficiencyValues)
 	}
 	
 	if len(throughputValues) > 0 {
-		compResults["avg_compilation_throughput"] = o.calculateMean(throughputValues)
-		compResults["max_compilation_throughput"] = o.calculateMax(throughputValues)
+		compResults.AvgCompilationThroughput = o.calculateMean(throughputValues)
+		compResults.MaxCompilationThroughput = o.calculateMax(throughputValues)
 	}
 	
 	// System utilization metrics
 	if len(cpuUtilizationValues) > 0 {
-		compResults["avg_cpu_utilization_percent"] = o.calculateMean(cpuUtilizationValues)
+		compResults.AvgCPUUtilizationPercent = o.calculateMean(cpuUtilizationValues)
 	}
 	
 	if len(memoryPressureValues) > 0 {
-		compResults["avg_memory_pressure_percent"] = o.calculateMean(memoryPressureValues)
+		compResults.AvgMemoryPressurePercent = o.calculateMean(memoryPressureValues)
 	}
 	
 	// Development workload metrics
 	if len(singleCorePerformanceValues) > 0 {
-		compResults["avg_single_core_performance_units"] = o.calculateMean(singleCorePerformanceValues)
+		compResults.AvgSingleCorePerformanceUnits = o.calculateMean(singleCorePerformanceValues)
 	}
 	
 	if len(multiCoreScalingValues) > 0 {
-		compResults["avg_multicore_scaling_efficiency"] = o.calculateMean(multiCoreScalingValues)
+		compResults.AvgMulticoreScalingEfficiency = o.calculateMean(multiCoreScalingValues)
 	}
 	
 	if len(overallScoreValues) > 0 {
-		compResults["avg_overall_compilation_score"] = o.calculateMean(overallScoreValues)
-		compResults["std_overall_compilation_score"] = o.calculateStdDev(overallScoreValues)
-		compResults["max_overall_compilation_score"] = o.calculateMax(overallScoreValues)
+		compResults.AvgOverallCompilationScore = o.calculateMean(overallScoreValues)
+		compResults.StdOverallCompilationScore = o.calculateStdDev(overallScoreValues)
+		compResults.MaxOverallCompilationScore = o.calculateMax(overallScoreValues)
 	}
 	
 	// Compilation performance summary
@@ -2116,14 +2304,14 @@ func (o *Orchestrator) aggregateCompilationResults(allResults []map[string]inter
 		avgMultiTime := o.calculateMean(multiThreadedTimes)
 		avgSpeedup := o.calculateMean(speedupValues)
 		
-		compResults["compilation_performance_summary"] = map[string]interface{}{
-			"single_core_build_rate": 1.0 / avgSingleTime,
-			"multi_core_build_rate": 1.0 / avgMultiTime,
-			"parallel_efficiency_rating": o.getEfficiencyRating(avgSpeedup),
+		compResults.Summary = &CompilationPerformanceSummary{
+			SingleCoreBuildRate:      1.0 / avgSingleTime,
+			MultiCoreBuildRate:       1.0 / avgMultiTime,
+			ParallelEfficiencyRating: o.getEfficiencyRating(avgSpeedup),
 		}
 	}
 	
-	return aggregated, nil
+	return &BenchmarkResults{Compilation: compResults}, nil
 }
 
 func (o *Orchestrator) generate7ZipCommand() string {
@@ -2352,6 +2540,18 @@ func (o *Orchestrator) executeSSHCommand(ctx context.Context, instanceID, comman
 }
 
 func (o *Orchestrator) executeSSMCommand(ctx context.Context, instanceID, command string) (string, error) {
+	commandID, err := o.sendSSMCommand(ctx, instanceID, command)
+	if err != nil {
+		return "", err
+	}
+	
+	// Wait for command completion and get output
+	return o.waitForSSMCommandCompletion(ctx, instanceID, commandID)
+}
+
+// sendSSMCommand starts a shell command on the instance and returns its SSM
+// command ID without waiting for it to finish.
+func (o *Orchestrator) sendSSMCommand(ctx context.Context, instanceID, command string) (string, error) {
 	fmt.Printf("   🔧 Executing benchmark command on instance %s...\n", instanceID)
 	
 	// Send command via SSM
@@ -2369,15 +2569,25 @@ func (o *Orchestrator) executeSSMCommand(ctx context.Context, instanceID, comman
 		return "", fmt.Errorf("failed to send SSM command: %w", err)
 	}
 	
-	commandID := *result.Command.CommandId
-	
-	// Wait for command completion and get output
-	return o.waitForSSMCommandCompletion(ctx, instanceID, commandID)
+	return *result.Command.CommandId, nil
 }
 
+// waitForSSMCommandCompletion waits for an SSM command to finish and returns
+// its stdout.
 func (o *Orchestrator) waitForSSMCommandCompletion(ctx context.Context, instanceID, commandID string) (string, error) {
-	maxAttempts := 120 // 2 hours max wait time (120 * 60 seconds)
-	waitTime := 60 * time.Second
+	stdout, _, err := o.waitForSSMCommandOutput(ctx, instanceID, commandID)
+	if err != nil {
+		return "", err
+	}
+	return stdout, nil
+}
+
+// waitForSSMCommandOutput waits for an SSM command to finish and returns its
+// stdout and stderr. Output is also returned alongside the error of a
+// command that failed.
+func (o *Orchestrator) waitForSSMCommandOutput(ctx context.Context, instanceID, commandID string) (string, string, error) {
+	maxAttempts := 120 // 2 hours max wait time with the default 60 second interval
+	waitTime := o.polling.CommandPollInterval
 	
 	for attempt := 0; attempt < maxAttempts; attempt++ {
 		// Get command invocation status
@@ -2389,125 +2599,100 @@ func (o *Orchestrator) waitForSSMCommandCompletion(ctx context.Context, instance
 		result, err := o.ssmClient.GetCommandInvocation(ctx, getCommandInput)
 		if err != nil {
 			// Command may not be ready yet, continue waiting
-			time.Sleep(waitTime)
+			if err := sleep(ctx, waitTime); err != nil {
+				return "", "", err
+			}
 			continue
 		}
+		stdout := aws.ToString(result.StandardOutputContent)
+		stderr := aws.ToString(result.StandardErrorContent)
 		
 		switch result.Status {
 		case "Success":
 			fmt.Printf("   ✅ Benchmark command completed successfully\n")
-			// Return the command output
-			output := ""
-			if result.StandardOutputContent != nil {
-				output = *result.StandardOutputContent
-			}
-			if output == "" && result.StandardErrorContent != nil {
-				return "", fmt.Errorf("command failed with error: %s", *result.StandardErrorContent)
+			if stdout == "" && result.StandardErrorContent != nil {
+				return stdout, stderr, fmt.Errorf("command failed with error: %s", stderr)
 			}
-			return output, nil
+			return stdout, stderr, nil
 			
 		case "Failed", "Cancelled", "TimedOut":
 			errorMsg := "Command failed"
 			if result.StandardErrorContent != nil {
-				errorMsg = *result.StandardErrorContent
+				errorMsg = stderr
 			}
-			return "", fmt.Errorf("SSM command failed with status %s: %s", result.Status, errorMsg)
+			return stdout, stderr, fmt.Errorf("SSM command failed with status %s: %s", result.Status, errorMsg)
 			
 		case "InProgress", "Pending", "Cancelling":
 			fmt.Printf("   ⏳ Command status: %s, waiting...\n", result.Status)
-			time.Sleep(waitTime)
+			if err := sleep(ctx, waitTime); err != nil {
+				return "", "", err
+			}
 			continue
 			
 		default:
 			fmt.Printf("   ⚠️  Unknown command status: %s, continuing to wait...\n", result.Status)
-			time.Sleep(waitTime)
+			if err := sleep(ctx, waitTime); err != nil {
+				return "", "", err
+			}
 		}
 	}
 	
-	return "", fmt.Errorf("command execution timed out after %d attempts", maxAttempts)
+	return "", "", fmt.Errorf("command execution timed out after %d attempts", maxAttempts)
 }
 
-func (o *Orchestrator) parseBenchmarkOutput(benchmarkSuite, output string) (map[string]interface{}, error) {
-	switch benchmarkSuite {
-	case "stream":
-		return o.parseSTREAMOutput(output)
-	case "hpl":
-		return o.parseHPLOutput(output)
-	case "dgemm":
-		return o.parseDGEMMOutput(output)
-	case "fftw":
-		return o.parseFFTWOutput(output)
-	case "vector_ops":
-		return o.parseVectorOpsOutput(output)
-	case "mixed_precision":
-		return o.parseMixedPrecisionOutput(output)
-	case "compilation":
-		return o.parseCompilationOutput(output)
-	case "coremark":
-		return o.parseCoreMarkOutput(output)
-	case "7zip":
-		return o.parse7ZipOutput(output)
-	case "sysbench":
-		return o.parseSysbenchOutput(output)
-	case "cache":
-		return o.parseCacheOutput(output)
-	default:
-		return nil, fmt.Errorf("unsupported benchmark suite: %s", benchmarkSuite)
+func (o *Orchestrator) parseBenchmarkOutput(benchmarkSuite, output string) (*BenchmarkResults, error) {
+	suite, err := LookupSuite(benchmarkSuite)
+	if err != nil {
+		return nil, err
 	}
+	return suite.ParseOutput(output)
 }
 
-func (o *Orchestrator) parseSTREAMOutput(output string) (map[string]interface{}, error) {
+func (o *Orchestrator) parseSTREAMOutput(output string) (*BenchmarkResults, error) {
 	lines := strings.Split(output, "\n")
 	
-	results := map[string]interface{}{
-		"stream": map[string]interface{}{},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-		},
-	}
-	
-	streamResults := make(map[string]interface{})
+	streamResults := &STREAMResult{}
+	found := false
 	
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
 		
-		if strings.HasPrefix(line, "Copy:") {
-			if rate := o.extractRateFromLine(line); rate > 0 {
-				streamResults["copy"] = map[string]interface{}{
-					"bandwidth": rate / 1000.0, // Convert MB/s to GB/s
-					"unit":      "GB/s",
-				}
-			}
-		} else if strings.HasPrefix(line, "Scale:") {
-			if rate := o.extractRateFromLine(line); rate > 0 {
-				streamResults["scale"] = map[string]interface{}{
-					"bandwidth": rate / 1000.0,
-					"unit":      "GB/s",
-				}
-			}
-		} else if strings.HasPrefix(line, "Add:") {
-			if rate := o.extractRateFromLine(line); rate > 0 {
-				streamResults["add"] = map[string]interface{}{
-					"bandwidth": rate / 1000.0,
-					"unit":      "GB/s",
-				}
-			}
-		} else if strings.HasPrefix(line, "Triad:") {
-			if rate := o.extractRateFromLine(line); rate > 0 {
-				streamResults["triad"] = map[string]interface{}{
-					"bandwidth": rate / 1000.0,
-					"unit":      "GB/s",
-				}
+		// STREAM reports rates even when its result check fails
+		if strings.HasPrefix(line, "Failed Validation") {
+			return nil, fmt.Errorf("STREAM run failed validation: %s", line)
+		}
+		
+		var target **StreamOperation
+		switch {
+		case strings.HasPrefix(line, "Copy:"):
+			target = &streamResults.Copy
+		case strings.HasPrefix(line, "Scale:"):
+			target = &streamResults.Scale
+		case strings.HasPrefix(line, "Add:"):
+			target = &streamResults.Add
+		case strings.HasPrefix(line, "Triad:"):
+			target = &streamResults.Triad
+		default:
+			continue
+		}
+		
+		if rate := o.extractRateFromLine(line); rate > 0 {
+			*target = &StreamOperation{
+				Bandwidth: rate / 1000.0, // Convert MB/s to GB/s
+				Unit:      "GB/s",
 			}
+			found = true
 		}
 	}
 	
-	if len(streamResults) == 0 {
+	if !found {
 		return nil, fmt.Errorf("no STREAM results found in output")
 	}
 	
-	results["stream"] = streamResults
-	return results, nil
+	return &BenchmarkResults{
+		STREAM:   streamResults,
+		Metadata: newIterationMetadata(),
+	}, nil
 }
 
 func (o *Orchestrator) extractRateFromLine(line string) float64 {
@@ -2521,17 +2706,11 @@ func (o *Orchestrator) extractRateFromLine(line string) float64 {
 	return 0
 }
 
-func (o *Orchestrator) parseHPLOutput(output string) (map[string]interface{}, error) {
+func (o *Orchestrator) parseHPLOutput(output string) (*BenchmarkResults, error) {
 	lines := strings.Split(output, "\n")
 	
-	results := map[string]interface{}{
-		"hpl": map[string]interface{}{},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-		},
-	}
-	
-	hplResults := make(map[string]interface{})
+	hplResults := &HPLResult{}
+	found := false
 	
 	// Parse the simplified HPL output format
 	// Expected format: "N=1000  Time=1.234  GFLOPS=5.678"
@@ -2542,10 +2721,12 @@ func (o *Orchestrator) parseHPLOutput(output string) (map[string]interface{}, er
 			// Extract GFLOPS value
 			parts := strings.Split(line, "GFLOPS=")
 			if len(parts) == 2 {
-				gflopsStr := strings.Fields(parts[1])[0]
-				if gflops, err := strconv.ParseFloat(gflopsStr, 64); err == nil {
-					hplResults["gflops"] = gflops
-					hplResults["unit"] = "GFLOPS"
+				if fields := strings.Fields(parts[1]); len(fields) > 0 {
+					if gflops, err := strconv.ParseFloat(fields[0], 64); err == nil {
+						hplResults.GFLOPS = gflops
+						hplResults.Unit = "GFLOPS"
+						found = true
+					}
 				}
 			}
 		}
@@ -2554,10 +2735,12 @@ func (o *Orchestrator) parseHPLOutput(output string) (map[string]interface{}, er
 			// Extract execution time
 			parts := strings.Split(line, "Time=")
 			if len(parts) == 2 {
-				timeStr := strings.Fields(parts[1])[0]
-				if execTime, err := strconv.ParseFloat(timeStr, 64); err == nil {
-					hplResults["execution_time"] = execTime
-					hplResults["time_unit"] = "seconds"
+				if fields := strings.Fields(parts[1]); len(fields) > 0 {
+					if execTime, err := strconv.ParseFloat(fields[0], 64); err == nil {
+						hplResults.ExecutionTime = execTime
+						hplResults.TimeUnit = "seconds"
+						found = true
+					}
 				}
 			}
 		}
@@ -2566,36 +2749,32 @@ func (o *Orchestrator) parseHPLOutput(output string) (map[string]interface{}, er
 			// Extract matrix size
 			parts := strings.Split(line, "N=")
 			if len(parts) == 2 {
-				nStr := strings.Fields(parts[1])[0]
-				if n, err := strconv.Atoi(nStr); err == nil {
-					hplResults["matrix_size"] = n
+				if fields := strings.Fields(parts[1]); len(fields) > 0 {
+					if n, err := strconv.Atoi(fields[0]); err == nil {
+						hplResults.MatrixSize = n
+						found = true
+					}
 				}
 			}
 		}
 	}
 	
 	// If no results found, return error
-	if len(hplResults) == 0 {
+	if !found {
 		return nil, fmt.Errorf("no HPL results found in output")
 	}
 	
-	results["hpl"] = hplResults
-	return results, nil
+	return &BenchmarkResults{
+		HPL:      hplResults,
+		Metadata: newIterationMetadata(),
+	}, nil
 }
 
-func (o *Orchestrator) parseDGEMMOutput(output string) (map[string]interface{}, error) {
+func (o *Orchestrator) parseDGEMMOutput(output string) (*BenchmarkResults, error) {
 	lines := strings.Split(output, "\n")
 	
-	results := map[string]interface{}{
-		"dgemm": map[string]interface{}{},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-		},
-	}
-	
-	dgemmResults := make(map[string]interface{})
-	matrixSizes := []string{}
-	gflopsValues := make(map[string]float64)
+	dgemmResults := &DGEMMResult{}
+	found := false
 	
 	// Parse enhanced DGEMM output
 	for _, line := range lines {
@@ -2611,17 +2790,23 @@ func (o *Orchestrator) parseDGEMMOutput(output string) (map[string]interface{},
 				rightPart := strings.TrimSpace(parts[1])
 				
 				// Get GFLOPS value
-				gflopsStr := strings.Fields(rightPart)[0]
-				if gflops, err := strconv.ParseFloat(gflopsStr, 64); err == nil {
+				fields := strings.Fields(rightPart)
+				if len(fields) == 0 {
+					continue
+				}
+				if gflops, err := strconv.ParseFloat(fields[0], 64); err == nil {
 					if strings.Contains(leftPart, "Small") {
-						gflopsValues["small_matrix_gflops"] = gflops
-						matrixSizes = append(matrixSizes, "small")
+						dgemmResults.SmallMatrixGFLOPS = gflops
+						dgemmResults.MatrixSizesTested = append(dgemmResults.MatrixSizesTested, "small")
+						found = true
 					} else if strings.Contains(leftPart, "Medium") {
-						gflopsValues["medium_matrix_gflops"] = gflops
-						matrixSizes = append(matrixSizes, "medium")
+						dgemmResults.MediumMatrixGFLOPS = gflops
+						dgemmResults.MatrixSizesTested = append(dgemmResults.MatrixSizesTested, "medium")
+						found = true
 					} else if strings.Contains(leftPart, "Large") {
-						gflopsValues["large_matrix_gflops"] = gflops
-						matrixSizes = append(matrixSizes, "large")
+						dgemmResults.LargeMatrixGFLOPS = gflops
+						dgemmResults.MatrixSizesTested = append(dgemmResults.MatrixSizesTested, "large")
+						found = true
 					}
 				}
 			}
@@ -2632,132 +2817,130 @@ func (o *Orchestrator) parseDGEMMOutput(output string) (map[string]interface{},
 			parts := strings.Fields(line)
 			if len(parts) >= 3 {
 				if peakGflops, err := strconv.ParseFloat(parts[2], 64); err == nil {
-					dgemmResults["peak_gflops"] = peakGflops
+					dgemmResults.PeakGFLOPS = peakGflops
+					found = true
 				}
 			}
 		}
 		
 		// Parse efficiency metrics
 		if strings.Contains(line, "Memory-bound efficiency:") {
-			parts := strings.Fields(line)
-			for _, part := range parts {
-				if strings.HasSuffix(part, "%") {
-					effStr := strings.TrimSuffix(part, "%")
-					if eff, err := strconv.ParseFloat(effStr, 64); err == nil {
-						dgemmResults["memory_bound_efficiency"] = eff / 100.0
-					}
-					break
-				}
+			if eff, ok := extractPercentFromLine(line); ok {
+				dgemmResults.MemoryBoundEfficiency = eff / 100.0
 			}
 		}
 		
 		if strings.Contains(line, "Cache efficiency:") {
-			parts := strings.Fields(line)
-			for _, part := range parts {
-				if strings.HasSuffix(part, "%") {
-					effStr := strings.TrimSuffix(part, "%")
-					if eff, err := strconv.ParseFloat(effStr, 64); err == nil {
-						dgemmResults["cache_efficiency"] = eff / 100.0
-					}
-					break
-				}
+			if eff, ok := extractPercentFromLine(line); ok {
+				dgemmResults.CacheEfficiency = eff / 100.0
 			}
 		}
 	}
 	
-	// Add all parsed GFLOPS values
-	for key, value := range gflopsValues {
-		dgemmResults[key] = value
-	}
-	
-	// Add metadata
-	dgemmResults["matrix_sizes_tested"] = matrixSizes
-	dgemmResults["unit"] = "GFLOPS"
-	dgemmResults["benchmark_type"] = "enhanced_dgemm"
-	
-	if len(dgemmResults) == 0 {
+	if !found {
 		return nil, fmt.Errorf("no DGEMM results found in output")
 	}
 	
-	results["dgemm"] = dgemmResults
-	return results, nil
+	dgemmResults.Unit = "GFLOPS"
+	dgemmResults.BenchmarkType = "enhanced_dgemm"
+	
+	return &BenchmarkResults{
+		DGEMM:    dgemmResults,
+		Metadata: newIterationMetadata(),
+	}, nil
 }
 
-func (o *Orchestrator) parseCoreMarkOutput(output string) (map[string]interface{}, error) {
+func (o *Orchestrator) parseCoreMarkOutput(output string) (*BenchmarkResults, error) {
 	lines := strings.Split(output, "\n")
 	
-	results := map[string]interface{}{
-		"coremark": map[string]interface{}{},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-		},
-	}
+	coremarkResults := &CoreMarkResult{}
+	found := false
 	
-	coremarkResults := make(map[string]interface{})
-	
-	// Parse CoreMark output
-	// Expected format: "CoreMark Score: 12345.67 operations/sec"
+	// Parse CoreMark output. Both the official EEMBC report and the format of
+	// the earlier custom benchmark are understood:
+	//   "CoreMark 1.0 : 26780.936000 / GCC11.4.0 -O2 -DPERFORMANCE_RUN=1 -lrt / Heap"
+	//   "Total time (secs): 14.936000"
+	//   "Iterations       : 400000"
+	//   "CoreMark Score: 12345.67 operations/sec"
+	//   "Time: 10.5 seconds"
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
 		
-		if strings.Contains(line, "CoreMark Score:") {
-			parts := strings.Split(line, "CoreMark Score:")
-			if len(parts) == 2 {
-				scoreStr := strings.Fields(parts[1])[0]
-				if score, err := strconv.ParseFloat(scoreStr, 64); err == nil {
-					coremarkResults["score"] = score
-					coremarkResults["unit"] = "operations/sec"
-				}
-			}
+		// CoreMark still prints partial statistics for invalid runs, so
+		// they must be rejected explicitly
+		if strings.HasPrefix(line, "ERROR!") || strings.Contains(line, "Errors detected") {
+			return nil, fmt.Errorf("CoreMark run failed validation: %s", line)
 		}
 		
-		if strings.Contains(line, "Time:") && strings.Contains(line, "seconds") {
-			parts := strings.Split(line, "Time:")
-			if len(parts) == 2 {
-				timeStr := strings.Fields(parts[1])[0]
-				if execTime, err := strconv.ParseFloat(timeStr, 64); err == nil {
-					coremarkResults["execution_time"] = execTime
-					coremarkResults["time_unit"] = "seconds"
-				}
-			}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
 		}
 		
-		if strings.Contains(line, "Iterations:") {
-			parts := strings.Split(line, "Iterations:")
-			if len(parts) == 2 {
-				iterStr := strings.TrimSpace(parts[1])
-				if iterations, err := strconv.Atoi(iterStr); err == nil {
-					coremarkResults["iterations"] = iterations
-				}
+		switch {
+		case key == "CoreMark Score" || strings.HasPrefix(key, "CoreMark 1."):
+			if score, err := strconv.ParseFloat(fields[0], 64); err == nil {
+				coremarkResults.Score = score
+				coremarkResults.Unit = "operations/sec"
+				found = true
+			}
+		case key == "Total time (secs)" || (key == "Time" && strings.Contains(value, "seconds")):
+			if execTime, err := strconv.ParseFloat(fields[0], 64); err == nil {
+				coremarkResults.ExecutionTime = execTime
+				coremarkResults.TimeUnit = "seconds"
+			}
+		case key == "Iterations":
+			if iterations, err := strconv.Atoi(fields[0]); err == nil {
+				coremarkResults.Iterations = iterations
 			}
 		}
 	}
 	
-	if len(coremarkResults) == 0 {
-		return nil, fmt.Errorf("no CoreMark results found in output")
+	// Execution time and iteration count alone do not make a usable result
+	if !found {
+		return nil, fmt.Errorf("no CoreMark score found in output")
 	}
 	
-	results["coremark"] = coremarkResults
-	return results, nil
+	return &BenchmarkResults{
+		CoreMark: coremarkResults,
+		Metadata: newIterationMetadata(),
+	}, nil
 }
 
-func (o *Orchestrator) parseCacheOutput(output string) (map[string]interface{}, error) {
+func (o *Orchestrator) parseCacheOutput(output string) (*BenchmarkResults, error) {
 	lines := strings.Split(output, "\n")
 	
-	results := map[string]interface{}{
-		"cache": map[string]interface{}{},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-		},
-	}
-	
-	cacheResults := make(map[string]interface{})
+	cacheResults := &CacheResult{}
+	found := false
 	
 	// Parse cache benchmark output
-	// Expected format: "L1,16,1.23" (CSV format)
+	// Expected format: "L1 Cache Access Time: 1.23 ns (size: 24 KB)" as
+	// printed by the cache benchmark program, or "L1,16,1.23" (CSV format)
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
 		
+		if idx := strings.Index(line, "Access Time:"); idx > 0 {
+			level := strings.ToLower(strings.Fields(line[:idx])[0])
+			var accessTime float64
+			var size int
+			// The benchmark reports 0.00 ns when a test buffer could not be allocated
+			if _, err := fmt.Sscanf(line[idx:], "Access Time: %f ns (size: %d KB)", &accessTime, &size); err == nil && accessTime > 0 {
+				if cacheResults.setLevel(level, &CacheLevelResult{
+					SizeKB:     size,
+					AccessTime: accessTime,
+					Unit:       "ns",
+				}) {
+					found = true
+				}
+			}
+			continue
+		}
+		
 		if strings.Contains(line, ",") && !strings.Contains(line, "Cache Level") {
 			parts := strings.Split(line, ",")
 			if len(parts) == 3 {
@@ -2767,10 +2950,12 @@ func (o *Orchestrator) parseCacheOutput(output string) (map[string]interface{},
 				
 				if size, err := strconv.Atoi(sizeStr); err == nil {
 					if accessTime, err := strconv.ParseFloat(timeStr, 64); err == nil {
-						cacheResults[level] = map[string]interface{}{
-							"size_kb":     size,
-							"access_time": accessTime,
-							"unit":        "ns",
+						if cacheResults.setLevel(level, &CacheLevelResult{
+							SizeKB:     size,
+							AccessTime: accessTime,
+							Unit:       "ns",
+						}) {
+							found = true
 						}
 					}
 				}
@@ -2778,78 +2963,74 @@ func (o *Orchestrator) parseCacheOutput(output string) (map[string]interface{},
 		}
 	}
 	
-	if len(cacheResults) == 0 {
+	if !found {
 		return nil, fmt.Errorf("no cache benchmark results found in output")
 	}
 	
-	results["cache"] = cacheResults
-	return results, nil
+	return &BenchmarkResults{
+		Cache:    cacheResults,
+		Metadata: newIterationMetadata(),
+	}, nil
 }
 
-func (o *Orchestrator) parse7ZipOutput(output string) (map[string]interface{}, error) {
+func (o *Orchestrator) parse7ZipOutput(output string) (*BenchmarkResults, error) {
 	lines := strings.Split(output, "\n")
 	
-	results := map[string]interface{}{
-		"7zip": map[string]interface{}{},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-		},
-	}
-	
-	sevenZipResults := make(map[string]interface{})
+	sevenZipResults := &SevenZipResult{}
+	found := false
 	
 	// Parse 7-zip benchmark output
-	// Expected format lines: "Tot:     45234 12345     45000 12300"
-	// Format: Tot: [compress MIPS] [decompress MIPS] [compress rating] [decompress rating]
+	// Expected summary lines of "7zz b":
+	//   "Avr:      8216   193   4467   8620  |      91287   200   3981   7954"
+	//   "Tot:             196   4224   8287"
+	// The last column of each half of "Avr:" is the compression and
+	// decompression rating in MIPS, the last column of "Tot:" the overall rating
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
 		
-		if strings.HasPrefix(line, "Tot:") {
-			fields := strings.Fields(line)
-			if len(fields) >= 5 {
-				// Extract MIPS values
-				if compressMIPS, err := strconv.ParseFloat(fields[1], 64); err == nil {
-					sevenZipResults["compression_mips"] = compressMIPS
-				}
-				if decompressMIPS, err := strconv.ParseFloat(fields[2], 64); err == nil {
-					sevenZipResults["decompression_mips"] = decompressMIPS
+		// Only the multi-threaded run, which is executed first, is recorded
+		if strings.Contains(line, "Single-threaded") {
+			sevenZipResults.ThreadingMode = "both"
+			break
+		}
+		
+		if strings.HasPrefix(line, "Avr:") {
+			if compress, decompress, ok := strings.Cut(line, "|"); ok {
+				if compressMIPS, ok := lastFloatField(compress); ok {
+					sevenZipResults.CompressionMIPS = compressMIPS
+					found = true
 				}
-				
-				// Calculate total MIPS
-				if comp, ok := sevenZipResults["compression_mips"].(float64); ok {
-					if decomp, ok := sevenZipResults["decompression_mips"].(float64); ok {
-						sevenZipResults["total_mips"] = (comp + decomp) / 2.0
-						sevenZipResults["unit"] = "MIPS"
-					}
+				if decompressMIPS, ok := lastFloatField(decompress); ok {
+					sevenZipResults.DecompressionMIPS = decompressMIPS
+					found = true
 				}
 			}
 		}
 		
-		// Also look for single-threaded results
-		if strings.Contains(line, "Single-threaded") {
-			sevenZipResults["threading_mode"] = "both"
+		if strings.HasPrefix(line, "Tot:") {
+			if totalMIPS, ok := lastFloatField(line); ok {
+				sevenZipResults.TotalMIPS = totalMIPS
+				sevenZipResults.Unit = "MIPS"
+				found = true
+			}
 		}
 	}
 	
-	if len(sevenZipResults) == 0 {
+	if !found {
 		return nil, fmt.Errorf("no 7-zip results found in output")
 	}
 	
-	results["7zip"] = sevenZipResults
-	return results, nil
+	return &BenchmarkResults{
+		SevenZip: sevenZipResults,
+		Metadata: newIterationMetadata(),
+	}, nil
 }
 
-func (o *Orchestrator) parseSysbenchOutput(output string) (map[string]interface{}, error) {
+func (o *Orchestrator) parseSysbenchOutput(output string) (*BenchmarkResults, error) {
 	lines := strings.Split(output, "\n")
 	
-	results := map[string]interface{}{
-		"sysbench": map[string]interface{}{},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-		},
-	}
-	
-	sysbenchResults := make(map[string]interface{})
+	sysbenchResults := &SysbenchResult{}
+	found := false
 	
 	// Parse sysbench CPU output
 	// Expected format: "events per second: 1234.56"
@@ -2857,13 +3038,19 @@ func (o *Orchestrator) parseSysbenchOutput(output string) (map[string]interface{
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
 		
+		// Only the multi-threaded run, which is executed first, is recorded
+		if strings.Contains(line, "Single-threaded") {
+			break
+		}
+		
 		if strings.Contains(line, "events per second:") {
 			parts := strings.Split(line, ":")
 			if len(parts) == 2 {
 				epsStr := strings.TrimSpace(parts[1])
 				if eps, err := strconv.ParseFloat(epsStr, 64); err == nil {
-					sysbenchResults["events_per_second"] = eps
-					sysbenchResults["unit"] = "events/sec"
+					sysbenchResults.EventsPerSecond = eps
+					sysbenchResults.Unit = "events/sec"
+					found = true
 				}
 			}
 		}
@@ -2875,8 +3062,8 @@ func (o *Orchestrator) parseSysbenchOutput(output string) (map[string]interface{
 				// Remove 's' suffix
 				timeStr = strings.TrimSuffix(timeStr, "s")
 				if totalTime, err := strconv.ParseFloat(timeStr, 64); err == nil {
-					sysbenchResults["total_time"] = totalTime
-					sysbenchResults["time_unit"] = "seconds"
+					sysbenchResults.TotalTime = totalTime
+					sysbenchResults.TimeUnit = "seconds"
 				}
 			}
 		}
@@ -2886,76 +3073,43 @@ func (o *Orchestrator) parseSysbenchOutput(output string) (map[string]interface{
 			if len(parts) == 2 {
 				eventsStr := strings.TrimSpace(parts[1])
 				if events, err := strconv.Atoi(eventsStr); err == nil {
-					sysbenchResults["total_events"] = events
+					sysbenchResults.TotalEvents = events
 				}
 			}
 		}
 	}
 	
-	if len(sysbenchResults) == 0 {
-		return nil, fmt.Errorf("no sysbench results found in output")
+	// Timing statistics alone do not make a usable result
+	if !found {
+		return nil, fmt.Errorf("no sysbench events per second found in output")
 	}
 	
-	results["sysbench"] = sysbenchResults
-	return results, nil
+	return &BenchmarkResults{
+		Sysbench: sysbenchResults,
+		Metadata: newIterationMetadata(),
+	}, nil
 }
 
-func (o *Orchestrator) aggregateBenchmarkResults(benchmarkSuite string, allResults []map[string]interface{}) (map[string]interface{}, error) {
-	switch benchmarkSuite {
-	case "stream":
-		return o.aggregateSTREAMResults(allResults)
-	case "hpl":
-		return o.aggregateHPLResults(allResults)
-	case "dgemm":
-		return o.aggregateDGEMMResults(allResults)
-	case "fftw":
-		return o.aggregateFFTWResults(allResults)
-	case "vector_ops":
-		return o.aggregateVectorOpsResults(allResults)
-	case "mixed_precision":
-		return o.aggregateMixedPrecisionResults(allResults)
-	case "compilation":
-		return o.aggregateCompilationResults(allResults)
-	case "coremark":
-		return o.aggregateCoreMarkResults(allResults)
-	case "7zip":
-		return o.aggregate7ZipResults(allResults)
-	case "sysbench":
-		return o.aggregateSysbenchResults(allResults)
-	case "cache":
-		return o.aggregateCacheResults(allResults)
-	default:
-		return nil, fmt.Errorf("unsupported benchmark suite for aggregation: %s", benchmarkSuite)
+func (o *Orchestrator) aggregateBenchmarkResults(benchmarkSuite string, allResults []*BenchmarkResults) (*BenchmarkResults, error) {
+	suite, err := LookupSuite(benchmarkSuite)
+	if err != nil {
+		return nil, fmt.Errorf("aggregation failed: %w", err)
 	}
+	return suite.AggregateResults(allResults)
 }
 
-func (o *Orchestrator) aggregateSTREAMResults(allResults []map[string]interface{}) (map[string]interface{}, error) {
+func (o *Orchestrator) aggregateSTREAMResults(allResults []*BenchmarkResults) (*BenchmarkResults, error) {
 	var copyValues, scaleValues, addValues, triadValues []float64
 	
 	// Extract values from all iterations
 	for _, result := range allResults {
-		if streamData, ok := result["stream"].(map[string]interface{}); ok {
-			if copy, ok := streamData["copy"].(map[string]interface{}); ok {
-				if bw, ok := copy["bandwidth"].(float64); ok {
-					copyValues = append(copyValues, bw)
-				}
-			}
-			if scale, ok := streamData["scale"].(map[string]interface{}); ok {
-				if bw, ok := scale["bandwidth"].(float64); ok {
-					scaleValues = append(scaleValues, bw)
-				}
-			}
-			if add, ok := streamData["add"].(map[string]interface{}); ok {
-				if bw, ok := add["bandwidth"].(float64); ok {
-					addValues = append(addValues, bw)
-				}
-			}
-			if triad, ok := streamData["triad"].(map[string]interface{}); ok {
-				if bw, ok := triad["bandwidth"].(float64); ok {
-					triadValues = append(triadValues, bw)
-				}
-			}
+		if result == nil || result.STREAM == nil {
+			continue
 		}
+		copyValues = appendMeasured(copyValues, result.STREAM.Bandwidth("copy"))
+		scaleValues = appendMeasured(scaleValues, result.STREAM.Bandwidth("scale"))
+		addValues = appendMeasured(addValues, result.STREAM.Bandwidth("add"))
+		triadValues = appendMeasured(triadValues, result.STREAM.Bandwidth("triad"))
 	}
 	
 	// Calculate statistics for each operation
@@ -2964,82 +3118,61 @@ func (o *Orchestrator) aggregateSTREAMResults(allResults []map[string]interface{
 	addStats := o.calculateStatistics(addValues)
 	triadStats := o.calculateStatistics(triadValues)
 	
-	return map[string]interface{}{
-		"stream": map[string]interface{}{
-			"copy":  map[string]interface{}{"bandwidth": copyStats.Mean, "std_dev": copyStats.StdDev, "unit": "GB/s"},
-			"scale": map[string]interface{}{"bandwidth": scaleStats.Mean, "std_dev": scaleStats.StdDev, "unit": "GB/s"},
-			"add":   map[string]interface{}{"bandwidth": addStats.Mean, "std_dev": addStats.StdDev, "unit": "GB/s"},
-			"triad": map[string]interface{}{"bandwidth": triadStats.Mean, "std_dev": triadStats.StdDev, "unit": "GB/s"},
-		},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-			"iterations": len(allResults),
-			"statistical_confidence": "95%",
+	return &BenchmarkResults{
+		STREAM: &STREAMResult{
+			Copy:  &StreamOperation{Bandwidth: copyStats.Mean, StdDev: copyStats.StdDev, Unit: "GB/s"},
+			Scale: &StreamOperation{Bandwidth: scaleStats.Mean, StdDev: scaleStats.StdDev, Unit: "GB/s"},
+			Add:   &StreamOperation{Bandwidth: addStats.Mean, StdDev: addStats.StdDev, Unit: "GB/s"},
+			Triad: &StreamOperation{Bandwidth: triadStats.Mean, StdDev: triadStats.StdDev, Unit: "GB/s"},
 		},
+		Metadata: newAggregateMetadata(len(allResults)),
 	}, nil
 }
 
-func (o *Orchestrator) aggregateHPLResults(allResults []map[string]interface{}) (map[string]interface{}, error) {
+func (o *Orchestrator) aggregateHPLResults(allResults []*BenchmarkResults) (*BenchmarkResults, error) {
 	var gflopsValues, timeValues []float64
 	
 	for _, result := range allResults {
-		if hplData, ok := result["hpl"].(map[string]interface{}); ok {
-			if gflops, ok := hplData["gflops"].(float64); ok {
-				gflopsValues = append(gflopsValues, gflops)
-			}
-			if execTime, ok := hplData["execution_time"].(float64); ok {
-				timeValues = append(timeValues, execTime)
-			}
+		if result == nil || result.HPL == nil {
+			continue
 		}
+		gflopsValues = appendMeasured(gflopsValues, result.HPL.GFLOPS)
+		timeValues = appendMeasured(timeValues, result.HPL.ExecutionTime)
 	}
 	
 	gflopsStats := o.calculateStatistics(gflopsValues)
 	timeStats := o.calculateStatistics(timeValues)
 	
-	return map[string]interface{}{
-		"hpl": map[string]interface{}{
-			"gflops": gflopsStats.Mean,
-			"gflops_std_dev": gflopsStats.StdDev,
-			"execution_time": timeStats.Mean,
-			"time_std_dev": timeStats.StdDev,
-			"unit": "GFLOPS",
-			"time_unit": "seconds",
-			"matrix_size": 2000, // Fixed size
-		},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-			"iterations": len(allResults),
-			"statistical_confidence": "95%",
+	return &BenchmarkResults{
+		HPL: &HPLResult{
+			GFLOPS:        gflopsStats.Mean,
+			GFLOPSStdDev:  gflopsStats.StdDev,
+			ExecutionTime: timeStats.Mean,
+			TimeStdDev:    timeStats.StdDev,
+			Unit:          "GFLOPS",
+			TimeUnit:      "seconds",
+			MatrixSize:    2000, // Fixed size
 		},
+		Metadata: newAggregateMetadata(len(allResults)),
 	}, nil
 }
 
-func (o *Orchestrator) aggregateDGEMMResults(allResults []map[string]interface{}) (map[string]interface{}, error) {
+func (o *Orchestrator) aggregateDGEMMResults(allResults []*BenchmarkResults) (*BenchmarkResults, error) {
 	var smallGflopsValues, mediumGflopsValues, largeGflopsValues, peakGflopsValues []float64
 	var memoryEffValues, cacheEffValues []float64
 	
 	// Extract values from all iterations
 	for _, result := range allResults {
-		if dgemmData, ok := result["dgemm"].(map[string]interface{}); ok {
-			if smallGflops, ok := dgemmData["small_matrix_gflops"].(float64); ok {
-				smallGflopsValues = append(smallGflopsValues, smallGflops)
-			}
-			if mediumGflops, ok := dgemmData["medium_matrix_gflops"].(float64); ok {
-				mediumGflopsValues = append(mediumGflopsValues, mediumGflops)
-			}
-			if largeGflops, ok := dgemmData["large_matrix_gflops"].(float64); ok {
-				largeGflopsValues = append(largeGflopsValues, largeGflops)
-			}
-			if peakGflops, ok := dgemmData["peak_gflops"].(float64); ok {
-				peakGflopsValues = append(peakGflopsValues, peakGflops)
-			}
-			if memEff, ok := dgemmData["memory_bound_efficiency"].(float64); ok {
-				memoryEffValues = append(memoryEffValues, memEff)
-			}
-			if cacheEff, ok := dgemmData["cache_efficiency"].(float64); ok {
-				cacheEffValues = append(cacheEffValues, cacheEff)
-			}
+		if result == nil || result.DGEMM == nil {
+			continue
 		}
+		dgemmData := result.DGEMM
+		smallGflopsValues = appendMeasured(smallGflopsValues, dgemmData.SmallMatrixGFLOPS)
+		mediumGflopsValues = appendMeasured(mediumGflopsValues, dgemmData.MediumMatrixGFLOPS)
+		largeGflopsValues = appendMeasured(largeGflopsValues, dgemmData.LargeMatrixGFLOPS)
+		peakGflopsValues = appendMeasured(peakGflopsValues, dgemmData.PeakGFLOPS)
+		memoryEffValues = appendMeasured(memoryEffValues, dgemmData.MemoryBoundEfficiency)
+		cacheEffValues = appendMeasured(cacheEffValues, dgemmData.CacheEfficiency)
 	}
 	
 	// Calculate statistics for each metric
@@ -3050,29 +3183,27 @@ func (o *Orchestrator) aggregateDGEMMResults(allResults []map[string]interface{}
 	memoryEffStats := o.calculateStatistics(memoryEffValues)
 	cacheEffStats := o.calculateStatistics(cacheEffValues)
 	
-	return map[string]interface{}{
-		"dgemm": map[string]interface{}{
-			"small_matrix_gflops": smallStats.Mean,
-			"small_matrix_std_dev": smallStats.StdDev,
-			"medium_matrix_gflops": mediumStats.Mean,
-			"medium_matrix_std_dev": mediumStats.StdDev,
-			"large_matrix_gflops": largeStats.Mean,
-			"large_matrix_std_dev": largeStats.StdDev,
-			"peak_gflops": peakStats.Mean,
-			"peak_std_dev": peakStats.StdDev,
-			"memory_bound_efficiency": memoryEffStats.Mean,
-			"memory_eff_std_dev": memoryEffStats.StdDev,
-			"cache_efficiency": cacheEffStats.Mean,
-			"cache_eff_std_dev": cacheEffStats.StdDev,
-			"unit": "GFLOPS",
-			"benchmark_type": "enhanced_dgemm",
-		},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-			"iterations": len(allResults),
-			"statistical_confidence": "95%",
-			"matrix_sizes": []string{"small", "medium", "large"},
+	metadata := newAggregateMetadata(len(allResults))
+	metadata.MatrixSizes = []string{"small", "medium", "large"}
+	
+	return &BenchmarkResults{
+		DGEMM: &DGEMMResult{
+			SmallMatrixGFLOPS:     smallStats.Mean,
+			SmallMatrixStdDev:     smallStats.StdDev,
+			MediumMatrixGFLOPS:    mediumStats.Mean,
+			MediumMatrixStdDev:    mediumStats.StdDev,
+			LargeMatrixGFLOPS:     largeStats.Mean,
+			LargeMatrixStdDev:     largeStats.StdDev,
+			PeakGFLOPS:            peakStats.Mean,
+			PeakStdDev:            peakStats.StdDev,
+			MemoryBoundEfficiency: memoryEffStats.Mean,
+			MemoryEffStdDev:       memoryEffStats.StdDev,
+			CacheEfficiency:       cacheEffStats.Mean,
+			CacheEffStdDev:        cacheEffStats.StdDev,
+			Unit:                  "GFLOPS",
+			BenchmarkType:         "enhanced_dgemm",
 		},
+		Metadata: metadata,
 	}, nil
 }
 
@@ -3384,67 +3515,53 @@ echo "Running FFTW benchmark..."
 `
 }
 
-func (o *Orchestrator) aggregateCoreMarkResults(allResults []map[string]interface{}) (map[string]interface{}, error) {
+func (o *Orchestrator) aggregateCoreMarkResults(allResults []*BenchmarkResults) (*BenchmarkResults, error) {
 	var scoreValues, timeValues []float64
 	
 	for _, result := range allResults {
-		if coremarkData, ok := result["coremark"].(map[string]interface{}); ok {
-			if score, ok := coremarkData["score"].(float64); ok {
-				scoreValues = append(scoreValues, score)
-			}
-			if execTime, ok := coremarkData["execution_time"].(float64); ok {
-				timeValues = append(timeValues, execTime)
-			}
+		if result == nil || result.CoreMark == nil {
+			continue
 		}
+		scoreValues = appendMeasured(scoreValues, result.CoreMark.Score)
+		timeValues = appendMeasured(timeValues, result.CoreMark.ExecutionTime)
 	}
 	
 	scoreStats := o.calculateStatistics(scoreValues)
 	timeStats := o.calculateStatistics(timeValues)
 	
-	return map[string]interface{}{
-		"coremark": map[string]interface{}{
-			"score": scoreStats.Mean,
-			"score_std_dev": scoreStats.StdDev,
-			"execution_time": timeStats.Mean,
-			"time_std_dev": timeStats.StdDev,
-			"unit": "operations/sec",
-			"time_unit": "seconds",
-			"iterations": 10000000,
-		},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-			"iterations": len(allResults),
-			"statistical_confidence": "95%",
+	return &BenchmarkResults{
+		CoreMark: &CoreMarkResult{
+			Score:         scoreStats.Mean,
+			ScoreStdDev:   scoreStats.StdDev,
+			ExecutionTime: timeStats.Mean,
+			TimeStdDev:    timeStats.StdDev,
+			Unit:          "operations/sec",
+			TimeUnit:      "seconds",
+			Iterations:    10000000,
 		},
+		Metadata: newAggregateMetadata(len(allResults)),
 	}, nil
 }
 
-func (o *Orchestrator) aggregateCacheResults(allResults []map[string]interface{}) (map[string]interface{}, error) {
+func (o *Orchestrator) aggregateCacheResults(allResults []*BenchmarkResults) (*BenchmarkResults, error) {
 	// Cache results should be consistent across runs, so we'll take the median
 	l1Times, l2Times, l3Times, memTimes := []float64{}, []float64{}, []float64{}, []float64{}
 	
 	for _, result := range allResults {
-		if cacheData, ok := result["cache"].(map[string]interface{}); ok {
-			if l1, ok := cacheData["l1"].(map[string]interface{}); ok {
-				if time, ok := l1["access_time"].(float64); ok {
-					l1Times = append(l1Times, time)
-				}
-			}
-			if l2, ok := cacheData["l2"].(map[string]interface{}); ok {
-				if time, ok := l2["access_time"].(float64); ok {
-					l2Times = append(l2Times, time)
-				}
-			}
-			if l3, ok := cacheData["l3"].(map[string]interface{}); ok {
-				if time, ok := l3["access_time"].(float64); ok {
-					l3Times = append(l3Times, time)
-				}
-			}
-			if mem, ok := cacheData["memory"].(map[string]interface{}); ok {
-				if time, ok := mem["access_time"].(float64); ok {
-					memTimes = append(memTimes, time)
-				}
-			}
+		if result == nil || result.Cache == nil {
+			continue
+		}
+		if l1 := result.Cache.L1; l1 != nil {
+			l1Times = appendMeasured(l1Times, l1.AccessTime)
+		}
+		if l2 := result.Cache.L2; l2 != nil {
+			l2Times = appendMeasured(l2Times, l2.AccessTime)
+		}
+		if l3 := result.Cache.L3; l3 != nil {
+			l3Times = appendMeasured(l3Times, l3.AccessTime)
+		}
+		if mem := result.Cache.Memory; mem != nil {
+			memTimes = appendMeasured(memTimes, mem.AccessTime)
 		}
 	}
 	
@@ -3453,57 +3570,28 @@ func (o *Orchestrator) aggregateCacheResults(allResults []map[string]interface{}
 	l3Stats := o.calculateStatistics(l3Times)
 	memStats := o.calculateStatistics(memTimes)
 	
-	return map[string]interface{}{
-		"cache": map[string]interface{}{
-			"l1": map[string]interface{}{
-				"access_time": l1Stats.Mean,
-				"std_dev": l1Stats.StdDev,
-				"size_kb": 16,
-				"unit": "ns",
-			},
-			"l2": map[string]interface{}{
-				"access_time": l2Stats.Mean,
-				"std_dev": l2Stats.StdDev,
-				"size_kb": 512,
-				"unit": "ns",
-			},
-			"l3": map[string]interface{}{
-				"access_time": l3Stats.Mean,
-				"std_dev": l3Stats.StdDev,
-				"size_kb": 16384,
-				"unit": "ns",
-			},
-			"memory": map[string]interface{}{
-				"access_time": memStats.Mean,
-				"std_dev": memStats.StdDev,
-				"size_kb": 131072,
-				"unit": "ns",
-			},
-		},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-			"iterations": len(allResults),
-			"statistical_confidence": "95%",
+	return &BenchmarkResults{
+		Cache: &CacheResult{
+			L1:     &CacheLevelResult{AccessTime: l1Stats.Mean, StdDev: l1Stats.StdDev, SizeKB: 16, Unit: "ns"},
+			L2:     &CacheLevelResult{AccessTime: l2Stats.Mean, StdDev: l2Stats.StdDev, SizeKB: 512, Unit: "ns"},
+			L3:     &CacheLevelResult{AccessTime: l3Stats.Mean, StdDev: l3Stats.StdDev, SizeKB: 16384, Unit: "ns"},
+			Memory: &CacheLevelResult{AccessTime: memStats.Mean, StdDev: memStats.StdDev, SizeKB: 131072, Unit: "ns"},
 		},
+		Metadata: newAggregateMetadata(len(allResults)),
 	}, nil
 }
 
-func (o *Orchestrator) aggregate7ZipResults(allResults []map[string]interface{}) (map[string]interface{}, error) {
+func (o *Orchestrator) aggregate7ZipResults(allResults []*BenchmarkResults) (*BenchmarkResults, error) {
 	var compressMIPSValues, decompressMIPSValues, totalMIPSValues []float64
 	
 	// Extract values from all iterations
 	for _, result := range allResults {
-		if sevenZipData, ok := result["7zip"].(map[string]interface{}); ok {
-			if compMIPS, ok := sevenZipData["compression_mips"].(float64); ok {
-				compressMIPSValues = append(compressMIPSValues, compMIPS)
-			}
-			if decompMIPS, ok := sevenZipData["decompression_mips"].(float64); ok {
-				decompressMIPSValues = append(decompressMIPSValues, decompMIPS)
-			}
-			if totalMIPS, ok := sevenZipData["total_mips"].(float64); ok {
-				totalMIPSValues = append(totalMIPSValues, totalMIPS)
-			}
+		if result == nil || result.SevenZip == nil {
+			continue
 		}
+		compressMIPSValues = appendMeasured(compressMIPSValues, result.SevenZip.CompressionMIPS)
+		decompressMIPSValues = appendMeasured(decompressMIPSValues, result.SevenZip.DecompressionMIPS)
+		totalMIPSValues = appendMeasured(totalMIPSValues, result.SevenZip.TotalMIPS)
 	}
 	
 	// Calculate statistics for each metric
@@ -3511,73 +3599,59 @@ func (o *Orchestrator) aggregate7ZipResults(allResults []map[string]interface{})
 	decompressStats := o.calculateStatistics(decompressMIPSValues)
 	totalStats := o.calculateStatistics(totalMIPSValues)
 	
-	return map[string]interface{}{
-		"7zip": map[string]interface{}{
-			"compression_mips": compressStats.Mean,
-			"compression_std_dev": compressStats.StdDev,
-			"decompression_mips": decompressStats.Mean,
-			"decompression_std_dev": decompressStats.StdDev,
-			"total_mips": totalStats.Mean,
-			"total_std_dev": totalStats.StdDev,
-			"unit": "MIPS",
-		},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-			"iterations": len(allResults),
-			"statistical_confidence": "95%",
-			"benchmark_type": "compression_workload",
+	metadata := newAggregateMetadata(len(allResults))
+	metadata.BenchmarkType = "compression_workload"
+	
+	return &BenchmarkResults{
+		SevenZip: &SevenZipResult{
+			CompressionMIPS:     compressStats.Mean,
+			CompressionStdDev:   compressStats.StdDev,
+			DecompressionMIPS:   decompressStats.Mean,
+			DecompressionStdDev: decompressStats.StdDev,
+			TotalMIPS:           totalStats.Mean,
+			TotalStdDev:         totalStats.StdDev,
+			Unit:                "MIPS",
 		},
+		Metadata: metadata,
 	}, nil
 }
 
-func (o *Orchestrator) aggregateSysbenchResults(allResults []map[string]interface{}) (map[string]interface{}, error) {
+func (o *Orchestrator) aggregateSysbenchResults(allResults []*BenchmarkResults) (*BenchmarkResults, error) {
 	var epsValues, timeValues []float64
 	
 	// Extract values from all iterations
 	for _, result := range allResults {
-		if sysbenchData, ok := result["sysbench"].(map[string]interface{}); ok {
-			if eps, ok := sysbenchData["events_per_second"].(float64); ok {
-				epsValues = append(epsValues, eps)
-			}
-			if time, ok := sysbenchData["total_time"].(float64); ok {
-				timeValues = append(timeValues, time)
-			}
+		if result == nil || result.Sysbench == nil {
+			continue
 		}
+		epsValues = appendMeasured(epsValues, result.Sysbench.EventsPerSecond)
+		timeValues = appendMeasured(timeValues, result.Sysbench.TotalTime)
 	}
 	
 	// Calculate statistics for each metric
 	epsStats := o.calculateStatistics(epsValues)
 	timeStats := o.calculateStatistics(timeValues)
 	
-	return map[string]interface{}{
-		"sysbench": map[string]interface{}{
-			"events_per_second": epsStats.Mean,
-			"eps_std_dev": epsStats.StdDev,
-			"total_time": timeStats.Mean,
-			"time_std_dev": timeStats.StdDev,
-			"unit": "events/sec",
-			"time_unit": "seconds",
-		},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-			"iterations": len(allResults),
-			"statistical_confidence": "95%",
-			"benchmark_type": "prime_calculation",
+	metadata := newAggregateMetadata(len(allResults))
+	metadata.BenchmarkType = "prime_calculation"
+	
+	return &BenchmarkResults{
+		Sysbench: &SysbenchResult{
+			EventsPerSecond: epsStats.Mean,
+			EPSStdDev:       epsStats.StdDev,
+			TotalTime:       timeStats.Mean,
+			TimeStdDev:      timeStats.StdDev,
+			Unit:            "events/sec",
+			TimeUnit:        "seconds",
 		},
+		Metadata: metadata,
 	}, nil
 }
 
-func (o *Orchestrator) parseFFTWOutput(output string) (map[string]interface{}, error) {
+func (o *Orchestrator) parseFFTWOutput(output string) (*BenchmarkResults, error) {
 	lines := strings.Split(output, "\n")
 	
-	results := map[string]interface{}{
-		"fftw": map[string]interface{}{},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-		},
-	}
-	
-	fftwResults := make(map[string]interface{})
+	fftwResults := &FFTWResult{}
 	
 	// Parse FFTW benchmark output
 	for _, line := range lines {
@@ -3586,68 +3660,44 @@ func (o *Orchestrator) parseFFTWOutput(output string) (map[string]interface{}, e
 		// Parse individual FFT results
 		// Format: "1D FFT Small (1048576 points): 78.45 GFLOPS"
 		if strings.Contains(line, "1D FFT Small") && strings.Contains(line, "GFLOPS") {
-			if gflops := o.extractGFLOPSFromLine(line); gflops > 0 {
-				fftwResults["fft_1d_small_gflops"] = gflops
-			}
+			fftwResults.FFT1DSmallGFLOPS = o.extractGFLOPSFromLine(line)
 		} else if strings.Contains(line, "1D FFT Medium") && strings.Contains(line, "GFLOPS") {
-			if gflops := o.extractGFLOPSFromLine(line); gflops > 0 {
-				fftwResults["fft_1d_medium_gflops"] = gflops
-			}
+			fftwResults.FFT1DMediumGFLOPS = o.extractGFLOPSFromLine(line)
 		} else if strings.Contains(line, "1D FFT Large") && strings.Contains(line, "GFLOPS") {
-			if gflops := o.extractGFLOPSFromLine(line); gflops > 0 {
-				fftwResults["fft_1d_large_gflops"] = gflops
-			}
+			fftwResults.FFT1DLargeGFLOPS = o.extractGFLOPSFromLine(line)
 		} else if strings.Contains(line, "2D FFT") && strings.Contains(line, "GFLOPS") {
-			if gflops := o.extractGFLOPSFromLine(line); gflops > 0 {
-				fftwResults["fft_2d_gflops"] = gflops
-			}
+			fftwResults.FFT2DGFLOPS = o.extractGFLOPSFromLine(line)
 		} else if strings.Contains(line, "3D FFT") && strings.Contains(line, "GFLOPS") {
-			if gflops := o.extractGFLOPSFromLine(line); gflops > 0 {
-				fftwResults["fft_3d_gflops"] = gflops
-			}
+			fftwResults.FFT3DGFLOPS = o.extractGFLOPSFromLine(line)
 		} else if strings.Contains(line, "Overall FFTW Performance:") {
-			if gflops := o.extractGFLOPSFromLine(line); gflops > 0 {
-				fftwResults["overall_gflops"] = gflops
-			}
+			fftwResults.OverallGFLOPS = o.extractGFLOPSFromLine(line)
 		} else if strings.Contains(line, "Peak 1D performance:") {
-			if gflops := o.extractGFLOPSFromLine(line); gflops > 0 {
-				fftwResults["peak_1d_gflops"] = gflops
-			}
+			fftwResults.Peak1DGFLOPS = o.extractGFLOPSFromLine(line)
 		} else if strings.Contains(line, "Memory scaling efficiency:") {
-			parts := strings.Fields(line)
-			for _, part := range parts {
-				if strings.HasSuffix(part, "%") {
-					effStr := strings.TrimSuffix(part, "%")
-					if eff, err := strconv.ParseFloat(effStr, 64); err == nil {
-						fftwResults["memory_scaling_efficiency"] = eff / 100.0
-						break
-					}
-				}
+			if eff, ok := extractPercentFromLine(line); ok {
+				fftwResults.MemoryScalingEfficiency = eff / 100.0
 			}
 		} else if strings.Contains(line, "Dimensionality efficiency:") {
-			parts := strings.Fields(line)
-			for _, part := range parts {
-				if strings.HasSuffix(part, "%") {
-					effStr := strings.TrimSuffix(part, "%")
-					if eff, err := strconv.ParseFloat(effStr, 64); err == nil {
-						fftwResults["dimensionality_efficiency"] = eff / 100.0
-						break
-					}
-				}
+			if eff, ok := extractPercentFromLine(line); ok {
+				fftwResults.DimensionalityEfficiency = eff / 100.0
 			}
 		}
 	}
 	
-	// Add metadata
-	fftwResults["unit"] = "GFLOPS"
-	fftwResults["benchmark_type"] = "fftw_scientific_computing"
-	
-	if len(fftwResults) == 0 {
+	if fftwResults.FFT1DSmallGFLOPS <= 0 && fftwResults.FFT1DMediumGFLOPS <= 0 &&
+		fftwResults.FFT1DLargeGFLOPS <= 0 && fftwResults.FFT2DGFLOPS <= 0 &&
+		fftwResults.FFT3DGFLOPS <= 0 && fftwResults.OverallGFLOPS <= 0 {
 		return nil, fmt.Errorf("no FFTW results found in output")
 	}
 	
-	results["fftw"] = fftwResults
-	return results, nil
+	// Add metadata
+	fftwResults.Unit = "GFLOPS"
+	fftwResults.BenchmarkType = "fftw_scientific_computing"
+	
+	return &BenchmarkResults{
+		FFTW:     fftwResults,
+		Metadata: newIterationMetadata(),
+	}, nil
 }
 
 func (o *Orchestrator) extractGFLOPSFromLine(line string) float64 {
@@ -3663,6 +3713,31 @@ func (o *Orchestrator) extractGFLOPSFromLine(line string) float64 {
 	return 0
 }
 
+// extractPercentFromLine returns the first "NN.N%" value on a line.
+func extractPercentFromLine(line string) (float64, bool) {
+	for _, part := range strings.Fields(line) {
+		if strings.HasSuffix(part, "%") {
+			if value, err := strconv.ParseFloat(strings.TrimSuffix(part, "%"), 64); err == nil {
+				return value, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// lastFloatField returns the last whitespace-separated field of s as a number.
+func lastFloatField(s string) (float64, bool) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(fields[len(fields)-1], 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
 func (o *Orchestrator) extractFloatFromLine(line string, unit string) float64 {
 	// Extract float from lines with various units like "ratio: 2.34", "time: 123.45 seconds", etc.
 	parts := strings.Fields(line)
@@ -3704,42 +3779,26 @@ func (o *Orchestrator) extractFloatFromLine(line string, unit string) float64 {
 	return 0
 }
 
-func (o *Orchestrator) aggregateFFTWResults(allResults []map[string]interface{}) (map[string]interface{}, error) {
+func (o *Orchestrator) aggregateFFTWResults(allResults []*BenchmarkResults) (*BenchmarkResults, error) {
 	var fft1dSmallValues, fft1dMediumValues, fft1dLargeValues []float64
 	var fft2dValues, fft3dValues, overallValues, peak1dValues []float64
 	var memScalingValues, dimEfficiencyValues []float64
 	
 	// Extract values from all iterations
 	for _, result := range allResults {
-		if fftwData, ok := result["fftw"].(map[string]interface{}); ok {
-			if fft1dSmall, ok := fftwData["fft_1d_small_gflops"].(float64); ok {
-				fft1dSmallValues = append(fft1dSmallValues, fft1dSmall)
-			}
-			if fft1dMedium, ok := fftwData["fft_1d_medium_gflops"].(float64); ok {
-				fft1dMediumValues = append(fft1dMediumValues, fft1dMedium)
-			}
-			if fft1dLarge, ok := fftwData["fft_1d_large_gflops"].(float64); ok {
-				fft1dLargeValues = append(fft1dLargeValues, fft1dLarge)
-			}
-			if fft2d, ok := fftwData["fft_2d_gflops"].(float64); ok {
-				fft2dValues = append(fft2dValues, fft2d)
-			}
-			if fft3d, ok := fftwData["fft_3d_gflops"].(float64); ok {
-				fft3dValues = append(fft3dValues, fft3d)
-			}
-			if overall, ok := fftwData["overall_gflops"].(float64); ok {
-				overallValues = append(overallValues, overall)
-			}
-			if peak1d, ok := fftwData["peak_1d_gflops"].(float64); ok {
-				peak1dValues = append(peak1dValues, peak1d)
-			}
-			if memScaling, ok := fftwData["memory_scaling_efficiency"].(float64); ok {
-				memScalingValues = append(memScalingValues, memScaling)
-			}
-			if dimEff, ok := fftwData["dimensionality_efficiency"].(float64); ok {
-				dimEfficiencyValues = append(dimEfficiencyValues, dimEff)
-			}
+		if result == nil || result.FFTW == nil {
+			continue
 		}
+		fftwData := result.FFTW
+		fft1dSmallValues = appendMeasured(fft1dSmallValues, fftwData.FFT1DSmallGFLOPS)
+		fft1dMediumValues = appendMeasured(fft1dMediumValues, fftwData.FFT1DMediumGFLOPS)
+		fft1dLargeValues = appendMeasured(fft1dLargeValues, fftwData.FFT1DLargeGFLOPS)
+		fft2dValues = appendMeasured(fft2dValues, fftwData.FFT2DGFLOPS)
+		fft3dValues = appendMeasured(fft3dValues, fftwData.FFT3DGFLOPS)
+		overallValues = appendMeasured(overallValues, fftwData.OverallGFLOPS)
+		peak1dValues = appendMeasured(peak1dValues, fftwData.Peak1DGFLOPS)
+		memScalingValues = appendMeasured(memScalingValues, fftwData.MemoryScalingEfficiency)
+		dimEfficiencyValues = appendMeasured(dimEfficiencyValues, fftwData.DimensionalityEfficiency)
 	}
 	
 	// Calculate statistics for each metric
@@ -3753,35 +3812,33 @@ func (o *Orchestrator) aggregateFFTWResults(allResults []map[string]interface{})
 	memScalingStats := o.calculateStatistics(memScalingValues)
 	dimEfficiencyStats := o.calculateStatistics(dimEfficiencyValues)
 	
-	return map[string]interface{}{
-		"fftw": map[string]interface{}{
-			"fft_1d_small_gflops": fft1dSmallStats.Mean,
-			"fft_1d_small_std_dev": fft1dSmallStats.StdDev,
-			"fft_1d_medium_gflops": fft1dMediumStats.Mean,
-			"fft_1d_medium_std_dev": fft1dMediumStats.StdDev,
-			"fft_1d_large_gflops": fft1dLargeStats.Mean,
-			"fft_1d_large_std_dev": fft1dLargeStats.StdDev,
-			"fft_2d_gflops": fft2dStats.Mean,
-			"fft_2d_std_dev": fft2dStats.StdDev,
-			"fft_3d_gflops": fft3dStats.Mean,
-			"fft_3d_std_dev": fft3dStats.StdDev,
-			"overall_gflops": overallStats.Mean,
-			"overall_std_dev": overallStats.StdDev,
-			"peak_1d_gflops": peak1dStats.Mean,
-			"peak_1d_std_dev": peak1dStats.StdDev,
-			"memory_scaling_efficiency": memScalingStats.Mean,
-			"memory_scaling_std_dev": memScalingStats.StdDev,
-			"dimensionality_efficiency": dimEfficiencyStats.Mean,
-			"dimensionality_std_dev": dimEfficiencyStats.StdDev,
-			"unit": "GFLOPS",
-			"benchmark_type": "fftw_scientific_computing",
-		},
-		"metadata": map[string]interface{}{
-			"timestamp": time.Now().Format(time.RFC3339),
-			"iterations": len(allResults),
-			"statistical_confidence": "95%",
-			"fft_types": []string{"1d_small", "1d_medium", "1d_large", "2d", "3d"},
+	metadata := newAggregateMetadata(len(allResults))
+	metadata.FFTTypes = []string{"1d_small", "1d_medium", "1d_large", "2d", "3d"}
+	
+	return &BenchmarkResults{
+		FFTW: &FFTWResult{
+			FFT1DSmallGFLOPS:         fft1dSmallStats.Mean,
+			FFT1DSmallStdDev:         fft1dSmallStats.StdDev,
+			FFT1DMediumGFLOPS:        fft1dMediumStats.Mean,
+			FFT1DMediumStdDev:        fft1dMediumStats.StdDev,
+			FFT1DLargeGFLOPS:         fft1dLargeStats.Mean,
+			FFT1DLargeStdDev:         fft1dLargeStats.StdDev,
+			FFT2DGFLOPS:              fft2dStats.Mean,
+			FFT2DStdDev:              fft2dStats.StdDev,
+			FFT3DGFLOPS:              fft3dStats.Mean,
+			FFT3DStdDev:              fft3dStats.StdDev,
+			OverallGFLOPS:            overallStats.Mean,
+			OverallStdDev:            overallStats.StdDev,
+			Peak1DGFLOPS:             peak1dStats.Mean,
+			Peak1DStdDev:             peak1dStats.StdDev,
+			MemoryScalingEfficiency:  memScalingStats.Mean,
+			MemoryScalingStdDev:      memScalingStats.StdDev,
+			DimensionalityEfficiency: dimEfficiencyStats.Mean,
+			DimensionalityStdDev:     dimEfficiencyStats.StdDev,
+			Unit:                     "GFLOPS",
+			BenchmarkType:            "fftw_scientific_computing",
 		},
+		Metadata: metadata,
 	}, nil
 }
 
@@ -4085,6 +4142,30 @@ echo "Running vector operations benchmark..."
 `
 }
 
+// appendMeasured appends value to values if it was measured. Parsers leave
+// metrics they could not find at zero, so zero values are skipped to keep
+// them from skewing the statistics.
+func appendMeasured(values []float64, value float64) []float64 {
+	if value > 0 {
+		return append(values, value)
+	}
+	return values
+}
+
+// newIterationMetadata returns the metadata recorded for a single parsed iteration.
+func newIterationMetadata() *ResultMetadata {
+	return &ResultMetadata{Timestamp: time.Now().Format(time.RFC3339)}
+}
+
+// newAggregateMetadata returns the metadata recorded for aggregated results.
+func newAggregateMetadata(iterations int) *ResultMetadata {
+	return &ResultMetadata{
+		Timestamp:             time.Now().Format(time.RFC3339),
+		Iterations:            iterations,
+		StatisticalConfidence: "95%",
+	}
+}
+
 type Statistics struct {
 	Mean   float64
 	StdDev float64
@@ -4239,8 +4320,21 @@ func (o *Orchestrator) terminateInstance(ctx context.Context, instanceID string)
 		InstanceIds: []string{instanceID},
 	}
 
-	_, err := o.ec2Client.TerminateInstances(ctx, input)
-	return err
+	if _, err := o.ec2Client.TerminateInstances(ctx, input); err != nil {
+		return err
+	}
+	o.record(JournalEntry{Event: JournalInstanceTerminated, InstanceID: instanceID})
+	return nil
+}
+
+// terminateJobInstance terminates the instance of a job and publishes the
+// termination.
+func (o *Orchestrator) terminateJobInstance(ctx context.Context, config BenchmarkConfig, instanceID string) error {
+	if err := o.terminateInstance(ctx, instanceID); err != nil {
+		return err
+	}
+	o.publish(config, Event{Type: EventInstanceTerminated, InstanceID: instanceID, Message: "instance terminated"})
+	return nil
 }
 
 func (o *Orchestrator) generateUserDataScript(config BenchmarkConfig) string {
@@ -1,8 +1,15 @@
 package aws
 
 import (
+	"context"
+	"errors"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/fakecloud"
 )
 
 func TestArchitectureDetection(t *testing.T) {
@@ -66,9 +73,14 @@ func detectArchitectureFromInstanceType(instanceType string) string {
 }
 
 func TestOrchestratorCreation(t *testing.T) {
+	// Skip this test if AWS credentials are not available
+	if testing.Short() {
+		t.Skip("Skipping AWS-dependent test in short mode")
+	}
+
 	orchestrator, err := NewOrchestrator("us-east-1")
 	if err != nil {
-		t.Fatalf("NewOrchestrator failed: %v", err)
+		t.Skipf("Skipping test due to AWS configuration error: %v", err)
 	}
 	
 	if orchestrator.region != "us-east-1" {
@@ -78,4 +90,206 @@ func TestOrchestratorCreation(t *testing.T) {
 	if orchestrator.ec2Client == nil {
 		t.Error("EC2 client should not be nil")
 	}
-}
\ No newline at end of file
+}
+
+const fakeSTREAMOutput = `-------------------------------------------------------------
+Function    Best Rate MB/s  Avg time     Min time     Max time
+Copy:           45234.2     0.000354     0.000354     0.000355
+Scale:          44876.1     0.000357     0.000356     0.000358
+Add:            42105.3     0.000571     0.000570     0.000572
+Triad:          41932.8     0.000573     0.000572     0.000574
+-------------------------------------------------------------`
+
+// newFakeOrchestrator wires an orchestrator to an in-memory cloud with
+// polling intervals short enough for unit tests.
+func newFakeOrchestrator(cloud *fakecloud.Cloud) *Orchestrator {
+	return NewOrchestratorWithClients(cloud.Region(), cloud.EC2(), cloud.SSM()).
+		WithPolling(PollingConfig{
+			InstanceStateInterval: time.Millisecond,
+			BenchmarkPollInterval: time.Millisecond,
+			BenchmarkMaxWait:      time.Second,
+			CommandPollInterval:   time.Millisecond,
+		})
+}
+
+// benchmarkInvocations returns the SSM commands a run sent, excluding the
+// kernel and OS release probe.
+func benchmarkInvocations(cloud *fakecloud.Cloud) []fakecloud.Invocation {
+	var invocations []fakecloud.Invocation
+	for _, inv := range cloud.Invocations() {
+		if inv.Command != osInfoCommand {
+			invocations = append(invocations, inv)
+		}
+	}
+	return invocations
+}
+
+func fakeBenchmarkConfig(instanceType, suite string) BenchmarkConfig {
+	return BenchmarkConfig{
+		InstanceType:    instanceType,
+		BenchmarkSuite:  suite,
+		Region:          "us-east-1",
+		KeyPairName:     "test-key",
+		SecurityGroupID: "sg-12345678",
+		SubnetID:        "subnet-12345678",
+		Timeout:         time.Second,
+	}
+}
+
+func TestRunBenchmarkWithFakeCloud(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	cloud.SetPendingPolls(1)
+	cloud.SetCommandPolls(2)
+	cloud.HandleCommands(func(instanceID, command string) fakecloud.CommandResult {
+		return fakecloud.CommandResult{Status: ssmtypes.CommandInvocationStatusSuccess, Stdout: fakeSTREAMOutput}
+	})
+
+	orchestrator := newFakeOrchestrator(cloud)
+	result, err := orchestrator.RunBenchmark(context.Background(), fakeBenchmarkConfig("c7g.large", "stream"))
+	if err != nil {
+		t.Fatalf("RunBenchmark failed: %v", err)
+	}
+
+	if result.Status != "completed" {
+		t.Errorf("Expected status completed, got %s", result.Status)
+	}
+	if result.PrivateIP == "" {
+		t.Error("Expected private IP to be populated from instance details")
+	}
+
+	if result.BenchmarkData == nil || result.BenchmarkData.STREAM == nil {
+		t.Fatalf("Expected aggregated stream data, got %+v", result.BenchmarkData)
+	}
+	if bw := result.BenchmarkData.STREAM.Bandwidth("triad"); bw < 41.9 || bw > 42.0 {
+		t.Errorf("Expected triad bandwidth ~41.93 GB/s, got %f", bw)
+	}
+	outputs := []string{fakeSTREAMOutput, fakeSTREAMOutput, fakeSTREAMOutput, fakeSTREAMOutput, fakeSTREAMOutput}
+	if result.RawOutputSHA256 != RawOutputHash(outputs) {
+		t.Errorf("Expected the hash of the 5 iteration outputs, got %q", result.RawOutputSHA256)
+	}
+
+	instances := cloud.Instances()
+	if len(instances) != 1 {
+		t.Fatalf("Expected 1 launched instance, got %d", len(instances))
+	}
+	if instances[0].State != types.InstanceStateNameShuttingDown && instances[0].State != types.InstanceStateNameTerminated {
+		t.Errorf("Expected instance to be terminated, got %s", instances[0].State)
+	}
+	if instances[0].Tags["BenchmarkSuite"] != "stream" {
+		t.Errorf("Expected BenchmarkSuite tag, got %v", instances[0].Tags)
+	}
+	if got := len(benchmarkInvocations(cloud)); got != 5 {
+		t.Errorf("Expected 5 benchmark iterations via SSM, got %d", got)
+	}
+}
+
+func TestRunBenchmarkCapacityErrorWithFakeCloud(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	cloud.SetCapacity("m7i.large", 0)
+
+	orchestrator := newFakeOrchestrator(cloud)
+	result, err := orchestrator.RunBenchmark(context.Background(), fakeBenchmarkConfig("m7i.large", "stream"))
+
+	var quotaErr *QuotaError
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("Expected QuotaError, got %v", err)
+	}
+	if quotaErr.InstanceType != "m7i.large" || quotaErr.Region != "us-east-1" {
+		t.Errorf("Unexpected quota error details: %+v", quotaErr)
+	}
+	if result.InstanceID != "" {
+		t.Errorf("Expected no instance to be launched, got %s", result.InstanceID)
+	}
+}
+
+func TestCheckQuotasWithFakeCloud(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	for i := 0; i < 10; i++ {
+		cloud.AddInstance(fakecloud.Instance{InstanceType: "m7i.large"})
+	}
+
+	orchestrator := newFakeOrchestrator(cloud)
+
+	var quotaErr *QuotaError
+	if err := orchestrator.checkQuotas(context.Background(), "m7i.large"); !errors.As(err, &quotaErr) {
+		t.Errorf("Expected QuotaError with 10 running instances, got %v", err)
+	}
+	if err := orchestrator.checkQuotas(context.Background(), "c7g.large"); err != nil {
+		t.Errorf("Expected no quota error for other instance type, got %v", err)
+	}
+}
+
+func TestRunBenchmarkCommandFailureWithFakeCloud(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	cloud.HandleCommands(func(instanceID, command string) fakecloud.CommandResult {
+		return fakecloud.CommandResult{Status: ssmtypes.CommandInvocationStatusFailed, Stderr: "stream: command not found"}
+	})
+
+	orchestrator := newFakeOrchestrator(cloud).WithPolling(PollingConfig{
+		InstanceStateInterval: time.Millisecond,
+		BenchmarkPollInterval: time.Millisecond,
+		BenchmarkMaxWait:      10 * time.Millisecond,
+		CommandPollInterval:   time.Millisecond,
+	})
+
+	result, err := orchestrator.RunBenchmark(context.Background(), fakeBenchmarkConfig("m7i.large", "stream"))
+	if err == nil {
+		t.Fatal("Expected benchmark execution to fail")
+	}
+	if !strings.Contains(err.Error(), "benchmark execution failed") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	inst, ok := cloud.Instance(result.InstanceID)
+	if !ok {
+		t.Fatalf("Expected launched instance %s to exist", result.InstanceID)
+	}
+	if inst.State == types.InstanceStateNameRunning {
+		t.Error("Expected failed benchmark instance to be terminated")
+	}
+}
+
+func TestRunBenchmarkPerRegionOrchestrators(t *testing.T) {
+	handler := func(instanceID, command string) fakecloud.CommandResult {
+		return fakecloud.CommandResult{Status: ssmtypes.CommandInvocationStatusSuccess, Stdout: fakeSTREAMOutput}
+	}
+	clouds := map[string]*fakecloud.Cloud{}
+	for _, region := range []string{"us-east-1", "eu-west-1"} {
+		cloud := fakecloud.New(region)
+		cloud.HandleCommands(handler)
+		clouds[region] = cloud
+	}
+	clouds["eu-west-1"].AddImage("amzn2-ami-hvm-2.0.20240701.0-x86_64-gp2", types.ArchitectureValuesX8664, "2024-07-01T00:00:00.000Z")
+
+	for region, cloud := range clouds {
+		config := fakeBenchmarkConfig("m7i.large", "stream")
+		config.Region = region
+		result, err := newFakeOrchestrator(cloud).RunBenchmark(context.Background(), config)
+		if err != nil {
+			t.Fatalf("RunBenchmark in %s failed: %v", region, err)
+		}
+
+		inst, _ := cloud.Instance(result.InstanceID)
+		if !strings.HasPrefix(inst.AvailabilityZone, region) {
+			t.Errorf("Expected instance in %s, got zone %s", region, inst.AvailabilityZone)
+		}
+		latest, err := newFakeOrchestrator(cloud).getLatestAMI(context.Background(), "m7i.large")
+		if err != nil || inst.ImageID != latest {
+			t.Errorf("Expected %s instance to use the region's latest AMI %s, got %s (%v)", region, latest, inst.ImageID, err)
+		}
+	}
+}
+
+func TestRunBenchmarkRegionMismatch(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+
+	config := fakeBenchmarkConfig("m7i.large", "stream")
+	config.Region = "eu-west-1"
+	_, err := newFakeOrchestrator(cloud).RunBenchmark(context.Background(), config)
+	if !errors.Is(err, ErrRegionMismatch) {
+		t.Fatalf("Expected ErrRegionMismatch, got %v", err)
+	}
+	if len(cloud.Instances()) != 0 {
+		t.Error("Expected no instance to be launched in the wrong region")
+	}
+}
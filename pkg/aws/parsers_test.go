@@ -0,0 +1,171 @@
+package aws
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// updateGolden rewrites golden files, e.g. the expected parser results:
+//
+//   go test ./pkg/aws -run TestParserGoldenCorpus -update
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// parserCorpusDir holds one directory of captured raw outputs per suite.
+// Every <case>.txt has a <case>.golden file with the expected parse result.
+const parserCorpusDir = "testdata/parsers"
+
+// parserCorpusCategories are the file name prefixes every suite's corpus must
+// cover: x86 and Graviton runs, truncated output, output produced under a
+// non-English locale, and failed runs.
+var parserCorpusCategories = []string{"x86_64_", "aarch64_", "truncated_", "localized_", "error_"}
+
+var parserCorpusSuites = []struct {
+	suite  string
+	result func(*BenchmarkResults) bool
+}{
+	{"stream", func(r *BenchmarkResults) bool { return r.STREAM != nil }},
+	{"dgemm", func(r *BenchmarkResults) bool { return r.DGEMM != nil }},
+	{"fftw", func(r *BenchmarkResults) bool { return r.FFTW != nil }},
+	{"coremark", func(r *BenchmarkResults) bool { return r.CoreMark != nil }},
+	{"7zip", func(r *BenchmarkResults) bool { return r.SevenZip != nil }},
+	{"sysbench", func(r *BenchmarkResults) bool { return r.Sysbench != nil }},
+	{"cache", func(r *BenchmarkResults) bool { return r.Cache != nil }},
+	{"mixed_precision", func(r *BenchmarkResults) bool { return r.MixedPrecision != nil }},
+	{"compilation", func(r *BenchmarkResults) bool { return r.Compilation != nil }},
+}
+
+func TestParserGoldenCorpus(t *testing.T) {
+	for _, tt := range parserCorpusSuites {
+		suite, err := LookupSuite(tt.suite)
+		if err != nil {
+			t.Fatalf("LookupSuite(%s): %v", tt.suite, err)
+		}
+
+		for _, input := range corpusFiles(t, tt.suite) {
+			name := strings.TrimSuffix(filepath.Base(input), ".txt")
+			t.Run(tt.suite+"/"+name, func(t *testing.T) {
+				raw, err := os.ReadFile(input)
+				if err != nil {
+					t.Fatalf("failed to read input: %v", err)
+				}
+
+				got := goldenParseResult(t, suite, string(raw))
+				goldenPath := strings.TrimSuffix(input, ".txt") + ".golden"
+
+				if *updateGolden {
+					if err := os.WriteFile(goldenPath, []byte(got), 0644); err != nil {
+						t.Fatalf("failed to write golden file: %v", err)
+					}
+					return
+				}
+
+				want, err := os.ReadFile(goldenPath)
+				if err != nil {
+					t.Fatalf("missing golden file (run with -update to create it): %v", err)
+				}
+				if got != string(want) {
+					t.Errorf("parse result does not match %s\ngot:\n%s\nwant:\n%s", goldenPath, got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestParserCorpusCoverage(t *testing.T) {
+	for _, tt := range parserCorpusSuites {
+		files := corpusFiles(t, tt.suite)
+		for _, prefix := range parserCorpusCategories {
+			covered := false
+			for _, file := range files {
+				if strings.HasPrefix(filepath.Base(file), prefix) {
+					covered = true
+					break
+				}
+			}
+			if !covered {
+				t.Errorf("corpus for %s has no %s* case", tt.suite, prefix)
+			}
+		}
+	}
+}
+
+// goldenParseResult renders a parse result in golden file form: the results
+// as indented JSON without the run-specific metadata, or the error message.
+func goldenParseResult(t *testing.T, suite BenchmarkSuite, output string) string {
+	results, err := suite.ParseOutput(output)
+	if err != nil {
+		return "error: " + err.Error() + "\n"
+	}
+
+	results.Metadata = nil
+	encoded, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to encode parse result: %v", err)
+	}
+	return string(encoded) + "\n"
+}
+
+func corpusFiles(t testing.TB, suite string) []string {
+	files, err := filepath.Glob(filepath.Join(parserCorpusDir, suite, "*.txt"))
+	if err != nil {
+		t.Fatalf("failed to list corpus for %s: %v", suite, err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("no corpus files for %s in %s", suite, parserCorpusDir)
+	}
+	return files
+}
+
+// fuzzParser seeds a fuzz target with the suite's corpus and checks that the
+// parser never panics and either fails or returns the suite's results.
+func fuzzParser(f *testing.F, name string, result func(*BenchmarkResults) bool) {
+	suite, err := LookupSuite(name)
+	if err != nil {
+		f.Fatalf("LookupSuite(%s): %v", name, err)
+	}
+
+	for _, input := range corpusFiles(f, name) {
+		raw, err := os.ReadFile(input)
+		if err != nil {
+			f.Fatalf("failed to read %s: %v", input, err)
+		}
+		f.Add(string(raw))
+	}
+
+	f.Fuzz(func(t *testing.T, output string) {
+		results, err := suite.ParseOutput(output)
+		if err != nil {
+			if results != nil {
+				t.Errorf("parser returned results along with error %v", err)
+			}
+			return
+		}
+		if results == nil || !result(results) {
+			t.Errorf("parser succeeded without %s results", name)
+		}
+	})
+}
+
+func fuzzCorpusSuite(f *testing.F, name string) {
+	for _, tt := range parserCorpusSuites {
+		if tt.suite == name {
+			fuzzParser(f, tt.suite, tt.result)
+			return
+		}
+	}
+	f.Fatalf("suite %s is not part of the parser corpus", name)
+}
+
+func FuzzParseSTREAMOutput(f *testing.F)         { fuzzCorpusSuite(f, "stream") }
+func FuzzParseDGEMMOutput(f *testing.F)          { fuzzCorpusSuite(f, "dgemm") }
+func FuzzParseFFTWOutput(f *testing.F)           { fuzzCorpusSuite(f, "fftw") }
+func FuzzParseCoreMarkOutput(f *testing.F)       { fuzzCorpusSuite(f, "coremark") }
+func FuzzParse7ZipOutput(f *testing.F)           { fuzzCorpusSuite(f, "7zip") }
+func FuzzParseSysbenchOutput(f *testing.F)       { fuzzCorpusSuite(f, "sysbench") }
+func FuzzParseCacheOutput(f *testing.F)          { fuzzCorpusSuite(f, "cache") }
+func FuzzParseMixedPrecisionOutput(f *testing.F) { fuzzCorpusSuite(f, "mixed_precision") }
+func FuzzParseCompilationOutput(f *testing.F)    { fuzzCorpusSuite(f, "compilation") }
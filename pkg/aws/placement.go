@@ -0,0 +1,73 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// Tenancy options for benchmark instances.
+const (
+	TenancyDefault   = "default"
+	TenancyDedicated = "dedicated"
+	TenancyHost      = "host"
+)
+
+// placementOptions returns the RunInstances placement and capacity
+// reservation settings for a config, or nil for those left unset.
+func placementOptions(config BenchmarkConfig) (*types.Placement, *types.CapacityReservationSpecification, error) {
+	switch config.Tenancy {
+	case "", TenancyDefault, TenancyDedicated, TenancyHost:
+	default:
+		return nil, nil, fmt.Errorf("unsupported tenancy %q (use %s, %s or %s)", config.Tenancy, TenancyDefault, TenancyDedicated, TenancyHost)
+	}
+	if config.HostID != "" && config.Tenancy != TenancyHost {
+		return nil, nil, fmt.Errorf("host ID %s requires %s tenancy", config.HostID, TenancyHost)
+	}
+	if config.CapacityReservationID != "" && config.PurchaseOption == PurchaseSpot {
+		return nil, nil, fmt.Errorf("capacity reservation %s cannot be used with %s instances", config.CapacityReservationID, PurchaseSpot)
+	}
+
+	var placement *types.Placement
+	if config.AvailabilityZone != "" || config.Tenancy != "" || config.HostID != "" || config.PlacementGroup != "" {
+		placement = &types.Placement{}
+		if config.AvailabilityZone != "" {
+			placement.AvailabilityZone = aws.String(config.AvailabilityZone)
+		}
+		if config.Tenancy != "" {
+			placement.Tenancy = types.Tenancy(config.Tenancy)
+		}
+		if config.HostID != "" {
+			placement.HostId = aws.String(config.HostID)
+		}
+		if config.PlacementGroup != "" {
+			placement.GroupName = aws.String(config.PlacementGroup)
+		}
+	}
+
+	var reservation *types.CapacityReservationSpecification
+	if config.CapacityReservationID != "" {
+		reservation = &types.CapacityReservationSpecification{
+			CapacityReservationTarget: &types.CapacityReservationTarget{
+				CapacityReservationId: aws.String(config.CapacityReservationID),
+			},
+		}
+	}
+
+	return placement, reservation, nil
+}
+
+// recordPlacement copies where EC2 actually placed an instance onto a result.
+func recordPlacement(result *InstanceResult, instance types.Instance) {
+	result.Tenancy = TenancyDefault
+	if instance.Placement != nil {
+		result.AvailabilityZone = aws.ToString(instance.Placement.AvailabilityZone)
+		result.HostID = aws.ToString(instance.Placement.HostId)
+		result.PlacementGroup = aws.ToString(instance.Placement.GroupName)
+		if instance.Placement.Tenancy != "" {
+			result.Tenancy = string(instance.Placement.Tenancy)
+		}
+	}
+	result.CapacityReservationID = aws.ToString(instance.CapacityReservationId)
+}
@@ -0,0 +1,146 @@
+package aws
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/fakecloud"
+)
+
+func newPlacementCloud() *fakecloud.Cloud {
+	cloud := fakecloud.New("us-east-1")
+	cloud.AddSubnet("subnet-12345678", "us-east-1b")
+	cloud.HandleCommands(func(instanceID, command string) fakecloud.CommandResult {
+		return fakecloud.CommandResult{Status: ssmtypes.CommandInvocationStatusSuccess, Stdout: fakeSTREAMOutput}
+	})
+	return cloud
+}
+
+func TestRunBenchmarkWithPlacement(t *testing.T) {
+	cloud := newPlacementCloud()
+	cloud.AddPlacementGroup("benchmarks-cluster")
+
+	config := fakeBenchmarkConfig("c7g.large", "stream")
+	config.AvailabilityZone = "us-east-1b"
+	config.Tenancy = TenancyHost
+	config.HostID = "h-0123456789abcdef0"
+	config.PlacementGroup = "benchmarks-cluster"
+
+	result, err := newFakeOrchestrator(cloud).RunBenchmark(context.Background(), config)
+	if err != nil {
+		t.Fatalf("RunBenchmark failed: %v", err)
+	}
+
+	if result.AvailabilityZone != "us-east-1b" || result.Tenancy != TenancyHost {
+		t.Errorf("Expected host tenancy in us-east-1b, got %q in %q", result.Tenancy, result.AvailabilityZone)
+	}
+	if result.HostID != config.HostID || result.PlacementGroup != config.PlacementGroup {
+		t.Errorf("Expected host %s in group %s, got host %q group %q", config.HostID, config.PlacementGroup, result.HostID, result.PlacementGroup)
+	}
+}
+
+func TestRunBenchmarkRecordsDefaultPlacement(t *testing.T) {
+	result, err := newFakeOrchestrator(newPlacementCloud()).RunBenchmark(context.Background(), fakeBenchmarkConfig("m7i.large", "stream"))
+	if err != nil {
+		t.Fatalf("RunBenchmark failed: %v", err)
+	}
+	if result.AvailabilityZone != "us-east-1b" || result.Tenancy != TenancyDefault {
+		t.Errorf("Expected default tenancy in the subnet's zone, got %q in %q", result.Tenancy, result.AvailabilityZone)
+	}
+}
+
+func TestRunBenchmarkWithCapacityReservation(t *testing.T) {
+	cloud := newPlacementCloud()
+	cloud.AddCapacityReservation("cr-0123456789abcdef0", "m7i.large", "us-east-1b", 1)
+
+	config := fakeBenchmarkConfig("m7i.large", "stream")
+	config.CapacityReservationID = "cr-0123456789abcdef0"
+	result, err := newFakeOrchestrator(cloud).RunBenchmark(context.Background(), config)
+	if err != nil {
+		t.Fatalf("RunBenchmark failed: %v", err)
+	}
+	if result.CapacityReservationID != config.CapacityReservationID {
+		t.Errorf("CapacityReservationID = %q, want %s", result.CapacityReservationID, config.CapacityReservationID)
+	}
+}
+
+func TestAsyncLaunchWithPlacement(t *testing.T) {
+	cloud := newPlacementCloud()
+	cloud.AddPlacementGroup("benchmarks-cluster")
+	cloud.AddCapacityReservation("cr-0123456789abcdef0", "m7i.large", "us-east-1b", 1)
+
+	config := fakeBenchmarkConfig("m7i.large", "stream")
+	config.AvailabilityZone = "us-east-1b"
+	config.PlacementGroup = "benchmarks-cluster"
+	config.CapacityReservationID = "cr-0123456789abcdef0"
+
+	launcher := NewAsyncLauncherWithStore(newFakeOrchestrator(cloud), NewMemoryObjectStore())
+	job, err := launcher.LaunchSingleBenchmark(context.Background(), config, "results", "placement", time.Hour)
+	if err != nil {
+		t.Fatalf("LaunchSingleBenchmark failed: %v", err)
+	}
+
+	instance, ok := cloud.Instance(job.InstanceID)
+	if !ok {
+		t.Fatalf("Instance %s not found", job.InstanceID)
+	}
+	if instance.AvailabilityZone != "us-east-1b" || instance.PlacementGroup != config.PlacementGroup {
+		t.Errorf("Expected group %s in us-east-1b, got group %q in %q", config.PlacementGroup, instance.PlacementGroup, instance.AvailabilityZone)
+	}
+	if instance.CapacityReservationID != config.CapacityReservationID {
+		t.Errorf("CapacityReservationID = %q, want %s", instance.CapacityReservationID, config.CapacityReservationID)
+	}
+}
+
+func TestPlacementOptionsValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*BenchmarkConfig)
+		wantErr string
+	}{
+		{
+			name:    "unknown tenancy",
+			modify:  func(c *BenchmarkConfig) { c.Tenancy = "shared" },
+			wantErr: "unsupported tenancy",
+		},
+		{
+			name:    "host ID without host tenancy",
+			modify:  func(c *BenchmarkConfig) { c.HostID = "h-0123456789abcdef0" },
+			wantErr: "requires host tenancy",
+		},
+		{
+			name: "capacity reservation on spot",
+			modify: func(c *BenchmarkConfig) {
+				c.PurchaseOption = PurchaseSpot
+				c.CapacityReservationID = "cr-0123456789abcdef0"
+			},
+			wantErr: "cannot be used with spot",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := fakeBenchmarkConfig("m7i.large", "stream")
+			tt.modify(&config)
+			if _, _, err := placementOptions(config); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAvailabilityZoneMustMatchSubnet(t *testing.T) {
+	cloud := newPlacementCloud()
+
+	config := fakeBenchmarkConfig("m7i.large", "stream")
+	config.AvailabilityZone = "us-east-1c"
+	if _, err := newFakeOrchestrator(cloud).RunBenchmark(context.Background(), config); err == nil {
+		t.Fatal("Expected launch into a subnet outside the pinned zone to fail")
+	}
+	if len(cloud.Instances()) != 0 {
+		t.Error("Expected no instance to be launched")
+	}
+}
@@ -0,0 +1,58 @@
+package aws
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Provenance ties a stored result to everything that produced it: the
+// orchestrator build, the container image and machine image the benchmark
+// ran on, and the raw output its measurements were parsed from. It is
+// stored under "provenance" in every result.
+type Provenance struct {
+	// OrchestratorVersion is the version of the binary that ran the benchmark.
+	OrchestratorVersion string `json:"orchestrator_version"`
+
+	// GitCommit is the commit the orchestrator was built from.
+	GitCommit string `json:"git_commit"`
+
+	// ContainerImage is the benchmark image reference, and
+	// ContainerImageDigest the digest it resolved to, if known.
+	ContainerImage       string `json:"container_image,omitempty"`
+	ContainerImageDigest string `json:"container_image_digest,omitempty"`
+
+	// AMIID is the AMI the instance was launched from.
+	AMIID string `json:"ami_id,omitempty"`
+
+	// RawOutputSHA256 is the RawOutputHash of the output the results were
+	// parsed from.
+	RawOutputSHA256 string `json:"raw_output_sha256,omitempty"`
+}
+
+// RawOutputHash returns the hex SHA-256 of the raw outputs of a
+// benchmark's iterations, in iteration order. Each output is prefixed with
+// its length, so moving bytes between iterations changes the hash.
+func RawOutputHash(outputs []string) string {
+	hash := sha256.New()
+	for _, output := range outputs {
+		fmt.Fprintf(hash, "%d\n", len(output))
+		io.WriteString(hash, output)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// ContainerImageDigest returns the digest of a container image: the digest
+// reported by the instance that ran it, or else the digest image is pinned
+// to, as in "repo@sha256:...". It returns "" if neither is known.
+func ContainerImageDigest(image, reported string) string {
+	if reported != "" {
+		return reported
+	}
+	if _, digest, ok := strings.Cut(image, "@"); ok {
+		return digest
+	}
+	return ""
+}
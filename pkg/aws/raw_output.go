@@ -0,0 +1,136 @@
+package aws
+
+import "fmt"
+
+// ParserVersion identifies the revision of the suite output parsers and is
+// stamped on every stored result. Bump it whenever a parser change can alter
+// the results parsed from the same output, so results parsed by an older
+// revision can be found and re-parsed from their archived raw output.
+const ParserVersion = "2"
+
+// minValidIterations is the fewest parsed iterations a result is
+// aggregated from.
+const minValidIterations = 3
+
+// IterationOutput is the raw output of one benchmark iteration, archived
+// with the result so it can be re-parsed without rerunning the benchmark.
+type IterationOutput struct {
+	// Iteration is the 1-based iteration number.
+	Iteration int `json:"iteration"`
+
+	Stdout string `json:"stdout"`
+	Stderr string `json:"stderr,omitempty"`
+
+	// CommandError records why the benchmark command failed. Output of
+	// failed commands is archived but never parsed.
+	CommandError string `json:"command_error,omitempty"`
+
+	// ParseError records why Stdout could not be parsed, and Parsed
+	// whether it was included in the aggregated results.
+	ParseError string `json:"parse_error,omitempty"`
+	Parsed     bool   `json:"parsed"`
+}
+
+// RawOutput is the archived raw output of every iteration of a benchmark,
+// stored next to its result through storage.ResultStore.ArchiveRawOutput.
+type RawOutput struct {
+	BenchmarkSuite string `json:"benchmark_suite"`
+
+	// ParserVersion is the ParserVersion that parsed the iterations.
+	ParserVersion string `json:"parser_version"`
+
+	// MinIterations is the fewest parsed iterations Reparse aggregates.
+	// Zero means the minimum of synchronous runs; async runs archive their
+	// single iteration with a minimum of one.
+	MinIterations int `json:"min_iterations,omitempty"`
+
+	Iterations []IterationOutput `json:"iterations"`
+}
+
+// NewRawOutput returns the raw output of a result parsed by the current
+// parsers.
+func NewRawOutput(benchmarkSuite string, iterations []IterationOutput) *RawOutput {
+	return &RawOutput{BenchmarkSuite: benchmarkSuite, ParserVersion: ParserVersion, Iterations: iterations}
+}
+
+// newAsyncRawOutput returns the raw output of an async benchmark, which
+// runs its command once on the instance. The iteration is marked parsed if
+// the current parsers can parse it.
+func newAsyncRawOutput(benchmarkSuite, stdout string) *RawOutput {
+	iteration := IterationOutput{Iteration: 1, Stdout: stdout}
+	if suite, err := LookupSuite(benchmarkSuite); err != nil {
+		iteration.ParseError = err.Error()
+	} else if _, err := suite.ParseOutput(stdout); err != nil {
+		iteration.ParseError = err.Error()
+	} else {
+		iteration.Parsed = true
+	}
+	raw := NewRawOutput(benchmarkSuite, []IterationOutput{iteration})
+	raw.MinIterations = 1
+	return raw
+}
+
+// Hash returns the RawOutputHash of the iterations that were parsed, which
+// matches the raw output hash in the provenance of their result.
+func (r *RawOutput) Hash() string {
+	return RawOutputHash(parsedOutputs(r.Iterations))
+}
+
+// Reparse parses the archived iterations again with the current parsers and
+// aggregates them. Iterations whose command failed are skipped; all others
+// are parsed, including those that failed to parse when collected. It
+// returns the aggregated results and a copy of the raw output recording the
+// new parse outcome of each iteration.
+//
+// Example:
+//   var raw aws.RawOutput
+//   if err := store.LoadRawOutput(ctx, key, &raw); err != nil {
+//       return err
+//   }
+//   benchmarkData, reparsed, err := raw.Reparse()
+func (r *RawOutput) Reparse() (*BenchmarkResults, *RawOutput, error) {
+	suite, err := LookupSuite(r.BenchmarkSuite)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reparsed := NewRawOutput(r.BenchmarkSuite, make([]IterationOutput, len(r.Iterations)))
+	reparsed.MinIterations = r.MinIterations
+	var parsed []*BenchmarkResults
+	for i, iteration := range r.Iterations {
+		iteration.Parsed, iteration.ParseError = false, ""
+		if iteration.CommandError == "" {
+			if result, err := suite.ParseOutput(iteration.Stdout); err != nil {
+				iteration.ParseError = err.Error()
+			} else {
+				iteration.Parsed = true
+				parsed = append(parsed, result)
+			}
+		}
+		reparsed.Iterations[i] = iteration
+	}
+
+	minimum := r.MinIterations
+	if minimum <= 0 {
+		minimum = minValidIterations
+	}
+	if len(parsed) < minimum {
+		return nil, reparsed, fmt.Errorf("insufficient valid iterations: got %d, need at least %d", len(parsed), minimum)
+	}
+	aggregated, err := suite.AggregateResults(parsed)
+	if err != nil {
+		return nil, reparsed, fmt.Errorf("aggregation failed: %w", err)
+	}
+	return aggregated, reparsed, nil
+}
+
+// parsedOutputs returns the stdout of the parsed iterations in order.
+func parsedOutputs(iterations []IterationOutput) []string {
+	var outputs []string
+	for _, iteration := range iterations {
+		if iteration.Parsed {
+			outputs = append(outputs, iteration.Stdout)
+		}
+	}
+	return outputs
+}
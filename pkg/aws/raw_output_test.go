@@ -0,0 +1,85 @@
+package aws
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/fakecloud"
+)
+
+func TestRunBenchmarkKeepsRawOutput(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	iterations := 0
+	cloud.HandleCommands(func(instanceID, command string) fakecloud.CommandResult {
+		if command == osInfoCommand {
+			return fakecloud.CommandResult{Status: ssmtypes.CommandInvocationStatusSuccess, Stdout: "kernel=6.1\n"}
+		}
+		iterations++
+		if iterations == 2 {
+			// Output the parser does not understand
+			return fakecloud.CommandResult{Status: ssmtypes.CommandInvocationStatusSuccess,
+				Stdout: "Triad bandwidth: 41.9 GB/s\n", Stderr: "stream: new output format\n"}
+		}
+		return fakecloud.CommandResult{Status: ssmtypes.CommandInvocationStatusSuccess,
+			Stdout: fakeSTREAMOutput, Stderr: "OMP_NUM_THREADS not set\n"}
+	})
+
+	result, err := newFakeOrchestrator(cloud).RunBenchmark(context.Background(), fakeBenchmarkConfig("c7g.large", "stream"))
+	if err != nil {
+		t.Fatalf("RunBenchmark failed: %v", err)
+	}
+
+	if len(result.RawOutputs) != 5 {
+		t.Fatalf("Expected the output of 5 iterations, got %d", len(result.RawOutputs))
+	}
+	for i, output := range result.RawOutputs {
+		if output.Iteration != i+1 || output.Stderr == "" || output.CommandError != "" {
+			t.Errorf("Unexpected output of iteration %d: %+v", i+1, output)
+		}
+		if parsed := i+1 != 2; output.Parsed != parsed || (output.ParseError == "") != parsed {
+			t.Errorf("Expected iteration %d parsed=%v, got %+v", i+1, parsed, output)
+		}
+	}
+	if result.RawOutputSHA256 != RawOutputHash([]string{fakeSTREAMOutput, fakeSTREAMOutput, fakeSTREAMOutput, fakeSTREAMOutput}) {
+		t.Errorf("Expected the hash of the 4 parsed outputs, got %q", result.RawOutputSHA256)
+	}
+
+	raw := NewRawOutput("stream", result.RawOutputs)
+	if raw.Hash() != result.RawOutputSHA256 || raw.ParserVersion != ParserVersion {
+		t.Errorf("Expected the archive to match the result, got %+v", raw)
+	}
+}
+
+func TestRawOutputReparse(t *testing.T) {
+	raw := NewRawOutput("stream", []IterationOutput{
+		{Iteration: 1, Stdout: fakeSTREAMOutput, Parsed: true},
+		{Iteration: 2, Stdout: fakeSTREAMOutput, ParseError: "parser bug"},
+		{Iteration: 3, Stdout: "partial", CommandError: "SSM command failed with status TimedOut"},
+		{Iteration: 4, Stdout: fakeSTREAMOutput, Parsed: true},
+	})
+	raw.ParserVersion = "1"
+
+	benchmarkData, reparsed, err := raw.Reparse()
+	if err != nil {
+		t.Fatalf("Reparse failed: %v", err)
+	}
+	if benchmarkData.STREAM == nil || benchmarkData.Metadata == nil || benchmarkData.Metadata.Iterations != 3 {
+		t.Errorf("Expected STREAM results aggregated from 3 iterations, got %+v", benchmarkData)
+	}
+	if reparsed.ParserVersion != ParserVersion || raw.ParserVersion != "1" {
+		t.Errorf("Expected only the copy to be stamped with %s, got %s and %s", ParserVersion, reparsed.ParserVersion, raw.ParserVersion)
+	}
+	if !reparsed.Iterations[1].Parsed || reparsed.Iterations[1].ParseError != "" || reparsed.Iterations[2].Parsed {
+		t.Errorf("Unexpected reparsed iterations %+v", reparsed.Iterations)
+	}
+	if reparsed.Hash() == raw.Hash() {
+		t.Error("Expected the newly parsed iteration to change the raw output hash")
+	}
+
+	raw.Iterations = raw.Iterations[:2]
+	if _, _, err := raw.Reparse(); err == nil || !strings.Contains(err.Error(), "insufficient valid iterations") {
+		t.Errorf("Expected too few iterations to fail, got %v", err)
+	}
+}
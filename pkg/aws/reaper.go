@@ -0,0 +1,329 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// Tags identifying instances launched by this project.
+const (
+	// TagPurpose is set to PurposeBenchmarks by Orchestrator launches.
+	TagPurpose = "Purpose"
+
+	// PurposeBenchmarks is the TagPurpose value of orchestrated instances.
+	PurposeBenchmarks = "aws-instance-benchmarks"
+
+	// TagLaunchedBy is set to LaunchedByAsync by AsyncLauncher launches.
+	TagLaunchedBy = "LaunchedBy"
+
+	// LaunchedByAsync is the TagLaunchedBy value of async benchmark instances.
+	LaunchedByAsync = "AsyncBenchmarkLauncher"
+
+	// TagBenchmarkJobID carries the run journal job ID of orchestrated instances.
+	TagBenchmarkJobID = "BenchmarkJobID"
+
+	// TagBenchmarkID carries the async job ID of async benchmark instances.
+	TagBenchmarkID = "BenchmarkID"
+)
+
+// Instance owners reported by the reaper.
+const (
+	OwnerOrchestrator = "orchestrator"
+	OwnerAsync        = "async"
+)
+
+// ReaperConfig controls which project instances ReapOrphanedInstances
+// terminates.
+type ReaperConfig struct {
+	// OlderThan protects instances launched more recently than this.
+	OlderThan time.Duration
+
+	// IncludeActive also reaps instances whose journal or async job is
+	// still in progress, e.g. runs that will never be resumed.
+	IncludeActive bool
+
+	// DryRun reports what would be terminated without terminating anything.
+	DryRun bool
+
+	// Journals are the run journals used to match orchestrated instances to
+	// their jobs. Terminations are recorded in the owning journal.
+	Journals []*Journal
+
+	// AsyncJobs are the async job records, with Status set from their
+	// sentinels, used to match async instances to their jobs.
+	AsyncJobs []*AsyncBenchmarkJob
+
+	// HourlyPrice returns the on-demand price of an instance type for cost
+	// reporting. Nil or a false result leaves the cost unknown.
+	HourlyPrice func(instanceType string) (float64, bool)
+
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// ReapedInstance describes one project instance found by the reaper.
+type ReapedInstance struct {
+	InstanceID   string
+	InstanceType string
+	State        string
+	Owner        string
+	JobID        string
+	LaunchTime   time.Time
+	Age          time.Duration
+
+	// HourlyPrice and AccruedCost are zero when the price is unknown.
+	// AccruedCost is the compute cost since launch; it is also zero for
+	// stopped instances, which accrue no compute charges and whose
+	// running time EC2 does not report.
+	HourlyPrice float64
+	AccruedCost float64
+
+	// Active is true when the owning job is still in progress.
+	Active bool
+
+	// Reap is true when the instance is (or in dry-run mode would be)
+	// terminated; Reason explains the decision either way.
+	Reap   bool
+	Reason string
+
+	// Terminated is true once termination has been requested.
+	Terminated bool
+	Error      error
+}
+
+// instanceStopped reports whether an instance is stopping or stopped.
+func instanceStopped(instance types.Instance) bool {
+	if instance.State == nil {
+		return false
+	}
+	return instance.State.Name == types.InstanceStateNameStopping || instance.State.Name == types.InstanceStateNameStopped
+}
+
+// ReapReport summarizes a reaper pass.
+type ReapReport struct {
+	// Instances lists every live project instance, oldest first.
+	Instances []ReapedInstance
+
+	// Reaped counts instances terminated (or selected, in dry-run mode).
+	Reaped int
+
+	// ReapedCost is the accrued cost of the reaped instances.
+	ReapedCost float64
+
+	// TotalCost is the accrued cost of all listed instances.
+	TotalCost float64
+
+	DryRun bool
+}
+
+// journalOwner links an instance to the journal job that launched it.
+type journalOwner struct {
+	journal *Journal
+	job     *JobState
+}
+
+// ReapOrphanedInstances finds instances launched by this project that have
+// outlived their benchmark and terminates them.
+//
+// Instances are discovered by their project tags rather than by local state,
+// so instances left behind by crashed or killed CLI processes are found even
+// when no journal survived. Each instance is matched against the run journals
+// and async job metadata in config:
+//   - Jobs that completed or failed no longer need their instance
+//   - Jobs still in progress are kept unless IncludeActive is set
+//   - Instances with no matching record are treated as orphans
+// Instances younger than OlderThan are always kept.
+//
+// Parameters:
+//   - ctx: Context for cancellation of EC2 calls
+//   - config: Age threshold, dry-run mode and job records to match against
+//
+// Returns:
+//   - *ReapReport: Every live project instance with its cost and decision
+//   - error: Instance discovery failures; termination failures are reported per instance
+//
+// Example:
+//   report, err := orchestrator.ReapOrphanedInstances(ctx, aws.ReaperConfig{
+//       OlderThan: 2 * time.Hour,
+//       Journals:  journals,
+//       DryRun:    true,
+//   })
+//   fmt.Printf("%d orphaned instances, $%.2f accrued\n", report.Reaped, report.ReapedCost)
+func (o *Orchestrator) ReapOrphanedInstances(ctx context.Context, config ReaperConfig) (*ReapReport, error) {
+	now := time.Now
+	if config.Now != nil {
+		now = config.Now
+	}
+
+	instances, err := o.listProjectInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	journaled := make(map[string]journalOwner)
+	for _, journal := range config.Journals {
+		for _, job := range journal.JobStates() {
+			for _, instanceID := range job.Instances {
+				journaled[instanceID] = journalOwner{journal: journal, job: job}
+			}
+		}
+	}
+	asyncJobs := make(map[string]*AsyncBenchmarkJob)
+	for _, job := range config.AsyncJobs {
+		if job.InstanceID != "" {
+			asyncJobs[job.InstanceID] = job
+		}
+		if job.BenchmarkID != "" {
+			asyncJobs[job.BenchmarkID] = job
+		}
+	}
+
+	report := &ReapReport{DryRun: config.DryRun}
+	for _, instance := range instances {
+		reaped := ReapedInstance{
+			InstanceID:   aws.ToString(instance.InstanceId),
+			InstanceType: string(instance.InstanceType),
+			LaunchTime:   aws.ToTime(instance.LaunchTime),
+			JobID:        instanceTag(instance, TagBenchmarkJobID),
+			Owner:        OwnerOrchestrator,
+		}
+		if instance.State != nil {
+			reaped.State = string(instance.State.Name)
+		}
+		if !reaped.LaunchTime.IsZero() {
+			reaped.Age = now().Sub(reaped.LaunchTime)
+		}
+		if config.HourlyPrice != nil {
+			if price, ok := config.HourlyPrice(reaped.InstanceType); ok {
+				reaped.HourlyPrice = price
+				if !instanceStopped(instance) {
+					reaped.AccruedCost = price * reaped.Age.Hours()
+				}
+			}
+		}
+
+		var owner journalOwner
+		if instanceTag(instance, TagLaunchedBy) == LaunchedByAsync {
+			reaped.Owner = OwnerAsync
+			reaped.JobID = instanceTag(instance, TagBenchmarkID)
+			reaped.Active, reaped.Reason = asyncInstanceStatus(reaped, asyncJobs)
+		} else {
+			owner = journaled[reaped.InstanceID]
+			reaped.Active, reaped.Reason = journalInstanceStatus(owner.job)
+			if owner.job != nil {
+				reaped.JobID = owner.job.ID
+			}
+		}
+
+		switch {
+		case reaped.Age < config.OlderThan:
+			reaped.Reason = fmt.Sprintf("younger than %v (%s)", config.OlderThan, reaped.Reason)
+		case reaped.Active && !config.IncludeActive:
+		default:
+			reaped.Reap = true
+		}
+
+		if reaped.Reap && !config.DryRun {
+			if err := o.terminateInstance(ctx, reaped.InstanceID); err != nil {
+				reaped.Error = fmt.Errorf("failed to terminate instance: %w", err)
+			} else {
+				reaped.Terminated = true
+				if owner.journal != nil {
+					if err := owner.journal.Record(JournalEntry{Event: JournalInstanceTerminated, JobID: reaped.JobID, InstanceID: reaped.InstanceID}); err != nil {
+						fmt.Printf("   ⚠️  Failed to update run journal %s: %v\n", owner.journal.Path(), err)
+					}
+				}
+			}
+		}
+
+		report.TotalCost += reaped.AccruedCost
+		if reaped.Reap && reaped.Error == nil {
+			report.Reaped++
+			report.ReapedCost += reaped.AccruedCost
+		}
+		report.Instances = append(report.Instances, reaped)
+	}
+
+	sort.SliceStable(report.Instances, func(i, j int) bool {
+		return report.Instances[i].LaunchTime.Before(report.Instances[j].LaunchTime)
+	})
+	return report, nil
+}
+
+// listProjectInstances returns live instances carrying the project's tags.
+func (o *Orchestrator) listProjectInstances(ctx context.Context) ([]types.Instance, error) {
+	input := &ec2.DescribeInstancesInput{
+		Filters: []types.Filter{
+			{Name: aws.String("tag:AutoTerminate"), Values: []string{"true"}},
+			{Name: aws.String("instance-state-name"), Values: []string{"pending", "running", "stopping", "stopped"}},
+		},
+	}
+
+	var instances []types.Instance
+	for {
+		resp, err := o.ec2Client.DescribeInstances(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list benchmark instances: %w", err)
+		}
+		for _, reservation := range resp.Reservations {
+			for _, instance := range reservation.Instances {
+				if instanceTag(instance, TagPurpose) == PurposeBenchmarks || instanceTag(instance, TagLaunchedBy) == LaunchedByAsync {
+					instances = append(instances, instance)
+				}
+			}
+		}
+		if aws.ToString(resp.NextToken) == "" {
+			return instances, nil
+		}
+		input.NextToken = resp.NextToken
+	}
+}
+
+// journalInstanceStatus decides whether an orchestrated instance's job is
+// still in progress.
+func journalInstanceStatus(job *JobState) (bool, string) {
+	switch {
+	case job == nil:
+		return false, "not in any run journal"
+	case job.Done():
+		return false, fmt.Sprintf("journal job %s %s", job.ID, job.Status)
+	case job.Status == JournalStatusFailed:
+		return false, fmt.Sprintf("journal job %s failed", job.ID)
+	default:
+		return true, fmt.Sprintf("journal job %s in progress", job.ID)
+	}
+}
+
+// asyncInstanceStatus decides whether an async instance's job is still in
+// progress.
+func asyncInstanceStatus(instance ReapedInstance, jobs map[string]*AsyncBenchmarkJob) (bool, string) {
+	job, ok := jobs[instance.InstanceID]
+	if !ok {
+		job, ok = jobs[instance.JobID]
+	}
+	if !ok {
+		return false, "no async job metadata"
+	}
+
+	switch job.Status {
+	case JobStatusLaunched, JobStatusRunning:
+		return true, fmt.Sprintf("async job %s %s", job.BenchmarkID, job.Status)
+	default:
+		return false, fmt.Sprintf("async job %s %s", job.BenchmarkID, job.Status)
+	}
+}
+
+func instanceTag(instance types.Instance, key string) string {
+	for _, tag := range instance.Tags {
+		if aws.ToString(tag.Key) == key {
+			return aws.ToString(tag.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,183 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/fakecloud"
+)
+
+var reaperNow = time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+
+func addTaggedInstance(cloud *fakecloud.Cloud, instanceType string, age time.Duration, tags map[string]string) string {
+	tags["AutoTerminate"] = "true"
+	return cloud.AddInstance(fakecloud.Instance{InstanceType: instanceType, LaunchTime: reaperNow.Add(-age), Tags: tags})
+}
+
+func reaperTestConfig() ReaperConfig {
+	return ReaperConfig{
+		OlderThan: time.Hour,
+		Now:       func() time.Time { return reaperNow },
+		HourlyPrice: func(instanceType string) (float64, bool) {
+			return 0.10, instanceType == "m7i.large"
+		},
+	}
+}
+
+func TestReapOrphanedInstances(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	orchestrated := map[string]string{TagPurpose: PurposeBenchmarks}
+
+	finished := fakeBenchmarkConfig("m7i.large", "stream")
+	inFlight := fakeBenchmarkConfig("c7g.large", "stream")
+	journal := newTestJournal(t, finished, inFlight)
+
+	unknown := addTaggedInstance(cloud, "m7i.large", 5*time.Hour, orchestrated)
+	young := addTaggedInstance(cloud, "m7i.large", 10*time.Minute, orchestrated)
+	done := addTaggedInstance(cloud, "m7i.large", 3*time.Hour, orchestrated)
+	active := addTaggedInstance(cloud, "c7g.large", 3*time.Hour, orchestrated)
+	asyncDone := addTaggedInstance(cloud, "c7g.large", 4*time.Hour, map[string]string{TagLaunchedBy: LaunchedByAsync, TagBenchmarkID: "bench-1"})
+	asyncRunning := addTaggedInstance(cloud, "c7g.large", 4*time.Hour, map[string]string{TagLaunchedBy: LaunchedByAsync, TagBenchmarkID: "bench-2"})
+	unrelated := cloud.AddInstance(fakecloud.Instance{InstanceType: "m7i.large", LaunchTime: reaperNow.Add(-10 * time.Hour)})
+
+	for _, entry := range []JournalEntry{
+		{Event: JournalInstanceLaunched, JobID: journalJobID(finished), InstanceID: done},
+		{Event: JournalJobCompleted, JobID: journalJobID(finished), InstanceID: done},
+		{Event: JournalInstanceLaunched, JobID: journalJobID(inFlight), InstanceID: active},
+	} {
+		if err := journal.Record(entry); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	config := reaperTestConfig()
+	config.Journals = []*Journal{journal}
+	config.AsyncJobs = []*AsyncBenchmarkJob{
+		{BenchmarkID: "bench-1", InstanceID: asyncDone, Status: JobStatusCompleted},
+		{BenchmarkID: "bench-2", InstanceID: asyncRunning, Status: JobStatusRunning},
+	}
+
+	report, err := newFakeOrchestrator(cloud).ReapOrphanedInstances(context.Background(), config)
+	if err != nil {
+		t.Fatalf("ReapOrphanedInstances failed: %v", err)
+	}
+
+	if len(report.Instances) != 6 {
+		t.Fatalf("expected 6 project instances, got %d", len(report.Instances))
+	}
+	if report.Instances[0].InstanceID != unknown {
+		t.Errorf("expected instances sorted oldest first, got %s first", report.Instances[0].InstanceID)
+	}
+
+	wantReaped := map[string]bool{unknown: true, done: true, asyncDone: true}
+	for _, instance := range report.Instances {
+		if instance.InstanceID == unrelated {
+			t.Errorf("untagged instance %s was reported", unrelated)
+		}
+		if instance.Reap != wantReaped[instance.InstanceID] || instance.Terminated != wantReaped[instance.InstanceID] {
+			t.Errorf("instance %s: reap=%v terminated=%v (%s)", instance.InstanceID, instance.Reap, instance.Terminated, instance.Reason)
+		}
+	}
+
+	for _, id := range []string{unknown, done, asyncDone} {
+		if !isTerminated(cloud, id) {
+			t.Errorf("expected %s to be terminated", id)
+		}
+	}
+	for _, id := range []string{young, active, asyncRunning, unrelated} {
+		if isTerminated(cloud, id) {
+			t.Errorf("expected %s to be kept", id)
+		}
+	}
+
+	if report.Reaped != 3 {
+		t.Errorf("expected 3 reaped instances, got %d", report.Reaped)
+	}
+	// Only m7i.large has a price: the 5h and 3h instances at $0.10/h
+	if report.ReapedCost < 0.799 || report.ReapedCost > 0.801 {
+		t.Errorf("expected reaped cost $0.80, got %f", report.ReapedCost)
+	}
+	if report.TotalCost < report.ReapedCost {
+		t.Errorf("total cost %f below reaped cost %f", report.TotalCost, report.ReapedCost)
+	}
+
+	if live := journal.JobStates()[0].LiveInstances; len(live) != 0 {
+		t.Errorf("expected reaped instance to be recorded as terminated in the journal, got live %v", live)
+	}
+}
+
+func TestReapOrphanedInstancesDryRunAndIncludeActive(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	inFlight := fakeBenchmarkConfig("m7i.large", "stream")
+	journal := newTestJournal(t, inFlight)
+
+	active := addTaggedInstance(cloud, "m7i.large", 3*time.Hour, map[string]string{TagPurpose: PurposeBenchmarks})
+	if err := journal.Record(JournalEntry{Event: JournalInstanceLaunched, JobID: journalJobID(inFlight), InstanceID: active}); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	config := reaperTestConfig()
+	config.Journals = []*Journal{journal}
+	config.IncludeActive = true
+	config.DryRun = true
+
+	report, err := newFakeOrchestrator(cloud).ReapOrphanedInstances(context.Background(), config)
+	if err != nil {
+		t.Fatalf("ReapOrphanedInstances failed: %v", err)
+	}
+	if report.Reaped != 1 || !report.Instances[0].Reap || !report.Instances[0].Active {
+		t.Errorf("expected active instance to be selected, got %+v", report.Instances)
+	}
+	if report.Instances[0].Terminated {
+		t.Error("dry run reported a termination")
+	}
+	if inst, _ := cloud.Instance(active); inst.State != types.InstanceStateNameRunning {
+		t.Errorf("dry run changed instance state to %s", inst.State)
+	}
+}
+
+func TestLaunchedInstancesCarryReaperTags(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	streamCommands(cloud)
+
+	config := fakeBenchmarkConfig("m7i.large", "stream")
+	config.JobID = "m7i.large/stream/2"
+	if _, err := newFakeOrchestrator(cloud).RunBenchmark(context.Background(), config); err != nil {
+		t.Fatalf("RunBenchmark failed: %v", err)
+	}
+
+	tags := cloud.Instances()[0].Tags
+	if tags[TagPurpose] != PurposeBenchmarks || tags[TagBenchmarkJobID] != config.JobID || tags["AutoTerminate"] != "true" {
+		t.Errorf("launched instance is missing reaper tags: %v", tags)
+	}
+}
+
+func TestReapOrphanedInstancesStoppedCost(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	stopped := cloud.AddInstance(fakecloud.Instance{InstanceType: "m7i.large", LaunchTime: reaperNow.Add(-5 * time.Hour),
+		State: types.InstanceStateNameStopped, Tags: map[string]string{"AutoTerminate": "true", TagPurpose: PurposeBenchmarks}})
+	running := addTaggedInstance(cloud, "m7i.large", 2*time.Hour, map[string]string{TagPurpose: PurposeBenchmarks})
+
+	config := reaperTestConfig()
+	config.DryRun = true
+	report, err := newFakeOrchestrator(cloud).ReapOrphanedInstances(context.Background(), config)
+	if err != nil {
+		t.Fatalf("ReapOrphanedInstances failed: %v", err)
+	}
+
+	costs := make(map[string]float64)
+	for _, instance := range report.Instances {
+		costs[instance.InstanceID] = instance.AccruedCost
+		if instance.HourlyPrice != 0.10 {
+			t.Errorf("expected the hourly price of %s, got %f", instance.InstanceID, instance.HourlyPrice)
+		}
+	}
+	if len(costs) != 2 || costs[stopped] != 0 {
+		t.Errorf("expected no compute cost for the stopped instance, got %v", costs)
+	}
+	if costs[running] < 0.199 || costs[running] > 0.201 {
+		t.Errorf("expected $0.20 for the running instance, got %f", costs[running])
+	}
+}
@@ -0,0 +1,658 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// BenchmarkResults holds the typed results of a benchmark run.
+//
+// Results for each built-in suite are stored in a dedicated struct and
+// serialized under the suite name, so the JSON layout is identical to the
+// untyped maps written by earlier versions:
+//
+//   {"stream": {"triad": {"bandwidth": 41.9, "unit": "GB/s"}, ...},
+//    "metadata": {"timestamp": "...", "iterations": 5}}
+//
+// The same type is used for the output of a single iteration and for the
+// aggregated result of a run. Results produced by suites registered outside
+// this package, and any keys this version does not understand, are kept in
+// Extra so that they survive a decode/encode round trip.
+//
+// Metric values of zero are treated as "not measured": parsers only record
+// values they found and aggregators skip zero values when computing
+// statistics.
+type BenchmarkResults struct {
+	STREAM         *STREAMResult         `json:"stream,omitempty"`
+	HPL            *HPLResult            `json:"hpl,omitempty"`
+	DGEMM          *DGEMMResult          `json:"dgemm,omitempty"`
+	FFTW           *FFTWResult           `json:"fftw,omitempty"`
+	VectorOps      *VectorOpsResult      `json:"vector_ops,omitempty"`
+	MixedPrecision *MixedPrecisionResult `json:"mixed_precision,omitempty"`
+	Compilation    *CompilationResult    `json:"compilation,omitempty"`
+	CoreMark       *CoreMarkResult       `json:"coremark,omitempty"`
+	SevenZip       *SevenZipResult       `json:"7zip,omitempty"`
+	Sysbench       *SysbenchResult       `json:"sysbench,omitempty"`
+	Cache          *CacheResult          `json:"cache,omitempty"`
+
+	// Metadata describes when and how the results were produced.
+	Metadata *ResultMetadata `json:"metadata,omitempty"`
+
+	// Extra holds results without a typed representation, keyed by their
+	// top-level JSON key (normally the suite name).
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// ResultMetadata records provenance for a set of benchmark results.
+type ResultMetadata struct {
+	Timestamp             string   `json:"timestamp,omitempty"`
+	Iterations            int      `json:"iterations,omitempty"`
+	StatisticalConfidence string   `json:"statistical_confidence,omitempty"`
+	BenchmarkType         string   `json:"benchmark_type,omitempty"`
+	MatrixSizes           []string `json:"matrix_sizes,omitempty"`
+	FFTTypes              []string `json:"fft_types,omitempty"`
+	Operations            []string `json:"operations,omitempty"`
+
+	// InstanceType and Region appear in results files written before
+	// the metadata was moved to the top-level result document.
+	InstanceType string `json:"instanceType,omitempty"`
+	Region       string `json:"region,omitempty"`
+}
+
+// STREAMResult contains memory bandwidth for the four STREAM kernels.
+type STREAMResult struct {
+	Copy  *StreamOperation `json:"copy,omitempty"`
+	Scale *StreamOperation `json:"scale,omitempty"`
+	Add   *StreamOperation `json:"add,omitempty"`
+	Triad *StreamOperation `json:"triad,omitempty"`
+}
+
+// StreamOperation is the measured bandwidth of a single STREAM kernel.
+type StreamOperation struct {
+	Bandwidth float64 `json:"bandwidth"`
+	StdDev    float64 `json:"std_dev,omitempty"`
+	Unit      string  `json:"unit,omitempty"`
+}
+
+// Operations returns the kernels that were measured, keyed by kernel name.
+func (r *STREAMResult) Operations() map[string]*StreamOperation {
+	operations := make(map[string]*StreamOperation)
+	if r == nil {
+		return operations
+	}
+	for name, op := range map[string]*StreamOperation{"copy": r.Copy, "scale": r.Scale, "add": r.Add, "triad": r.Triad} {
+		if op != nil {
+			operations[name] = op
+		}
+	}
+	return operations
+}
+
+// Bandwidth returns the bandwidth in GB/s of the named kernel, or 0 if the
+// kernel was not measured.
+func (r *STREAMResult) Bandwidth(operation string) float64 {
+	if op, ok := r.Operations()[operation]; ok {
+		return op.Bandwidth
+	}
+	return 0
+}
+
+// HPLResult contains High Performance LINPACK results.
+type HPLResult struct {
+	GFLOPS        float64 `json:"gflops,omitempty"`
+	GFLOPSStdDev  float64 `json:"gflops_std_dev,omitempty"`
+	ExecutionTime float64 `json:"execution_time,omitempty"`
+	TimeStdDev    float64 `json:"time_std_dev,omitempty"`
+	Efficiency    float64 `json:"efficiency,omitempty"`
+	Residual      float64 `json:"residual,omitempty"`
+	MatrixSize    int     `json:"matrix_size,omitempty"`
+	Unit          string  `json:"unit,omitempty"`
+	TimeUnit      string  `json:"time_unit,omitempty"`
+}
+
+// DGEMMResult contains dense matrix multiplication results across matrix sizes.
+type DGEMMResult struct {
+	SmallMatrixGFLOPS     float64  `json:"small_matrix_gflops,omitempty"`
+	SmallMatrixStdDev     float64  `json:"small_matrix_std_dev,omitempty"`
+	MediumMatrixGFLOPS    float64  `json:"medium_matrix_gflops,omitempty"`
+	MediumMatrixStdDev    float64  `json:"medium_matrix_std_dev,omitempty"`
+	LargeMatrixGFLOPS     float64  `json:"large_matrix_gflops,omitempty"`
+	LargeMatrixStdDev     float64  `json:"large_matrix_std_dev,omitempty"`
+	PeakGFLOPS            float64  `json:"peak_gflops,omitempty"`
+	PeakStdDev            float64  `json:"peak_std_dev,omitempty"`
+	MemoryBoundEfficiency float64  `json:"memory_bound_efficiency,omitempty"`
+	MemoryEffStdDev       float64  `json:"memory_eff_std_dev,omitempty"`
+	CacheEfficiency       float64  `json:"cache_efficiency,omitempty"`
+	CacheEffStdDev        float64  `json:"cache_eff_std_dev,omitempty"`
+	MatrixSizesTested     []string `json:"matrix_sizes_tested,omitempty"`
+	Unit                  string   `json:"unit,omitempty"`
+	BenchmarkType         string   `json:"benchmark_type,omitempty"`
+}
+
+// FFTWResult contains Fast Fourier Transform throughput results.
+type FFTWResult struct {
+	FFT1DSmallGFLOPS         float64 `json:"fft_1d_small_gflops,omitempty"`
+	FFT1DSmallStdDev         float64 `json:"fft_1d_small_std_dev,omitempty"`
+	FFT1DMediumGFLOPS        float64 `json:"fft_1d_medium_gflops,omitempty"`
+	FFT1DMediumStdDev        float64 `json:"fft_1d_medium_std_dev,omitempty"`
+	FFT1DLargeGFLOPS         float64 `json:"fft_1d_large_gflops,omitempty"`
+	FFT1DLargeStdDev         float64 `json:"fft_1d_large_std_dev,omitempty"`
+	FFT2DGFLOPS              float64 `json:"fft_2d_gflops,omitempty"`
+	FFT2DStdDev              float64 `json:"fft_2d_std_dev,omitempty"`
+	FFT3DGFLOPS              float64 `json:"fft_3d_gflops,omitempty"`
+	FFT3DStdDev              float64 `json:"fft_3d_std_dev,omitempty"`
+	OverallGFLOPS            float64 `json:"overall_gflops,omitempty"`
+	OverallStdDev            float64 `json:"overall_std_dev,omitempty"`
+	Peak1DGFLOPS             float64 `json:"peak_1d_gflops,omitempty"`
+	Peak1DStdDev             float64 `json:"peak_1d_std_dev,omitempty"`
+	MemoryScalingEfficiency  float64 `json:"memory_scaling_efficiency,omitempty"`
+	MemoryScalingStdDev      float64 `json:"memory_scaling_std_dev,omitempty"`
+	DimensionalityEfficiency float64 `json:"dimensionality_efficiency,omitempty"`
+	DimensionalityStdDev     float64 `json:"dimensionality_std_dev,omitempty"`
+	Unit                     string  `json:"unit,omitempty"`
+	BenchmarkType            string  `json:"benchmark_type,omitempty"`
+}
+
+// VectorOpsResult contains BLAS Level 1 vector operation results.
+type VectorOpsResult struct {
+	AvgAXPYGFLOPS    float64 `json:"avg_axpy_gflops,omitempty"`
+	AXPYStdDev       float64 `json:"axpy_std_dev,omitempty"`
+	AvgDotGFLOPS     float64 `json:"avg_dot_gflops,omitempty"`
+	DotStdDev        float64 `json:"dot_std_dev,omitempty"`
+	AvgNormGFLOPS    float64 `json:"avg_norm_gflops,omitempty"`
+	NormStdDev       float64 `json:"norm_std_dev,omitempty"`
+	OverallAvgGFLOPS float64 `json:"overall_avg_gflops,omitempty"`
+	OverallStdDev    float64 `json:"overall_std_dev,omitempty"`
+	Unit             string  `json:"unit,omitempty"`
+	BenchmarkType    string  `json:"benchmark_type,omitempty"`
+}
+
+// MixedPrecisionResult contains FP16/FP32/FP64 throughput results.
+//
+// A single iteration populates the Peak* fields, efficiency ratios and the
+// overall score; aggregation populates the Avg*/Std* statistics and the
+// precision summary.
+type MixedPrecisionResult struct {
+	// Per-iteration measurements
+	PeakFP16GFLOPS             float64 `json:"peak_fp16_gflops,omitempty"`
+	PeakFP32GFLOPS             float64 `json:"peak_fp32_gflops,omitempty"`
+	PeakFP64GFLOPS             float64 `json:"peak_fp64_gflops,omitempty"`
+	FP16FP32Efficiency         float64 `json:"fp16_fp32_efficiency,omitempty"`
+	FP32FP64Efficiency         float64 `json:"fp32_fp64_efficiency,omitempty"`
+	OverallMixedPrecisionScore float64 `json:"overall_mixed_precision_score,omitempty"`
+
+	// Aggregated statistics
+	Timestamp             string                     `json:"timestamp,omitempty"`
+	Iterations            int                        `json:"iterations,omitempty"`
+	AvgFP16GFLOPS         float64                    `json:"avg_fp16_gflops,omitempty"`
+	StdFP16GFLOPS         float64                    `json:"std_fp16_gflops,omitempty"`
+	AvgFP32GFLOPS         float64                    `json:"avg_fp32_gflops,omitempty"`
+	StdFP32GFLOPS         float64                    `json:"std_fp32_gflops,omitempty"`
+	AvgFP64GFLOPS         float64                    `json:"avg_fp64_gflops,omitempty"`
+	StdFP64GFLOPS         float64                    `json:"std_fp64_gflops,omitempty"`
+	AvgOverallScore       float64                    `json:"avg_overall_score,omitempty"`
+	StdOverallScore       float64                    `json:"std_overall_score,omitempty"`
+	AvgFP16FP32Efficiency float64                    `json:"avg_fp16_fp32_efficiency,omitempty"`
+	AvgFP32FP64Efficiency float64                    `json:"avg_fp32_fp64_efficiency,omitempty"`
+	Summary               *PrecisionPerformanceSummary `json:"precision_performance_summary,omitempty"`
+}
+
+// PrecisionPerformanceSummary compares throughput between precisions.
+type PrecisionPerformanceSummary struct {
+	BestPrecision     string  `json:"best_precision"`
+	FP16VsFP32Speedup float64 `json:"fp16_vs_fp32_speedup"`
+	FP32VsFP64Speedup float64 `json:"fp32_vs_fp64_speedup"`
+}
+
+// CompilationResult contains software build performance results.
+//
+// A single iteration populates the raw timing and utilization fields;
+// aggregation populates the Avg*/Std*/Min*/Max* statistics and the
+// performance summary.
+type CompilationResult struct {
+	// Per-iteration measurements
+	SingleThreadedTimeSeconds   float64 `json:"single_threaded_time_seconds,omitempty"`
+	MultiThreadedTimeSeconds    float64 `json:"multi_threaded_time_seconds,omitempty"`
+	IncrementalBuildTimeSeconds float64 `json:"incremental_build_time_seconds,omitempty"`
+	ParallelSpeedup             float64 `json:"parallel_speedup,omitempty"`
+	ParallelEfficiencyPercent   float64 `json:"parallel_efficiency_percent,omitempty"`
+	CompilationThroughput       float64 `json:"compilation_throughput,omitempty"`
+	SingleCorePerformanceUnits  float64 `json:"single_core_performance_units,omitempty"`
+	MulticoreScalingEfficiency  float64 `json:"multicore_scaling_efficiency,omitempty"`
+	MemoryPressurePercent       float64 `json:"memory_pressure_percent,omitempty"`
+	OverallCompilationScore     float64 `json:"overall_compilation_score,omitempty"`
+
+	// AvgCPUUtilizationPercent is reported per iteration and averaged on aggregation.
+	AvgCPUUtilizationPercent float64 `json:"avg_cpu_utilization_percent,omitempty"`
+
+	// Aggregated statistics
+	Timestamp                      string                         `json:"timestamp,omitempty"`
+	Iterations                     int                            `json:"iterations,omitempty"`
+	AvgSingleThreadedTimeSeconds   float64                        `json:"avg_single_threaded_time_seconds,omitempty"`
+	StdSingleThreadedTimeSeconds   float64                        `json:"std_single_threaded_time_seconds,omitempty"`
+	MinSingleThreadedTimeSeconds   float64                        `json:"min_single_threaded_time_seconds,omitempty"`
+	AvgMultiThreadedTimeSeconds    float64                        `json:"avg_multi_threaded_time_seconds,omitempty"`
+	StdMultiThreadedTimeSeconds    float64                        `json:"std_multi_threaded_time_seconds,omitempty"`
+	MinMultiThreadedTimeSeconds    float64                        `json:"min_multi_threaded_time_seconds,omitempty"`
+	AvgIncrementalBuildTimeSeconds float64                        `json:"avg_incremental_build_time_seconds,omitempty"`
+	StdIncrementalBuildTimeSeconds float64                        `json:"std_incremental_build_time_seconds,omitempty"`
+	MinIncrementalBuildTimeSeconds float64                        `json:"min_incremental_build_time_seconds,omitempty"`
+	AvgParallelSpeedup             float64                        `json:"avg_parallel_speedup,omitempty"`
+	StdParallelSpeedup             float64                        `json:"std_parallel_speedup,omitempty"`
+	MaxParallelSpeedup             float64                        `json:"max_parallel_speedup,omitempty"`
+	AvgParallelEfficiencyPercent   float64                        `json:"avg_parallel_efficiency_percent,omitempty"`
+	StdParallelEfficiencyPercent   float64                        `json:"std_parallel_efficiency_percent,omitempty"`
+	AvgCompilationThroughput       float64                        `json:"avg_compilation_throughput,omitempty"`
+	MaxCompilationThroughput       float64                        `json:"max_compilation_throughput,omitempty"`
+	AvgMemoryPressurePercent       float64                        `json:"avg_memory_pressure_percent,omitempty"`
+	AvgSingleCorePerformanceUnits  float64                        `json:"avg_single_core_performance_units,omitempty"`
+	AvgMulticoreScalingEfficiency  float64                        `json:"avg_multicore_scaling_efficiency,omitempty"`
+	AvgOverallCompilationScore     float64                        `json:"avg_overall_compilation_score,omitempty"`
+	StdOverallCompilationScore     float64                        `json:"std_overall_compilation_score,omitempty"`
+	MaxOverallCompilationScore     float64                        `json:"max_overall_compilation_score,omitempty"`
+	Summary                        *CompilationPerformanceSummary `json:"compilation_performance_summary,omitempty"`
+}
+
+// CompilationPerformanceSummary condenses build rates into headline figures.
+type CompilationPerformanceSummary struct {
+	SingleCoreBuildRate      float64 `json:"single_core_build_rate"`
+	MultiCoreBuildRate       float64 `json:"multi_core_build_rate"`
+	ParallelEfficiencyRating string  `json:"parallel_efficiency_rating"`
+}
+
+// CoreMarkResult contains CoreMark integer performance results.
+type CoreMarkResult struct {
+	Score         float64 `json:"score,omitempty"`
+	ScoreStdDev   float64 `json:"score_std_dev,omitempty"`
+	ExecutionTime float64 `json:"execution_time,omitempty"`
+	TimeStdDev    float64 `json:"time_std_dev,omitempty"`
+	Iterations    int     `json:"iterations,omitempty"`
+	Unit          string  `json:"unit,omitempty"`
+	TimeUnit      string  `json:"time_unit,omitempty"`
+}
+
+// SevenZipResult contains 7-Zip compression benchmark results.
+type SevenZipResult struct {
+	CompressionMIPS     float64 `json:"compression_mips,omitempty"`
+	CompressionStdDev   float64 `json:"compression_std_dev,omitempty"`
+	DecompressionMIPS   float64 `json:"decompression_mips,omitempty"`
+	DecompressionStdDev float64 `json:"decompression_std_dev,omitempty"`
+	TotalMIPS           float64 `json:"total_mips,omitempty"`
+	TotalStdDev         float64 `json:"total_std_dev,omitempty"`
+	ThreadingMode       string  `json:"threading_mode,omitempty"`
+	Unit                string  `json:"unit,omitempty"`
+}
+
+// SysbenchResult contains sysbench CPU benchmark results.
+type SysbenchResult struct {
+	EventsPerSecond float64 `json:"events_per_second,omitempty"`
+	EPSStdDev       float64 `json:"eps_std_dev,omitempty"`
+	TotalTime       float64 `json:"total_time,omitempty"`
+	TimeStdDev      float64 `json:"time_std_dev,omitempty"`
+	TotalEvents     int     `json:"total_events,omitempty"`
+	Unit            string  `json:"unit,omitempty"`
+	TimeUnit        string  `json:"time_unit,omitempty"`
+}
+
+// CacheResult contains access latency for each level of the memory hierarchy.
+type CacheResult struct {
+	L1     *CacheLevelResult `json:"l1,omitempty"`
+	L2     *CacheLevelResult `json:"l2,omitempty"`
+	L3     *CacheLevelResult `json:"l3,omitempty"`
+	Memory *CacheLevelResult `json:"memory,omitempty"`
+}
+
+// CacheLevelResult is the measured access latency for one cache level.
+type CacheLevelResult struct {
+	AccessTime float64 `json:"access_time"`
+	StdDev     float64 `json:"std_dev,omitempty"`
+	SizeKB     int     `json:"size_kb,omitempty"`
+	Unit       string  `json:"unit,omitempty"`
+}
+
+// Level returns the result for the named level ("l1", "l2", "l3", "memory").
+func (r *CacheResult) Level(name string) *CacheLevelResult {
+	if r == nil {
+		return nil
+	}
+	switch name {
+	case "l1":
+		return r.L1
+	case "l2":
+		return r.L2
+	case "l3":
+		return r.L3
+	case "memory":
+		return r.Memory
+	default:
+		return nil
+	}
+}
+
+// setLevel stores the result for the named level. Unknown levels are ignored.
+func (r *CacheResult) setLevel(name string, level *CacheLevelResult) bool {
+	switch name {
+	case "l1":
+		r.L1 = level
+	case "l2":
+		r.L2 = level
+	case "l3":
+		r.L3 = level
+	case "memory":
+		r.Memory = level
+	default:
+		return false
+	}
+	return true
+}
+
+// Empty reports whether no suite produced any results.
+func (r *BenchmarkResults) Empty() bool {
+	if r == nil {
+		return true
+	}
+	return r.STREAM == nil && r.HPL == nil && r.DGEMM == nil && r.FFTW == nil &&
+		r.VectorOps == nil && r.MixedPrecision == nil && r.Compilation == nil &&
+		r.CoreMark == nil && r.SevenZip == nil && r.Sysbench == nil && r.Cache == nil &&
+		len(r.Extra) == 0
+}
+
+// SetSuiteData stores results for a suite without a typed field, such as a
+// suite registered from another package, under the suite's name in Extra.
+func (r *BenchmarkResults) SetSuiteData(suite string, data interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s results: %w", suite, err)
+	}
+	if r.Extra == nil {
+		r.Extra = make(map[string]json.RawMessage)
+	}
+	r.Extra[suite] = encoded
+	return nil
+}
+
+// SuiteData decodes results stored with SetSuiteData into v.
+func (r *BenchmarkResults) SuiteData(suite string, v interface{}) error {
+	if r == nil {
+		return fmt.Errorf("no %s results", suite)
+	}
+	encoded, ok := r.Extra[suite]
+	if !ok {
+		return fmt.Errorf("no %s results", suite)
+	}
+	return json.Unmarshal(encoded, v)
+}
+
+// AsMap returns the untyped JSON representation of the results, for
+// consumers such as schema validation that operate on generic documents.
+func (r *BenchmarkResults) AsMap() map[string]interface{} {
+	result := make(map[string]interface{})
+	if r == nil {
+		return result
+	}
+	encoded, err := json.Marshal(r)
+	if err != nil {
+		return result
+	}
+	_ = json.Unmarshal(encoded, &result)
+	return result
+}
+
+// benchmarkResultsFields is used to (un)marshal the typed fields without
+// recursing into the custom JSON methods.
+type benchmarkResultsFields BenchmarkResults
+
+// MarshalJSON encodes the typed results and merges in any Extra entries.
+func (r BenchmarkResults) MarshalJSON() ([]byte, error) {
+	typed, err := json.Marshal(benchmarkResultsFields(r))
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Extra) == 0 {
+		return typed, nil
+	}
+
+	merged := make(map[string]json.RawMessage, len(r.Extra))
+	if err := json.Unmarshal(typed, &merged); err != nil {
+		return nil, err
+	}
+	for key, value := range r.Extra {
+		if _, exists := merged[key]; !exists {
+			merged[key] = value
+		}
+	}
+	return json.Marshal(merged)
+}
+
+// UnmarshalJSON decodes typed results and collects unrecognised keys into
+// Extra. Legacy flat layouts are upgraded via upgradeLegacyResults.
+func (r *BenchmarkResults) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var fields benchmarkResultsFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	*r = BenchmarkResults(fields)
+
+	for key, value := range raw {
+		if knownResultKeys[key] {
+			continue
+		}
+		if r.Extra == nil {
+			r.Extra = make(map[string]json.RawMessage)
+		}
+		r.Extra[key] = value
+	}
+
+	return r.upgradeLegacyResults()
+}
+
+// knownResultKeys lists the top-level keys decoded into typed fields.
+var knownResultKeys = map[string]bool{
+	"stream": true, "hpl": true, "dgemm": true, "fftw": true, "vector_ops": true,
+	"mixed_precision": true, "compilation": true, "coremark": true, "7zip": true,
+	"sysbench": true, "cache": true, "metadata": true,
+}
+
+// legacySTREAMKeys maps flat STREAM keys used by early result files to kernels.
+var legacySTREAMKeys = map[string]string{
+	"copy_bandwidth":  "copy",
+	"scale_bandwidth": "scale",
+	"add_bandwidth":   "add",
+	"triad_bandwidth": "triad",
+}
+
+// legacyHPLKeys are flat HPL keys used by early result files.
+var legacyHPLKeys = []string{"gflops", "efficiency", "residual", "execution_time", "matrix_size"}
+
+// scriptSTREAMKeys maps the STREAM keys of the "results" section written by
+// the async benchmark script to kernels. Their bandwidths are in MB/s.
+var scriptSTREAMKeys = map[string]string{
+	"copy_bandwidth_mbps":  "copy",
+	"scale_bandwidth_mbps": "scale",
+	"add_bandwidth_mbps":   "add",
+	"triad_bandwidth_mbps": "triad",
+}
+
+// scriptEnvelopeKeys are the keys the async benchmark script writes next to
+// its "results" section.
+var scriptEnvelopeKeys = []string{"benchmark_suite", "success", "exit_code", "timestamp"}
+
+// upgradeLegacyResults converts flat, suite-less layouts written by early
+// versions (e.g. {"triad_bandwidth": 41.9} or {"gflops": 123.4}) and the
+// results.json of the async benchmark script into the typed
+// representation. Keys that are consumed are removed from Extra.
+func (r *BenchmarkResults) upgradeLegacyResults() error {
+	if len(r.Extra) == 0 {
+		return nil
+	}
+	r.upgradeScriptResults()
+
+	if r.STREAM == nil {
+		stream := &STREAMResult{}
+		found := false
+		for key, operation := range legacySTREAMKeys {
+			value, ok := r.Extra[key]
+			if !ok {
+				continue
+			}
+			var bandwidth float64
+			if err := json.Unmarshal(value, &bandwidth); err != nil {
+				return fmt.Errorf("invalid legacy STREAM value %s: %w", key, err)
+			}
+			stream.setOperation(operation, &StreamOperation{Bandwidth: bandwidth, Unit: "GB/s"})
+			delete(r.Extra, key)
+			found = true
+		}
+		if found {
+			r.STREAM = stream
+		}
+	}
+
+	if r.HPL == nil {
+		if _, hasGFLOPS := r.Extra["gflops"]; hasGFLOPS {
+			flat := make(map[string]json.RawMessage)
+			for _, key := range legacyHPLKeys {
+				if value, ok := r.Extra[key]; ok {
+					flat[key] = value
+					delete(r.Extra, key)
+				}
+			}
+			encoded, err := json.Marshal(flat)
+			if err != nil {
+				return err
+			}
+			hpl := &HPLResult{}
+			if err := json.Unmarshal(encoded, hpl); err != nil {
+				return fmt.Errorf("invalid legacy HPL values: %w", err)
+			}
+			hpl.Unit = "GFLOPS"
+			r.HPL = hpl
+		}
+	}
+
+	if len(r.Extra) == 0 {
+		r.Extra = nil
+	}
+	return nil
+}
+
+// upgradeScriptResults converts the results.json written by the async
+// benchmark script, e.g.
+//
+//   {"benchmark_suite": "stream", "results": {"triad_bandwidth_mbps": 41932.8}, "success": true}
+//
+// into the typed representation. The script writes 0 for values it found
+// no output for, which are left unmeasured. The "results" section is kept
+// in Extra if it holds values of other suites, such as the raw output
+// recorded for suites the script does not parse.
+func (r *BenchmarkResults) upgradeScriptResults() {
+	if _, ok := r.Extra["benchmark_suite"]; !ok {
+		return
+	}
+	var section map[string]interface{}
+	if err := json.Unmarshal(r.Extra["results"], &section); err != nil {
+		return
+	}
+
+	stream := &STREAMResult{}
+	for key, operation := range scriptSTREAMKeys {
+		if mbps, ok := section[key].(float64); ok {
+			if mbps > 0 {
+				stream.setOperation(operation, &StreamOperation{Bandwidth: mbps / 1000.0, Unit: "GB/s"})
+			}
+			delete(section, key)
+		}
+	}
+	if r.STREAM == nil && len(stream.Operations()) > 0 {
+		r.STREAM = stream
+	}
+	if gflops, ok := section["peak_gflops"].(float64); ok {
+		if r.HPL == nil && gflops > 0 {
+			r.HPL = &HPLResult{GFLOPS: gflops, Unit: "GFLOPS"}
+		}
+		delete(section, "peak_gflops")
+	}
+
+	for _, key := range scriptEnvelopeKeys {
+		delete(r.Extra, key)
+	}
+	if len(section) == 0 {
+		delete(r.Extra, "results")
+	}
+}
+
+// setOperation records the result of the named kernel.
+func (r *STREAMResult) setOperation(operation string, op *StreamOperation) {
+	switch operation {
+	case "copy":
+		r.Copy = op
+	case "scale":
+		r.Scale = op
+	case "add":
+		r.Add = op
+	case "triad":
+		r.Triad = op
+	}
+}
+
+// DecodeBenchmarkResults converts untyped benchmark data, such as a
+// map[string]interface{} produced by json.Unmarshal into an interface{}, into
+// typed results.
+//
+// This is the compatibility layer for result files written before results
+// were typed. It accepts the nested per-suite layout as well as the legacy
+// flat STREAM and HPL layouts, and it also accepts a complete stored result
+// document, in which case the "performance" section is decoded.
+//
+// Parameters:
+//   - data: Untyped benchmark data or stored result document
+//
+// Returns:
+//   - *BenchmarkResults: Typed results
+//   - error: If the data cannot be represented as benchmark results
+func DecodeBenchmarkResults(data interface{}) (*BenchmarkResults, error) {
+	if data == nil {
+		return nil, fmt.Errorf("no benchmark data")
+	}
+
+	if document, ok := data.(map[string]interface{}); ok {
+		if performance, ok := document["performance"].(map[string]interface{}); ok {
+			return decodePerformanceSection(performance)
+		}
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode benchmark data: %w", err)
+	}
+
+	var results BenchmarkResults
+	if err := json.Unmarshal(encoded, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode benchmark data: %w", err)
+	}
+	return &results, nil
+}
+
+// decodePerformanceSection merges the category sections ("memory", "cpu")
+// of a stored result document into a single set of typed results.
+func decodePerformanceSection(performance map[string]interface{}) (*BenchmarkResults, error) {
+	merged := make(map[string]interface{})
+	for _, category := range []string{CategoryMemory, CategoryCPU} {
+		if section, ok := performance[category].(map[string]interface{}); ok {
+			for key, value := range section {
+				if _, exists := merged[key]; !exists {
+					merged[key] = value
+				}
+			}
+		}
+	}
+	if len(merged) == 0 {
+		return nil, fmt.Errorf("no benchmark data in performance section")
+	}
+	return DecodeBenchmarkResults(merged)
+}
@@ -0,0 +1,141 @@
+package aws
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBenchmarkResultsRoundTrip(t *testing.T) {
+	original := &BenchmarkResults{
+		STREAM: &STREAMResult{
+			Triad: &StreamOperation{Bandwidth: 41.9, StdDev: 0.3, Unit: "GB/s"},
+		},
+		CoreMark: &CoreMarkResult{Score: 123456.7},
+		Metadata: &ResultMetadata{Iterations: 5, StatisticalConfidence: "95%"},
+		Extra:    map[string]json.RawMessage{"custom": json.RawMessage(`{"value":1}`)},
+	}
+
+	encoded, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded BenchmarkResults
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if got := decoded.STREAM.Bandwidth("triad"); got != 41.9 {
+		t.Errorf("triad bandwidth = %v, want 41.9", got)
+	}
+	if decoded.CoreMark == nil || decoded.CoreMark.Score != 123456.7 {
+		t.Errorf("coremark score not preserved: %+v", decoded.CoreMark)
+	}
+	if decoded.Metadata == nil || decoded.Metadata.Iterations != 5 {
+		t.Errorf("metadata not preserved: %+v", decoded.Metadata)
+	}
+	if string(decoded.Extra["custom"]) != `{"value":1}` {
+		t.Errorf("extra key not preserved: %s", decoded.Extra["custom"])
+	}
+}
+
+func TestBenchmarkResultsLegacyLayout(t *testing.T) {
+	results, err := DecodeBenchmarkResults(map[string]interface{}{
+		"triad_bandwidth": 41.9,
+		"copy_bandwidth":  45.2,
+		"gflops":          123.4,
+	})
+	if err != nil {
+		t.Fatalf("DecodeBenchmarkResults failed: %v", err)
+	}
+
+	if got := results.STREAM.Bandwidth("triad"); got != 41.9 {
+		t.Errorf("triad bandwidth = %v, want 41.9", got)
+	}
+	if got := results.STREAM.Bandwidth("copy"); got != 45.2 {
+		t.Errorf("copy bandwidth = %v, want 45.2", got)
+	}
+	if results.HPL == nil || results.HPL.GFLOPS != 123.4 {
+		t.Errorf("legacy HPL gflops not upgraded: %+v", results.HPL)
+	}
+	if len(results.Extra) != 0 {
+		t.Errorf("legacy keys left in Extra: %v", results.Extra)
+	}
+}
+
+func TestBenchmarkResultsScriptLayout(t *testing.T) {
+	var stream BenchmarkResults
+	if err := json.Unmarshal([]byte(`{
+		"benchmark_suite": "stream",
+		"results": {"copy_bandwidth_mbps": 45234.2, "scale_bandwidth_mbps": 0, "add_bandwidth_mbps": 42105.3, "triad_bandwidth_mbps": 41932.8},
+		"success": true,
+		"exit_code": 0,
+		"timestamp": "2025-06-30T12:00:00+00:00"
+	}`), &stream); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if got := stream.STREAM.Bandwidth("triad"); math.Abs(got-41.9328) > 1e-9 {
+		t.Errorf("triad bandwidth = %v, want 41.9328", got)
+	}
+	if stream.STREAM.Scale != nil {
+		t.Errorf("Expected the unmeasured scale kernel to be left out, got %+v", stream.STREAM.Scale)
+	}
+	if len(stream.Extra) != 0 {
+		t.Errorf("script keys left in Extra: %v", stream.Extra)
+	}
+
+	var hpl BenchmarkResults
+	if err := json.Unmarshal([]byte(`{"benchmark_suite": "hpl", "results": {"peak_gflops": 123.4}, "success": true, "exit_code": 0}`), &hpl); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if hpl.HPL == nil || hpl.HPL.GFLOPS != 123.4 || len(hpl.Extra) != 0 {
+		t.Errorf("script HPL results not upgraded: %+v", hpl)
+	}
+
+	var generic BenchmarkResults
+	if err := json.Unmarshal([]byte(`{"benchmark_suite": "coremark", "results": {"raw_output": "Q29yZU1hcms="}, "success": true}`), &generic); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if _, ok := generic.Extra["results"]; !ok || len(generic.Extra) != 1 {
+		t.Errorf("Expected only the raw output section kept, got %v", generic.Extra)
+	}
+}
+
+func TestDecodeStoredResultsFiles(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "results", "2025-06-30", "*.json"))
+	if err != nil || len(files) == 0 {
+		t.Skip("no stored results available")
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+
+		var document map[string]interface{}
+		if err := json.Unmarshal(data, &document); err != nil {
+			t.Fatalf("failed to parse %s: %v", file, err)
+		}
+
+		results, err := DecodeBenchmarkResults(document)
+		if err != nil {
+			t.Errorf("%s: DecodeBenchmarkResults failed: %v", filepath.Base(file), err)
+			continue
+		}
+		if results.Empty() {
+			t.Errorf("%s: decoded results are empty", filepath.Base(file))
+		}
+	}
+}
+
+func TestSuiteDataMissing(t *testing.T) {
+	var results BenchmarkResults
+	var v struct{}
+	if err := results.SuiteData("missing", &v); err == nil {
+		t.Error("expected error for missing suite data")
+	}
+}
@@ -0,0 +1,214 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// Purchase options for benchmark instances.
+const (
+	PurchaseOnDemand = "on-demand"
+	PurchaseSpot     = "spot"
+)
+
+// Sources of a detected spot interruption.
+const (
+	// InterruptionSourceIMDS means the instance saw the two-minute
+	// interruption notice through the instance metadata service.
+	InterruptionSourceIMDS = "imds"
+
+	// InterruptionSourceInstanceState means EC2 reported the instance as
+	// reclaimed by a spot interruption.
+	InterruptionSourceInstanceState = "instance-state"
+)
+
+// ErrSpotInterrupted is matched by errors.Is for every spot interruption.
+var ErrSpotInterrupted = errors.New("spot instance interrupted")
+
+// spotNoticeFile is where the user-data watcher stores the IMDS notice.
+const spotNoticeFile = "/var/run/benchmark/spot-interruption"
+
+// spotNoticeMarker prefixes the notice in benchmark command output.
+const spotNoticeMarker = "SPOT_INTERRUPTION_NOTICE:"
+
+// SpotInterruption records a spot interruption of a benchmark attempt.
+//
+// It is returned as the error of an interrupted attempt; use errors.As to
+// retrieve it, or errors.Is with ErrSpotInterrupted to test for it.
+type SpotInterruption struct {
+	InstanceID       string    `json:"instance_id"`
+	AvailabilityZone string    `json:"availability_zone,omitempty"`
+	DetectedAt       time.Time `json:"detected_at"`
+	Source           string    `json:"source"`
+
+	// Notice is the instance-action document from IMDS, when available.
+	Notice string `json:"notice,omitempty"`
+}
+
+func (i *SpotInterruption) Error() string {
+	return fmt.Sprintf("spot instance %s interrupted (detected via %s)", i.InstanceID, i.Source)
+}
+
+// Is makes errors.Is(err, ErrSpotInterrupted) match any SpotInterruption.
+func (i *SpotInterruption) Is(target error) bool {
+	return target == ErrSpotInterrupted
+}
+
+// spotMarketOptions returns the RunInstances market options for a config.
+func spotMarketOptions(config BenchmarkConfig) (*types.InstanceMarketOptionsRequest, error) {
+	switch config.PurchaseOption {
+	case "", PurchaseOnDemand:
+		return nil, nil
+	case PurchaseSpot:
+		options := &types.SpotMarketOptions{
+			SpotInstanceType:             types.SpotInstanceTypeOneTime,
+			InstanceInterruptionBehavior: types.InstanceInterruptionBehaviorTerminate,
+		}
+		if config.SpotMaxPrice != "" {
+			options.MaxPrice = aws.String(config.SpotMaxPrice)
+		}
+		return &types.InstanceMarketOptionsRequest{
+			MarketType:  types.MarketTypeSpot,
+			SpotOptions: options,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported purchase option %q (use %s or %s)", config.PurchaseOption, PurchaseOnDemand, PurchaseSpot)
+	}
+}
+
+// withSpotInterruptionWatcher adds a background IMDS poller to a user-data
+// script. When the two-minute interruption notice appears it is written to
+// spotNoticeFile, where wrapSpotCommand picks it up.
+func withSpotInterruptionWatcher(userData string) string {
+	watcher := `
+# Watch for spot interruption notices (IMDSv2)
+mkdir -p ` + "$(dirname " + spotNoticeFile + ")" + `
+(
+  while true; do
+    token=$(curl -s -X PUT "http://169.254.169.254/latest/api/token" -H "X-aws-ec2-metadata-token-ttl-seconds: 300")
+    notice=$(curl -s -f -H "X-aws-ec2-metadata-token: $token" http://169.254.169.254/latest/meta-data/spot/instance-action)
+    if [ -n "$notice" ]; then
+      echo "$notice" > ` + spotNoticeFile + `
+      break
+    fi
+    sleep 5
+  done
+) &
+`
+	shebang, rest, found := strings.Cut(userData, "\n")
+	if !found {
+		return userData + watcher
+	}
+	return shebang + "\n" + watcher + rest
+}
+
+// wrapSpotCommand makes a benchmark command fail with the interruption
+// notice if one was received before or while it ran, so the partial output
+// of a reclaimed instance is never parsed as a result.
+func wrapSpotCommand(command string) string {
+	check := `if [ -s ` + spotNoticeFile + ` ]; then echo "` + spotNoticeMarker + ` $(cat ` + spotNoticeFile + `)" >&2; exit 75; fi`
+	return check + "\n(\n" + command + "\n)\nstatus=$?\n" + check + "\nexit $status"
+}
+
+// detectSpotInterruption reports whether a failed step of a spot benchmark
+// was caused by an interruption, using the notice surfaced by the benchmark
+// command or the state EC2 reports for the instance.
+func (o *Orchestrator) detectSpotInterruption(ctx context.Context, config BenchmarkConfig, instanceID string, cause error) *SpotInterruption {
+	if config.PurchaseOption != PurchaseSpot || cause == nil {
+		return nil
+	}
+
+	var existing *SpotInterruption
+	if errors.As(cause, &existing) {
+		return existing
+	}
+
+	interruption := &SpotInterruption{InstanceID: instanceID, DetectedAt: time.Now().UTC()}
+	if _, notice, found := strings.Cut(cause.Error(), spotNoticeMarker); found {
+		interruption.Source = InterruptionSourceIMDS
+		interruption.Notice = strings.TrimSpace(notice)
+	}
+
+	resp, err := o.ec2Client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{InstanceIds: []string{instanceID}})
+	if err != nil || len(resp.Reservations) == 0 || len(resp.Reservations[0].Instances) == 0 {
+		if interruption.Source != "" {
+			return interruption
+		}
+		return nil
+	}
+
+	instance := resp.Reservations[0].Instances[0]
+	if instance.Placement != nil {
+		interruption.AvailabilityZone = aws.ToString(instance.Placement.AvailabilityZone)
+	}
+	if interruption.Source == "" && instance.StateReason != nil &&
+		strings.HasPrefix(aws.ToString(instance.StateReason.Code), "Server.SpotInstance") {
+		interruption.Source = InterruptionSourceInstanceState
+	}
+	if interruption.Source == "" {
+		return nil
+	}
+	return interruption
+}
+
+// interruptedError converts a step failure into the SpotInterruption that
+// caused it, or returns err unchanged.
+func (o *Orchestrator) interruptedError(ctx context.Context, config BenchmarkConfig, instanceID string, err error) error {
+	if errors.Is(err, ErrSpotInterrupted) {
+		return err
+	}
+	if interruption := o.detectSpotInterruption(ctx, config, instanceID, err); interruption != nil {
+		fmt.Printf("   ⚡ %v\n", interruption)
+		return interruption
+	}
+	return err
+}
+
+// runWithSpotRetry runs a benchmark attempt and, while attempts end in a
+// spot interruption, retries spot in each of config.SpotRetrySubnetIDs and
+// then on-demand if config.SpotFallbackOnDemand is set. The interruptions of
+// earlier attempts are recorded on the final result.
+func (o *Orchestrator) runWithSpotRetry(config BenchmarkConfig, attempt func(BenchmarkConfig) (*InstanceResult, error)) (*InstanceResult, error) {
+	result, err := attempt(config)
+
+	var interruptions []SpotInterruption
+	retrySubnets := config.SpotRetrySubnetIDs
+	next := config
+	for {
+		var interruption *SpotInterruption
+		if result == nil || !errors.As(err, &interruption) {
+			break
+		}
+		result.Status = "interrupted"
+		interruptions = append(interruptions, *interruption)
+
+		switch {
+		case next.PurchaseOption == PurchaseSpot && len(retrySubnets) > 0:
+			next.SubnetID, retrySubnets = retrySubnets[0], retrySubnets[1:]
+			next.AvailabilityZone = "" // the retry subnet decides the zone
+			fmt.Printf("   🔁 Retrying %s on spot in subnet %s after interruption\n", config.InstanceType, next.SubnetID)
+		case next.PurchaseOption == PurchaseSpot && config.SpotFallbackOnDemand:
+			next.PurchaseOption = PurchaseOnDemand
+			next.SubnetID = config.SubnetID
+			next.AvailabilityZone = config.AvailabilityZone
+			fmt.Printf("   🔁 Retrying %s on-demand after spot interruption\n", config.InstanceType)
+		default:
+			result.Interruptions = interruptions
+			return result, err
+		}
+
+		result, err = attempt(next)
+	}
+
+	if result != nil && len(interruptions) > 0 {
+		result.Interruptions = append(interruptions, result.Interruptions...)
+	}
+	return result, err
+}
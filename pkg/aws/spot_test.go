@@ -0,0 +1,172 @@
+package aws
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/fakecloud"
+)
+
+func spotBenchmarkConfig(instanceType string) BenchmarkConfig {
+	config := fakeBenchmarkConfig(instanceType, "stream")
+	config.PurchaseOption = PurchaseSpot
+	return config
+}
+
+// interruptSpotIn returns a command handler that reclaims spot instances in
+// the given availability zones and runs STREAM everywhere else.
+func interruptSpotIn(cloud *fakecloud.Cloud, zones ...string) fakecloud.CommandHandler {
+	return func(instanceID, command string) fakecloud.CommandResult {
+		inst, _ := cloud.Instance(instanceID)
+		for _, zone := range zones {
+			if inst.Lifecycle == types.InstanceLifecycleTypeSpot && inst.AvailabilityZone == zone {
+				_ = cloud.InterruptSpot(instanceID)
+			}
+		}
+		return fakecloud.CommandResult{Status: ssmtypes.CommandInvocationStatusSuccess, Stdout: fakeSTREAMOutput}
+	}
+}
+
+func TestRunBenchmarkOnSpot(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	cloud.HandleCommands(interruptSpotIn(cloud))
+
+	config := spotBenchmarkConfig("c7g.large")
+	config.SpotMaxPrice = "0.05"
+	result, err := newFakeOrchestrator(cloud).RunBenchmark(context.Background(), config)
+	if err != nil {
+		t.Fatalf("RunBenchmark failed: %v", err)
+	}
+	if result.PurchaseOption != PurchaseSpot {
+		t.Errorf("PurchaseOption = %q, want spot", result.PurchaseOption)
+	}
+	if len(result.Interruptions) != 0 {
+		t.Errorf("Expected no interruptions, got %+v", result.Interruptions)
+	}
+
+	inst, _ := cloud.Instance(result.InstanceID)
+	if inst.Lifecycle != types.InstanceLifecycleTypeSpot || inst.SpotMaxPrice != "0.05" {
+		t.Errorf("Expected spot launch with max price 0.05, got lifecycle %q price %q", inst.Lifecycle, inst.SpotMaxPrice)
+	}
+	userData, err := base64.StdEncoding.DecodeString(inst.UserData)
+	if err != nil || !strings.Contains(string(userData), "spot/instance-action") {
+		t.Error("Expected user data to poll IMDS for interruption notices")
+	}
+	for _, inv := range benchmarkInvocations(cloud) {
+		if !strings.Contains(inv.Command, spotNoticeFile) {
+			t.Fatal("Expected benchmark commands to check for interruption notices")
+		}
+	}
+}
+
+func TestSpotMaxPriceTooLow(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	cloud.SetSpotPrice("m7i.large", 0.04)
+
+	config := spotBenchmarkConfig("m7i.large")
+	config.SpotMaxPrice = "0.02"
+	_, err := newFakeOrchestrator(cloud).RunBenchmark(context.Background(), config)
+
+	var quotaErr *QuotaError
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("Expected QuotaError, got %v", err)
+	}
+	if len(cloud.Instances()) != 0 {
+		t.Error("Expected no instance to be launched")
+	}
+}
+
+func TestSpotInterruptionFallsBackToOnDemand(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	cloud.HandleCommands(interruptSpotIn(cloud, "us-east-1a"))
+
+	config := spotBenchmarkConfig("m7i.large")
+	config.SpotFallbackOnDemand = true
+	result, err := newFakeOrchestrator(cloud).RunBenchmark(context.Background(), config)
+	if err != nil {
+		t.Fatalf("RunBenchmark failed: %v", err)
+	}
+
+	if result.Status != "completed" || result.PurchaseOption != PurchaseOnDemand {
+		t.Errorf("Expected completed on-demand result, got status %q purchase option %q", result.Status, result.PurchaseOption)
+	}
+	if len(result.Interruptions) != 1 {
+		t.Fatalf("Expected 1 recorded interruption, got %d", len(result.Interruptions))
+	}
+	interruption := result.Interruptions[0]
+	if interruption.Source != InterruptionSourceInstanceState || interruption.AvailabilityZone != "us-east-1a" {
+		t.Errorf("Unexpected interruption: %+v", interruption)
+	}
+	if interruption.InstanceID == result.InstanceID {
+		t.Error("Expected the on-demand retry to use a new instance")
+	}
+	if got := len(cloud.Instances()); got != 2 {
+		t.Errorf("Expected 2 launched instances, got %d", got)
+	}
+}
+
+func TestSpotInterruptionRetriesInOtherSubnet(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	cloud.AddSubnet("subnet-12345678", "us-east-1a")
+	cloud.AddSubnet("subnet-b", "us-east-1b")
+	cloud.HandleCommands(interruptSpotIn(cloud, "us-east-1a"))
+
+	config := spotBenchmarkConfig("m7i.large")
+	config.SpotRetrySubnetIDs = []string{"subnet-b"}
+	config.SpotFallbackOnDemand = true
+	result, err := newFakeOrchestrator(cloud).RunBenchmark(context.Background(), config)
+	if err != nil {
+		t.Fatalf("RunBenchmark failed: %v", err)
+	}
+
+	if result.PurchaseOption != PurchaseSpot {
+		t.Errorf("Expected retry to stay on spot, got %q", result.PurchaseOption)
+	}
+	inst, _ := cloud.Instance(result.InstanceID)
+	if inst.AvailabilityZone != "us-east-1b" {
+		t.Errorf("Expected retry in us-east-1b, got %s", inst.AvailabilityZone)
+	}
+	if len(result.Interruptions) != 1 || result.Interruptions[0].AvailabilityZone != "us-east-1a" {
+		t.Errorf("Unexpected interruptions: %+v", result.Interruptions)
+	}
+}
+
+func TestSpotInterruptionNoticeWithoutRetry(t *testing.T) {
+	cloud := fakecloud.New("us-east-1")
+	cloud.HandleCommands(func(instanceID, command string) fakecloud.CommandResult {
+		return fakecloud.CommandResult{
+			Status: ssmtypes.CommandInvocationStatusFailed,
+			Stderr: spotNoticeMarker + ` {"action": "terminate", "time": "2025-06-30T12:02:00Z"}`,
+		}
+	})
+
+	result, err := newFakeOrchestrator(cloud).RunBenchmark(context.Background(), spotBenchmarkConfig("m7i.large"))
+	if !errors.Is(err, ErrSpotInterrupted) {
+		t.Fatalf("Expected spot interruption error, got %v", err)
+	}
+	if result.Status != "interrupted" {
+		t.Errorf("Status = %q, want interrupted", result.Status)
+	}
+	if len(result.Interruptions) != 1 {
+		t.Fatalf("Expected 1 recorded interruption, got %d", len(result.Interruptions))
+	}
+	if got := result.Interruptions[0]; got.Source != InterruptionSourceIMDS || !strings.Contains(got.Notice, `"action": "terminate"`) {
+		t.Errorf("Unexpected interruption: %+v", got)
+	}
+	if got := len(benchmarkInvocations(cloud)); got != 1 {
+		t.Errorf("Expected the interruption to stop after 1 command, got %d", got)
+	}
+}
+
+func TestOnDemandFailureIsNotAnInterruption(t *testing.T) {
+	orchestrator := newFakeOrchestrator(fakecloud.New("us-east-1"))
+	err := orchestrator.interruptedError(context.Background(), fakeBenchmarkConfig("m7i.large", "stream"), "i-missing", errors.New(spotNoticeMarker))
+	if errors.Is(err, ErrSpotInterrupted) {
+		t.Error("Expected on-demand failures never to be reported as spot interruptions")
+	}
+}
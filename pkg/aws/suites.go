@@ -0,0 +1,297 @@
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// BenchmarkSuite defines a benchmark that the Orchestrator can execute on an
+// instance.
+//
+// A suite owns every suite-specific step of a benchmark run: generating the
+// shell command executed via SSM, parsing the raw output of one iteration,
+// aggregating the parsed iterations into a final result, and describing how
+// the result maps onto the benchmark result schema. The Orchestrator itself
+// only handles instance lifecycle and iteration control.
+//
+// Built-in suites populate their typed field in BenchmarkResults. Suites
+// defined in other packages store their results with SetSuiteData under
+// their own name, which keeps the {"<suite>": {...}} layout of stored
+// results.
+//
+// Implementations must be safe for concurrent use because a single registered
+// suite is shared by all orchestrators in the process.
+type BenchmarkSuite interface {
+	// Name returns the unique identifier used in BenchmarkConfig.BenchmarkSuite.
+	Name() string
+
+	// GenerateCommand returns the shell script executed for one iteration.
+	GenerateCommand(config BenchmarkConfig) string
+
+	// ParseOutput extracts the results of one iteration from raw command output.
+	ParseOutput(output string) (*BenchmarkResults, error)
+
+	// AggregateResults combines parsed iterations into statistical results.
+	AggregateResults(iterations []*BenchmarkResults) (*BenchmarkResults, error)
+
+	// Metadata describes the suite for help text and schema mapping.
+	Metadata() SuiteMetadata
+}
+
+// SuiteMetadata describes how a benchmark suite's results fit into the
+// benchmark result schema.
+type SuiteMetadata struct {
+	// Description is a short human-readable summary of what the suite measures.
+	Description string
+
+	// Category is the schema performance section ("memory" or "cpu") that
+	// the suite's results are stored under.
+	Category string
+
+	// PrimaryMetric is the headline metric within the suite's results, using
+	// dotted notation for nested values (e.g. "triad.bandwidth").
+	PrimaryMetric string
+
+	// Unit is the unit of the primary metric.
+	Unit string
+}
+
+// Schema performance categories used by SuiteMetadata.Category.
+const (
+	CategoryMemory = "memory"
+	CategoryCPU    = "cpu"
+)
+
+var (
+	suitesMu sync.RWMutex
+	suites   = make(map[string]BenchmarkSuite)
+)
+
+// RegisterSuite makes a benchmark suite available by name.
+//
+// Suites defined outside this package register themselves from an init
+// function, in the same way database/sql drivers do:
+//
+//   func init() {
+//       aws.RegisterSuite(mySuite{})
+//   }
+//
+// RegisterSuite panics if the suite is nil, has an empty name, or if a suite
+// with the same name is already registered.
+func RegisterSuite(suite BenchmarkSuite) {
+	if suite == nil {
+		panic("aws: RegisterSuite suite is nil")
+	}
+
+	name := suite.Name()
+	if name == "" {
+		panic("aws: RegisterSuite suite has empty name")
+	}
+
+	suitesMu.Lock()
+	defer suitesMu.Unlock()
+
+	if _, exists := suites[name]; exists {
+		panic("aws: RegisterSuite called twice for suite " + name)
+	}
+	suites[name] = suite
+}
+
+// LookupSuite returns the registered benchmark suite with the given name.
+//
+// Returns an error wrapping ErrUnsupportedBenchmark if no suite with that
+// name has been registered.
+func LookupSuite(name string) (BenchmarkSuite, error) {
+	suitesMu.RLock()
+	defer suitesMu.RUnlock()
+
+	suite, exists := suites[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedBenchmark, name)
+	}
+	return suite, nil
+}
+
+// RegisteredSuites returns the names of all registered suites in sorted order.
+func RegisteredSuites() []string {
+	suitesMu.RLock()
+	defer suitesMu.RUnlock()
+
+	names := make([]string, 0, len(suites))
+	for name := range suites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// builtinSuite adapts the command generators, parsers and aggregators that
+// ship with the Orchestrator to the BenchmarkSuite interface.
+//
+// The adapted methods do not touch orchestrator state, so they are invoked on
+// a shared zero-value Orchestrator.
+type builtinSuite struct {
+	name      string
+	metadata  SuiteMetadata
+	command   func(*Orchestrator) string
+	parse     func(*Orchestrator, string) (*BenchmarkResults, error)
+	aggregate func(*Orchestrator, []*BenchmarkResults) (*BenchmarkResults, error)
+}
+
+var builtinReceiver = &Orchestrator{}
+
+func (s builtinSuite) Name() string { return s.name }
+
+func (s builtinSuite) GenerateCommand(config BenchmarkConfig) string {
+	return s.command(builtinReceiver)
+}
+
+func (s builtinSuite) ParseOutput(output string) (*BenchmarkResults, error) {
+	return s.parse(builtinReceiver, output)
+}
+
+func (s builtinSuite) AggregateResults(iterations []*BenchmarkResults) (*BenchmarkResults, error) {
+	return s.aggregate(builtinReceiver, iterations)
+}
+
+func (s builtinSuite) Metadata() SuiteMetadata { return s.metadata }
+
+func init() {
+	for _, suite := range []builtinSuite{
+		{
+			name: "stream",
+			metadata: SuiteMetadata{
+				Description:   "STREAM memory bandwidth (copy, scale, add, triad)",
+				Category:      CategoryMemory,
+				PrimaryMetric: "triad.bandwidth",
+				Unit:          "GB/s",
+			},
+			command:   (*Orchestrator).generateSTREAMCommand,
+			parse:     (*Orchestrator).parseSTREAMOutput,
+			aggregate: (*Orchestrator).aggregateSTREAMResults,
+		},
+		{
+			name: "hpl",
+			metadata: SuiteMetadata{
+				Description:   "High Performance LINPACK floating-point throughput",
+				Category:      CategoryCPU,
+				PrimaryMetric: "gflops",
+				Unit:          "GFLOPS",
+			},
+			command:   (*Orchestrator).generateHPLCommand,
+			parse:     (*Orchestrator).parseHPLOutput,
+			aggregate: (*Orchestrator).aggregateHPLResults,
+		},
+		{
+			name: "dgemm",
+			metadata: SuiteMetadata{
+				Description:   "Double-precision matrix multiplication across matrix sizes",
+				Category:      CategoryCPU,
+				PrimaryMetric: "peak_gflops",
+				Unit:          "GFLOPS",
+			},
+			command:   (*Orchestrator).generateDGEMMCommand,
+			parse:     (*Orchestrator).parseDGEMMOutput,
+			aggregate: (*Orchestrator).aggregateDGEMMResults,
+		},
+		{
+			name: "fftw",
+			metadata: SuiteMetadata{
+				Description:   "Fast Fourier Transform throughput",
+				Category:      CategoryCPU,
+				PrimaryMetric: "overall_gflops",
+				Unit:          "GFLOPS",
+			},
+			command:   (*Orchestrator).generateFFTWCommand,
+			parse:     (*Orchestrator).parseFFTWOutput,
+			aggregate: (*Orchestrator).aggregateFFTWResults,
+		},
+		{
+			name: "vector_ops",
+			metadata: SuiteMetadata{
+				Description:   "BLAS Level 1 vector operations",
+				Category:      CategoryCPU,
+				PrimaryMetric: "overall_avg_gflops",
+				Unit:          "GFLOPS",
+			},
+			command:   (*Orchestrator).generateVectorOpsCommand,
+			parse:     (*Orchestrator).parseVectorOpsOutput,
+			aggregate: (*Orchestrator).aggregateVectorOpsResults,
+		},
+		{
+			name: "mixed_precision",
+			metadata: SuiteMetadata{
+				Description:   "FP16/FP32/FP64 arithmetic throughput",
+				Category:      CategoryCPU,
+				PrimaryMetric: "avg_fp32_gflops",
+				Unit:          "GFLOPS",
+			},
+			command:   (*Orchestrator).generateMixedPrecisionCommand,
+			parse:     (*Orchestrator).parseMixedPrecisionOutput,
+			aggregate: (*Orchestrator).aggregateMixedPrecisionResults,
+		},
+		{
+			name: "compilation",
+			metadata: SuiteMetadata{
+				Description:   "Source compilation times and parallel build speedup",
+				Category:      CategoryCPU,
+				PrimaryMetric: "avg_multi_threaded_time_seconds",
+				Unit:          "seconds",
+			},
+			command:   (*Orchestrator).generateCompilationCommand,
+			parse:     (*Orchestrator).parseCompilationOutput,
+			aggregate: (*Orchestrator).aggregateCompilationResults,
+		},
+		{
+			name: "coremark",
+			metadata: SuiteMetadata{
+				Description:   "CoreMark integer performance",
+				Category:      CategoryCPU,
+				PrimaryMetric: "score",
+				Unit:          "operations/sec",
+			},
+			command:   (*Orchestrator).generateCoreMarkCommand,
+			parse:     (*Orchestrator).parseCoreMarkOutput,
+			aggregate: (*Orchestrator).aggregateCoreMarkResults,
+		},
+		{
+			name: "7zip",
+			metadata: SuiteMetadata{
+				Description:   "7-Zip compression and decompression rating",
+				Category:      CategoryCPU,
+				PrimaryMetric: "total_mips",
+				Unit:          "MIPS",
+			},
+			command:   (*Orchestrator).generate7ZipCommand,
+			parse:     (*Orchestrator).parse7ZipOutput,
+			aggregate: (*Orchestrator).aggregate7ZipResults,
+		},
+		{
+			name: "sysbench",
+			metadata: SuiteMetadata{
+				Description:   "Sysbench CPU prime computation",
+				Category:      CategoryCPU,
+				PrimaryMetric: "events_per_second",
+				Unit:          "events/sec",
+			},
+			command:   (*Orchestrator).generateSysbenchCommand,
+			parse:     (*Orchestrator).parseSysbenchOutput,
+			aggregate: (*Orchestrator).aggregateSysbenchResults,
+		},
+		{
+			name: "cache",
+			metadata: SuiteMetadata{
+				Description:   "Cache hierarchy and memory access latency",
+				Category:      CategoryMemory,
+				PrimaryMetric: "l1.access_time",
+				Unit:          "ns",
+			},
+			command:   (*Orchestrator).generateCacheCommand,
+			parse:     (*Orchestrator).parseCacheOutput,
+			aggregate: (*Orchestrator).aggregateCacheResults,
+		},
+	} {
+		RegisterSuite(suite)
+	}
+}
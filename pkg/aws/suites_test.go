@@ -0,0 +1,127 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/fakecloud"
+)
+
+func TestBuiltinSuitesRegistered(t *testing.T) {
+	expected := []string{"7zip", "cache", "compilation", "coremark", "dgemm", "fftw", "hpl", "mixed_precision", "sysbench", "stream", "vector_ops"}
+
+	registered := RegisteredSuites()
+	for _, name := range expected {
+		suite, err := LookupSuite(name)
+		if err != nil {
+			t.Errorf("suite %s not registered: %v (registered: %v)", name, err, registered)
+			continue
+		}
+		if suite.Name() != name {
+			t.Errorf("suite registered as %s reports name %s", name, suite.Name())
+		}
+		meta := suite.Metadata()
+		if meta.Category != CategoryMemory && meta.Category != CategoryCPU {
+			t.Errorf("suite %s has invalid category %q", name, meta.Category)
+		}
+		if meta.PrimaryMetric == "" || meta.Unit == "" {
+			t.Errorf("suite %s is missing primary metric metadata", name)
+		}
+		if suite.GenerateCommand(BenchmarkConfig{BenchmarkSuite: name}) == "" {
+			t.Errorf("suite %s generated an empty command", name)
+		}
+	}
+}
+
+func TestLookupSuiteUnknown(t *testing.T) {
+	_, err := LookupSuite("nonexistent")
+	if !errors.Is(err, ErrUnsupportedBenchmark) {
+		t.Errorf("expected ErrUnsupportedBenchmark, got %v", err)
+	}
+}
+
+func TestRegisterSuiteDuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic when registering a duplicate suite name")
+		}
+	}()
+
+	suite, _ := LookupSuite("stream")
+	RegisterSuite(suite)
+}
+
+// echoSuite is a minimal suite used to verify that suites registered from
+// outside the built-in set flow through the full orchestration path.
+type echoSuite struct{}
+
+func (echoSuite) Name() string { return "test_echo" }
+
+func (echoSuite) GenerateCommand(config BenchmarkConfig) string {
+	return "echo score=42"
+}
+
+func (echoSuite) ParseOutput(output string) (*BenchmarkResults, error) {
+	value := strings.TrimPrefix(strings.TrimSpace(output), "score=")
+	score, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("no score in output: %w", err)
+	}
+	return echoResults(score)
+}
+
+func (echoSuite) AggregateResults(iterations []*BenchmarkResults) (*BenchmarkResults, error) {
+	total := 0.0
+	for _, iteration := range iterations {
+		var data echoData
+		if err := iteration.SuiteData("test_echo", &data); err != nil {
+			return nil, err
+		}
+		total += data.Score
+	}
+	return echoResults(total / float64(len(iterations)))
+}
+
+type echoData struct {
+	Score float64 `json:"score"`
+}
+
+func echoResults(score float64) (*BenchmarkResults, error) {
+	results := &BenchmarkResults{}
+	if err := results.SetSuiteData("test_echo", echoData{Score: score}); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (echoSuite) Metadata() SuiteMetadata {
+	return SuiteMetadata{Description: "test suite", Category: CategoryCPU, PrimaryMetric: "score", Unit: "points"}
+}
+
+func TestRunBenchmarkWithRegisteredSuite(t *testing.T) {
+	RegisterSuite(echoSuite{})
+
+	cloud := fakecloud.New("us-east-1")
+	cloud.HandleCommands(func(instanceID, command string) fakecloud.CommandResult {
+		if command != "echo score=42" {
+			return fakecloud.CommandResult{Status: ssmtypes.CommandInvocationStatusFailed, Stderr: "unexpected command"}
+		}
+		return fakecloud.CommandResult{Status: ssmtypes.CommandInvocationStatusSuccess, Stdout: "score=42\n"}
+	})
+
+	orchestrator := newFakeOrchestrator(cloud)
+	result, err := orchestrator.RunBenchmark(context.Background(), fakeBenchmarkConfig("m7i.large", "test_echo"))
+	if err != nil {
+		t.Fatalf("RunBenchmark failed: %v", err)
+	}
+
+	var data echoData
+	if err := result.BenchmarkData.SuiteData("test_echo", &data); err != nil || data.Score != 42 {
+		t.Errorf("unexpected benchmark data: %+v (%v)", result.BenchmarkData, err)
+	}
+}
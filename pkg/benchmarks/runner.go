@@ -0,0 +1,30 @@
+package benchmarks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+// CommandRunner executes a command and returns its standard output.
+//
+// Benchmarks run their containers through a CommandRunner rather than
+// calling os/exec directly, so tests can substitute a fake that checks the
+// command line and returns canned benchmark output.
+type CommandRunner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+// execCommand runs a command on the host. A command that exits with a
+// non-zero status is reported as ErrContainerExecution with its stderr.
+func execCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	stdout, err := exec.CommandContext(ctx, name, args...).Output()
+	if err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) {
+			return nil, fmt.Errorf("%w (exit %d): %s", ErrContainerExecution,
+				exitError.ExitCode(), string(exitError.Stderr))
+		}
+		return nil, fmt.Errorf("failed to execute container: %w", err)
+	}
+	return stdout, nil
+}
@@ -0,0 +1,231 @@
+package benchmarks
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Runtime names accepted by NewRuntime.
+const (
+	RuntimeDocker  = "docker"
+	RuntimePodman  = "podman"
+	RuntimeProcess = "process"
+)
+
+// ErrUnknownRuntime is returned by NewRuntime for an unsupported runtime name.
+var ErrUnknownRuntime = errors.New("unknown benchmark runtime")
+
+// hostMemoryReserve is the share of instance memory left to the operating
+// system when a workload is sized from the NUMA topology.
+const hostMemoryReserve = 0.1
+
+// Mount makes a host directory available to a workload.
+type Mount struct {
+	Source   string
+	Target   string
+	ReadOnly bool
+}
+
+// RunSpec describes one benchmark workload independently of how it is run.
+//
+// Container runtimes run Image with the limits, mounts and environment
+// below; the process runtime runs Process directly on the host instead,
+// for instances without a container engine.
+type RunSpec struct {
+	// Name identifies the workload, e.g. as the container name.
+	Name string
+
+	// Image is the container image to run.
+	Image string
+
+	// Process is the command line run by the process runtime. It must honour
+	// the same environment variables and print the same output as the
+	// image's entrypoint.
+	Process []string
+
+	// Env holds "KEY=value" variables passed to the workload.
+	Env []string
+
+	// Mounts are host directories the workload reads its input from.
+	Mounts []Mount
+
+	// Tmpfs lists writable scratch directories inside a read-only container.
+	Tmpfs []string
+
+	// MemoryGB and CPUs limit a container; zero leaves it unlimited.
+	MemoryGB int
+	CPUs     int
+
+	// CPUSet pins the workload to these CPU cores and MemoryNodes binds its
+	// memory to these NUMA nodes; empty leaves placement to the kernel.
+	CPUSet      []int
+	MemoryNodes []int
+}
+
+// Runtime turns a RunSpec into the command that runs it. Commands are
+// executed through a CommandRunner, so runtimes only build command lines.
+type Runtime interface {
+	// Name returns the runtime name, e.g. "docker".
+	Name() string
+
+	// Command returns the executable and arguments that run spec.
+	Command(spec RunSpec) (string, []string)
+}
+
+// NewRuntime returns the runtime with the given name: "docker", "podman"
+// or "process".
+func NewRuntime(name string) (Runtime, error) {
+	switch name {
+	case RuntimeDocker:
+		return NewDockerRuntime(), nil
+	case RuntimePodman:
+		return NewPodmanRuntime(), nil
+	case RuntimeProcess:
+		return NewProcessRuntime(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownRuntime, name)
+	}
+}
+
+// containerRuntime runs workloads with a Docker-compatible container CLI.
+type containerRuntime struct {
+	binary string
+}
+
+// NewDockerRuntime returns a runtime that runs workloads with docker.
+func NewDockerRuntime() Runtime {
+	return containerRuntime{binary: RuntimeDocker}
+}
+
+// NewPodmanRuntime returns a runtime that runs workloads with podman, e.g.
+// rootless on hosts without a Docker daemon.
+func NewPodmanRuntime() Runtime {
+	return containerRuntime{binary: RuntimePodman}
+}
+
+// Name returns the container CLI name.
+func (r containerRuntime) Name() string {
+	return r.binary
+}
+
+// Command builds a "run" command with security and resource constraints:
+//
+//   docker run --rm --name {name}
+//          [--memory {N}G] [--cpus {N}]
+//          [--cpuset-cpus {cores}] [--cpuset-mems {nodes}]
+//          --security-opt no-new-privileges
+//          --read-only --network none
+//          [--tmpfs {dir}] [-v {source}:{target}[:ro]] [-e KEY=value]
+//          {image}
+func (r containerRuntime) Command(spec RunSpec) (string, []string) {
+	args := []string{"run", "--rm", "--name", spec.Name}
+	if spec.MemoryGB > 0 {
+		args = append(args, "--memory", fmt.Sprintf("%dG", spec.MemoryGB))
+	}
+	if spec.CPUs > 0 {
+		args = append(args, "--cpus", strconv.Itoa(spec.CPUs))
+	}
+	if len(spec.CPUSet) > 0 {
+		args = append(args, "--cpuset-cpus", joinInts(spec.CPUSet))
+	}
+	if len(spec.MemoryNodes) > 0 {
+		args = append(args, "--cpuset-mems", joinInts(spec.MemoryNodes))
+	}
+	args = append(args,
+		"--security-opt", "no-new-privileges", // Security hardening
+		"--read-only",                         // Immutable container filesystem
+		"--network", "none",                   // Network isolation
+	)
+	for _, dir := range spec.Tmpfs {
+		args = append(args, "--tmpfs", dir)
+	}
+	for _, mount := range spec.Mounts {
+		volume := mount.Source + ":" + mount.Target
+		if mount.ReadOnly {
+			volume += ":ro"
+		}
+		args = append(args, "-v", volume)
+	}
+	for _, env := range spec.Env {
+		args = append(args, "-e", env)
+	}
+	return r.binary, append(args, spec.Image)
+}
+
+// processRuntime runs workloads directly on the host.
+type processRuntime struct{}
+
+// NewProcessRuntime returns a runtime that runs each workload's Process
+// command on the host, using the whole instance without container limits.
+func NewProcessRuntime() Runtime {
+	return processRuntime{}
+}
+
+// Name returns "process".
+func (processRuntime) Name() string {
+	return RuntimeProcess
+}
+
+// Command runs the workload's Process through env(1) to pass its
+// environment. Without a container there are no mounts, so variables that
+// point below a mount target are rewritten to the host directory, and CPU
+// and memory placement is applied with numactl(8).
+func (processRuntime) Command(spec RunSpec) (string, []string) {
+	args := make([]string, 0, len(spec.Env)+len(spec.Process)+3)
+	for _, env := range spec.Env {
+		key, value, _ := strings.Cut(env, "=")
+		for _, mount := range spec.Mounts {
+			if value == mount.Target || strings.HasPrefix(value, mount.Target+"/") {
+				value = mount.Source + strings.TrimPrefix(value, mount.Target)
+				break
+			}
+		}
+		args = append(args, key+"="+value)
+	}
+	if len(spec.CPUSet) > 0 || len(spec.MemoryNodes) > 0 {
+		args = append(args, "numactl")
+		if len(spec.CPUSet) > 0 {
+			args = append(args, "--physcpubind="+joinInts(spec.CPUSet))
+		}
+		if len(spec.MemoryNodes) > 0 {
+			args = append(args, "--membind="+joinInts(spec.MemoryNodes))
+		}
+	}
+	return "env", append(args, spec.Process...)
+}
+
+// joinInts formats a CPU or node list, e.g. "0,1,2".
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = strconv.Itoa(value)
+	}
+	return strings.Join(parts, ",")
+}
+
+// setEnv returns env with key set to value, replacing an existing entry.
+func setEnv(env []string, key, value string) []string {
+	entry := key + "=" + value
+	for i, existing := range env {
+		if strings.HasPrefix(existing, key+"=") {
+			env[i] = entry
+			return env
+		}
+	}
+	return append(env, entry)
+}
+
+// topologyLimits sizes a workload to the whole instance: every core and
+// the memory not reserved for the operating system. Unknown memory or
+// cores leave the workload unlimited; the per-node estimate of TotalCores
+// is not used, as it would cap larger instances.
+func topologyLimits(topology NumaTopology) (memoryGB, cpus int) {
+	memoryGB = int(math.Floor(topology.TotalMemory() * (1 - hostMemoryReserve)))
+	for _, node := range topology.NodesInfo {
+		cpus += len(node.CPUCores)
+	}
+	return memoryGB, cpus
+}
@@ -0,0 +1,101 @@
+package benchmarks
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestContainerRuntimeCommand(t *testing.T) {
+	spec := RunSpec{
+		Name:     "test-run",
+		Image:    testContainerImage,
+		Env:      []string{"RUN_ID=1"},
+		Mounts:   []Mount{{Source: "/host/input", Target: "/input", ReadOnly: true}},
+		Tmpfs:    []string{"/tmp"},
+		MemoryGB: 8,
+		CPUs:     4,
+	}
+	expected := []string{
+		"run", "--rm", "--name", "test-run",
+		"--memory", "8G", "--cpus", "4",
+		"--security-opt", "no-new-privileges",
+		"--read-only", "--network", "none",
+		"--tmpfs", "/tmp",
+		"-v", "/host/input:/input:ro",
+		"-e", "RUN_ID=1",
+		testContainerImage,
+	}
+	
+	for _, runtime := range []Runtime{NewDockerRuntime(), NewPodmanRuntime()} {
+		name, args := runtime.Command(spec)
+		if name != runtime.Name() {
+			t.Errorf("Expected %s binary, got %s", runtime.Name(), name)
+		}
+		if !reflect.DeepEqual(args, expected) {
+			t.Errorf("%s args = %v, want %v", runtime.Name(), args, expected)
+		}
+	}
+}
+
+func TestProcessRuntimeCommand(t *testing.T) {
+	spec := RunSpec{
+		Image:    testContainerImage,
+		Process:  []string{"stream", "--quiet"},
+		Env:      []string{"INPUT=/input/data.txt", "INPUTS=/inputs", "RUN_ID=1"},
+		Mounts:   []Mount{{Source: "/host/input", Target: "/input"}},
+		MemoryGB: 8,
+		CPUs:     4,
+	}
+	
+	name, args := NewProcessRuntime().Command(spec)
+	expected := []string{"INPUT=/host/input/data.txt", "INPUTS=/inputs", "RUN_ID=1", "stream", "--quiet"}
+	if name != "env" || !reflect.DeepEqual(args, expected) {
+		t.Errorf("Command = %s %v, want env %v", name, args, expected)
+	}
+}
+
+func TestProcessRuntimePlacement(t *testing.T) {
+	spec := RunSpec{Process: []string{"stream"}, CPUSet: []int{4, 5}, MemoryNodes: []int{1}}
+	
+	_, args := NewProcessRuntime().Command(spec)
+	expected := []string{"numactl", "--physcpubind=4,5", "--membind=1", "stream"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("args = %v, want %v", args, expected)
+	}
+}
+
+func TestNewRuntime(t *testing.T) {
+	for _, name := range []string{RuntimeDocker, RuntimePodman, RuntimeProcess} {
+		runtime, err := NewRuntime(name)
+		if err != nil {
+			t.Fatalf("NewRuntime(%q) failed: %v", name, err)
+		}
+		if runtime.Name() != name {
+			t.Errorf("Expected runtime %q, got %q", name, runtime.Name())
+		}
+	}
+	
+	if _, err := NewRuntime("containerd"); !errors.Is(err, ErrUnknownRuntime) {
+		t.Errorf("Expected ErrUnknownRuntime, got %v", err)
+	}
+}
+
+func TestTopologyLimits(t *testing.T) {
+	memoryGB, cpus := topologyLimits(NumaTopology{
+		NodeCount: 2,
+		NodesInfo: []NumaNode{
+			{CPUCores: []int{0, 1, 2, 3}, MemorySize: 64},
+			{CPUCores: []int{4, 5, 6, 7}, MemorySize: 64},
+		},
+	})
+	if memoryGB != 115 || cpus != 8 {
+		t.Errorf("topologyLimits = %dG, %d CPUs; want 115G, 8 CPUs", memoryGB, cpus)
+	}
+
+	// Without per-node details nothing is known about cores or memory
+	memoryGB, cpus = topologyLimits(NumaTopology{NodeCount: 2})
+	if memoryGB != 0 || cpus != 0 {
+		t.Errorf("topologyLimits without nodes info = %dG, %d CPUs; want unlimited", memoryGB, cpus)
+	}
+}
@@ -49,7 +49,6 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"os/exec"
 	"strconv"
 	"strings"
 	"time"
@@ -88,13 +87,24 @@ type StreamBenchmark struct {
 	// numaTopology contains NUMA node information for optimization.
 	numaTopology NumaTopology
 	
-	// storage provides S3-based result persistence (optional).
+	// cacheHierarchy describes the instance caches used to size the STREAM
+	// arrays (optional). If unknown, a fixed array size is used.
+	cacheHierarchy CacheHierarchy
+	
+	// storage provides result persistence in S3 or a local directory (optional).
 	// If nil, results are not automatically stored.
-	storage *storage.S3Storage
+	storage storage.ResultStore
 	
 	// metricsCollector provides CloudWatch metrics integration (optional).
 	// If nil, metrics are not automatically published.
 	metricsCollector *monitoring.MetricsCollector
+	
+	// runtime builds the command that runs the STREAM workload; Docker by
+	// default, set via WithRuntime().
+	runtime Runtime
+	
+	// runner executes the workload command; replaced by tests via WithRunner.
+	runner CommandRunner
 }
 
 // BenchmarkConfig defines comprehensive configuration for benchmark execution
@@ -129,6 +139,23 @@ type BenchmarkConfig struct {
 	// EnableNUMA controls whether NUMA-aware optimizations are applied.
 	// Set to false for single-socket systems or when testing cross-NUMA performance.
 	EnableNUMA bool
+	
+	// ArraySize overrides the number of elements in each STREAM array.
+	// Zero sizes the arrays from the cache hierarchy and NUMA topology.
+	ArraySize int64
+	
+	// EnableSweep adds a thread-scaling sweep and, on multi-node systems,
+	// a NUMA node-to-node bandwidth matrix to the results.
+	EnableSweep bool
+	
+	// SweepThreads lists the thread counts of the scaling sweep; they
+	// must be positive and duplicates run once. Empty sweeps powers of two
+	// up to the total core count.
+	SweepThreads []int
+	
+	// SweepIterations is the number of runs averaged for each sweep point.
+	// Defaults to 3.
+	SweepIterations int
 }
 
 // BenchmarkResult contains comprehensive results from benchmark execution
@@ -157,6 +184,10 @@ type BenchmarkResult struct {
 	// statistical confidence and stability requirements.
 	ValidationStatus ValidationStatus
 	
+	// Sweep contains the thread-scaling curve and NUMA bandwidth matrix.
+	// Nil unless BenchmarkConfig.EnableSweep is set.
+	Sweep *SweepResult
+	
 	// Timestamp records when the benchmark execution completed.
 	Timestamp time.Time
 }
@@ -231,6 +262,10 @@ type ExecutionMetadata struct {
 	
 	// Region indicates the AWS region where the benchmark was executed.
 	Region string
+	
+	// ArraySizing records the STREAM array size and the rule that chose it.
+	// Nil for other benchmark suites.
+	ArraySizing *ArraySizing
 }
 
 // CompilerInfo contains detailed information about the compiler toolchain
@@ -292,6 +327,11 @@ type CacheLevel struct {
 	
 	// LineSize specifies the cache line size in bytes.
 	LineSize int
+	
+	// Instances is the number of separate caches at this level, e.g. one
+	// L3 per CCD on AMD EPYC. Zero means one per NUMA node for the L3 and
+	// one per core for the L2.
+	Instances int
 }
 
 // NumaTopology contains NUMA (Non-Uniform Memory Access) system topology
@@ -452,6 +492,9 @@ func NewStreamBenchmark(config BenchmarkConfig, containerImage string, numaTopol
 	if config.MemoryPattern == "" {
 		config.MemoryPattern = "sequential"
 	}
+	if config.SweepIterations == 0 {
+		config.SweepIterations = 3
+	}
 
 	return &StreamBenchmark{
 		config:         config,
@@ -459,17 +502,21 @@ func NewStreamBenchmark(config BenchmarkConfig, containerImage string, numaTopol
 		numaTopology:     numaTopology,
 		storage:          nil, // Storage is optional, set via WithStorage()
 		metricsCollector: nil, // Metrics are optional, set via WithMetrics()
+		runtime:          NewDockerRuntime(),
+		runner:           execCommand,
 	}
 }
 
-// WithStorage configures S3 storage for automatic result persistence.
+// WithStorage configures a result store for automatic result persistence.
 //
 // This method enables automatic storage of benchmark results to S3 with
 // comprehensive metadata and intelligent organization. Results are stored
 // immediately after successful execution with proper error handling.
+// A storage.LocalStorage keeps the same layout in a local directory for
+// runs without AWS access.
 //
 // Parameters:
-//   - storage: Configured S3Storage instance for result persistence
+//   - storage: Configured ResultStore, e.g. S3Storage or LocalStorage
 //
 // Returns:
 //   - *StreamBenchmark: The same benchmark instance for method chaining
@@ -498,7 +545,7 @@ func NewStreamBenchmark(config BenchmarkConfig, containerImage string, numaTopol
 //   - Storage failures do not cause benchmark execution to fail
 //   - Storage errors are logged but do not propagate to the caller
 //   - Metadata includes comprehensive context for analysis and filtering
-func (s *StreamBenchmark) WithStorage(storage *storage.S3Storage) *StreamBenchmark {
+func (s *StreamBenchmark) WithStorage(storage storage.ResultStore) *StreamBenchmark {
 	s.storage = storage
 	return s
 }
@@ -550,6 +597,53 @@ func (s *StreamBenchmark) WithMetrics(collector *monitoring.MetricsCollector) *S
 	return s
 }
 
+// WithCacheHierarchy provides the instance cache hierarchy, e.g. from
+// system profiling, so the STREAM arrays can be sized to at least 4x the
+// last-level cache as the STREAM run rules require.
+//
+// Parameters:
+//   - cache: Cache sizes and instance counts of the target instance
+//
+// Returns:
+//   - *StreamBenchmark: The same benchmark instance for method chaining
+func (s *StreamBenchmark) WithCacheHierarchy(cache CacheHierarchy) *StreamBenchmark {
+	s.cacheHierarchy = cache
+	return s
+}
+
+// WithRuntime selects how the STREAM workload is run: in a Docker or
+// Podman container, or directly on the host with NewProcessRuntime.
+//
+// Parameters:
+//   - runtime: Runtime that builds the workload command
+//
+// Returns:
+//   - *StreamBenchmark: The same benchmark instance for method chaining
+//
+// Example:
+//   runtime, err := NewRuntime("podman")
+//   if err != nil {
+//       return err
+//   }
+//   benchmark := NewStreamBenchmark(config, image, topology).WithRuntime(runtime)
+func (s *StreamBenchmark) WithRuntime(runtime Runtime) *StreamBenchmark {
+	s.runtime = runtime
+	return s
+}
+
+// WithRunner replaces the command runner used to execute the STREAM
+// workload, e.g. with a fake in tests.
+//
+// Parameters:
+//   - runner: Runs a command and returns its standard output
+//
+// Returns:
+//   - *StreamBenchmark: The same benchmark instance for method chaining
+func (s *StreamBenchmark) WithRunner(runner CommandRunner) *StreamBenchmark {
+	s.runner = runner
+	return s
+}
+
 // Execute runs the STREAM benchmark with statistical validation and returns
 // comprehensive results including confidence intervals and performance analysis.
 //
@@ -613,6 +707,7 @@ func (s *StreamBenchmark) Execute(ctx context.Context) (*BenchmarkResult, error)
 	
 	// Collect system information for metadata
 	systemInfo := s.collectSystemInfo(ctx)
+	sizing := s.arraySizing()
 	
 	// Execute multiple benchmark runs
 	rawResults, err := s.executeMultipleRuns(ctx)
@@ -632,6 +727,15 @@ func (s *StreamBenchmark) Execute(ctx context.Context) (*BenchmarkResult, error)
 	// Validate results quality
 	validation := s.validateResults(measurements, summary)
 	
+	// Run the thread-scaling and NUMA sweep if requested
+	var sweep *SweepResult
+	if s.config.EnableSweep {
+		sweep, err = s.executeSweep(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("benchmark sweep failed: %w", err)
+		}
+	}
+	
 	// Construct comprehensive result
 	result := &BenchmarkResult{
 		BenchmarkSuite: "stream",
@@ -640,9 +744,11 @@ func (s *StreamBenchmark) Execute(ctx context.Context) (*BenchmarkResult, error)
 			SystemInfo:        systemInfo,
 			ExecutionDuration: time.Since(startTime),
 			ContainerImage:    s.containerImage,
+			ArraySizing:       &sizing,
 		},
 		StatisticalSummary: summary,
 		ValidationStatus:   validation,
+		Sweep:              sweep,
 		Timestamp:          time.Now(),
 	}
 	
@@ -718,7 +824,7 @@ func (s *StreamBenchmark) validateConfig() error {
 func (s *StreamBenchmark) collectSystemInfo(_ context.Context) SystemInfo {
 	// Implementation would collect real system information
 	// For now, return mock data matching the structure
-	return SystemInfo{
+	info := SystemInfo{
 		CPUModel:    "Intel Xeon Platinum 8375C",
 		CPUCores:    8,
 		CPUThreads:  16,
@@ -730,6 +836,10 @@ func (s *StreamBenchmark) collectSystemInfo(_ context.Context) SystemInfo {
 			L3Cache: CacheLevel{Size: 54000, Associativity: 20, LineSize: 64},
 		},
 	}
+	if s.cacheHierarchy.L3Cache.Size > 0 || s.cacheHierarchy.L2Cache.Size > 0 {
+		info.CacheHierarchy = s.cacheHierarchy
+	}
+	return info
 }
 
 // executeMultipleRuns performs the specified number of benchmark iterations.
@@ -809,23 +919,22 @@ func (s *StreamBenchmark) executeMultipleRuns(ctx context.Context) ([]map[string
 //   - JSON parsing failures due to unexpected output format
 //   - Timeout errors for slow instances or large array sizes
 func (s *StreamBenchmark) executeSingleRun(ctx context.Context, runNumber int) (map[string]float64, error) {
-	// Build Docker command with appropriate configuration
-	dockerArgs := s.buildDockerCommand(runNumber)
-	
-	// Execute container with timeout protection
-	cmd := exec.CommandContext(ctx, "docker", dockerArgs...)
-	
-	// Capture stdout and stderr for parsing and debugging
-	stdout, err := cmd.Output()
+	return s.executeRunSpec(ctx, s.buildRunSpec(runNumber))
+}
+
+// executeRunSpec runs one STREAM workload with the configured runtime and
+// returns its validated bandwidth results in GB/s.
+func (s *StreamBenchmark) executeRunSpec(ctx context.Context, spec RunSpec) (map[string]float64, error) {
+	// Build the workload command for the configured runtime
+	name, args := s.runtime.Command(spec)
+	
+	// Execute the workload, capturing stdout for parsing
+	stdout, err := s.runner(ctx, name, args...)
 	if err != nil {
-		if exitError, ok := err.(*exec.ExitError); ok {
-			return nil, fmt.Errorf("%w (exit %d): %s", ErrContainerExecution,
-				exitError.ExitCode(), string(exitError.Stderr))
-		}
-		return nil, fmt.Errorf("failed to execute container: %w", err)
+		return nil, err
 	}
 	
-	// Parse JSON output from container
+	// Parse JSON output from the workload
 	results, err := s.parseContainerOutput(stdout)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse container output: %w", err)
@@ -839,78 +948,64 @@ func (s *StreamBenchmark) executeSingleRun(ctx context.Context, runNumber int) (
 	return results, nil
 }
 
-// buildDockerCommand constructs the Docker command arguments for STREAM benchmark execution.
+// buildRunSpec describes one STREAM run independently of the runtime.
 //
-// This method builds a comprehensive Docker command with proper resource constraints,
-// NUMA configuration, security settings, and environment variables. It ensures
-// reproducible execution across different environments while maintaining security.
-//
-// The command includes:
-//   - Resource limits (memory, CPU) based on instance capabilities
-//   - NUMA topology configuration for multi-socket optimization
-//   - Security constraints (read-only filesystem, no-network)
-//   - Environment variables for benchmark configuration
-//   - Proper cleanup settings for container lifecycle management
+// Resource limits are derived from the NUMA topology so the benchmark
+// measures the bandwidth of the whole instance: all cores, and all memory
+// except a reserve for the operating system. Limits that the topology does
+// not determine are left unset, so the container runs uncapped.
 //
 // Parameters:
 //   - runNumber: Sequential run identifier for container naming and logging
 //
 // Returns:
-//   - []string: Complete Docker command arguments ready for exec.Command
+//   - RunSpec: Image, process command, limits and environment of the run
 //
 // Docker Command Structure:
-//   docker run --rm --name stream-run-{N} 
-//          --memory 4G --cpus 8 
-//          --security-opt no-new-privileges 
+//   docker run --rm --name stream-run-{N}
+//          --memory {90% of instance memory}G --cpus {total cores}
+//          --security-opt no-new-privileges
 //          --read-only --network none
-//          -e NUMA_NODE=0 -e ARRAY_SIZE=80000000 
-//          -e MEMORY_PATTERN=sequential -e OUTPUT_FORMAT=json
+//          -e NUMA_NODE=0 -e NUMA_NODES={nodes}
+//          -e OMP_NUM_THREADS={total cores} -e OUTPUT_FORMAT=json
+//          -e RUN_ID={N} -e MEMORY_PATTERN=sequential -e ARRAY_SIZE={elements}
 //          {container_image}
 //
-// Security Considerations:
-//   - Read-only root filesystem prevents container modification
-//   - Network isolation prevents external communication
-//   - No privilege escalation allowed
-//   - Resource limits prevent resource exhaustion attacks
-//
-// Performance Tuning:
-//   - Memory limit based on instance type and array size requirements
-//   - CPU constraints aligned with NUMA topology
-//   - Environment variables for architecture-specific optimizations
-func (s *StreamBenchmark) buildDockerCommand(runNumber int) []string {
-	containerName := fmt.Sprintf("stream-run-%d-%d", runNumber, time.Now().Unix())
-	
-	// Base Docker arguments with security and resource constraints
-	args := []string{
-		"run",
-		"--rm",                              // Automatic cleanup
-		"--name", containerName,             // Unique container name
-		"--memory", "4G",                    // Memory limit for safety
-		"--cpus", "8",                       // CPU limit aligned with typical instances
-		"--security-opt", "no-new-privileges", // Security hardening
-		"--read-only",                       // Immutable container filesystem
-		"--network", "none",                 // Network isolation
+// Process Runtime:
+//   Runs the stream binary from PATH with the same environment. A binary
+//   that ignores OUTPUT_FORMAT and prints the classic STREAM report is
+//   parsed by the text fallback of parseContainerOutput.
+func (s *StreamBenchmark) buildRunSpec(runNumber int) RunSpec {
+	memoryGB, cpus := topologyLimits(s.numaTopology)
+	spec := RunSpec{
+		Name:     fmt.Sprintf("stream-run-%d-%d", runNumber, time.Now().Unix()),
+		Image:    s.containerImage,
+		Process:  []string{"stream"},
+		MemoryGB: memoryGB,
+		CPUs:     cpus,
 	}
 	
 	// Add NUMA configuration if enabled
 	if s.config.EnableNUMA && s.numaTopology.NodeCount > 0 {
 		// Use first NUMA node for single-node benchmarks
-		args = append(args, "-e", "NUMA_NODE=0")
-		args = append(args, "-e", fmt.Sprintf("NUMA_NODES=%d", s.numaTopology.NodeCount))
+		spec.Env = append(spec.Env, "NUMA_NODE=0")
+		spec.Env = append(spec.Env, fmt.Sprintf("NUMA_NODES=%d", s.numaTopology.NodeCount))
+	}
+	
+	// One OpenMP thread per core
+	if cpus > 0 {
+		spec.Env = append(spec.Env, fmt.Sprintf("OMP_NUM_THREADS=%d", cpus))
 	}
 	
 	// Add benchmark configuration environment variables
-	args = append(args,
-		"-e", "OUTPUT_FORMAT=json",                    // Ensure JSON output
-		"-e", fmt.Sprintf("RUN_ID=%d", runNumber),     // Run identification
-		"-e", fmt.Sprintf("MEMORY_PATTERN=%s", s.config.MemoryPattern), // Access pattern
-		"-e", "ARRAY_SIZE=80000000",                   // Standard STREAM array size
+	spec.Env = append(spec.Env,
+		"OUTPUT_FORMAT=json",                                     // Ensure JSON output
+		fmt.Sprintf("RUN_ID=%d", runNumber),                      // Run identification
+		fmt.Sprintf("MEMORY_PATTERN=%s", s.config.MemoryPattern), // Access pattern
+		fmt.Sprintf("ARRAY_SIZE=%d", s.arraySizing().ArraySize),  // Elements per array
 	)
 	
-	// Add container image as final argument
-	args = append(args, s.containerImage)
-	
-	return args
+	return spec
 }
 
 // parseContainerOutput parses JSON output from the STREAM benchmark container.
@@ -1226,6 +1321,12 @@ func (s *StreamBenchmark) validateResults(measurements map[string]Measurement, s
 		}
 	}
 	
+	// Check the arrays exceeded the last-level cache
+	sizing := s.arraySizing()
+	sizingErrors, sizingWarnings := validateArraySizing(sizing)
+	errors = append(errors, sizingErrors...)
+	warnings = append(warnings, sizingWarnings...)
+	
 	// Check outlier removal rate
 	outlierRate := float64(summary.TotalOutliers) / float64(s.config.Iterations*len(measurements)) * 100
 	if outlierRate > 20.0 {
@@ -1240,6 +1341,9 @@ func (s *StreamBenchmark) validateResults(measurements map[string]Measurement, s
 	if outlierRate > 15.0 {
 		qualityScore -= 0.2
 	}
+	if sizing.TooSmall() {
+		qualityScore -= 0.5 // Results may include cache bandwidth
+	}
 	
 	return ValidationStatus{
 		IsValid:           len(errors) == 0,
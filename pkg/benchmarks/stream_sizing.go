@@ -0,0 +1,142 @@
+package benchmarks
+
+import (
+	"fmt"
+	"math"
+)
+
+// Rules that choose the STREAM array size, recorded in ArraySizing.Rule.
+const (
+	// ArraySizeRuleConfigured uses BenchmarkConfig.ArraySize as given.
+	ArraySizeRuleConfigured = "configured"
+
+	// ArraySizeRuleLLC sizes each array to 4x the last-level cache, the
+	// minimum required by the STREAM run rules.
+	ArraySizeRuleLLC = "4x-llc"
+
+	// ArraySizeRuleMinimum uses the minimum array size because 4x the
+	// last-level cache is smaller.
+	ArraySizeRuleMinimum = "minimum"
+
+	// ArraySizeRuleMemoryLimit caps the array size so that the three arrays
+	// fit in the memory available to the workload.
+	ArraySizeRuleMemoryLimit = "memory-limit"
+
+	// ArraySizeRuleDefault uses the default array size because the cache
+	// hierarchy is unknown.
+	ArraySizeRuleDefault = "default"
+)
+
+const (
+	// defaultStreamArraySize is used when the cache hierarchy is unknown.
+	defaultStreamArraySize = 80000000
+
+	// minStreamArraySize keeps small-cache instances from running arrays
+	// so short that timer resolution dominates.
+	minStreamArraySize = 10000000
+
+	// streamArrayCacheMultiple is the STREAM rule: each array must be at
+	// least 4 times the size of all last-level caches combined.
+	streamArrayCacheMultiple = 4
+
+	// streamArrayCount is the number of arrays STREAM allocates.
+	streamArrayCount = 3
+
+	// streamElementBytes is the size of one double-precision element.
+	streamElementBytes = 8
+
+	// streamMemoryFraction is the share of the workload's memory the
+	// arrays may use, leaving the rest to the OS and the runtime.
+	streamMemoryFraction = 0.5
+)
+
+// ArraySizing records how the STREAM array size was chosen.
+type ArraySizing struct {
+	// ArraySize is the number of elements in each STREAM array.
+	ArraySize int64
+
+	// Rule names the rule that chose ArraySize, e.g. "4x-llc".
+	Rule string
+
+	// LastLevelCacheKB is the combined size of all last-level caches in
+	// KB; zero when the cache hierarchy is unknown.
+	LastLevelCacheKB int64
+
+	// RequiredArraySize is the smallest array size that satisfies the
+	// 4x last-level cache rule; zero when it cannot be determined.
+	RequiredArraySize int64
+}
+
+// TooSmall reports whether the arrays are too small for STREAM to
+// measure memory rather than cache bandwidth.
+func (a ArraySizing) TooSmall() bool {
+	return a.RequiredArraySize > 0 && a.ArraySize < a.RequiredArraySize
+}
+
+// arraySizing chooses the STREAM array size from the configuration, the
+// cache hierarchy and the NUMA topology.
+//
+// An explicit BenchmarkConfig.ArraySize always wins. Otherwise each array
+// is sized to 4x the combined last-level cache, but at least
+// minStreamArraySize, and capped so the three arrays fit in half of the
+// memory available to the workload. Without a known cache hierarchy the
+// historic fixed size is used.
+func (s *StreamBenchmark) arraySizing() ArraySizing {
+	sizing := ArraySizing{LastLevelCacheKB: s.lastLevelCacheKB()}
+	if sizing.LastLevelCacheKB > 0 {
+		sizing.RequiredArraySize = int64(math.Ceil(
+			float64(sizing.LastLevelCacheKB*1024*streamArrayCacheMultiple) / streamElementBytes))
+	}
+
+	switch {
+	case s.config.ArraySize > 0:
+		sizing.ArraySize, sizing.Rule = s.config.ArraySize, ArraySizeRuleConfigured
+		return sizing
+	case sizing.RequiredArraySize == 0:
+		sizing.ArraySize, sizing.Rule = defaultStreamArraySize, ArraySizeRuleDefault
+	case sizing.RequiredArraySize < minStreamArraySize:
+		sizing.ArraySize, sizing.Rule = minStreamArraySize, ArraySizeRuleMinimum
+	default:
+		sizing.ArraySize, sizing.Rule = sizing.RequiredArraySize, ArraySizeRuleLLC
+	}
+
+	if memoryGB, _ := topologyLimits(s.numaTopology); memoryGB > 0 {
+		maxSize := int64(float64(memoryGB) * (1 << 30) * streamMemoryFraction / (streamArrayCount * streamElementBytes))
+		if sizing.ArraySize > maxSize {
+			sizing.ArraySize, sizing.Rule = maxSize, ArraySizeRuleMemoryLimit
+		}
+	}
+	return sizing
+}
+
+// lastLevelCacheKB returns the combined size of all last-level caches in
+// KB: the L3, or the L2 on parts without one, times its instance count.
+// Without an instance count there is assumed to be one L3 per NUMA node,
+// or one L2 per core.
+func (s *StreamBenchmark) lastLevelCacheKB() int64 {
+	cache, instances := s.cacheHierarchy.L3Cache, maxInt(1, s.numaTopology.NodeCount)
+	if cache.Size == 0 {
+		cache, instances = s.cacheHierarchy.L2Cache, maxInt(1, s.numaTopology.TotalCores())
+	}
+	if cache.Instances > 0 {
+		instances = cache.Instances
+	}
+	return int64(cache.Size) * int64(instances)
+}
+
+// validateArraySizing flags results whose arrays were too small to
+// exceed the last-level cache, and results whose array size could not be
+// checked.
+func validateArraySizing(sizing ArraySizing) (errs, warnings []string) {
+	switch {
+	case sizing.TooSmall():
+		errs = append(errs, fmt.Sprintf(
+			"array size %d is below the 4x last-level cache minimum of %d elements (%s)",
+			sizing.ArraySize, sizing.RequiredArraySize, sizing.Rule))
+	case sizing.RequiredArraySize == 0:
+		warnings = append(warnings, fmt.Sprintf(
+			"cache hierarchy unknown; array size %d not checked against the last-level cache",
+			sizing.ArraySize))
+	}
+	return errs, warnings
+}
@@ -0,0 +1,91 @@
+package benchmarks
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestArraySizing(t *testing.T) {
+	testCases := []struct {
+		name        string
+		arraySize   int64
+		cache       CacheHierarchy
+		topology    NumaTopology
+		expectSize  int64
+		expectRule  string
+		expectSmall bool
+	}{
+		{
+			name:       "unknown cache uses default",
+			expectSize: defaultStreamArraySize,
+			expectRule: ArraySizeRuleDefault,
+		},
+		{
+			name:       "small cache uses minimum",
+			cache:      CacheHierarchy{L3Cache: CacheLevel{Size: 8 * 1024}},
+			topology:   NumaTopology{NodeCount: 1, TotalMemoryGB: 64},
+			expectSize: minStreamArraySize,
+			expectRule: ArraySizeRuleMinimum,
+		},
+		{
+			// Genoa-X: 12 CCDs with 96 MB of L3 each
+			name:       "large cache uses 4x LLC",
+			cache:      CacheHierarchy{L3Cache: CacheLevel{Size: 96 * 1024, Instances: 12}},
+			topology:   NumaTopology{NodeCount: 1, TotalMemoryGB: 768},
+			expectSize: 4 * 12 * 96 * 1024 * 1024 / 8,
+			expectRule: ArraySizeRuleLLC,
+		},
+		{
+			name:        "memory limit caps the arrays",
+			cache:       CacheHierarchy{L3Cache: CacheLevel{Size: 96 * 1024, Instances: 12}},
+			topology:    NumaTopology{NodeCount: 1, TotalMemoryGB: 16},
+			expectSize:  14 * (1 << 30) / 2 / 24,
+			expectRule:  ArraySizeRuleMemoryLimit,
+			expectSmall: true,
+		},
+		{
+			name:        "configured size is kept but checked",
+			arraySize:   1000000,
+			cache:       CacheHierarchy{L3Cache: CacheLevel{Size: 32 * 1024}},
+			topology:    NumaTopology{NodeCount: 2},
+			expectSize:  1000000,
+			expectRule:  ArraySizeRuleConfigured,
+			expectSmall: true,
+		},
+	}
+	
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			benchmark := NewStreamBenchmark(BenchmarkConfig{ArraySize: tc.arraySize}, testContainerImage, tc.topology).
+				WithCacheHierarchy(tc.cache)
+			
+			sizing := benchmark.arraySizing()
+			if sizing.ArraySize != tc.expectSize || sizing.Rule != tc.expectRule {
+				t.Errorf("arraySizing = %d (%s), want %d (%s)",
+					sizing.ArraySize, sizing.Rule, tc.expectSize, tc.expectRule)
+			}
+			if sizing.TooSmall() != tc.expectSmall {
+				t.Errorf("TooSmall = %v, want %v", sizing.TooSmall(), tc.expectSmall)
+			}
+		})
+	}
+}
+
+func TestValidateResultsFlagsSmallArrays(t *testing.T) {
+	benchmark := NewStreamBenchmark(BenchmarkConfig{ArraySize: 1000000}, testContainerImage, NumaTopology{NodeCount: 1}).
+		WithCacheHierarchy(CacheHierarchy{L3Cache: CacheLevel{Size: 32 * 1024}})
+	measurements := map[string]Measurement{"triad": {CoefficientOfVariation: 1.0}}
+	
+	validation := benchmark.validateResults(measurements, StatisticalSummary{OverallStability: 1.0})
+	if validation.IsValid {
+		t.Error("Results with arrays smaller than 4x LLC should not be valid")
+	}
+	if len(validation.ValidationErrors) != 1 || !strings.Contains(validation.ValidationErrors[0], "last-level cache") {
+		t.Errorf("Expected array size error, got %v", validation.ValidationErrors)
+	}
+	
+	_, args := NewDockerRuntime().Command(benchmark.buildRunSpec(1))
+	if !strings.Contains(strings.Join(args, " "), "ARRAY_SIZE=1000000") {
+		t.Errorf("Expected configured array size in command: %v", args)
+	}
+}
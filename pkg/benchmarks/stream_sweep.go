@@ -0,0 +1,186 @@
+package benchmarks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// saturationFraction is the share of peak bandwidth at which the memory
+// subsystem is considered saturated: more threads add little bandwidth.
+const saturationFraction = 0.9
+
+// ErrSweepTopology is returned when the sweep cannot determine how many
+// threads to run.
+var ErrSweepTopology = errors.New("sweep requires the core count from the NUMA topology")
+
+// SweepResult contains the STREAM Triad bandwidth measured across thread
+// counts and NUMA node placements.
+type SweepResult struct {
+	// ThreadScaling is the bandwidth-vs-threads curve, ordered by thread
+	// count, with threads spread across all NUMA nodes.
+	ThreadScaling []ScalingPoint
+
+	// PeakBandwidth is the highest bandwidth on the curve in GB/s.
+	PeakBandwidth float64
+
+	// SaturationThreads is the fewest threads reaching 90% of
+	// PeakBandwidth; adding threads beyond it gains little bandwidth.
+	SaturationThreads int
+
+	// NUMABandwidth[i][j] is the bandwidth in GB/s of threads on NUMA
+	// node i accessing memory on node j: local on the diagonal, remote
+	// elsewhere. Nil on single-node systems or when the topology does not
+	// list the cores of each node.
+	NUMABandwidth [][]float64
+}
+
+// ScalingPoint is one point of the bandwidth-vs-threads curve.
+type ScalingPoint struct {
+	// Threads is the number of OpenMP threads.
+	Threads int
+
+	// Bandwidth is the mean Triad bandwidth in GB/s.
+	Bandwidth float64
+}
+
+// executeSweep runs the thread-scaling sweep and, on multi-node systems,
+// every CPU node and memory node pairing.
+//
+// Each point runs SweepIterations times and records the mean Triad
+// bandwidth. Scaling runs spread threads across the whole instance with
+// OMP_PROC_BIND=spread; NUMA runs pin one thread per core of the CPU node
+// and bind all memory to the memory node.
+func (s *StreamBenchmark) executeSweep(ctx context.Context) (*SweepResult, error) {
+	threadCounts, err := s.sweepThreadCounts()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SweepResult{}
+	for _, threads := range threadCounts {
+		spec := s.buildRunSpec(0)
+		spec.Name = fmt.Sprintf("stream-sweep-t%d", threads)
+		spec.Env = setEnv(spec.Env, "OMP_NUM_THREADS", strconv.Itoa(threads))
+		spec.Env = setEnv(spec.Env, "OMP_PROC_BIND", "spread")
+		spec.Env = setEnv(spec.Env, "OMP_PLACES", "cores")
+
+		bandwidth, err := s.measureSweepPoint(ctx, spec)
+		if err != nil {
+			return nil, fmt.Errorf("%d threads: %w", threads, err)
+		}
+		result.ThreadScaling = append(result.ThreadScaling, ScalingPoint{Threads: threads, Bandwidth: bandwidth})
+	}
+	result.PeakBandwidth, result.SaturationThreads = saturationPoint(result.ThreadScaling)
+
+	result.NUMABandwidth, err = s.sweepNUMANodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// sweepNUMANodes measures the bandwidth from the cores of each NUMA node
+// to the memory of each node.
+func (s *StreamBenchmark) sweepNUMANodes(ctx context.Context) ([][]float64, error) {
+	nodes := s.numaTopology.NodesInfo
+	if len(nodes) < 2 {
+		return nil, nil
+	}
+	for _, node := range nodes {
+		if len(node.CPUCores) == 0 {
+			return nil, nil
+		}
+	}
+
+	matrix := make([][]float64, len(nodes))
+	for i, cpuNode := range nodes {
+		matrix[i] = make([]float64, len(nodes))
+		for j, memoryNode := range nodes {
+			spec := s.buildRunSpec(0)
+			spec.Name = fmt.Sprintf("stream-sweep-n%d-m%d", cpuNode.NodeID, memoryNode.NodeID)
+			spec.CPUs = len(cpuNode.CPUCores)
+			spec.CPUSet = cpuNode.CPUCores
+			spec.MemoryNodes = []int{memoryNode.NodeID}
+			spec.Env = setEnv(spec.Env, "OMP_NUM_THREADS", strconv.Itoa(len(cpuNode.CPUCores)))
+			spec.Env = setEnv(spec.Env, "OMP_PROC_BIND", "close")
+
+			bandwidth, err := s.measureSweepPoint(ctx, spec)
+			if err != nil {
+				return nil, fmt.Errorf("node %d to node %d: %w", cpuNode.NodeID, memoryNode.NodeID, err)
+			}
+			matrix[i][j] = bandwidth
+		}
+	}
+	return matrix, nil
+}
+
+// measureSweepPoint runs one sweep configuration SweepIterations times and
+// returns its mean Triad bandwidth.
+func (s *StreamBenchmark) measureSweepPoint(ctx context.Context, spec RunSpec) (float64, error) {
+	iterations := maxInt(1, s.config.SweepIterations)
+	values := make([]float64, 0, iterations)
+	for i := 0; i < iterations; i++ {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		default:
+		}
+
+		spec.Env = setEnv(spec.Env, "RUN_ID", strconv.Itoa(i))
+		results, err := s.executeRunSpec(ctx, spec)
+		if err != nil {
+			return 0, err
+		}
+		values = append(values, results["triad"])
+	}
+	return calculateMean(values), nil
+}
+
+// sweepThreadCounts returns the distinct configured thread counts in
+// ascending order, or powers of two up to the total core count, which is
+// always included.
+func (s *StreamBenchmark) sweepThreadCounts() ([]int, error) {
+	if len(s.config.SweepThreads) > 0 {
+		counts := append([]int(nil), s.config.SweepThreads...)
+		sort.Ints(counts)
+		if counts[0] <= 0 {
+			return nil, fmt.Errorf("invalid sweep thread count %d: must be positive", counts[0])
+		}
+		distinct := counts[:1]
+		for _, threads := range counts[1:] {
+			if threads != distinct[len(distinct)-1] {
+				distinct = append(distinct, threads)
+			}
+		}
+		return distinct, nil
+	}
+
+	cores := s.numaTopology.TotalCores()
+	if cores == 0 {
+		return nil, ErrSweepTopology
+	}
+	var counts []int
+	for threads := 1; threads < cores; threads *= 2 {
+		counts = append(counts, threads)
+	}
+	return append(counts, cores), nil
+}
+
+// saturationPoint returns the peak bandwidth of a scaling curve and the
+// fewest threads that reach saturationFraction of it.
+func saturationPoint(points []ScalingPoint) (peak float64, threads int) {
+	for _, point := range points {
+		if point.Bandwidth > peak {
+			peak = point.Bandwidth
+		}
+	}
+	for _, point := range points {
+		if point.Bandwidth >= peak*saturationFraction {
+			return peak, point.Threads
+		}
+	}
+	return peak, 0
+}
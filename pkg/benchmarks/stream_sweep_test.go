@@ -0,0 +1,136 @@
+package benchmarks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// sweepRunner fakes a STREAM container whose Triad bandwidth grows by
+// 10 GB/s per thread up to 40 GB/s, and drops to 60% for remote memory.
+func sweepRunner() CommandRunner {
+	return func(_ context.Context, _ string, args ...string) ([]byte, error) {
+		var threads int
+		var cpuSet, memoryNodes string
+		for i, arg := range args {
+			switch {
+			case strings.HasPrefix(arg, "OMP_NUM_THREADS="):
+				threads, _ = strconv.Atoi(strings.TrimPrefix(arg, "OMP_NUM_THREADS="))
+			case arg == "--cpuset-cpus":
+				cpuSet = args[i+1]
+			case arg == "--cpuset-mems":
+				memoryNodes = args[i+1]
+			}
+		}
+		
+		triad := float64(threads) * 10
+		if triad > 40 {
+			triad = 40
+		}
+		if cpuSet != "" {
+			triad = 30
+			cpuNode := "0"
+			if strings.HasPrefix(cpuSet, "4") {
+				cpuNode = "1"
+			}
+			if cpuNode != memoryNodes {
+				triad = 18
+			}
+		}
+		return []byte(fmt.Sprintf(`{"stream_results": {"copy": %[1]g, "scale": %[1]g, "add": %[1]g, "triad": %[1]g}}`, triad)), nil
+	}
+}
+
+func TestExecuteSweep(t *testing.T) {
+	topology := NumaTopology{
+		NodeCount:     2,
+		TotalMemoryGB: 64,
+		NodesInfo: []NumaNode{
+			{NodeID: 0, CPUCores: []int{0, 1, 2, 3}},
+			{NodeID: 1, CPUCores: []int{4, 5, 6, 7}},
+		},
+	}
+	benchmark := NewStreamBenchmark(BenchmarkConfig{EnableSweep: true, SweepIterations: 2}, testContainerImage, topology).
+		WithRunner(sweepRunner())
+	
+	sweep, err := benchmark.executeSweep(context.Background())
+	if err != nil {
+		t.Fatalf("executeSweep failed: %v", err)
+	}
+	
+	expectedCurve := []ScalingPoint{{1, 10}, {2, 20}, {4, 40}, {8, 40}}
+	if !reflect.DeepEqual(sweep.ThreadScaling, expectedCurve) {
+		t.Errorf("ThreadScaling = %v, want %v", sweep.ThreadScaling, expectedCurve)
+	}
+	if sweep.PeakBandwidth != 40 || sweep.SaturationThreads != 4 {
+		t.Errorf("Expected saturation at 4 threads and 40 GB/s, got %d threads and %.1f GB/s",
+			sweep.SaturationThreads, sweep.PeakBandwidth)
+	}
+	
+	expectedMatrix := [][]float64{{30, 18}, {18, 30}}
+	if !reflect.DeepEqual(sweep.NUMABandwidth, expectedMatrix) {
+		t.Errorf("NUMABandwidth = %v, want %v", sweep.NUMABandwidth, expectedMatrix)
+	}
+}
+
+func TestExecuteSweepSingleNode(t *testing.T) {
+	config := BenchmarkConfig{EnableSweep: true, SweepThreads: []int{4, 1}}
+	benchmark := NewStreamBenchmark(config, testContainerImage, NumaTopology{NodeCount: 1}).
+		WithRunner(sweepRunner())
+	
+	sweep, err := benchmark.executeSweep(context.Background())
+	if err != nil {
+		t.Fatalf("executeSweep failed: %v", err)
+	}
+	if len(sweep.ThreadScaling) != 2 || sweep.ThreadScaling[0].Threads != 1 {
+		t.Errorf("Expected configured thread counts in ascending order, got %v", sweep.ThreadScaling)
+	}
+	if sweep.NUMABandwidth != nil {
+		t.Errorf("Expected no NUMA matrix on a single node, got %v", sweep.NUMABandwidth)
+	}
+}
+
+func TestSweepThreadCounts(t *testing.T) {
+	tests := []struct {
+		name    string
+		threads []int
+		want    []int
+		wantErr bool
+	}{
+		{name: "sorted", threads: []int{8, 1, 4}, want: []int{1, 4, 8}},
+		{name: "duplicates", threads: []int{4, 1, 4, 1, 2}, want: []int{1, 2, 4}},
+		{name: "zero", threads: []int{4, 0}, wantErr: true},
+		{name: "negative", threads: []int{-2, 4}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			benchmark := NewStreamBenchmark(BenchmarkConfig{EnableSweep: true, SweepThreads: tt.threads}, testContainerImage, NumaTopology{NodeCount: 1})
+			counts, err := benchmark.sweepThreadCounts()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %v", counts)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("sweepThreadCounts failed: %v", err)
+			}
+			if !reflect.DeepEqual(counts, tt.want) {
+				t.Errorf("sweepThreadCounts = %v, want %v", counts, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteSweepUnknownTopology(t *testing.T) {
+	benchmark := NewStreamBenchmark(BenchmarkConfig{EnableSweep: true}, testContainerImage, NumaTopology{})
+	
+	if _, err := benchmark.executeSweep(context.Background()); !errors.Is(err, ErrSweepTopology) {
+		t.Errorf("Expected ErrSweepTopology, got %v", err)
+	}
+}
@@ -442,6 +442,27 @@ func TestWithStorage(t *testing.T) {
 	}
 }
 
+func TestExecuteWithLocalStorage(t *testing.T) {
+	store := storage.NewLocalStorage(t.TempDir(), storage.Config{})
+	benchmark := NewStreamBenchmark(BenchmarkConfig{Iterations: 3, MinValidRuns: 2}, testContainerImage, NumaTopology{NodeCount: 1}).
+		WithRunner(func(_ context.Context, _ string, _ ...string) ([]byte, error) {
+			return []byte(`{"stream_results": {"copy": 45.2, "scale": 44.8, "add": 42.1, "triad": 41.9}}`), nil
+		}).
+		WithStorage(store)
+	
+	if _, err := benchmark.Execute(context.Background()); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	
+	results, err := store.GetResults(context.Background(), storage.ResultQuery{BenchmarkSuites: []string{"stream"}})
+	if err != nil {
+		t.Fatalf("GetResults failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Expected the result to be stored locally, found %d results", len(results))
+	}
+}
+
 func TestWithMetrics(t *testing.T) {
 	config := BenchmarkConfig{
 		Iterations:      5,
@@ -517,11 +538,11 @@ func TestBuildDockerCommand(t *testing.T) {
 	}
 	
 	containerImage := testContainerImage
-	numaTopology := NumaTopology{NodeCount: 2}
+	numaTopology := NumaTopology{NodeCount: 2, TotalMemoryGB: 32}
 	
 	benchmark := NewStreamBenchmark(config, containerImage, numaTopology)
 	
-	args := benchmark.buildDockerCommand(1)
+	_, args := NewDockerRuntime().Command(benchmark.buildRunSpec(1))
 	
 	// Verify essential Docker arguments are present
 	cmdStr := strings.Join(args, " ")
@@ -534,14 +555,19 @@ func TestBuildDockerCommand(t *testing.T) {
 		t.Error("Expected '--rm' flag for container cleanup")
 	}
 	
-	if !strings.Contains(cmdStr, "--memory") {
+	// Limits cover the whole instance less the OS memory reserve
+	if !strings.Contains(cmdStr, "--memory 28G") {
 		t.Error("Expected memory limit in Docker args")
 	}
 	
-	if !strings.Contains(cmdStr, "--cpus") {
+	if !strings.Contains(cmdStr, "--cpus 16") {
 		t.Error("Expected CPU limit in Docker args")
 	}
 	
+	if !strings.Contains(cmdStr, "OMP_NUM_THREADS=16") {
+		t.Error("Expected one OpenMP thread per core")
+	}
+	
 	if !strings.Contains(cmdStr, "--read-only") {
 		t.Error("Expected read-only filesystem for security")
 	}
@@ -572,6 +598,36 @@ func TestBuildDockerCommand(t *testing.T) {
 	}
 }
 
+func TestBuildRunSpecUnknownMemory(t *testing.T) {
+	benchmark := NewStreamBenchmark(BenchmarkConfig{}, testContainerImage, NumaTopology{})
+	
+	_, args := NewDockerRuntime().Command(benchmark.buildRunSpec(1))
+	cmdStr := strings.Join(args, " ")
+	
+	if strings.Contains(cmdStr, "--memory") || strings.Contains(cmdStr, "--cpus") {
+		t.Errorf("Expected no limits without a known topology: %s", cmdStr)
+	}
+}
+
+func TestExecuteSingleRunWithRuntime(t *testing.T) {
+	benchmark := NewStreamBenchmark(BenchmarkConfig{}, testContainerImage, NumaTopology{NodeCount: 1}).
+		WithRuntime(NewProcessRuntime()).
+		WithRunner(func(_ context.Context, name string, args ...string) ([]byte, error) {
+			if name != "env" || args[len(args)-1] != "stream" {
+				t.Errorf("Expected stream to run through env, got %s %v", name, args)
+			}
+			return []byte(`{"stream_results": {"copy": 45.2, "scale": 44.8, "add": 42.1, "triad": 41.9}}`), nil
+		})
+	
+	results, err := benchmark.executeSingleRun(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("executeSingleRun failed: %v", err)
+	}
+	if results["triad"] != 41.9 {
+		t.Errorf("Expected triad bandwidth 41.9, got %f", results["triad"])
+	}
+}
+
 func TestParseContainerOutput(t *testing.T) {
 	benchmark := &StreamBenchmark{}
 	
@@ -0,0 +1,322 @@
+// Package dashboard renders the live progress of benchmark jobs.
+//
+// A Dashboard is an aws.EventSink: subscribed to the event bus of an
+// Orchestrator or AsyncCollector, it tracks each job's status, progress,
+// elapsed time, estimated cost and latest log line. On a terminal it redraws
+// a table in place; elsewhere, e.g. in CI logs, it prints one line per
+// change.
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	awspkg "github.com/scttfrdmn/aws-instance-benchmarks/pkg/aws"
+)
+
+// DefaultRefreshInterval is how often an interactive dashboard redraws.
+const DefaultRefreshInterval = time.Second
+
+// ANSI sequences used to redraw an interactive dashboard in place.
+const (
+	clearScreen = "\033[H\033[2J"
+	bold        = "\033[1m"
+	reset       = "\033[0m"
+)
+
+// progressBarWidth is the number of cells in a progress bar.
+const progressBarWidth = 10
+
+// maxMessageWidth truncates log lines in the interactive table.
+const maxMessageWidth = 60
+
+// jobState is the dashboard's view of one job.
+type jobState struct {
+	id             string
+	instanceType   string
+	benchmarkSuite string
+	region         string
+	purchaseOption string
+	status         awspkg.JobStatus
+	percent        float64
+	message        string
+	started        time.Time
+	finished       time.Time
+}
+
+// Dashboard shows the state of benchmark jobs from their events. It is safe
+// for concurrent use.
+type Dashboard struct {
+	mu          sync.Mutex
+	out         io.Writer
+	interactive bool
+	hourlyPrice func(instanceType, region string) (float64, bool)
+	now         func() time.Time
+	started     time.Time
+	jobs        map[string]*jobState
+}
+
+// New returns a dashboard writing to out. An interactive dashboard redraws
+// a table on each Render; a plain one prints a line per status or log
+// change as events arrive.
+func New(out io.Writer, interactive bool) *Dashboard {
+	return &Dashboard{
+		out:         out,
+		interactive: interactive,
+		now:         time.Now,
+		started:     time.Now(),
+		jobs:        make(map[string]*jobState),
+	}
+}
+
+// WithPricing estimates the cost of each job from the hourly on-demand price
+// of its instance type in its region. Jobs without a price are shown without
+// a cost. Spot jobs are billed at most the on-demand price, so their cost is
+// shown as an upper bound, e.g. "≤$0.1008".
+func (d *Dashboard) WithPricing(hourlyPrice func(instanceType, region string) (float64, bool)) *Dashboard {
+	d.hourlyPrice = hourlyPrice
+	return d
+}
+
+// IsTerminal reports whether f is a terminal, i.e. whether a dashboard
+// writing to it should be interactive.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// HandleEvent updates the job the event belongs to.
+func (d *Dashboard) HandleEvent(event awspkg.Event) {
+	if event.JobID == "" {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	job, ok := d.jobs[event.JobID]
+	if !ok {
+		job = &jobState{id: event.JobID, started: event.StartTime}
+		if job.started.IsZero() {
+			job.started = event.Time
+		}
+		d.jobs[event.JobID] = job
+	}
+	if !event.StartTime.IsZero() && (job.started.IsZero() || event.StartTime.Before(job.started)) {
+		job.started = event.StartTime
+	}
+	if event.InstanceType != "" {
+		job.instanceType = event.InstanceType
+	}
+	if event.BenchmarkSuite != "" {
+		job.benchmarkSuite = event.BenchmarkSuite
+	}
+	if event.Region != "" {
+		job.region = event.Region
+	}
+	if event.PurchaseOption != "" {
+		job.purchaseOption = event.PurchaseOption
+	}
+
+	changed := !ok || (event.Status != "" && event.Status != job.status) || (event.Message != "" && event.Message != job.message)
+	if event.Status != "" {
+		job.status = event.Status
+	}
+	if event.Type == awspkg.EventJobProgress || event.PercentComplete > 0 || event.Status == awspkg.JobStatusLaunched {
+		job.percent = event.PercentComplete
+	}
+	if event.Message != "" {
+		job.message = event.Message
+	}
+	if event.Final() {
+		if job.finished.IsZero() {
+			job.finished = event.Time
+		}
+	} else if event.Status != "" {
+		job.finished = time.Time{} // A retried or resumed job runs again
+	}
+
+	if changed && !d.interactive {
+		d.printLine(job)
+	}
+}
+
+// printLine prints the current state of a job as a single line.
+func (d *Dashboard) printLine(job *jobState) {
+	line := fmt.Sprintf("%s %s %s %.0f%%", d.now().Format("15:04:05"), job.id, job.status, job.percent)
+	if job.message != "" {
+		line += " " + job.message
+	}
+	line += fmt.Sprintf(" (elapsed %v", d.elapsed(job).Round(time.Second))
+	if cost, ok := d.cost(job); ok {
+		line += ", " + formatCost(cost, job.spot())
+	}
+	fmt.Fprintln(d.out, line+")")
+}
+
+// elapsed returns how long a job has run, stopping the clock when it finished.
+func (d *Dashboard) elapsed(job *jobState) time.Duration {
+	end := job.finished
+	if end.IsZero() {
+		end = d.now()
+	}
+	if job.started.IsZero() || end.Before(job.started) {
+		return 0
+	}
+	return end.Sub(job.started)
+}
+
+// spot reports whether a job runs on a spot instance.
+func (job *jobState) spot() bool {
+	return job.purchaseOption == awspkg.PurchaseSpot
+}
+
+// formatCost formats a cost in USD, marking costs of spot instances
+// estimated at the on-demand price as an upper bound.
+func formatCost(cost float64, upperBound bool) string {
+	if upperBound {
+		return fmt.Sprintf("≤$%.4f", cost)
+	}
+	return fmt.Sprintf("$%.4f", cost)
+}
+
+// cost estimates the instance cost a job has accrued so far at the
+// on-demand price.
+func (d *Dashboard) cost(job *jobState) (float64, bool) {
+	if d.hourlyPrice == nil || job.instanceType == "" {
+		return 0, false
+	}
+	price, ok := d.hourlyPrice(job.instanceType, job.region)
+	if !ok {
+		return 0, false
+	}
+	return price * d.elapsed(job).Hours(), true
+}
+
+// sortedJobs returns the jobs in the order they started.
+func (d *Dashboard) sortedJobs() []*jobState {
+	jobs := make([]*jobState, 0, len(d.jobs))
+	for _, job := range d.jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if !jobs[i].started.Equal(jobs[j].started) {
+			return jobs[i].started.Before(jobs[j].started)
+		}
+		return jobs[i].id < jobs[j].id
+	})
+	return jobs
+}
+
+// Render redraws the table of an interactive dashboard. It does nothing for
+// a plain dashboard, which prints as events arrive.
+func (d *Dashboard) Render() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.interactive {
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString(clearScreen)
+	fmt.Fprintf(&b, "%s%s%s\n\n", bold, d.summary(), reset)
+	fmt.Fprintf(&b, "%-36s %-15s %-17s %9s %9s  %s\n", "JOB", "STATUS", "PROGRESS", "ELAPSED", "COST", "LATEST")
+	for _, job := range d.sortedJobs() {
+		cost := "-"
+		if value, ok := d.cost(job); ok {
+			cost = formatCost(value, job.spot())
+		}
+		fmt.Fprintf(&b, "%-36s %-15s %-17s %9v %9s  %s\n",
+			truncate(job.id, 36), job.status, progressBar(job.percent),
+			d.elapsed(job).Round(time.Second), cost, truncate(job.message, maxMessageWidth))
+	}
+	io.WriteString(d.out, b.String())
+}
+
+// summary describes all jobs in one line: counts by status, elapsed time
+// and the estimated cost so far.
+func (d *Dashboard) summary() string {
+	counts := make(map[awspkg.JobStatus]int)
+	var statuses []string
+	var total float64
+	priced, upperBound := false, false
+	for _, job := range d.jobs {
+		if counts[job.status] == 0 {
+			statuses = append(statuses, string(job.status))
+		}
+		counts[job.status]++
+		if cost, ok := d.cost(job); ok {
+			total += cost
+			priced = true
+			upperBound = upperBound || job.spot()
+		}
+	}
+	sort.Strings(statuses)
+
+	parts := []string{fmt.Sprintf("%d jobs", len(d.jobs))}
+	for _, status := range statuses {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[awspkg.JobStatus(status)], status))
+	}
+	parts = append(parts, fmt.Sprintf("elapsed %v", d.now().Sub(d.started).Round(time.Second)))
+	if priced {
+		parts = append(parts, "est. cost "+formatCost(total, upperBound))
+	}
+	return strings.Join(parts, " | ")
+}
+
+// Summary returns a one-line summary of all jobs.
+func (d *Dashboard) Summary() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.summary()
+}
+
+// Run redraws an interactive dashboard every interval until ctx is done,
+// then renders a final time. A plain dashboard prints its summary instead.
+func (d *Dashboard) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		d.Render()
+		select {
+		case <-ctx.Done():
+			d.Render()
+			if !d.interactive {
+				fmt.Fprintln(d.out, d.Summary())
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// progressBar draws a percentage as a fixed-width bar, e.g. "[####------] 40%".
+func progressBar(percent float64) string {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	filled := int(percent / 100 * progressBarWidth)
+	return fmt.Sprintf("[%s%s] %3.0f%%", strings.Repeat("#", filled), strings.Repeat("-", progressBarWidth-filled), percent)
+}
+
+// truncate shortens s to at most width runes.
+func truncate(s string, width int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
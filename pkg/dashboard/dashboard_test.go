@@ -0,0 +1,179 @@
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	awspkg "github.com/scttfrdmn/aws-instance-benchmarks/pkg/aws"
+)
+
+var start = time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+
+// newTestDashboard returns a dashboard whose clock is at start+offset.
+func newTestDashboard(out *bytes.Buffer, interactive bool, offset *time.Duration) *Dashboard {
+	d := New(out, interactive).WithPricing(func(instanceType, region string) (float64, bool) {
+		if instanceType == "m7i.large" && region == "us-east-1" {
+			return 0.1008, true
+		}
+		return 0, false
+	})
+	d.now = func() time.Time { return start.Add(*offset) }
+	d.started = start
+	return d
+}
+
+func streamEvent(status awspkg.JobStatus, percent float64, message string, at time.Duration) awspkg.Event {
+	return awspkg.Event{
+		Type:            awspkg.EventJobProgress,
+		Time:            start.Add(at),
+		JobID:           "us-east-1/m7i.large/stream/1",
+		InstanceType:    "m7i.large",
+		BenchmarkSuite:  "stream",
+		Region:          "us-east-1",
+		Status:          status,
+		PercentComplete: percent,
+		Message:         message,
+	}
+}
+
+func TestPlainDashboardPrintsChanges(t *testing.T) {
+	var out bytes.Buffer
+	offset := time.Duration(0)
+	d := newTestDashboard(&out, false, &offset)
+
+	launched := streamEvent(awspkg.JobStatusLaunched, 0, "instance launched", 0)
+	launched.StartTime = start
+	d.HandleEvent(launched)
+	offset = 30 * time.Minute
+	d.HandleEvent(streamEvent(awspkg.JobStatusRunning, 40, "running iteration 3/5", offset))
+	d.HandleEvent(streamEvent(awspkg.JobStatusRunning, 40, "running iteration 3/5", offset)) // Unchanged
+	offset = time.Hour
+	d.HandleEvent(streamEvent(awspkg.JobStatusCompleted, 100, "completed", offset))
+	offset = 2 * time.Hour // The clock stops when the job finishes
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	want := []string{
+		"12:00:00 us-east-1/m7i.large/stream/1 LAUNCHED 0% instance launched (elapsed 0s, $0.0000)",
+		"12:30:00 us-east-1/m7i.large/stream/1 RUNNING 40% running iteration 3/5 (elapsed 30m0s, $0.0504)",
+		"13:00:00 us-east-1/m7i.large/stream/1 COMPLETED 100% completed (elapsed 1h0m0s, $0.1008)",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("Expected %d lines, got:\n%s", len(want), out.String())
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+
+	if summary := d.Summary(); summary != "1 jobs | 1 COMPLETED | elapsed 2h0m0s | est. cost $0.1008" {
+		t.Errorf("Unexpected summary %q", summary)
+	}
+	if strings.Contains(out.String(), "\033[") {
+		t.Error("Expected no ANSI escapes in plain mode")
+	}
+}
+
+func TestInteractiveDashboardRendersTable(t *testing.T) {
+	var out bytes.Buffer
+	offset := 15 * time.Minute
+	d := newTestDashboard(&out, true, &offset)
+
+	d.HandleEvent(streamEvent(awspkg.JobStatusRunning, 40, "running iteration 3/5", 0))
+	failed := streamEvent(awspkg.JobStatusFailed, 0, "capacity: InsufficientInstanceCapacity\nmore", 5*time.Minute)
+	failed.JobID, failed.InstanceType = "bench-c7g", "c7g.large"
+	d.HandleEvent(failed)
+	if out.Len() != 0 {
+		t.Fatalf("Expected events not to print in interactive mode, got %q", out.String())
+	}
+
+	d.Render()
+	screen := out.String()
+	if !strings.HasPrefix(screen, clearScreen) {
+		t.Error("Expected the screen to be cleared before redrawing")
+	}
+	for _, want := range []string{
+		"2 jobs | 1 FAILED | 1 RUNNING | elapsed 15m0s | est. cost $0.0252",
+		"[####------]  40%",
+		"running iteration 3/5",
+		"$0.0252",
+		"capacity: InsufficientInstanceCapacity more",
+	} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected the table to contain %q:\n%s", want, screen)
+		}
+	}
+	if strings.Index(screen, "us-east-1/m7i.large/stream/1") > strings.Index(screen, "bench-c7g") {
+		t.Error("Expected jobs in the order they started")
+	}
+}
+
+func TestSpotJobCostIsAnUpperBound(t *testing.T) {
+	var out bytes.Buffer
+	offset := 30 * time.Minute
+	d := newTestDashboard(&out, false, &offset)
+
+	running := streamEvent(awspkg.JobStatusRunning, 40, "running iteration 3/5", offset)
+	running.StartTime, running.PurchaseOption = start, awspkg.PurchaseSpot
+	d.HandleEvent(running)
+
+	if line := strings.TrimSpace(out.String()); !strings.HasSuffix(line, "(elapsed 30m0s, ≤$0.0504)") {
+		t.Errorf("Expected the spot cost as an upper bound, got %q", line)
+	}
+	if summary := d.Summary(); !strings.HasSuffix(summary, "est. cost ≤$0.0504") {
+		t.Errorf("Expected the total as an upper bound, got %q", summary)
+	}
+}
+
+func TestRunStopsWithContext(t *testing.T) {
+	var out bytes.Buffer
+	offset := time.Minute
+	d := newTestDashboard(&out, false, &offset)
+	d.HandleEvent(streamEvent(awspkg.JobStatusRunning, 20, "running iteration 2/5", 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d.Run(ctx, time.Millisecond)
+
+	if !strings.HasSuffix(out.String(), "1 jobs | 1 RUNNING | elapsed 1m0s | est. cost $0.0017\n") {
+		t.Errorf("Expected a final summary line, got:\n%s", out.String())
+	}
+}
+
+func TestProgressBarAndTruncate(t *testing.T) {
+	for percent, want := range map[float64]string{
+		0:   "[----------]   0%",
+		55:  "[#####-----]  55%",
+		100: "[##########] 100%",
+		150: "[##########] 100%",
+	} {
+		if got := progressBar(percent); got != want {
+			t.Errorf("progressBar(%v) = %q, want %q", percent, got, want)
+		}
+	}
+	if got := truncate("iteration 3/5", 9); got != "iteratio…" {
+		t.Errorf("truncate = %q, want %q", got, "iteratio…")
+	}
+}
+
+func TestPlainDashboardIgnoresEventsWithoutStatus(t *testing.T) {
+	var out bytes.Buffer
+	offset := time.Duration(0)
+	d := newTestDashboard(&out, false, &offset)
+
+	d.HandleEvent(streamEvent(awspkg.JobStatusRunning, 20, "running iteration 2/5", 0))
+	command := streamEvent("", 0, "", 0)
+	command.Type, command.CommandID = awspkg.EventCommandSent, "cmd-2"
+	d.HandleEvent(command)
+	d.HandleEvent(awspkg.Event{Type: awspkg.EventJobStatus, Status: awspkg.JobStatusFailed})
+
+	if lines := strings.Split(strings.TrimSpace(out.String()), "\n"); len(lines) != 1 {
+		t.Errorf("Expected only the running line, got:\n%s", out.String())
+	}
+	if summary := d.Summary(); !strings.HasPrefix(summary, "1 jobs | 1 RUNNING") {
+		t.Errorf("Unexpected summary %q", summary)
+	}
+}
@@ -0,0 +1,1104 @@
+// Package fakecloud provides an in-memory, in-process simulation of the EC2
+// and Systems Manager APIs used by benchmark orchestration.
+//
+// The fake is designed for offline and CI testing of the complete
+// orchestration flow in pkg/aws without an AWS account. It models the parts
+// of AWS behaviour the orchestrator depends on rather than the full API:
+//
+//   - Instance lifecycle: pending → running → shutting-down → terminated,
+//     advanced each time an instance is observed through DescribeInstances
+//   - SSM command lifecycle: Pending → InProgress → terminal status,
+//     advanced each time an invocation is polled
+//   - Quota and capacity errors: per-instance-type capacity pools and an
+//     account-wide running instance limit
+//   - Spot launches with max-price checks and simulated interruptions
+//   - Tenancy, placement groups and capacity reservation targeting
+//   - Launch templates and SSM parameters for custom AMI resolution
+//   - AMI lookup with name, architecture and state filters
+//
+// Usage:
+//   cloud := fakecloud.New("us-east-1")
+//   cloud.HandleCommands(func(instanceID, command string) fakecloud.CommandResult {
+//       return fakecloud.CommandResult{Status: ssmtypes.CommandInvocationStatusSuccess, Stdout: streamOutput}
+//   })
+//   orchestrator := aws.NewOrchestratorWithClients("us-east-1", cloud.EC2(), cloud.SSM())
+//
+// All methods are safe for concurrent use.
+package fakecloud
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/aws/smithy-go"
+)
+
+// EC2 error codes returned by the fake, matching the codes AWS uses.
+const (
+	ErrCodeInsufficientCapacity = "InsufficientInstanceCapacity"
+	ErrCodeInstanceLimit        = "InstanceLimitExceeded"
+	ErrCodeInstanceNotFound     = "InvalidInstanceID.NotFound"
+	ErrCodeAMINotFound          = "InvalidAMIID.NotFound"
+	ErrCodeInvalidParameter     = "InvalidParameterValue"
+	ErrCodeInvalidInstanceID    = "InvalidInstanceId"
+	ErrCodeSpotMaxPriceTooLow   = "SpotMaxPriceTooLow"
+
+	ErrCodePlacementGroupUnknown       = "InvalidPlacementGroup.Unknown"
+	ErrCodeCapacityReservationNotFound = "InvalidCapacityReservationId.NotFound"
+	ErrCodeReservationCapacityExceeded = "ReservationCapacityExceeded"
+
+	ErrCodeLaunchTemplateNotFound = "InvalidLaunchTemplateName.NotFoundException"
+	ErrCodeLaunchTemplateVersion  = "InvalidLaunchTemplateId.VersionNotFound"
+	ErrCodeParameterNotFound      = "ParameterNotFound"
+)
+
+// StateReasonSpotTermination is the state reason code EC2 reports for
+// instances reclaimed by a spot interruption.
+const StateReasonSpotTermination = "Server.SpotInstanceTermination"
+
+// CommandResult is the terminal outcome of a simulated SSM command.
+type CommandResult struct {
+	// Status is the final invocation status, e.g. Success, Failed or TimedOut.
+	Status ssmtypes.CommandInvocationStatus
+
+	// Stdout is returned as StandardOutputContent.
+	Stdout string
+
+	// Stderr is returned as StandardErrorContent.
+	Stderr string
+}
+
+// CommandHandler produces the result of a command sent to an instance.
+// It is invoked once per SendCommand call.
+type CommandHandler func(instanceID, command string) CommandResult
+
+// Instance is a snapshot of a simulated EC2 instance.
+type Instance struct {
+	ID               string
+	InstanceType     string
+	ImageID          string
+	SubnetID         string
+	KeyName          string
+	UserData         string
+	AvailabilityZone string
+	PublicIP         string
+	PrivateIP        string
+	State            types.InstanceStateName
+	Tags             map[string]string
+	LaunchTime       time.Time
+
+	// Lifecycle is "spot" for spot instances and empty for on-demand.
+	Lifecycle types.InstanceLifecycleType
+
+	// SpotMaxPrice is the max price requested for a spot instance.
+	SpotMaxPrice string
+
+	// StateReason is the code explaining the last state change, e.g.
+	// StateReasonSpotTermination.
+	StateReason string
+
+	// Tenancy is the requested tenancy; empty means default.
+	Tenancy types.Tenancy
+
+	// HostID is the dedicated host the instance was placed on.
+	HostID string
+
+	// PlacementGroup is the placement group the instance was launched into.
+	PlacementGroup string
+
+	// CapacityReservationID is the capacity reservation the instance uses.
+	CapacityReservationID string
+
+	// LaunchTemplate is the name of the launch template the instance was
+	// launched from.
+	LaunchTemplate string
+
+	// observations counts DescribeInstances calls that returned this
+	// instance and drives state transitions.
+	observations int
+}
+
+// Invocation is a snapshot of a simulated SSM command invocation.
+type Invocation struct {
+	CommandID  string
+	InstanceID string
+	Command    string
+	Result     CommandResult
+	SentAt     time.Time
+
+	// polls counts GetCommandInvocation calls for this invocation.
+	polls int
+}
+
+// Cloud holds the simulated state shared by the EC2 and SSM fakes.
+type Cloud struct {
+	mu sync.Mutex
+
+	region string
+	nextID int
+
+	instances     map[string]*Instance
+	instanceOrder []string
+	images        []types.Image
+	invocations   map[string]*Invocation
+
+	// capacity holds the remaining launch capacity per instance type.
+	// Instance types without an entry have unlimited capacity.
+	capacity map[string]int
+
+	// instanceLimit caps pending and running instances account-wide.
+	// Zero means unlimited.
+	instanceLimit int
+
+	// pendingPolls is how many observations an instance stays pending.
+	pendingPolls int
+
+	// commandPolls is how many polls a command reports InProgress.
+	commandPolls int
+
+	// spotPrices holds the current spot price per instance type. Spot
+	// requests with a lower max price are rejected.
+	spotPrices map[string]float64
+
+	// subnetZones maps subnets to their availability zone. Instances in
+	// unknown subnets are placed in the region's "a" zone.
+	subnetZones map[string]string
+
+	// placementGroups holds the placement groups that launches may target.
+	placementGroups map[string]bool
+
+	// reservations holds the capacity reservations launches may target.
+	reservations map[string]*capacityReservation
+
+	// launchTemplates maps template names to the AMI of each version;
+	// version N is at index N-1 and version 1 is the default.
+	launchTemplates map[string][]string
+
+	// parameters holds SSM Parameter Store values by name.
+	parameters map[string]string
+
+	handler CommandHandler
+	now     func() time.Time
+}
+
+// New creates an empty fake cloud for the given region, seeded with one
+// Amazon Linux 2 image per supported architecture.
+func New(region string) *Cloud {
+	c := &Cloud{
+		region:      region,
+		instances:   make(map[string]*Instance),
+		invocations: make(map[string]*Invocation),
+		capacity:    make(map[string]int),
+		spotPrices:  make(map[string]float64),
+		subnetZones: make(map[string]string),
+
+		placementGroups: make(map[string]bool),
+		reservations:    make(map[string]*capacityReservation),
+		launchTemplates: make(map[string][]string),
+		parameters:      make(map[string]string),
+		handler: func(string, string) CommandResult {
+			return CommandResult{Status: ssmtypes.CommandInvocationStatusSuccess}
+		},
+		now: time.Now,
+	}
+
+	c.AddImage("amzn2-ami-hvm-2.0.20240620.0-x86_64-gp2", types.ArchitectureValuesX8664, "2024-06-20T00:00:00.000Z")
+	c.AddImage("amzn2-ami-hvm-2.0.20240620.0-arm64-gp2", types.ArchitectureValuesArm64, "2024-06-20T00:00:00.000Z")
+
+	return c
+}
+
+// Region returns the region the fake cloud simulates.
+func (c *Cloud) Region() string {
+	return c.region
+}
+
+// EC2 returns an EC2 client view of the fake cloud.
+func (c *Cloud) EC2() *EC2 {
+	return &EC2{cloud: c}
+}
+
+// SSM returns a Systems Manager client view of the fake cloud.
+func (c *Cloud) SSM() *SSM {
+	return &SSM{cloud: c}
+}
+
+// AddImage registers an available AMI and returns its generated image ID.
+func (c *Cloud) AddImage(name string, arch types.ArchitectureValues, creationDate string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := c.newID("ami")
+	c.images = append(c.images, types.Image{
+		ImageId:      aws.String(id),
+		Name:         aws.String(name),
+		Architecture: arch,
+		CreationDate: aws.String(creationDate),
+		State:        types.ImageStateAvailable,
+		OwnerId:      aws.String("amazon"),
+	})
+	return id
+}
+
+// SetCapacity limits how many more instances of the given type can be
+// launched. Launches beyond the limit fail with InsufficientInstanceCapacity.
+// Terminating an instance returns its capacity to the pool.
+func (c *Cloud) SetCapacity(instanceType string, remaining int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.capacity[instanceType] = remaining
+}
+
+// SetInstanceLimit caps the number of pending and running instances across
+// all types. Launches beyond the limit fail with InstanceLimitExceeded.
+func (c *Cloud) SetInstanceLimit(limit int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.instanceLimit = limit
+}
+
+// SetPendingPolls sets how many DescribeInstances observations a new
+// instance remains in the pending state before becoming running.
+func (c *Cloud) SetPendingPolls(polls int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pendingPolls = polls
+}
+
+// SetCommandPolls sets how many GetCommandInvocation polls a command reports
+// InProgress before its terminal result becomes visible.
+func (c *Cloud) SetCommandPolls(polls int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.commandPolls = polls
+}
+
+// AddSubnet places a subnet in an availability zone. Instances launched into
+// the subnet report that zone.
+func (c *Cloud) AddSubnet(subnetID, availabilityZone string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.subnetZones[subnetID] = availabilityZone
+}
+
+// AddPlacementGroup creates a placement group that launches may target.
+func (c *Cloud) AddPlacementGroup(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.placementGroups[name] = true
+}
+
+// capacityReservation is a simulated targeted capacity reservation.
+type capacityReservation struct {
+	instanceType     string
+	availabilityZone string
+	available        int
+}
+
+// AddCapacityReservation creates a capacity reservation for count instances
+// of a type in an availability zone. Launches targeting it are placed in
+// that zone and fail once it is used up.
+func (c *Cloud) AddCapacityReservation(reservationID, instanceType, availabilityZone string, count int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reservations[reservationID] = &capacityReservation{
+		instanceType:     instanceType,
+		availabilityZone: availabilityZone,
+		available:        count,
+	}
+}
+
+// AddLaunchTemplateVersion adds a version of a launch template that launches
+// the given AMI, creating the template if needed, and returns the new
+// version number.
+func (c *Cloud) AddLaunchTemplateVersion(name, imageID string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.launchTemplates[name] = append(c.launchTemplates[name], imageID)
+	return len(c.launchTemplates[name])
+}
+
+// SetParameter stores an SSM Parameter Store value, e.g. an AMI ID under
+// a path like /aws/service/ami-amazon-linux-latest/....
+func (c *Cloud) SetParameter(name, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.parameters[name] = value
+}
+
+// launchTemplateImage resolves the AMI of a launch template version. The
+// version may be a number, "$Latest", or "$Default" / empty for version 1.
+func (c *Cloud) launchTemplateImage(spec *types.LaunchTemplateSpecification) (string, error) {
+	name := aws.ToString(spec.LaunchTemplateName)
+	versions, ok := c.launchTemplates[name]
+	if !ok {
+		return "", apiError(ErrCodeLaunchTemplateNotFound, "The specified launch template, with template name %s, does not exist.", name)
+	}
+
+	version := aws.ToString(spec.Version)
+	switch version {
+	case "", "$Default":
+		return versions[0], nil
+	case "$Latest":
+		return versions[len(versions)-1], nil
+	}
+	var number int
+	if _, err := fmt.Sscanf(version, "%d", &number); err != nil || number < 1 || number > len(versions) {
+		return "", apiError(ErrCodeLaunchTemplateVersion, "Could not find launch template version %s of %s", version, name)
+	}
+	return versions[number-1], nil
+}
+
+// SetSpotPrice sets the current spot price of an instance type. Spot
+// launches with a max price below it fail with SpotMaxPriceTooLow.
+func (c *Cloud) SetSpotPrice(instanceType string, price float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.spotPrices[instanceType] = price
+}
+
+// InterruptSpot reclaims a spot instance the way EC2 does after the
+// two-minute interruption notice: the instance starts shutting down with
+// the spot termination state reason and running commands fail.
+func (c *Cloud) InterruptSpot(instanceID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	inst, ok := c.instances[instanceID]
+	if !ok {
+		return apiError(ErrCodeInstanceNotFound, "The instance ID '%s' does not exist", instanceID)
+	}
+	if inst.Lifecycle != types.InstanceLifecycleTypeSpot {
+		return apiError(ErrCodeInvalidParameter, "The instance '%s' is not a spot instance", instanceID)
+	}
+	inst.StateReason = StateReasonSpotTermination
+	c.setState(inst, types.InstanceStateNameShuttingDown)
+	return nil
+}
+
+// SetClock replaces the time source used for launch and command timestamps.
+func (c *Cloud) SetClock(now func() time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// HandleCommands installs the handler that produces SSM command results.
+func (c *Cloud) HandleCommands(handler CommandHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handler = handler
+}
+
+// SetInstanceState forces an instance into the given state, simulating
+// events outside the caller's control such as spot interruptions.
+func (c *Cloud) SetInstanceState(instanceID string, state types.InstanceStateName) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	inst, ok := c.instances[instanceID]
+	if !ok {
+		return apiError(ErrCodeInstanceNotFound, "The instance ID '%s' does not exist", instanceID)
+	}
+	c.setState(inst, state)
+	return nil
+}
+
+// AddInstance registers an instance directly, bypassing RunInstances. It is
+// useful for seeding pre-existing or leaked instances.
+func (c *Cloud) AddInstance(inst Instance) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if inst.ID == "" {
+		inst.ID = c.newID("i")
+	}
+	if inst.State == "" {
+		inst.State = types.InstanceStateNameRunning
+	}
+	if inst.LaunchTime.IsZero() {
+		inst.LaunchTime = c.now()
+	}
+	if inst.AvailabilityZone == "" {
+		inst.AvailabilityZone = c.region + "a"
+	}
+	if inst.Tags == nil {
+		inst.Tags = make(map[string]string)
+	}
+	c.instances[inst.ID] = &inst
+	c.instanceOrder = append(c.instanceOrder, inst.ID)
+	return inst.ID
+}
+
+// Instances returns snapshots of all instances in launch order.
+func (c *Cloud) Instances() []Instance {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]Instance, 0, len(c.instanceOrder))
+	for _, id := range c.instanceOrder {
+		out = append(out, c.instances[id].snapshot())
+	}
+	return out
+}
+
+// Instance returns a snapshot of a single instance.
+func (c *Cloud) Instance(instanceID string) (Instance, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	inst, ok := c.instances[instanceID]
+	if !ok {
+		return Instance{}, false
+	}
+	return inst.snapshot(), true
+}
+
+// Invocations returns snapshots of all SSM command invocations in send order.
+func (c *Cloud) Invocations() []Invocation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]Invocation, 0, len(c.invocations))
+	for _, inv := range c.invocations {
+		out = append(out, *inv)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CommandID < out[j].CommandID })
+	return out
+}
+
+// ActiveInstances counts instances that are pending or running.
+func (c *Cloud) ActiveInstances() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.activeCount()
+}
+
+func (c *Cloud) activeCount() int {
+	count := 0
+	for _, inst := range c.instances {
+		if inst.State == types.InstanceStateNamePending || inst.State == types.InstanceStateNameRunning {
+			count++
+		}
+	}
+	return count
+}
+
+func (c *Cloud) newID(prefix string) string {
+	c.nextID++
+	return fmt.Sprintf("%s-%017x", prefix, c.nextID)
+}
+
+// setState moves an instance to a new state and releases capacity when the
+// instance stops counting against the pool.
+func (c *Cloud) setState(inst *Instance, state types.InstanceStateName) {
+	wasActive := inst.State == types.InstanceStateNamePending || inst.State == types.InstanceStateNameRunning
+	inst.State = state
+	isActive := state == types.InstanceStateNamePending || state == types.InstanceStateNameRunning
+	if wasActive && !isActive {
+		if remaining, limited := c.capacity[inst.InstanceType]; limited {
+			c.capacity[inst.InstanceType] = remaining + 1
+		}
+		if reservation, ok := c.reservations[inst.CapacityReservationID]; ok {
+			reservation.available++
+		}
+	}
+}
+
+// observe advances an instance's lifecycle by one DescribeInstances call.
+func (c *Cloud) observe(inst *Instance) {
+	inst.observations++
+	switch inst.State {
+	case types.InstanceStateNamePending:
+		if inst.observations > c.pendingPolls {
+			c.setState(inst, types.InstanceStateNameRunning)
+		}
+	case types.InstanceStateNameShuttingDown:
+		c.setState(inst, types.InstanceStateNameTerminated)
+	case types.InstanceStateNameStopping:
+		c.setState(inst, types.InstanceStateNameStopped)
+	}
+}
+
+func (inst *Instance) snapshot() Instance {
+	out := *inst
+	out.Tags = make(map[string]string, len(inst.Tags))
+	for k, v := range inst.Tags {
+		out.Tags[k] = v
+	}
+	return out
+}
+
+func (inst *Instance) toEC2() types.Instance {
+	tags := make([]types.Tag, 0, len(inst.Tags))
+	keys := make([]string, 0, len(inst.Tags))
+	for k := range inst.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		tags = append(tags, types.Tag{Key: aws.String(k), Value: aws.String(inst.Tags[k])})
+	}
+
+	placement := &types.Placement{AvailabilityZone: aws.String(inst.AvailabilityZone), Tenancy: types.TenancyDefault}
+	if inst.Tenancy != "" {
+		placement.Tenancy = inst.Tenancy
+	}
+	if inst.HostID != "" {
+		placement.HostId = aws.String(inst.HostID)
+	}
+	if inst.PlacementGroup != "" {
+		placement.GroupName = aws.String(inst.PlacementGroup)
+	}
+
+	out := types.Instance{
+		InstanceId:   aws.String(inst.ID),
+		InstanceType: types.InstanceType(inst.InstanceType),
+		ImageId:      aws.String(inst.ImageID),
+		State:        &types.InstanceState{Name: inst.State, Code: aws.Int32(stateCode(inst.State))},
+		LaunchTime:   aws.Time(inst.LaunchTime),
+		Placement:    placement,
+		Tags:         tags,
+	}
+	if inst.CapacityReservationID != "" {
+		out.CapacityReservationId = aws.String(inst.CapacityReservationID)
+	}
+	if inst.Lifecycle != "" {
+		out.InstanceLifecycle = inst.Lifecycle
+	}
+	if inst.StateReason != "" {
+		out.StateReason = &types.StateReason{Code: aws.String(inst.StateReason), Message: aws.String(inst.StateReason)}
+	}
+	if inst.SubnetID != "" {
+		out.SubnetId = aws.String(inst.SubnetID)
+	}
+	if inst.KeyName != "" {
+		out.KeyName = aws.String(inst.KeyName)
+	}
+	if inst.PrivateIP != "" {
+		out.PrivateIpAddress = aws.String(inst.PrivateIP)
+	}
+	if inst.PublicIP != "" && inst.State == types.InstanceStateNameRunning {
+		out.PublicIpAddress = aws.String(inst.PublicIP)
+	}
+	return out
+}
+
+func stateCode(state types.InstanceStateName) int32 {
+	switch state {
+	case types.InstanceStateNamePending:
+		return 0
+	case types.InstanceStateNameRunning:
+		return 16
+	case types.InstanceStateNameShuttingDown:
+		return 32
+	case types.InstanceStateNameTerminated:
+		return 48
+	case types.InstanceStateNameStopping:
+		return 64
+	case types.InstanceStateNameStopped:
+		return 80
+	default:
+		return 0
+	}
+}
+
+// matchFilters reports whether an instance satisfies all EC2 filters.
+func (inst *Instance) matchFilters(filters []types.Filter) (bool, error) {
+	for _, f := range filters {
+		name := aws.ToString(f.Name)
+		var actual []string
+		switch {
+		case name == "instance-type":
+			actual = []string{inst.InstanceType}
+		case name == "instance-state-name":
+			actual = []string{string(inst.State)}
+		case name == "instance-id":
+			actual = []string{inst.ID}
+		case name == "image-id":
+			actual = []string{inst.ImageID}
+		case name == "availability-zone":
+			actual = []string{inst.AvailabilityZone}
+		case name == "instance-lifecycle":
+			actual = []string{string(inst.Lifecycle)}
+		case name == "tag-key":
+			for k := range inst.Tags {
+				actual = append(actual, k)
+			}
+		case strings.HasPrefix(name, "tag:"):
+			if v, ok := inst.Tags[strings.TrimPrefix(name, "tag:")]; ok {
+				actual = []string{v}
+			}
+		default:
+			return false, apiError(ErrCodeInvalidParameter, "The filter '%s' is invalid", name)
+		}
+		if !anyMatch(f.Values, actual) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// anyMatch reports whether any actual value matches any filter pattern.
+// Patterns support the '*' and '?' wildcards that EC2 filters accept.
+func anyMatch(patterns, actual []string) bool {
+	for _, p := range patterns {
+		for _, a := range actual {
+			if ok, _ := path.Match(p, a); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func apiError(code, format string, args ...interface{}) error {
+	return &smithy.GenericAPIError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// EC2 implements the EC2 operations used by the orchestrator against the
+// shared fake cloud state.
+type EC2 struct {
+	cloud *Cloud
+}
+
+// RunInstances launches simulated instances in the pending state.
+func (e *EC2) RunInstances(ctx context.Context, params *ec2.RunInstancesInput, _ ...func(*ec2.Options)) (*ec2.RunInstancesOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c := e.cloud
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	count := int(aws.ToInt32(params.MinCount))
+	if count < 1 {
+		count = 1
+	}
+	instanceType := string(params.InstanceType)
+	imageID := aws.ToString(params.ImageId)
+	var launchTemplate string
+	if params.LaunchTemplate != nil {
+		templateImage, err := c.launchTemplateImage(params.LaunchTemplate)
+		if err != nil {
+			return nil, err
+		}
+		if imageID == "" {
+			imageID = templateImage
+		}
+		launchTemplate = aws.ToString(params.LaunchTemplate.LaunchTemplateName)
+	}
+
+	if !c.hasImage(imageID) {
+		return nil, apiError(ErrCodeAMINotFound, "The image id '[%s]' does not exist", imageID)
+	}
+	if c.instanceLimit > 0 && c.activeCount()+count > c.instanceLimit {
+		return nil, apiError(ErrCodeInstanceLimit,
+			"You have requested more instances (%d) than your current instance limit of %d allows", c.activeCount()+count, c.instanceLimit)
+	}
+	lifecycle, spotMaxPrice := types.InstanceLifecycleType(""), ""
+	if market := params.InstanceMarketOptions; market != nil && market.MarketType == types.MarketTypeSpot {
+		lifecycle = types.InstanceLifecycleTypeSpot
+		if market.SpotOptions != nil && market.SpotOptions.MaxPrice != nil {
+			spotMaxPrice = aws.ToString(market.SpotOptions.MaxPrice)
+			var maxPrice float64
+			if _, err := fmt.Sscanf(spotMaxPrice, "%g", &maxPrice); err != nil {
+				return nil, apiError(ErrCodeInvalidParameter, "Invalid max price '%s'", spotMaxPrice)
+			}
+			if price, ok := c.spotPrices[instanceType]; ok && maxPrice < price {
+				return nil, apiError(ErrCodeSpotMaxPriceTooLow,
+					"Your Spot request price of %s is lower than the minimum required Spot request fulfillment price of %g", spotMaxPrice, price)
+			}
+		}
+	}
+	if remaining, limited := c.capacity[instanceType]; limited {
+		if remaining < count {
+			return nil, apiError(ErrCodeInsufficientCapacity,
+				"We currently do not have sufficient %s capacity in the Availability Zone you requested", instanceType)
+		}
+		c.capacity[instanceType] = remaining - count
+	}
+
+	tags := make(map[string]string)
+	for _, spec := range params.TagSpecifications {
+		if spec.ResourceType != types.ResourceTypeInstance {
+			continue
+		}
+		for _, tag := range spec.Tags {
+			tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+	}
+
+	subnetID := aws.ToString(params.SubnetId)
+	publicIP := false
+	for _, ni := range params.NetworkInterfaces {
+		if ni.SubnetId != nil {
+			subnetID = aws.ToString(ni.SubnetId)
+		}
+		if aws.ToBool(ni.AssociatePublicIpAddress) {
+			publicIP = true
+		}
+	}
+
+	az := c.region + "a"
+	subnetZone, knownSubnet := c.subnetZones[subnetID]
+	if knownSubnet {
+		az = subnetZone
+	}
+
+	placement := params.Placement
+	if placement == nil {
+		placement = &types.Placement{}
+	}
+	if placement.AvailabilityZone != nil {
+		az = aws.ToString(placement.AvailabilityZone)
+		if knownSubnet && az != subnetZone {
+			return nil, apiError(ErrCodeInvalidParameter,
+				"Subnet '%s' is in availability zone %s, not the requested %s", subnetID, subnetZone, az)
+		}
+	}
+	groupName := aws.ToString(placement.GroupName)
+	if groupName != "" && !c.placementGroups[groupName] {
+		return nil, apiError(ErrCodePlacementGroupUnknown, "The Placement Group '%s' is unknown.", groupName)
+	}
+
+	var reservationID string
+	var reservation *capacityReservation
+	if spec := params.CapacityReservationSpecification; spec != nil && spec.CapacityReservationTarget != nil {
+		reservationID = aws.ToString(spec.CapacityReservationTarget.CapacityReservationId)
+		found, ok := c.reservations[reservationID]
+		if !ok {
+			return nil, apiError(ErrCodeCapacityReservationNotFound, "The capacity reservation '%s' does not exist", reservationID)
+		}
+		if found.instanceType != instanceType || (placement.AvailabilityZone != nil && found.availabilityZone != az) ||
+			(knownSubnet && found.availabilityZone != subnetZone) {
+			return nil, apiError(ErrCodeInvalidParameter,
+				"The capacity reservation '%s' does not match the requested instance type and availability zone", reservationID)
+		}
+		if found.available < count {
+			return nil, apiError(ErrCodeReservationCapacityExceeded,
+				"There is not enough available capacity in reservation '%s'", reservationID)
+		}
+		reservation = found
+		az = found.availabilityZone
+	}
+
+	out := &ec2.RunInstancesOutput{}
+	for i := 0; i < count; i++ {
+		id := c.newID("i")
+		inst := &Instance{
+			ID:               id,
+			InstanceType:     instanceType,
+			ImageID:          imageID,
+			SubnetID:         subnetID,
+			KeyName:          aws.ToString(params.KeyName),
+			UserData:         aws.ToString(params.UserData),
+			AvailabilityZone: az,
+			PrivateIP:        fmt.Sprintf("10.0.%d.%d", (c.nextID/250)%250, c.nextID%250+1),
+			State:            types.InstanceStateNamePending,
+			Tags:             copyTags(tags),
+			LaunchTime:       c.now(),
+			Lifecycle:        lifecycle,
+			SpotMaxPrice:     spotMaxPrice,
+
+			Tenancy:               placement.Tenancy,
+			HostID:                aws.ToString(placement.HostId),
+			PlacementGroup:        groupName,
+			CapacityReservationID: reservationID,
+			LaunchTemplate:        launchTemplate,
+		}
+		if publicIP {
+			inst.PublicIP = fmt.Sprintf("54.0.%d.%d", (c.nextID/250)%250, c.nextID%250+1)
+		}
+		if reservation != nil {
+			reservation.available--
+		}
+		c.instances[id] = inst
+		c.instanceOrder = append(c.instanceOrder, id)
+		out.Instances = append(out.Instances, inst.toEC2())
+	}
+
+	return out, nil
+}
+
+// DescribeInstances returns matching instances, advancing each one's state.
+func (e *EC2) DescribeInstances(ctx context.Context, params *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c := e.cloud
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if params == nil {
+		params = &ec2.DescribeInstancesInput{}
+	}
+
+	ids := params.InstanceIds
+	if len(ids) == 0 {
+		ids = c.instanceOrder
+	} else {
+		for _, id := range ids {
+			if _, ok := c.instances[id]; !ok {
+				return nil, apiError(ErrCodeInstanceNotFound, "The instance ID '%s' does not exist", id)
+			}
+		}
+	}
+
+	reservation := types.Reservation{ReservationId: aws.String("r-fakecloud")}
+	for _, id := range ids {
+		inst := c.instances[id]
+		c.observe(inst)
+		ok, err := inst.matchFilters(params.Filters)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			reservation.Instances = append(reservation.Instances, inst.toEC2())
+		}
+	}
+
+	out := &ec2.DescribeInstancesOutput{}
+	if len(reservation.Instances) > 0 {
+		out.Reservations = []types.Reservation{reservation}
+	}
+	return out, nil
+}
+
+// DescribeImages returns registered images matching the request filters.
+func (e *EC2) DescribeImages(ctx context.Context, params *ec2.DescribeImagesInput, _ ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c := e.cloud
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if params == nil {
+		params = &ec2.DescribeImagesInput{}
+	}
+
+	out := &ec2.DescribeImagesOutput{}
+	for _, image := range c.images {
+		if len(params.ImageIds) > 0 && !anyMatch(params.ImageIds, []string{aws.ToString(image.ImageId)}) {
+			continue
+		}
+		matched := true
+		for _, f := range params.Filters {
+			var actual string
+			switch aws.ToString(f.Name) {
+			case "name":
+				actual = aws.ToString(image.Name)
+			case "architecture":
+				actual = string(image.Architecture)
+			case "state":
+				actual = string(image.State)
+			case "image-id":
+				actual = aws.ToString(image.ImageId)
+			default:
+				return nil, apiError(ErrCodeInvalidParameter, "The filter '%s' is invalid", aws.ToString(f.Name))
+			}
+			if !anyMatch(f.Values, []string{actual}) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			out.Images = append(out.Images, image)
+		}
+	}
+	return out, nil
+}
+
+// TerminateInstances moves instances to shutting-down. They become
+// terminated on the next DescribeInstances observation.
+func (e *EC2) TerminateInstances(ctx context.Context, params *ec2.TerminateInstancesInput, _ ...func(*ec2.Options)) (*ec2.TerminateInstancesOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c := e.cloud
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, id := range params.InstanceIds {
+		if _, ok := c.instances[id]; !ok {
+			return nil, apiError(ErrCodeInstanceNotFound, "The instance ID '%s' does not exist", id)
+		}
+	}
+
+	out := &ec2.TerminateInstancesOutput{}
+	for _, id := range params.InstanceIds {
+		inst := c.instances[id]
+		previous := inst.State
+		if previous != types.InstanceStateNameTerminated {
+			c.setState(inst, types.InstanceStateNameShuttingDown)
+		}
+		out.TerminatingInstances = append(out.TerminatingInstances, types.InstanceStateChange{
+			InstanceId:    aws.String(id),
+			PreviousState: &types.InstanceState{Name: previous, Code: aws.Int32(stateCode(previous))},
+			CurrentState:  &types.InstanceState{Name: inst.State, Code: aws.Int32(stateCode(inst.State))},
+		})
+	}
+	return out, nil
+}
+
+func (c *Cloud) hasImage(imageID string) bool {
+	for _, image := range c.images {
+		if aws.ToString(image.ImageId) == imageID {
+			return true
+		}
+	}
+	return false
+}
+
+func copyTags(tags map[string]string) map[string]string {
+	out := make(map[string]string, len(tags))
+	for k, v := range tags {
+		out[k] = v
+	}
+	return out
+}
+
+// SSM implements the Systems Manager operations used by the orchestrator
+// against the shared fake cloud state.
+type SSM struct {
+	cloud *Cloud
+}
+
+// SendCommand records a command invocation for each target instance. The
+// command result is produced immediately by the installed CommandHandler but
+// only becomes visible once the invocation has been polled enough times.
+func (s *SSM) SendCommand(ctx context.Context, params *ssm.SendCommandInput, _ ...func(*ssm.Options)) (*ssm.SendCommandOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c := s.cloud
+	c.mu.Lock()
+
+	for _, id := range params.InstanceIds {
+		inst, ok := c.instances[id]
+		if !ok || inst.State != types.InstanceStateNameRunning {
+			c.mu.Unlock()
+			return nil, apiError(ErrCodeInvalidInstanceID, "Instances [[%s]] not in a valid state for account", id)
+		}
+	}
+
+	command := strings.Join(params.Parameters["commands"], "\n")
+	commandID := c.newID("cmd")
+	handler := c.handler
+	instanceIDs := append([]string(nil), params.InstanceIds...)
+	sentAt := c.now()
+	c.mu.Unlock()
+
+	// Run handlers without holding the lock so they may inspect the cloud.
+	results := make([]CommandResult, len(instanceIDs))
+	for i, id := range instanceIDs {
+		results[i] = handler(id, command)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, id := range instanceIDs {
+		c.invocations[invocationKey(commandID, id)] = &Invocation{
+			CommandID:  commandID,
+			InstanceID: id,
+			Command:    command,
+			Result:     results[i],
+			SentAt:     sentAt,
+		}
+	}
+
+	return &ssm.SendCommandOutput{
+		Command: &ssmtypes.Command{
+			CommandId:    aws.String(commandID),
+			DocumentName: params.DocumentName,
+			InstanceIds:  instanceIDs,
+			Status:       ssmtypes.CommandStatusPending,
+		},
+	}, nil
+}
+
+// GetCommandInvocation reports an invocation's progress, returning the
+// terminal result once the configured number of polls has elapsed. A
+// command whose instance stopped running before completion is reported as
+// Failed, mirroring what SSM does when the agent disappears.
+func (s *SSM) GetCommandInvocation(ctx context.Context, params *ssm.GetCommandInvocationInput, _ ...func(*ssm.Options)) (*ssm.GetCommandInvocationOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c := s.cloud
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	commandID := aws.ToString(params.CommandId)
+	instanceID := aws.ToString(params.InstanceId)
+	inv, ok := c.invocations[invocationKey(commandID, instanceID)]
+	if !ok {
+		return nil, &ssmtypes.InvocationDoesNotExist{Message: aws.String("invocation does not exist")}
+	}
+
+	inv.polls++
+	out := &ssm.GetCommandInvocationOutput{
+		CommandId:  aws.String(commandID),
+		InstanceId: aws.String(instanceID),
+	}
+
+	if inst := c.instances[instanceID]; inst.State != types.InstanceStateNameRunning {
+		out.Status = ssmtypes.CommandInvocationStatusFailed
+		out.StandardErrorContent = aws.String(fmt.Sprintf("instance %s is %s", instanceID, inst.State))
+		return out, nil
+	}
+
+	if inv.polls <= c.commandPolls {
+		out.Status = ssmtypes.CommandInvocationStatusInProgress
+		return out, nil
+	}
+
+	out.Status = inv.Result.Status
+	if out.Status == "" {
+		out.Status = ssmtypes.CommandInvocationStatusSuccess
+	}
+	out.StandardOutputContent = aws.String(inv.Result.Stdout)
+	out.StandardErrorContent = aws.String(inv.Result.Stderr)
+	return out, nil
+}
+
+// GetParameter returns a value stored with Cloud.SetParameter.
+func (s *SSM) GetParameter(ctx context.Context, params *ssm.GetParameterInput, _ ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c := s.cloud
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := aws.ToString(params.Name)
+	value, ok := c.parameters[name]
+	if !ok {
+		return nil, apiError(ErrCodeParameterNotFound, "Parameter %s not found.", name)
+	}
+	return &ssm.GetParameterOutput{
+		Parameter: &ssmtypes.Parameter{Name: aws.String(name), Value: aws.String(value), Type: ssmtypes.ParameterTypeString},
+	}, nil
+}
+
+func invocationKey(commandID, instanceID string) string {
+	return commandID + "/" + instanceID
+}
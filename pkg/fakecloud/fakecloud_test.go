@@ -0,0 +1,331 @@
+package fakecloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/aws/smithy-go"
+)
+
+func launch(t *testing.T, cloud *Cloud, instanceType string) string {
+	t.Helper()
+
+	images, err := cloud.EC2().DescribeImages(context.Background(), &ec2.DescribeImagesInput{
+		Filters: []types.Filter{{Name: aws.String("architecture"), Values: []string{"x86_64"}}},
+	})
+	if err != nil || len(images.Images) == 0 {
+		t.Fatalf("DescribeImages failed: %v", err)
+	}
+
+	out, err := cloud.EC2().RunInstances(context.Background(), &ec2.RunInstancesInput{
+		ImageId:      images.Images[0].ImageId,
+		InstanceType: types.InstanceType(instanceType),
+		MinCount:     aws.Int32(1),
+		MaxCount:     aws.Int32(1),
+		TagSpecifications: []types.TagSpecification{{
+			ResourceType: types.ResourceTypeInstance,
+			Tags:         []types.Tag{{Key: aws.String("Purpose"), Value: aws.String("aws-instance-benchmarks")}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("RunInstances failed: %v", err)
+	}
+	return aws.ToString(out.Instances[0].InstanceId)
+}
+
+func describeState(t *testing.T, cloud *Cloud, instanceID string) types.InstanceStateName {
+	t.Helper()
+
+	out, err := cloud.EC2().DescribeInstances(context.Background(), &ec2.DescribeInstancesInput{
+		InstanceIds: []string{instanceID},
+	})
+	if err != nil {
+		t.Fatalf("DescribeInstances failed: %v", err)
+	}
+	return out.Reservations[0].Instances[0].State.Name
+}
+
+func TestInstanceLifecycle(t *testing.T) {
+	cloud := New("us-east-1")
+	cloud.SetPendingPolls(2)
+
+	id := launch(t, cloud, "m7i.large")
+
+	expected := []types.InstanceStateName{
+		types.InstanceStateNamePending,
+		types.InstanceStateNamePending,
+		types.InstanceStateNameRunning,
+	}
+	for i, want := range expected {
+		if got := describeState(t, cloud, id); got != want {
+			t.Errorf("observation %d: state = %s, want %s", i+1, got, want)
+		}
+	}
+
+	if _, err := cloud.EC2().TerminateInstances(context.Background(), &ec2.TerminateInstancesInput{
+		InstanceIds: []string{id},
+	}); err != nil {
+		t.Fatalf("TerminateInstances failed: %v", err)
+	}
+	if got := describeState(t, cloud, id); got != types.InstanceStateNameTerminated {
+		t.Errorf("state after terminate = %s, want terminated", got)
+	}
+}
+
+func TestDescribeInstancesFilters(t *testing.T) {
+	cloud := New("us-east-1")
+	launch(t, cloud, "m7i.large")
+	launch(t, cloud, "c7g.large")
+
+	out, err := cloud.EC2().DescribeInstances(context.Background(), &ec2.DescribeInstancesInput{
+		Filters: []types.Filter{
+			{Name: aws.String("instance-type"), Values: []string{"m7i.large"}},
+			{Name: aws.String("tag:Purpose"), Values: []string{"aws-instance-*"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("DescribeInstances failed: %v", err)
+	}
+	if len(out.Reservations) != 1 || len(out.Reservations[0].Instances) != 1 {
+		t.Fatalf("expected exactly one matching instance, got %+v", out.Reservations)
+	}
+
+	_, err = cloud.EC2().DescribeInstances(context.Background(), &ec2.DescribeInstancesInput{
+		Filters: []types.Filter{{Name: aws.String("bogus"), Values: []string{"x"}}},
+	})
+	if err == nil {
+		t.Error("expected error for unsupported filter")
+	}
+}
+
+func TestCapacityAndLimitErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(*Cloud)
+		wantCode string
+	}{
+		{
+			name:     "insufficient capacity",
+			setup:    func(c *Cloud) { c.SetCapacity("m7i.large", 1) },
+			wantCode: ErrCodeInsufficientCapacity,
+		},
+		{
+			name:     "instance limit",
+			setup:    func(c *Cloud) { c.SetInstanceLimit(1) },
+			wantCode: ErrCodeInstanceLimit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cloud := New("us-east-1")
+			tt.setup(cloud)
+			first := launch(t, cloud, "m7i.large")
+
+			_, err := cloud.EC2().RunInstances(context.Background(), &ec2.RunInstancesInput{
+				ImageId:      aws.String(cloud.Instances()[0].ImageID),
+				InstanceType: types.InstanceTypeM7iLarge,
+				MinCount:     aws.Int32(1),
+				MaxCount:     aws.Int32(1),
+			})
+			var apiErr smithy.APIError
+			if !errors.As(err, &apiErr) || apiErr.ErrorCode() != tt.wantCode {
+				t.Fatalf("expected %s error, got %v", tt.wantCode, err)
+			}
+
+			// Terminating the first instance frees capacity for another launch.
+			if _, err := cloud.EC2().TerminateInstances(context.Background(), &ec2.TerminateInstancesInput{
+				InstanceIds: []string{first},
+			}); err != nil {
+				t.Fatalf("TerminateInstances failed: %v", err)
+			}
+			launch(t, cloud, "m7i.large")
+		})
+	}
+}
+
+func TestCommandLifecycle(t *testing.T) {
+	cloud := New("us-east-1")
+	cloud.SetCommandPolls(1)
+	cloud.HandleCommands(func(instanceID, command string) CommandResult {
+		return CommandResult{Status: ssmtypes.CommandInvocationStatusSuccess, Stdout: "ran: " + command}
+	})
+
+	id := launch(t, cloud, "m7i.large")
+
+	// Commands cannot be sent until the instance is running.
+	if _, err := cloud.SSM().SendCommand(context.Background(), &ssm.SendCommandInput{
+		InstanceIds: []string{id},
+		Parameters:  map[string][]string{"commands": {"echo hi"}},
+	}); err == nil {
+		t.Fatal("expected SendCommand to fail for a pending instance")
+	}
+
+	describeState(t, cloud, id)
+	sent, err := cloud.SSM().SendCommand(context.Background(), &ssm.SendCommandInput{
+		InstanceIds: []string{id},
+		Parameters:  map[string][]string{"commands": {"echo hi"}},
+	})
+	if err != nil {
+		t.Fatalf("SendCommand failed: %v", err)
+	}
+
+	input := &ssm.GetCommandInvocationInput{CommandId: sent.Command.CommandId, InstanceId: aws.String(id)}
+
+	first, err := cloud.SSM().GetCommandInvocation(context.Background(), input)
+	if err != nil {
+		t.Fatalf("GetCommandInvocation failed: %v", err)
+	}
+	if first.Status != ssmtypes.CommandInvocationStatusInProgress {
+		t.Errorf("first poll status = %s, want InProgress", first.Status)
+	}
+
+	second, err := cloud.SSM().GetCommandInvocation(context.Background(), input)
+	if err != nil {
+		t.Fatalf("GetCommandInvocation failed: %v", err)
+	}
+	if second.Status != ssmtypes.CommandInvocationStatusSuccess {
+		t.Errorf("second poll status = %s, want Success", second.Status)
+	}
+	if got := aws.ToString(second.StandardOutputContent); got != "ran: echo hi" {
+		t.Errorf("stdout = %q, want %q", got, "ran: echo hi")
+	}
+
+	if _, err := cloud.SSM().GetCommandInvocation(context.Background(), &ssm.GetCommandInvocationInput{
+		CommandId: aws.String("cmd-missing"), InstanceId: aws.String(id),
+	}); err == nil {
+		t.Error("expected error for unknown invocation")
+	}
+}
+
+func TestCommandFailsWhenInstanceStops(t *testing.T) {
+	cloud := New("us-east-1")
+	cloud.SetCommandPolls(5)
+
+	id := launch(t, cloud, "m7i.large")
+	describeState(t, cloud, id)
+
+	sent, err := cloud.SSM().SendCommand(context.Background(), &ssm.SendCommandInput{
+		InstanceIds: []string{id},
+		Parameters:  map[string][]string{"commands": {"sleep 600"}},
+	})
+	if err != nil {
+		t.Fatalf("SendCommand failed: %v", err)
+	}
+
+	if err := cloud.SetInstanceState(id, types.InstanceStateNameTerminated); err != nil {
+		t.Fatalf("SetInstanceState failed: %v", err)
+	}
+
+	out, err := cloud.SSM().GetCommandInvocation(context.Background(), &ssm.GetCommandInvocationInput{
+		CommandId: sent.Command.CommandId, InstanceId: aws.String(id),
+	})
+	if err != nil {
+		t.Fatalf("GetCommandInvocation failed: %v", err)
+	}
+	if out.Status != ssmtypes.CommandInvocationStatusFailed {
+		t.Errorf("status = %s, want Failed", out.Status)
+	}
+}
+
+func TestSpotLaunchAndInterruption(t *testing.T) {
+	cloud := New("us-east-1")
+	cloud.SetSpotPrice("m7i.large", 0.04)
+	onDemand := launch(t, cloud, "m7i.large")
+
+	runSpot := func(maxPrice string) (*ec2.RunInstancesOutput, error) {
+		return cloud.EC2().RunInstances(context.Background(), &ec2.RunInstancesInput{
+			ImageId:      aws.String(cloud.Instances()[0].ImageID),
+			InstanceType: types.InstanceTypeM7iLarge,
+			MinCount:     aws.Int32(1),
+			MaxCount:     aws.Int32(1),
+			InstanceMarketOptions: &types.InstanceMarketOptionsRequest{
+				MarketType:  types.MarketTypeSpot,
+				SpotOptions: &types.SpotMarketOptions{MaxPrice: aws.String(maxPrice)},
+			},
+		})
+	}
+
+	var apiErr smithy.APIError
+	if _, err := runSpot("0.02"); !errors.As(err, &apiErr) || apiErr.ErrorCode() != ErrCodeSpotMaxPriceTooLow {
+		t.Fatalf("expected %s error, got %v", ErrCodeSpotMaxPriceTooLow, err)
+	}
+
+	out, err := runSpot("0.05")
+	if err != nil {
+		t.Fatalf("RunInstances failed: %v", err)
+	}
+	id := aws.ToString(out.Instances[0].InstanceId)
+	if out.Instances[0].InstanceLifecycle != types.InstanceLifecycleTypeSpot {
+		t.Errorf("lifecycle = %q, want spot", out.Instances[0].InstanceLifecycle)
+	}
+
+	if err := cloud.InterruptSpot(onDemand); err == nil {
+		t.Error("expected error interrupting an on-demand instance")
+	}
+	if err := cloud.InterruptSpot(id); err != nil {
+		t.Fatalf("InterruptSpot failed: %v", err)
+	}
+
+	described, err := cloud.EC2().DescribeInstances(context.Background(), &ec2.DescribeInstancesInput{InstanceIds: []string{id}})
+	if err != nil {
+		t.Fatalf("DescribeInstances failed: %v", err)
+	}
+	inst := described.Reservations[0].Instances[0]
+	if inst.StateReason == nil || aws.ToString(inst.StateReason.Code) != StateReasonSpotTermination {
+		t.Errorf("expected spot termination state reason, got %+v", inst.StateReason)
+	}
+}
+
+func TestLaunchTemplatesAndParameters(t *testing.T) {
+	cloud := New("us-east-1")
+	first := cloud.AddImage("custom-ami-1", types.ArchitectureValuesX8664, "2025-01-01T00:00:00.000Z")
+	second := cloud.AddImage("custom-ami-2", types.ArchitectureValuesX8664, "2025-02-01T00:00:00.000Z")
+	cloud.AddLaunchTemplateVersion("benchmarks", first)
+	if v := cloud.AddLaunchTemplateVersion("benchmarks", second); v != 2 {
+		t.Fatalf("version = %d, want 2", v)
+	}
+
+	runTemplate := func(version string) (string, error) {
+		spec := &types.LaunchTemplateSpecification{LaunchTemplateName: aws.String("benchmarks")}
+		if version != "" {
+			spec.Version = aws.String(version)
+		}
+		out, err := cloud.EC2().RunInstances(context.Background(), &ec2.RunInstancesInput{
+			InstanceType:   types.InstanceTypeM7iLarge,
+			MinCount:       aws.Int32(1),
+			MaxCount:       aws.Int32(1),
+			LaunchTemplate: spec,
+		})
+		if err != nil {
+			return "", err
+		}
+		return aws.ToString(out.Instances[0].ImageId), nil
+	}
+
+	for version, want := range map[string]string{"": first, "$Default": first, "$Latest": second, "2": second} {
+		if got, err := runTemplate(version); err != nil || got != want {
+			t.Errorf("version %q: got image %s (err %v), want %s", version, got, err, want)
+		}
+	}
+
+	var apiErr smithy.APIError
+	if _, err := runTemplate("3"); !errors.As(err, &apiErr) || apiErr.ErrorCode() != ErrCodeLaunchTemplateVersion {
+		t.Errorf("expected %s error, got %v", ErrCodeLaunchTemplateVersion, err)
+	}
+
+	cloud.SetParameter("/benchmarks/ami", second)
+	param, err := cloud.SSM().GetParameter(context.Background(), &ssm.GetParameterInput{Name: aws.String("/benchmarks/ami")})
+	if err != nil || aws.ToString(param.Parameter.Value) != second {
+		t.Errorf("GetParameter = %+v, %v; want %s", param, err, second)
+	}
+	if _, err := cloud.SSM().GetParameter(context.Background(), &ssm.GetParameterInput{Name: aws.String("/missing")}); !errors.As(err, &apiErr) || apiErr.ErrorCode() != ErrCodeParameterNotFound {
+		t.Errorf("expected %s error, got %v", ErrCodeParameterNotFound, err)
+	}
+}
@@ -46,6 +46,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -61,6 +62,12 @@ var (
 	ErrDimensionLimit     = errors.New("dimension count exceeds CloudWatch limit")
 )
 
+// CloudWatchAPI is the subset of the CloudWatch client used to publish
+// metrics, allowing tests to substitute a fake.
+type CloudWatchAPI interface {
+	PutMetricData(ctx context.Context, params *cloudwatch.PutMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutMetricDataOutput, error)
+}
+
 // MetricsCollector provides comprehensive CloudWatch metrics collection and
 // publishing capabilities for AWS Instance Benchmarks.
 //
@@ -76,7 +83,7 @@ var (
 type MetricsCollector struct {
 	// cloudwatchClient is the AWS SDK v2 CloudWatch client for metric publishing.
 	// Configured with automatic retry logic and regional endpoint optimization.
-	cloudwatchClient *cloudwatch.Client
+	cloudwatchClient CloudWatchAPI
 	
 	// namespace is the CloudWatch namespace for all published metrics.
 	// Provides logical separation and access control for benchmark metrics.
@@ -89,6 +96,11 @@ type MetricsCollector struct {
 	// defaultDimensions contains standard dimensions applied to all metrics.
 	// Enables consistent filtering and aggregation across metric queries.
 	defaultDimensions []types.Dimension
+
+	// activeJobs maps the jobs seen in flight by HandleEvent to their start
+	// time, so that each job's metrics are published once when it finishes.
+	eventsMu   sync.Mutex
+	activeJobs map[string]time.Time
 }
 
 // BenchmarkMetrics contains comprehensive performance and execution metrics
@@ -241,6 +253,12 @@ func NewMetricsCollector(region string) (*MetricsCollector, error) {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
+	return NewMetricsCollectorWithClient(region, cloudwatch.NewFromConfig(cfg)), nil
+}
+
+// NewMetricsCollectorWithClient creates a metrics collector that publishes
+// through the given CloudWatch client, e.g. a fake in tests.
+func NewMetricsCollectorWithClient(region string, client CloudWatchAPI) *MetricsCollector {
 	defaultDimensions := []types.Dimension{
 		{
 			Name:  aws.String("Project"),
@@ -257,11 +275,11 @@ func NewMetricsCollector(region string) (*MetricsCollector, error) {
 	}
 
 	return &MetricsCollector{
-		cloudwatchClient:  cloudwatch.NewFromConfig(cfg),
+		cloudwatchClient:  client,
 		namespace:         "InstanceBenchmarks",
 		region:           region,
 		defaultDimensions: defaultDimensions,
-	}, nil
+	}
 }
 
 // PublishBenchmarkMetrics publishes comprehensive benchmark execution metrics
@@ -0,0 +1,114 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	awspkg "github.com/scttfrdmn/aws-instance-benchmarks/pkg/aws"
+)
+
+// eventPublishTimeout bounds publishing the metrics of one finished job.
+const eventPublishTimeout = 30 * time.Second
+
+// HandleEvent makes the MetricsCollector an aws.EventSink: it publishes the
+// BenchmarkMetrics of each job that finishes while the collector is
+// subscribed. Successful jobs are published with their performance metrics
+// when their results are parsed, failed jobs on their final status.
+//
+// Jobs that were already finished when first seen, e.g. old jobs listed by
+// the async collector, are skipped so repeated collection does not publish
+// them twice. Publishing calls CloudWatch, so subscribe the collector
+// through an aws.BufferedSink.
+func (mc *MetricsCollector) HandleEvent(event awspkg.Event) {
+	metrics, ok := mc.finishedJobMetrics(event)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), eventPublishTimeout)
+	defer cancel()
+	if err := mc.PublishBenchmarkMetrics(ctx, metrics); err != nil {
+		fmt.Printf("   ⚠️ Failed to publish metrics for %s: %v\n", event.JobID, err)
+	}
+}
+
+// finishedJobMetrics tracks the jobs in flight and returns the metrics of a
+// job the event finishes.
+func (mc *MetricsCollector) finishedJobMetrics(event awspkg.Event) (BenchmarkMetrics, bool) {
+	if event.JobID == "" {
+		return BenchmarkMetrics{}, false
+	}
+
+	mc.eventsMu.Lock()
+	defer mc.eventsMu.Unlock()
+	if mc.activeJobs == nil {
+		mc.activeJobs = make(map[string]time.Time)
+	}
+
+	started, active := mc.activeJobs[event.JobID]
+	succeeded := event.Type == awspkg.EventResultParsed && event.Results != nil
+	failed := event.Final() && event.Status != awspkg.JobStatusCompleted
+	if !succeeded && !failed {
+		if !active && !event.Final() {
+			started = event.StartTime
+			if started.IsZero() {
+				started = event.Time
+			}
+			mc.activeJobs[event.JobID] = started
+		}
+		return BenchmarkMetrics{}, false
+	}
+	if !active {
+		return BenchmarkMetrics{}, false
+	}
+	delete(mc.activeJobs, event.JobID)
+
+	metrics := BenchmarkMetrics{
+		InstanceType:   event.InstanceType,
+		InstanceFamily: instanceFamily(event.InstanceType),
+		BenchmarkSuite: event.BenchmarkSuite,
+		Region:         event.Region,
+		Success:        succeeded,
+		Timestamp:      event.Time,
+	}
+	if !started.IsZero() && event.Time.After(started) {
+		metrics.ExecutionDuration = event.Time.Sub(started).Seconds()
+	}
+	if succeeded {
+		metrics.PerformanceMetrics = ExtractPerformanceMetrics(event.Results)
+	} else {
+		metrics.ErrorCategory = string(event.FailureCategory)
+	}
+	return metrics, true
+}
+
+// ExtractPerformanceMetrics returns the suite-specific performance metrics
+// of benchmark results, keyed as published to CloudWatch, e.g.
+// "triad_bandwidth" for STREAM or "gflops" for HPL.
+func ExtractPerformanceMetrics(results *awspkg.BenchmarkResults) map[string]float64 {
+	metrics := make(map[string]float64)
+	if results == nil {
+		return metrics
+	}
+	if stream := results.STREAM; stream != nil {
+		for operation, op := range stream.Operations() {
+			metrics[operation+"_bandwidth"] = op.Bandwidth
+		}
+	}
+	if hpl := results.HPL; hpl != nil {
+		metrics["gflops"] = hpl.GFLOPS
+		metrics["efficiency"] = hpl.Efficiency
+		metrics["execution_time"] = hpl.ExecutionTime
+		metrics["residual"] = hpl.Residual
+	}
+	return metrics
+}
+
+// instanceFamily returns the family of an instance type, e.g. "m7i" for
+// "m7i.large".
+func instanceFamily(instanceType string) string {
+	family, _, _ := strings.Cut(instanceType, ".")
+	return family
+}
@@ -0,0 +1,119 @@
+package monitoring
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+
+	awspkg "github.com/scttfrdmn/aws-instance-benchmarks/pkg/aws"
+)
+
+// fakeCloudWatch records the metrics put to it.
+type fakeCloudWatch struct {
+	mu      sync.Mutex
+	metrics []types.MetricDatum
+}
+
+func (f *fakeCloudWatch) PutMetricData(ctx context.Context, params *cloudwatch.PutMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutMetricDataOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.metrics = append(f.metrics, params.MetricData...)
+	return &cloudwatch.PutMetricDataOutput{}, nil
+}
+
+// metric returns the value and dimensions of the named metric.
+func (f *fakeCloudWatch) metric(name string) (float64, map[string]string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, datum := range f.metrics {
+		if aws.ToString(datum.MetricName) == name {
+			dimensions := make(map[string]string)
+			for _, dimension := range datum.Dimensions {
+				dimensions[aws.ToString(dimension.Name)] = aws.ToString(dimension.Value)
+			}
+			return aws.ToFloat64(datum.Value), dimensions, true
+		}
+	}
+	return 0, nil, false
+}
+
+func TestMetricsCollectorPublishesFinishedJobs(t *testing.T) {
+	client := &fakeCloudWatch{}
+	collector := NewMetricsCollectorWithClient(testRegion, client)
+	start := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	job := awspkg.Event{JobID: "stream-1", InstanceType: "m7i.large", BenchmarkSuite: "stream", Region: testRegion}
+
+	launched := job
+	launched.Type, launched.Status, launched.Time, launched.StartTime = awspkg.EventInstanceLaunched, awspkg.JobStatusLaunched, start, start
+	collector.HandleEvent(launched)
+
+	parsed := job
+	parsed.Type, parsed.Time = awspkg.EventResultParsed, start.Add(90*time.Second)
+	parsed.Results = &awspkg.BenchmarkResults{STREAM: &awspkg.STREAMResult{Triad: &awspkg.StreamOperation{Bandwidth: 41.9}}}
+	collector.HandleEvent(parsed)
+
+	completed := job
+	completed.Type, completed.Status, completed.Time = awspkg.EventJobStatus, awspkg.JobStatusCompleted, start.Add(2*time.Minute)
+	collector.HandleEvent(completed)
+
+	if triad, _, ok := client.metric("Performance_triad_bandwidth"); !ok || triad != 41.9 {
+		t.Errorf("Expected the triad bandwidth to be published, got %v", triad)
+	}
+	duration, dimensions, ok := client.metric("ExecutionDuration")
+	if !ok || duration != 90 {
+		t.Errorf("ExecutionDuration = %v, want 90", duration)
+	}
+	if dimensions["InstanceFamily"] != "m7i" || dimensions["Success"] != "true" {
+		t.Errorf("Unexpected dimensions %v", dimensions)
+	}
+	executions := 0
+	for _, datum := range client.metrics {
+		if aws.ToString(datum.MetricName) == "BenchmarkExecution" {
+			executions++
+		}
+	}
+	if executions != 1 {
+		t.Errorf("Expected the job to be published once, got %d", executions)
+	}
+}
+
+func TestMetricsCollectorPublishesFailuresOfActiveJobsOnly(t *testing.T) {
+	client := &fakeCloudWatch{}
+	collector := NewMetricsCollectorWithClient(testRegion, client)
+	start := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	// A job that had already failed when first seen is not published
+	collector.HandleEvent(awspkg.Event{Type: awspkg.EventJobStatus, JobID: "old", InstanceType: "c7g.large",
+		BenchmarkSuite: "stream", Status: awspkg.JobStatusFailed, Time: start})
+	if len(client.metrics) != 0 {
+		t.Fatalf("Expected no metrics for a job finished before subscribing, got %d", len(client.metrics))
+	}
+
+	running := awspkg.Event{Type: awspkg.EventJobProgress, JobID: "new", InstanceType: "c7g.large",
+		BenchmarkSuite: "stream", Status: awspkg.JobStatusRunning, Time: start}
+	collector.HandleEvent(running)
+	failed := running
+	failed.Type, failed.Status, failed.Time = awspkg.EventJobStatus, awspkg.JobStatusFailed, start.Add(time.Minute)
+	failed.FailureCategory = awspkg.FailureCategorySpotInterruption
+	collector.HandleEvent(failed)
+
+	_, dimensions, ok := client.metric("BenchmarkExecution")
+	if !ok || dimensions["Success"] != "false" || dimensions["ErrorCategory"] != "spot_interruption" {
+		t.Errorf("Expected a failed execution with its category, got %v", dimensions)
+	}
+}
+
+func TestExtractPerformanceMetrics(t *testing.T) {
+	metrics := ExtractPerformanceMetrics(&awspkg.BenchmarkResults{HPL: &awspkg.HPLResult{GFLOPS: 120.5, Efficiency: 0.8}})
+	if metrics["gflops"] != 120.5 || metrics["efficiency"] != 0.8 {
+		t.Errorf("Unexpected HPL metrics %v", metrics)
+	}
+	if len(ExtractPerformanceMetrics(nil)) != 0 {
+		t.Error("Expected no metrics without results")
+	}
+}
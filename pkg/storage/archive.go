@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// rawOutputSuffix replaces the .json extension of a result key to form the
+// key of the raw output archived next to it.
+const rawOutputSuffix = ".output.json.gz"
+
+// ErrRawOutputNotFound is returned by LoadRawOutput for a result without
+// archived raw output, e.g. one stored before output was archived.
+var ErrRawOutputNotFound = errors.New("raw output not archived")
+
+// rawOutputKey returns the key of the raw output archived for the result
+// stored under resultKey:
+//
+//   {prefix}raw/{YYYY}/{MM}/{DD}/{region}/{instance-type}/{YYYYMMDD-HHMMSS}[-{suite}]-{hash}.output.json.gz
+//
+// Archives do not end with .json, so queries never mistake them for results.
+func rawOutputKey(resultKey string) string {
+	return strings.TrimSuffix(resultKey, ".json") + rawOutputSuffix
+}
+
+// compressJSON returns the gzipped JSON encoding of v.
+func compressJSON(v interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if err := json.NewEncoder(writer).Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to serialize raw output: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress raw output: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// decodeRawOutput decodes an archive read back with readResultBody.
+func decodeRawOutput(resultKey string, body []byte, output interface{}) error {
+	if err := json.Unmarshal(body, output); err != nil {
+		return fmt.Errorf("failed to decode raw output of %s: %w", resultKey, err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArchiveRawOutput(t *testing.T) {
+	ctx := context.Background()
+	s3Storage, standIn := newTestStorage(t)
+	stores := map[string]ResultStore{
+		"s3":    s3Storage,
+		"local": NewLocalStorage(t.TempDir(), Config{KeyPrefix: "test/"}),
+	}
+	day := time.Date(2024, 6, 26, 10, 0, 0, 0, time.UTC)
+	type iteration struct {
+		Stdout string `json:"stdout"`
+		Stderr string `json:"stderr"`
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			result := storedResult("m7i.large", "us-east-1", "stream", day)
+			output := []iteration{{Stdout: "Triad: 41900.1", Stderr: "warning: small array"}}
+			archiveKey, err := store.ArchiveRawOutput(ctx, result, output)
+			if err != nil {
+				t.Fatalf("ArchiveRawOutput failed: %v", err)
+			}
+			if err := store.StoreResult(ctx, result); err != nil {
+				t.Fatalf("StoreResult failed: %v", err)
+			}
+
+			results, err := store.GetResults(ctx, ResultQuery{IncludeMetadata: true})
+			if err != nil {
+				t.Fatalf("GetResults failed: %v", err)
+			}
+			if len(results) != 1 {
+				t.Fatalf("Expected the archive not to be queried as a result, got %d results", len(results))
+			}
+			key := results[0].(StoredResult).Key
+			if archiveKey != strings.TrimSuffix(key, ".json")+".output.json.gz" {
+				t.Errorf("Expected the archive next to %s, got %s", key, archiveKey)
+			}
+
+			var loaded []iteration
+			if err := store.LoadRawOutput(ctx, key, &loaded); err != nil {
+				t.Fatalf("LoadRawOutput failed: %v", err)
+			}
+			if len(loaded) != 1 || loaded[0] != output[0] {
+				t.Errorf("Expected the archived output back, got %+v", loaded)
+			}
+
+			other := storedResult("c7g.large", "us-east-1", "stream", day)
+			if err := store.LoadRawOutput(ctx, resultKey("test/", other, contentHash(t, other)), &loaded); !errors.Is(err, ErrRawOutputNotFound) {
+				t.Errorf("Expected ErrRawOutputNotFound, got %v", err)
+			}
+		})
+	}
+
+	// Archives are stored compressed
+	for key, object := range standIn.objects {
+		if strings.HasSuffix(key, rawOutputSuffix) && (len(object.body) < 2 || object.body[0] != 0x1f || object.body[1] != 0x8b) {
+			t.Errorf("Expected %s to be gzipped", key)
+		}
+	}
+}
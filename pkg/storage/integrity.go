@@ -0,0 +1,241 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// keyHashLength is the number of hex digits of the content hash at the
+	// end of a result key.
+	keyHashLength = 16
+
+	// emptySHA256 is the SHA-256 of no data, which results stored before
+	// checksums were computed record as a placeholder.
+	emptySHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// keyHashPattern matches the content hash suffix of a result file name.
+var keyHashPattern = regexp.MustCompile(`-([0-9a-f]{16})$`)
+
+// ErrDuplicateResult is returned by StoreResult for a result whose content
+// is already stored. Use errors.As with *DuplicateResultError for the key
+// of the stored copy.
+var ErrDuplicateResult = errors.New("result already stored")
+
+// DuplicateResultError reports a result that was not stored because a
+// result with the same content hash already was.
+type DuplicateResultError struct {
+	// Hash is the content hash shared by both results.
+	Hash string
+
+	// Key is the key of the stored copy.
+	Key string
+}
+
+func (e *DuplicateResultError) Error() string {
+	return fmt.Sprintf("%v: content %s is stored as %s", ErrDuplicateResult, e.Hash, e.Key)
+}
+
+// Unwrap makes errors.Is(err, ErrDuplicateResult) hold.
+func (e *DuplicateResultError) Unwrap() error {
+	return ErrDuplicateResult
+}
+
+// ContentHash returns the hex SHA-256 of a result's canonical JSON form:
+// the result encoded, decoded into generic values and encoded again, so
+// object keys are sorted and whitespace is dropped. The hash of a stored
+// result therefore does not depend on how the store formatted it.
+func ContentHash(result interface{}) (string, error) {
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize result: %w", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(encoded, &generic); err != nil {
+		return "", fmt.Errorf("failed to serialize result: %w", err)
+	}
+	canonical, err := json.Marshal(generic)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize result: %w", err)
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// hashIndexKey returns the key under which the key of the result with a
+// content hash is recorded, used to detect duplicate uploads.
+func hashIndexKey(prefix, hash string) string {
+	return prefix + "sha256/" + hash
+}
+
+// keyHash returns the content hash prefix at the end of a result key, or
+// "" for keys written before results were keyed by content.
+func keyHash(name string) string {
+	if match := keyHashPattern.FindStringSubmatch(name); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// VerificationStatus is the outcome of verifying one stored result.
+type VerificationStatus string
+
+const (
+	// VerificationOK means the content matches its recorded hashes.
+	VerificationOK VerificationStatus = "ok"
+
+	// VerificationTampered means the content decodes but does not match
+	// a recorded hash.
+	VerificationTampered VerificationStatus = "tampered"
+
+	// VerificationCorrupt means the result cannot be read or decoded.
+	VerificationCorrupt VerificationStatus = "corrupt"
+
+	// VerificationUnverified means the result records no hash to check,
+	// e.g. one stored before results were keyed by content.
+	VerificationUnverified VerificationStatus = "unverified"
+)
+
+// VerifiedResult is the outcome of verifying one stored result.
+type VerifiedResult struct {
+	Key    string             `json:"key"`
+	Status VerificationStatus `json:"status"`
+
+	// ExpectedHash is the recorded hash the content was checked against.
+	ExpectedHash string `json:"expected_hash,omitempty"`
+
+	// ActualHash is the hash of the content as stored.
+	ActualHash string `json:"actual_hash,omitempty"`
+
+	// Detail explains a failed verification.
+	Detail string `json:"detail,omitempty"`
+}
+
+// verifiableSource is a resultSource whose raw results can be read back
+// for verification.
+type verifiableSource interface {
+	resultSource
+
+	// readResult returns the stored bytes of a result, decompressed.
+	readResult(ctx context.Context, key string) ([]byte, error)
+}
+
+// verifyResults re-hashes the results of a query, checking every result
+// rather than stopping at the first unreadable one. Results are returned
+// in key order.
+func verifyResults(ctx context.Context, source verifiableSource, query ResultQuery) ([]VerifiedResult, error) {
+	keys := make(chan string)
+	listErr := make(chan error, 1)
+	go func() {
+		defer close(keys)
+		listErr <- source.listResultKeys(ctx, query, keys)
+	}()
+
+	var mu sync.Mutex
+	var verified []VerifiedResult
+	var workers sync.WaitGroup
+	for i := 0; i < queryConcurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for key := range keys {
+				result := verifyResult(ctx, source, key)
+				mu.Lock()
+				verified = append(verified, result)
+				mu.Unlock()
+			}
+		}()
+	}
+	workers.Wait()
+
+	if err := <-listErr; err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(verified, func(i, j int) bool { return verified[i].Key < verified[j].Key })
+	return verified, nil
+}
+
+// verifyResult checks one result against the content hash in its key and
+// the benchmark data checksum it records, if any.
+func verifyResult(ctx context.Context, source verifiableSource, key string) VerifiedResult {
+	result := VerifiedResult{Key: key}
+	body, err := source.readResult(ctx, key)
+	if err != nil {
+		result.Status, result.Detail = VerificationCorrupt, err.Error()
+		return result
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		result.Status, result.Detail = VerificationCorrupt, fmt.Sprintf("invalid JSON: %v", err)
+		return result
+	}
+	if result.ActualHash, err = ContentHash(data); err != nil {
+		result.Status, result.Detail = VerificationCorrupt, err.Error()
+		return result
+	}
+
+	result.Status = VerificationUnverified
+	if expected := keyHash(trimJSONExtension(key)); expected != "" {
+		result.ExpectedHash = expected
+		if result.ActualHash[:keyHashLength] != expected {
+			result.Status, result.Detail = VerificationTampered, "content does not match the hash in its key"
+			return result
+		}
+		result.Status = VerificationOK
+	}
+
+	if expected, actual, ok := benchmarkChecksum(data); ok {
+		if actual != expected {
+			result.Status, result.Detail = VerificationTampered, "benchmark data does not match its recorded checksum"
+			result.ExpectedHash, result.ActualHash = expected, actual
+			return result
+		}
+		result.Status = VerificationOK
+	}
+	return result
+}
+
+// benchmarkChecksum returns the SHA-256 a result records for its benchmark
+// data under validation.checksums, and the ContentHash of that data. It
+// returns false if the result records no usable checksum.
+func benchmarkChecksum(data map[string]interface{}) (expected, actual string, ok bool) {
+	validation, _ := data["validation"].(map[string]interface{})
+	checksums, _ := validation["checksums"].(map[string]interface{})
+	expected, _ = checksums["sha256"].(string)
+	if expected == "" || expected == emptySHA256 {
+		return "", "", false
+	}
+
+	// Results hold the data of one category, e.g. "memory", under "performance"
+	performance, _ := data["performance"].(map[string]interface{})
+	if len(performance) != 1 {
+		return "", "", false
+	}
+	for _, benchmarkData := range performance {
+		hash, err := ContentHash(benchmarkData)
+		if err != nil {
+			return "", "", false
+		}
+		actual = hash
+	}
+	return expected, actual, true
+}
+
+// trimJSONExtension returns the file name of a key without its directory
+// and .json extension.
+func trimJSONExtension(key string) string {
+	return strings.TrimSuffix(path.Base(key), ".json")
+}
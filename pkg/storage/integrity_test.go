@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// contentHash returns the ContentHash of a result.
+func contentHash(t *testing.T, result interface{}) string {
+	t.Helper()
+	hash, err := ContentHash(result)
+	if err != nil {
+		t.Fatalf("ContentHash failed: %v", err)
+	}
+	return hash
+}
+
+func TestContentHashIgnoresFormatting(t *testing.T) {
+	type flat struct {
+		Region       string `json:"region"`
+		InstanceType string `json:"instance_type"`
+	}
+	fromStruct := contentHash(t, flat{Region: "us-east-1", InstanceType: "m7i.large"})
+	fromMap := contentHash(t, map[string]interface{}{"instance_type": "m7i.large", "region": "us-east-1"})
+	if fromStruct != fromMap {
+		t.Errorf("Expected field order not to change the hash, got %s and %s", fromStruct, fromMap)
+	}
+	if other := contentHash(t, map[string]interface{}{"instance_type": "m7i.xlarge", "region": "us-east-1"}); other == fromMap {
+		t.Error("Expected different content to hash differently")
+	}
+}
+
+func TestStoreResultDetectsDuplicates(t *testing.T) {
+	ctx := context.Background()
+	s3Storage, _ := newTestStorage(t)
+	stores := map[string]ResultStore{
+		"s3":    s3Storage,
+		"local": NewLocalStorage(t.TempDir(), Config{KeyPrefix: "test/"}),
+	}
+	day := time.Date(2024, 6, 26, 10, 0, 0, 0, time.UTC)
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			result := storedResult("m7i.large", "us-east-1", "stream", day)
+			if err := store.StoreResult(ctx, result); err != nil {
+				t.Fatalf("StoreResult failed: %v", err)
+			}
+
+			err := store.StoreResult(ctx, storedResult("m7i.large", "us-east-1", "stream", day))
+			var duplicate *DuplicateResultError
+			if !errors.Is(err, ErrDuplicateResult) || !errors.As(err, &duplicate) {
+				t.Fatalf("Expected a duplicate result error, got %v", err)
+			}
+			if duplicate.Hash != contentHash(t, result) || !strings.HasSuffix(duplicate.Key, "-stream-"+duplicate.Hash[:16]+".json") {
+				t.Errorf("Unexpected duplicate %+v", duplicate)
+			}
+
+			// Same attributes with different content are stored side by side
+			rerun := storedResult("m7i.large", "us-east-1", "stream", day)
+			rerun["performance"] = map[string]interface{}{"memory": map[string]interface{}{"stream": "rerun"}}
+			if err := store.StoreResult(ctx, rerun); err != nil {
+				t.Fatalf("StoreResult failed: %v", err)
+			}
+			results, err := store.GetResults(ctx, ResultQuery{})
+			if err != nil {
+				t.Fatalf("GetResults failed: %v", err)
+			}
+			if len(results) != 2 {
+				t.Errorf("Expected 2 stored results, got %d", len(results))
+			}
+		})
+	}
+}
+
+func TestVerifyResults(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store := NewLocalStorage(root, Config{KeyPrefix: "test/"})
+	day := time.Date(2024, 6, 26, 10, 0, 0, 0, time.UTC)
+
+	intact := storedResult("m7i.large", "us-east-1", "stream", day)
+	tampered := storedResult("c7g.large", "us-east-1", "stream", day)
+	corrupt := storedResult("r7i.large", "us-east-1", "stream", day)
+	for _, result := range []map[string]interface{}{intact, tampered, corrupt} {
+		if err := store.StoreResult(ctx, result); err != nil {
+			t.Fatalf("StoreResult failed: %v", err)
+		}
+	}
+
+	dir := filepath.Join(root, "test", "raw", "2024", "06", "26", "us-east-1")
+	rewrite := func(instanceType string, result map[string]interface{}, edit func(string) string) {
+		file := filepath.Join(dir, instanceType, "20240626-100000-stream-"+contentHash(t, result)[:16]+".json")
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(edit(string(data))), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rewrite("c7g.large", tampered, func(data string) string { return strings.Replace(data, "graviton3", "graviton4", 1) })
+	rewrite("r7i.large", corrupt, func(data string) string { return data[:len(data)/2] })
+
+	// Results written before content hashing cannot be checked
+	legacy := filepath.Join(dir, "m7g.large", "20240626-100000-stream.json")
+	if err := os.MkdirAll(filepath.Dir(legacy), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(legacy, []byte(`{"schema_version": "1.0.0"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	verified, err := store.VerifyResults(ctx, ResultQuery{})
+	if err != nil {
+		t.Fatalf("VerifyResults failed: %v", err)
+	}
+	statuses := make(map[string]VerificationStatus)
+	for _, result := range verified {
+		statuses[strings.Split(result.Key, "/")[6]] = result.Status
+	}
+	expected := map[string]VerificationStatus{
+		"c7g.large": VerificationTampered,
+		"m7g.large": VerificationUnverified,
+		"m7i.large": VerificationOK,
+		"r7i.large": VerificationCorrupt,
+	}
+	if len(statuses) != len(expected) {
+		t.Fatalf("Expected %d verified results, got %v", len(expected), verified)
+	}
+	for instanceType, status := range expected {
+		if statuses[instanceType] != status {
+			t.Errorf("Expected %s to be %s, got %s", instanceType, status, statuses[instanceType])
+		}
+	}
+}
+
+func TestVerifyResultsChecksBenchmarkChecksum(t *testing.T) {
+	benchmarkData := map[string]interface{}{"stream": map[string]interface{}{"triad": map[string]interface{}{"bandwidth": 41.9}}}
+	result := map[string]interface{}{
+		"performance": map[string]interface{}{"memory": benchmarkData},
+		"validation": map[string]interface{}{"checksums": map[string]interface{}{
+			"sha256": contentHash(t, benchmarkData),
+		}},
+	}
+	if expected, actual, ok := benchmarkChecksum(result); !ok || expected != actual {
+		t.Errorf("Expected a matching checksum, got %s and %s", expected, actual)
+	}
+
+	benchmarkData["stream"] = map[string]interface{}{"triad": map[string]interface{}{"bandwidth": 99.9}}
+	if expected, actual, ok := benchmarkChecksum(result); !ok || expected == actual {
+		t.Error("Expected edited benchmark data not to match its checksum")
+	}
+
+	result["validation"] = map[string]interface{}{"checksums": map[string]interface{}{"sha256": emptySHA256}}
+	if _, _, ok := benchmarkChecksum(result); ok {
+		t.Error("Expected the placeholder checksum to be ignored")
+	}
+}
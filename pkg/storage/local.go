@@ -0,0 +1,379 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// legacyDayFormat names the per-day directories of results written by the
+// run command, e.g. results/2025-06-29/.
+const legacyDayFormat = "2006-01-02"
+
+// legacyRegionSuffix matches the region that ends the instance type and
+// suite of a legacy file name, e.g. "-us-east-1" or "-us-gov-west-1".
+var legacyRegionSuffix = regexp.MustCompile(`-([a-z]{2}(?:-[a-z]+)+-[0-9]+)$`)
+
+// LocalStorage stores benchmark results as JSON files under a directory,
+// laid out like the keys of S3Storage:
+//
+//   {root}/{prefix}raw/{YYYY}/{MM}/{DD}/{region}/{instance-type}/{YYYYMMDD-HHMMSS}[-{suite}]-{hash}.json
+//
+// Queries also find results in the layout written by the run command,
+// {root}/{YYYY-MM-DD}/{instance-type}-{suite}[-{region}]-{YYYYMMDD-HHMMSS}.json, so
+// directories such as results/ can be queried without converting them.
+//
+// LocalStorage lets benchmarks, data processing and analysis run without
+// AWS access. It is safe for concurrent use.
+//
+// Example:
+//   store := storage.NewLocalStorage("results", storage.Config{})
+//   results, err := store.GetResults(ctx, storage.ResultQuery{
+//       BenchmarkSuites: []string{"stream"},
+//       SortBy:          "timestamp",
+//   })
+type LocalStorage struct {
+	// root is the directory holding the results.
+	root string
+
+	// config provides the KeyPrefix of stored results.
+	config Config
+}
+
+// NewLocalStorage creates a LocalStorage rooted at dir. The directory is
+// created when the first result is stored.
+//
+// Parameters:
+//   - dir: Directory holding the results
+//   - storageConfig: Storage configuration; only KeyPrefix applies
+//
+// Returns:
+//   - *LocalStorage: Storage instance ready for operations
+func NewLocalStorage(dir string, storageConfig Config) *LocalStorage {
+	return &LocalStorage{root: dir, config: storageConfig}
+}
+
+// StoreResult writes a result as indented JSON to the file for its key.
+//
+// Results are keyed by content hash: the file name ends with the start of
+// the result's ContentHash, and the key is recorded under
+// {root}/{prefix}sha256/{hash}. Storing a result whose content is already
+// stored returns a *DuplicateResultError and leaves the store unchanged.
+func (s *LocalStorage) StoreResult(_ context.Context, result interface{}) error {
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize result: %w", err)
+	}
+	hash, err := ContentHash(result)
+	if err != nil {
+		return err
+	}
+
+	key := resultKey(s.config.KeyPrefix, result, hash)
+	release, err := s.claimHash(hash, key)
+	if err != nil {
+		return err
+	}
+	if err := s.writeFile(key, jsonData); err != nil {
+		release()
+		return fmt.Errorf("failed to write result: %w", err)
+	}
+	return nil
+}
+
+// claimHash records key as the result with a content hash. It fails with a
+// *DuplicateResultError if another stored result has the hash; a record
+// left behind by a failed store, whose result is missing, is taken over.
+// The returned function removes the record again.
+func (s *LocalStorage) claimHash(hash, key string) (func(), error) {
+	index := filepath.Join(s.root, filepath.FromSlash(hashIndexKey(s.config.KeyPrefix, hash)))
+	if err := os.MkdirAll(filepath.Dir(index), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create hash index: %w", err)
+	}
+
+	for {
+		file, err := os.OpenFile(index, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			_, writeErr := file.WriteString(key)
+			if closeErr := file.Close(); writeErr == nil {
+				writeErr = closeErr
+			}
+			if writeErr != nil {
+				os.Remove(index)
+				return nil, fmt.Errorf("failed to record content hash: %w", writeErr)
+			}
+			return func() { os.Remove(index) }, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("failed to record content hash: %w", err)
+		}
+
+		existing, err := os.ReadFile(index)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read hash index: %w", err)
+		}
+		if _, err := os.Stat(filepath.Join(s.root, filepath.FromSlash(string(existing)))); err == nil {
+			return nil, &DuplicateResultError{Hash: hash, Key: string(existing)}
+		}
+		if err := os.Remove(index); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to replace stale hash index: %w", err)
+		}
+	}
+}
+
+// writeFile atomically replaces the file for key with data.
+func (s *LocalStorage) writeFile(key string, data []byte) error {
+	file := filepath.Join(s.root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".tmp-"+filepath.Base(file)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// ArchiveRawOutput writes the gzipped JSON of the raw output a result was
+// parsed from next to the file the result is stored in, replacing any
+// earlier archive, and returns its key.
+func (s *LocalStorage) ArchiveRawOutput(_ context.Context, result interface{}, output interface{}) (string, error) {
+	hash, err := ContentHash(result)
+	if err != nil {
+		return "", err
+	}
+	data, err := compressJSON(output)
+	if err != nil {
+		return "", err
+	}
+
+	key := rawOutputKey(resultKey(s.config.KeyPrefix, result, hash))
+	if err := s.writeFile(key, data); err != nil {
+		return "", fmt.Errorf("failed to write raw output: %w", err)
+	}
+	return key, nil
+}
+
+// LoadRawOutput decodes the raw output archived for the result stored
+// under resultKey into output.
+func (s *LocalStorage) LoadRawOutput(ctx context.Context, resultKey string, output interface{}) error {
+	body, err := s.readResult(ctx, rawOutputKey(resultKey))
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrRawOutputNotFound, resultKey)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read raw output of %s: %w", resultKey, err)
+	}
+	return decodeRawOutput(resultKey, body, output)
+}
+
+// GetResults returns the results matching a query, sorted by SortBy and
+// SortOrder and limited to MaxResults, like S3Storage.GetResults.
+func (s *LocalStorage) GetResults(ctx context.Context, query ResultQuery) ([]interface{}, error) {
+	return getResults(ctx, s, query)
+}
+
+// QueryResults starts a query and returns an iterator over its results,
+// like S3Storage.QueryResults.
+//
+// Date ranges are narrowed to the matching day directories of both
+// layouts; without a start date the whole directory is searched.
+func (s *LocalStorage) QueryResults(ctx context.Context, query ResultQuery) *ResultIterator {
+	return runQuery(ctx, s, query)
+}
+
+// VerifyResults re-hashes the results matching a query and reports, in
+// key order, whether each still matches the content hash in its key and
+// the checksum of its benchmark data. Unreadable results are reported as
+// corrupt rather than failing the verification.
+func (s *LocalStorage) VerifyResults(ctx context.Context, query ResultQuery) ([]VerifiedResult, error) {
+	return verifyResults(ctx, s, query)
+}
+
+// Close releases nothing; it exists to satisfy ResultStore.
+func (s *LocalStorage) Close() error {
+	return nil
+}
+
+// keyInfo returns the result attributes encoded in a key of either layout.
+func (s *LocalStorage) keyInfo(key string) (resultInfo, bool) {
+	if info, ok := parseResultKey(s.config.KeyPrefix, key); ok {
+		return info, true
+	}
+	return legacyKeyInfo(key)
+}
+
+// legacyKeyInfo returns the result attributes encoded in a file name
+// written by the run command:
+//
+//   {instance-type}-{suite}[-{region}]-{YYYYMMDD-HHMMSS}.json
+//
+// Files written before runs spanned regions have no region in their name.
+func legacyKeyInfo(key string) (resultInfo, bool) {
+	name, ok := strings.CutSuffix(path.Base(key), ".json")
+	if !ok || len(name) < len(resultKeyTimeFormat)+4 {
+		return resultInfo{}, false
+	}
+
+	stamp := name[len(name)-len(resultKeyTimeFormat):]
+	timestamp, err := time.Parse(resultKeyTimeFormat, stamp)
+	if err != nil {
+		return resultInfo{}, false
+	}
+	// Suites never contain a dash, but instance types such as u-6tb1.metal do
+	rest := strings.TrimSuffix(strings.TrimSuffix(name, stamp), "-")
+	var region string
+	if match := legacyRegionSuffix.FindStringSubmatchIndex(rest); match != nil {
+		region = rest[match[2]:match[3]]
+		rest = rest[:match[0]]
+	}
+	split := strings.LastIndex(rest, "-")
+	if split <= 0 || split == len(rest)-1 {
+		return resultInfo{}, false
+	}
+	instanceType, suite := rest[:split], rest[split+1:]
+	return resultInfo{InstanceType: instanceType, Region: region, BenchmarkSuite: suite, Timestamp: timestamp}, true
+}
+
+// queryDirs returns the directories to search for a query: the day
+// directories of both layouts within the date range, or the root.
+func (s *LocalStorage) queryDirs(q ResultQuery) ([]string, error) {
+	if q.DateRange.Start.IsZero() {
+		return []string{s.root}, nil
+	}
+
+	var dirs []string
+	for _, prefix := range resultPrefixes(s.config.KeyPrefix, q) {
+		dirs = append(dirs, filepath.Join(s.root, filepath.FromSlash(prefix)))
+	}
+
+	entries, err := os.ReadDir(s.root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list results in %s: %w", s.root, err)
+	}
+	for _, entry := range entries {
+		day, err := time.Parse(legacyDayFormat, entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		if day.AddDate(0, 0, 1).After(q.DateRange.Start) && (q.DateRange.End.IsZero() || day.Before(q.DateRange.End)) {
+			dirs = append(dirs, filepath.Join(s.root, entry.Name()))
+		}
+	}
+	return dirs, nil
+}
+
+// listResultKeys walks the directories of a query and sends the keys of
+// result files that are not ruled out by their key alone.
+func (s *LocalStorage) listResultKeys(ctx context.Context, query ResultQuery, keys chan<- string) error {
+	dirs, err := s.queryDirs(query)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) && file == dir && dir != s.root {
+					return filepath.SkipDir // No results for this prefix
+				}
+				return err
+			}
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".tmp-") {
+				return nil
+			}
+
+			rel, err := filepath.Rel(s.root, file)
+			if err != nil {
+				return err
+			}
+			key := filepath.ToSlash(rel)
+			info, ok := s.keyInfo(key)
+			if !ok || query.excludes(info, nil) {
+				return nil
+			}
+			select {
+			case keys <- key:
+				return nil
+			case <-ctx.Done():
+				return fs.SkipAll
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list results under %s: %w", dir, err)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+	return nil
+}
+
+// fetchResult reads and decodes one result file. It returns false if the
+// result does not match the query.
+func (s *LocalStorage) fetchResult(_ context.Context, query ResultQuery, key string) (StoredResult, bool, error) {
+	file, err := os.Open(filepath.Join(s.root, filepath.FromSlash(key)))
+	if err != nil {
+		return StoredResult{}, false, &skipError{key: key, err: err}
+	}
+	defer file.Close()
+
+	body, err := readResultBody(file)
+	if err != nil {
+		return StoredResult{}, false, &skipError{key: key, err: err}
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return StoredResult{}, false, &skipError{key: key, err: err}
+	}
+
+	info, _ := s.keyInfo(key)
+	info.merge(describeResultJSON(body))
+	if info.Timestamp.IsZero() {
+		if stat, err := file.Stat(); err == nil {
+			info.Timestamp = stat.ModTime().UTC()
+		}
+	}
+	metadata := attributeMetadata(info)
+	if !query.matches(info, metadata) {
+		return StoredResult{}, false, nil
+	}
+
+	return StoredResult{
+		Key:            key,
+		InstanceType:   info.InstanceType,
+		Region:         info.Region,
+		BenchmarkSuite: info.BenchmarkSuite,
+		Architecture:   info.Architecture,
+		Timestamp:      info.Timestamp,
+		Metadata:       metadata,
+		Data:           data,
+	}, true, nil
+}
+
+// readResult reads the stored bytes of one result file.
+func (s *LocalStorage) readResult(_ context.Context, key string) ([]byte, error) {
+	file, err := os.Open(filepath.Join(s.root, filepath.FromSlash(key)))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return readResultBody(file)
+}
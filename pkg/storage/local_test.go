@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLocalStorageRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	store := NewLocalStorage(root, Config{KeyPrefix: "test/"})
+	ctx := context.Background()
+	day := time.Date(2024, 6, 26, 10, 0, 0, 0, time.UTC)
+
+	first := storedResult("m7i.large", "us-east-1", "stream", day)
+	latest := storedResult("m7i.large", "us-east-1", "stream", day.AddDate(0, 0, 1))
+	for _, result := range []map[string]interface{}{
+		first,
+		storedResult("m7i.large", "us-east-1", "hpl", day.Add(time.Hour)),
+		storedResult("c7g.large", "us-west-2", "stream", day.Add(2*time.Hour)),
+		latest,
+	} {
+		if err := store.StoreResult(ctx, result); err != nil {
+			t.Fatalf("StoreResult failed: %v", err)
+		}
+	}
+
+	file := filepath.Join(root, "test", "raw", "2024", "06", "26", "us-east-1", "m7i.large", "20240626-100000-stream-"+contentHash(t, first)[:16]+".json")
+	if _, err := os.Stat(file); err != nil {
+		t.Fatalf("Expected result file at the S3 key layout: %v", err)
+	}
+
+	testCases := []struct {
+		name        string
+		query       ResultQuery
+		expectCount int
+	}{
+		{name: "everything", query: ResultQuery{}, expectCount: 4},
+		{
+			name:        "one day",
+			query:       ResultQuery{DateRange: DateRange{Start: day.Truncate(24 * time.Hour), End: day.Truncate(24*time.Hour).AddDate(0, 0, 1)}},
+			expectCount: 3,
+		},
+		{
+			name: "region and instance type",
+			query: ResultQuery{
+				DateRange:     DateRange{Start: day.AddDate(0, 0, -1), End: day.AddDate(0, 0, 2)},
+				Regions:       []string{"us-east-1"},
+				InstanceTypes: []string{"m7i.large"},
+			},
+			expectCount: 3,
+		},
+		{name: "benchmark suite", query: ResultQuery{BenchmarkSuites: []string{"hpl"}}, expectCount: 1},
+		{name: "tags", query: ResultQuery{Tags: map[string]string{"region": "us-west-2"}}, expectCount: 1},
+		{name: "max results", query: ResultQuery{MaxResults: 2}, expectCount: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			results, err := store.GetResults(ctx, tc.query)
+			if err != nil {
+				t.Fatalf("GetResults failed: %v", err)
+			}
+			if len(results) != tc.expectCount {
+				t.Errorf("Expected %d results, got %d", tc.expectCount, len(results))
+			}
+		})
+	}
+
+	results, err := store.GetResults(ctx, ResultQuery{SortBy: "timestamp", SortOrder: "desc", IncludeMetadata: true})
+	if err != nil {
+		t.Fatalf("GetResults failed: %v", err)
+	}
+	newest := results[0].(StoredResult)
+	if !newest.Timestamp.Equal(day.AddDate(0, 0, 1)) || newest.Key != "test/raw/2024/06/27/us-east-1/m7i.large/20240627-100000-stream-"+contentHash(t, latest)[:16]+".json" {
+		t.Errorf("Unexpected latest result %s at %v", newest.Key, newest.Timestamp)
+	}
+	if newest.Metadata["instance-type"] != "m7i.large" || newest.Metadata["architecture"] != "graviton3" {
+		t.Errorf("Expected result attributes as metadata, got %v", newest.Metadata)
+	}
+}
+
+func TestLocalStorageLegacyLayout(t *testing.T) {
+	root := t.TempDir()
+	ctx := context.Background()
+	day := time.Date(2025, 6, 29, 0, 0, 0, 0, time.UTC)
+
+	files := map[string]map[string]interface{}{
+		"2025-06-29/c5.large-stream-20250629-180635.json":     storedResult("c5.large", "us-east-1", "stream", day.Add(18*time.Hour)),
+		"2025-06-29/r7i.large-coremark-20250629-190000.json":  storedResult("r7i.large", "us-west-2", "coremark", day.Add(19*time.Hour)),
+		"2025-06-30/u-6tb1.metal-stream-20250630-010000.json": {"schema_version": "1.0.0"},
+		"2025-06-30/m7i.large-stream-eu-west-1-20250630-020000.json": {"schema_version": "1.0.0"},
+		"2025-06-30/m7i.large-stream-ap-northeast-1-20250630-020000.json": {"schema_version": "1.0.0"},
+		"2025-06-30/summary.json":                             {"total": 3},
+	}
+	for name, result := range files {
+		data, _ := json.Marshal(result)
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store := NewLocalStorage(root, Config{})
+	if err := store.StoreResult(ctx, storedResult("m7g.large", "us-east-1", "stream", day.Add(20*time.Hour))); err != nil {
+		t.Fatalf("StoreResult failed: %v", err)
+	}
+
+	testCases := []struct {
+		name        string
+		query       ResultQuery
+		expectCount int
+	}{
+		{name: "everything", query: ResultQuery{}, expectCount: 6},
+		{name: "one day in both layouts", query: ResultQuery{DateRange: DateRange{Start: day, End: day.AddDate(0, 0, 1)}}, expectCount: 3},
+		{name: "suite from file name", query: ResultQuery{BenchmarkSuites: []string{"stream"}}, expectCount: 5},
+		{name: "instance type with a dash", query: ResultQuery{InstanceTypes: []string{"u-6tb1.metal"}}, expectCount: 1},
+		{name: "region from content", query: ResultQuery{Regions: []string{"us-west-2"}}, expectCount: 1},
+		{name: "region from file name", query: ResultQuery{Regions: []string{"eu-west-1"}}, expectCount: 1},
+		{name: "same run in two regions", query: ResultQuery{InstanceTypes: []string{"m7i.large"}}, expectCount: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			results, err := store.GetResults(ctx, tc.query)
+			if err != nil {
+				t.Fatalf("GetResults failed: %v", err)
+			}
+			if len(results) != tc.expectCount {
+				t.Errorf("Expected %d results, got %d", tc.expectCount, len(results))
+			}
+		})
+	}
+}
+
+func TestLocalStorageErrors(t *testing.T) {
+	ctx := context.Background()
+
+	missing := NewLocalStorage(filepath.Join(t.TempDir(), "missing"), Config{})
+	if _, err := missing.GetResults(ctx, ResultQuery{}); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "2024-06-26"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "2024-06-26", "m7i.large-stream-20240626-100000.json"), []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store := NewLocalStorage(root, Config{})
+	if err := store.StoreResult(ctx, storedResult("m7i.large", "us-east-1", "stream", time.Date(2024, 6, 26, 11, 0, 0, 0, time.UTC))); err != nil {
+		t.Fatalf("StoreResult failed: %v", err)
+	}
+
+	results := store.QueryResults(ctx, ResultQuery{})
+	count := 0
+	for results.Next() {
+		count++
+	}
+	results.Close()
+	if err := results.Err(); err != nil {
+		t.Errorf("Expected the invalid file to be skipped, got error %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 valid result, got %d", count)
+	}
+	if skipped := results.Skipped(); len(skipped) != 1 || skipped[0].Key != "2024-06-26/m7i.large-stream-20240626-100000.json" {
+		t.Errorf("Expected the invalid file to be reported as skipped, got %v", skipped)
+	}
+}
@@ -0,0 +1,757 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+const (
+	// queryConcurrency is the number of objects fetched and decoded in
+	// parallel while querying results.
+	queryConcurrency = 16
+
+	// maxDayPrefixes is the longest date range listed day by day; longer
+	// ranges are listed by month and filtered on the key date.
+	maxDayPrefixes = 92
+
+	// resultKeyTimeFormat is the timestamp at the start of a result file name.
+	resultKeyTimeFormat = "20060102-150405"
+
+	// Placeholders used in keys when a result does not name its region or
+	// instance type.
+	unknownRegion       = "unknown-region"
+	unknownInstanceType = "unknown-instance"
+)
+
+// Object metadata keys written by StoreResult and used to filter queries.
+const (
+	metadataInstanceType   = "instance-type"
+	metadataRegion         = "region"
+	metadataBenchmarkSuite = "benchmark-suite"
+	metadataArchitecture   = "architecture"
+
+	// metadataContentHash records the full ContentHash of a result.
+	metadataContentHash = "content-sha256"
+)
+
+// StoredResult is one benchmark result read back from a ResultStore.
+type StoredResult struct {
+	// Key is the S3 object key of the result, or its slash-separated path
+	// relative to the root of a LocalStorage.
+	Key string
+
+	// InstanceType, Region, BenchmarkSuite and Architecture identify the
+	// result, from its key, object metadata or content.
+	InstanceType   string
+	Region         string
+	BenchmarkSuite string
+	Architecture   string
+
+	// Timestamp is when the benchmark ran, or when the result was stored
+	// if the result does not record it.
+	Timestamp time.Time
+
+	// Metadata is the S3 object metadata. Local results have no object
+	// metadata; theirs holds the identifying attributes under the keys
+	// StoreResult uses.
+	Metadata map[string]string
+
+	// Data is the decoded JSON result.
+	Data map[string]interface{}
+}
+
+// SkippedResult is a stored object a query passed over because it could
+// not be read or decoded as a result.
+type SkippedResult struct {
+	Key string
+	Err error
+}
+
+// skipError reports that one result could not be read or decoded. Queries
+// skip such results instead of failing.
+type skipError struct {
+	key string
+	err error
+}
+
+func (e *skipError) Error() string {
+	return fmt.Sprintf("failed to decode %s: %v", e.key, e.err)
+}
+
+func (e *skipError) Unwrap() error {
+	return e.err
+}
+
+// resultInfo holds the attributes used to organize and query results.
+// Empty fields are unknown.
+type resultInfo struct {
+	InstanceType   string
+	Region         string
+	BenchmarkSuite string
+	Architecture   string
+	Timestamp      time.Time
+}
+
+// merge fills the unknown fields of info from other.
+func (info *resultInfo) merge(other resultInfo) {
+	if info.InstanceType == "" {
+		info.InstanceType = other.InstanceType
+	}
+	if info.Region == "" {
+		info.Region = other.Region
+	}
+	if info.BenchmarkSuite == "" {
+		info.BenchmarkSuite = other.BenchmarkSuite
+	}
+	if info.Architecture == "" {
+		info.Architecture = other.Architecture
+	}
+	if info.Timestamp.IsZero() {
+		info.Timestamp = other.Timestamp
+	}
+}
+
+// describeResult extracts the attributes of a result. It understands the
+// stored result format, with attributes under "metadata", as well as flat
+// results such as benchmarks.BenchmarkResult.
+func describeResult(result interface{}) resultInfo {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return resultInfo{}
+	}
+	return describeResultJSON(data)
+}
+
+// describeResultJSON extracts the attributes of a JSON encoded result.
+// Fields of unexpected types are ignored.
+func describeResultJSON(data []byte) resultInfo {
+	var fields struct {
+		InstanceType   string `json:"instance_type"`
+		Region         string `json:"region"`
+		BenchmarkSuite string `json:"benchmark_suite"`
+		Suite          string `json:"BenchmarkSuite"`
+		Architecture   string `json:"architecture"`
+		Timestamp      string `json:"timestamp"`
+		Metadata       struct {
+			InstanceType   string `json:"instanceType"`
+			Region         string `json:"region"`
+			BenchmarkSuite string `json:"benchmark_suite"`
+			Architecture   string `json:"processorArchitecture"`
+			Timestamp      string `json:"timestamp"`
+		} `json:"metadata"`
+	}
+	_ = json.Unmarshal(data, &fields) // Partial results are still useful
+
+	info := resultInfo{
+		InstanceType:   fields.Metadata.InstanceType,
+		Region:         fields.Metadata.Region,
+		BenchmarkSuite: fields.Metadata.BenchmarkSuite,
+		Architecture:   fields.Metadata.Architecture,
+		Timestamp:      parseTimestamp(fields.Metadata.Timestamp),
+	}
+	info.merge(resultInfo{
+		InstanceType:   fields.InstanceType,
+		Region:         fields.Region,
+		BenchmarkSuite: fields.BenchmarkSuite,
+		Architecture:   fields.Architecture,
+		Timestamp:      parseTimestamp(fields.Timestamp),
+	})
+	if info.BenchmarkSuite == "" {
+		info.BenchmarkSuite = fields.Suite
+	}
+	return info
+}
+
+// parseTimestamp parses an RFC 3339 timestamp, returning the zero time for
+// anything else.
+func parseTimestamp(value string) time.Time {
+	timestamp, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return timestamp.UTC()
+}
+
+// metadataInfo returns the result attributes recorded in object metadata.
+func metadataInfo(metadata map[string]string) resultInfo {
+	return resultInfo{
+		InstanceType:   metadata[metadataInstanceType],
+		Region:         metadata[metadataRegion],
+		BenchmarkSuite: metadata[metadataBenchmarkSuite],
+		Architecture:   metadata[metadataArchitecture],
+	}
+}
+
+// resultKey returns the key of a result with a content hash below prefix:
+//
+//   {prefix}raw/{YYYY}/{MM}/{DD}/{region}/{instance-type}/{YYYYMMDD-HHMMSS}[-{suite}]-{hash}.json
+//
+// The region, instance type and benchmark suite are taken from the result;
+// placeholders are used for those it does not record. The date comes from
+// the result timestamp, or the current time if it has none. The key ends
+// with the first keyHashLength digits of the ContentHash, so results with
+// the same attributes but different content never overwrite each other.
+func resultKey(prefix string, result interface{}, hash string) string {
+	info := describeResult(result)
+
+	timestamp := info.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now().UTC()
+	}
+	region := info.Region
+	if region == "" {
+		region = unknownRegion
+	}
+	instanceType := info.InstanceType
+	if instanceType == "" {
+		instanceType = unknownInstanceType
+	}
+	name := timestamp.Format(resultKeyTimeFormat)
+	if info.BenchmarkSuite != "" {
+		name += "-" + info.BenchmarkSuite
+	}
+	if len(hash) >= keyHashLength {
+		name += "-" + hash[:keyHashLength]
+	}
+
+	return fmt.Sprintf("%sraw/%04d/%02d/%02d/%s/%s/%s.json",
+		prefix, timestamp.Year(), timestamp.Month(), timestamp.Day(), region, instanceType, name)
+}
+
+// parseResultKey returns the result attributes encoded in a key generated
+// by resultKey with the same prefix.
+func parseResultKey(prefix, key string) (resultInfo, bool) {
+	path := strings.TrimPrefix(key, prefix+"raw/")
+	parts := strings.Split(path, "/")
+	if path == key || len(parts) != 6 || !strings.HasSuffix(parts[5], ".json") {
+		return resultInfo{}, false
+	}
+
+	info := resultInfo{Region: parts[3], InstanceType: parts[4]}
+	if info.Region == unknownRegion {
+		info.Region = ""
+	}
+	if info.InstanceType == unknownInstanceType {
+		info.InstanceType = ""
+	}
+
+	name := strings.TrimSuffix(parts[5], ".json")
+	if hash := keyHash(name); hash != "" {
+		name = strings.TrimSuffix(name, "-"+hash)
+	}
+	if len(name) >= len(resultKeyTimeFormat) {
+		if timestamp, err := time.Parse(resultKeyTimeFormat, name[:len(resultKeyTimeFormat)]); err == nil {
+			info.Timestamp = timestamp
+		}
+		info.BenchmarkSuite = strings.TrimPrefix(name[len(resultKeyTimeFormat):], "-")
+	}
+	return info, true
+}
+
+// attributeMetadata returns the known result attributes as object
+// metadata, the inverse of metadataInfo.
+func attributeMetadata(info resultInfo) map[string]string {
+	metadata := make(map[string]string)
+	for key, value := range map[string]string{
+		metadataInstanceType:   info.InstanceType,
+		metadataRegion:         info.Region,
+		metadataBenchmarkSuite: info.BenchmarkSuite,
+		metadataArchitecture:   info.Architecture,
+	} {
+		if value != "" {
+			metadata[key] = value
+		}
+	}
+	return metadata
+}
+
+// keyInfo returns the result attributes encoded in a key generated by
+// generateResultKey.
+func (s *S3Storage) keyInfo(key string) (resultInfo, bool) {
+	return parseResultKey(s.config.KeyPrefix, key)
+}
+
+// excludes reports whether the known attributes of a result rule it out.
+// Unknown attributes never exclude a result.
+func (q ResultQuery) excludes(info resultInfo, metadata map[string]string) bool {
+	if !matchesAny(q.InstanceTypes, info.InstanceType, true) ||
+		!matchesAny(q.Regions, info.Region, true) ||
+		!matchesAny(q.BenchmarkSuites, info.BenchmarkSuite, true) ||
+		!matchesAny(q.Architectures, info.Architecture, true) {
+		return true
+	}
+	if !info.Timestamp.IsZero() && !q.DateRange.contains(info.Timestamp) {
+		return true
+	}
+	if metadata != nil {
+		for tag, value := range q.Tags {
+			if metadata[tag] != value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matches reports whether a fully described result satisfies the query.
+// A filtered attribute that is still unknown does not match.
+func (q ResultQuery) matches(info resultInfo, metadata map[string]string) bool {
+	if q.excludes(info, metadata) {
+		return false
+	}
+	return matchesAny(q.InstanceTypes, info.InstanceType, false) &&
+		matchesAny(q.Regions, info.Region, false) &&
+		matchesAny(q.BenchmarkSuites, info.BenchmarkSuite, false) &&
+		matchesAny(q.Architectures, info.Architecture, false) &&
+		(q.DateRange.Start.IsZero() && q.DateRange.End.IsZero() || !info.Timestamp.IsZero())
+}
+
+// matchesAny reports whether value is one of the wanted values. An empty
+// filter matches everything; an empty value matches if unknownMatches.
+func matchesAny(wanted []string, value string, unknownMatches bool) bool {
+	if len(wanted) == 0 || value == "" && unknownMatches {
+		return true
+	}
+	for _, candidate := range wanted {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// contains reports whether t falls within the range. A zero Start or End
+// leaves that side open.
+func (r DateRange) contains(t time.Time) bool {
+	if !r.Start.IsZero() && t.Before(r.Start) {
+		return false
+	}
+	return r.End.IsZero() || t.Before(r.End)
+}
+
+// resultPrefixes returns the prefixes of the keys generated by resultKey
+// with the given prefix that can match a query.
+//
+// Keys are organized by date, then region, then instance type, so a date
+// range narrows the listing to its days, and within a day the regions and
+// instance types narrow it further. Ranges longer than maxDayPrefixes days
+// are listed by month; without a start date the whole raw tree is listed.
+func resultPrefixes(prefix string, q ResultQuery) []string {
+	base := prefix + "raw/"
+	if q.DateRange.Start.IsZero() {
+		return []string{base}
+	}
+
+	start := q.DateRange.Start.UTC()
+	end := q.DateRange.End.UTC()
+	if q.DateRange.End.IsZero() {
+		end = time.Now().UTC().Add(24 * time.Hour)
+	}
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+
+	if end.Sub(day) > maxDayPrefixes*24*time.Hour {
+		var prefixes []string
+		for month := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC); month.Before(end); month = month.AddDate(0, 1, 0) {
+			prefixes = append(prefixes, fmt.Sprintf("%s%04d/%02d/", base, month.Year(), month.Month()))
+		}
+		return prefixes
+	}
+
+	var prefixes []string
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		dayPrefix := fmt.Sprintf("%s%04d/%02d/%02d/", base, day.Year(), day.Month(), day.Day())
+		if len(q.Regions) == 0 {
+			prefixes = append(prefixes, dayPrefix)
+			continue
+		}
+		for _, region := range q.Regions {
+			if len(q.InstanceTypes) == 0 {
+				prefixes = append(prefixes, dayPrefix+region+"/")
+				continue
+			}
+			for _, instanceType := range q.InstanceTypes {
+				prefixes = append(prefixes, dayPrefix+region+"/"+instanceType+"/")
+			}
+		}
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
+
+// queryPrefixes returns the key prefixes to list for a query.
+func (s *S3Storage) queryPrefixes(q ResultQuery) []string {
+	return resultPrefixes(s.config.KeyPrefix, q)
+}
+
+// ResultIterator streams the results of a query. Results are fetched and
+// decoded concurrently, so they arrive in no particular order.
+//
+// Example:
+//   results := storage.QueryResults(ctx, query)
+//   defer results.Close()
+//   for results.Next() {
+//       result := results.Result()
+//       fmt.Println(result.Key, result.InstanceType)
+//   }
+//   if err := results.Err(); err != nil {
+//       return err
+//   }
+type ResultIterator struct {
+	results   chan StoredResult
+	cancel    context.CancelFunc
+	current   StoredResult
+	remaining int
+	onSkip    func(SkippedResult)
+
+	mu      sync.Mutex
+	err     error
+	skipped []SkippedResult
+	closed  bool
+}
+
+// Next advances to the next result. It returns false when the results are
+// exhausted, MaxResults is reached, or an error occurred; check Err.
+func (it *ResultIterator) Next() bool {
+	if it.remaining == 0 {
+		it.Close()
+		return false
+	}
+	result, ok := <-it.results
+	if !ok {
+		return false
+	}
+	it.current = result
+	if it.remaining > 0 {
+		it.remaining--
+	}
+	return true
+}
+
+// Result returns the result Next advanced to.
+func (it *ResultIterator) Result() StoredResult {
+	return it.current
+}
+
+// Err returns the first error that stopped the query, if any. Results
+// that could not be decoded do not stop a query; see Skipped.
+func (it *ResultIterator) Err() error {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	return it.err
+}
+
+// Skipped returns the results skipped so far because they could not be
+// read or decoded, in no particular order.
+func (it *ResultIterator) Skipped() []SkippedResult {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	return append([]SkippedResult(nil), it.skipped...)
+}
+
+// Close stops the query and releases its goroutines. It is safe to call
+// more than once.
+func (it *ResultIterator) Close() {
+	it.mu.Lock()
+	it.closed = true
+	it.mu.Unlock()
+
+	it.cancel()
+	for range it.results {
+	}
+}
+
+// fail records the first error and stops the query. Errors caused by
+// Close stopping the query are not recorded.
+func (it *ResultIterator) fail(err error) {
+	it.mu.Lock()
+	if it.err == nil && !it.closed {
+		it.err = err
+	}
+	it.mu.Unlock()
+	it.cancel()
+}
+
+// skip records a result that could not be decoded and reports it to the
+// query's OnSkip callback.
+func (it *ResultIterator) skip(skipped SkippedResult) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	it.skipped = append(it.skipped, skipped)
+	if it.onSkip != nil {
+		it.onSkip(skipped)
+	}
+}
+
+// resultSource is a store that results can be queried from: it lists the
+// keys that may match a query and fetches a result by key.
+type resultSource interface {
+	// listResultKeys sends the keys that are not ruled out by the key
+	// alone, stopping early if ctx is cancelled.
+	listResultKeys(ctx context.Context, query ResultQuery, keys chan<- string) error
+
+	// fetchResult reads and decodes one result, returning false if it
+	// does not match the query. A result that cannot be decoded is
+	// reported with a *skipError, which skips it without failing the query.
+	fetchResult(ctx context.Context, query ResultQuery, key string) (StoredResult, bool, error)
+}
+
+// QueryResults starts a query and returns an iterator over its results.
+//
+// The query is translated into key prefixes (see queryPrefixes), which are
+// listed page by page. Keys and object metadata are checked against the
+// query before an object is decoded, so non-matching results cost at most
+// a request. Objects that are not valid results are skipped and reported
+// through Skipped and the query's OnSkip. SortBy and SortOrder are
+// ignored; use GetResults for sorted results.
+//
+// Parameters:
+//   - ctx: Context for cancellation of the whole query
+//   - query: Filters and the maximum number of results
+//
+// Returns:
+//   - *ResultIterator: Iterator over the matching results; always Close it
+func (s *S3Storage) QueryResults(ctx context.Context, query ResultQuery) *ResultIterator {
+	if s.client == nil {
+		return failedQuery(ErrClientNotInitialized)
+	}
+	return runQuery(ctx, s, query)
+}
+
+// failedQuery returns an iterator that yields no results and reports err.
+func failedQuery(err error) *ResultIterator {
+	it := &ResultIterator{
+		results: make(chan StoredResult),
+		cancel:  func() {},
+		err:     err,
+	}
+	close(it.results)
+	return it
+}
+
+// runQuery lists the keys of source that may match the query and fetches
+// them with queryConcurrency workers, streaming the matching results.
+func runQuery(ctx context.Context, source resultSource, query ResultQuery) *ResultIterator {
+	queryCtx, cancel := context.WithCancel(ctx)
+	it := &ResultIterator{
+		results:   make(chan StoredResult, queryConcurrency),
+		cancel:    cancel,
+		remaining: -1,
+		onSkip:    query.OnSkip,
+	}
+	if query.MaxResults > 0 {
+		it.remaining = query.MaxResults
+	}
+
+	keys := make(chan string)
+	go func() {
+		defer close(keys)
+		if err := source.listResultKeys(queryCtx, query, keys); err != nil {
+			it.fail(err)
+		}
+	}()
+
+	var workers sync.WaitGroup
+	for i := 0; i < queryConcurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for key := range keys {
+				result, ok, err := source.fetchResult(queryCtx, query, key)
+				var skipped *skipError
+				if errors.As(err, &skipped) {
+					it.skip(SkippedResult{Key: skipped.key, Err: skipped.err})
+					continue
+				}
+				if err != nil {
+					it.fail(err)
+					continue
+				}
+				if !ok {
+					continue
+				}
+				select {
+				case it.results <- result:
+				case <-queryCtx.Done():
+				}
+			}
+		}()
+	}
+	go func() {
+		workers.Wait()
+		close(it.results)
+	}()
+
+	return it
+}
+
+// listResultKeys sends the keys under the query prefixes that are not
+// ruled out by their key alone.
+func (s *S3Storage) listResultKeys(ctx context.Context, query ResultQuery, keys chan<- string) error {
+	for _, prefix := range s.queryPrefixes(query) {
+		paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+			Bucket: aws.String(s.config.BucketName),
+			Prefix: aws.String(prefix),
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to list results under %s: %w", prefix, err)
+			}
+			for _, object := range page.Contents {
+				key := aws.ToString(object.Key)
+				info, ok := s.keyInfo(key)
+				if !ok || query.excludes(info, nil) {
+					continue
+				}
+				select {
+				case keys <- key:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// fetchResult downloads and decodes one result. It returns false if the
+// result does not match the query; the body is not read when the object
+// metadata already rules it out.
+func (s *S3Storage) fetchResult(ctx context.Context, query ResultQuery, key string) (StoredResult, bool, error) {
+	output, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.config.BucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return StoredResult{}, false, nil
+		}
+		return StoredResult{}, false, fmt.Errorf("failed to get %s: %w", key, err)
+	}
+	defer output.Body.Close()
+
+	info, _ := s.keyInfo(key)
+	info.merge(metadataInfo(output.Metadata))
+	if query.excludes(info, output.Metadata) {
+		return StoredResult{}, false, nil
+	}
+
+	body, err := readResultBody(output.Body)
+	if err != nil {
+		return StoredResult{}, false, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return StoredResult{}, false, &skipError{key: key, err: err}
+	}
+
+	info.merge(describeResultJSON(body))
+	if info.Timestamp.IsZero() && output.LastModified != nil {
+		info.Timestamp = output.LastModified.UTC()
+	}
+	if !query.matches(info, output.Metadata) {
+		return StoredResult{}, false, nil
+	}
+
+	return StoredResult{
+		Key:            key,
+		InstanceType:   info.InstanceType,
+		Region:         info.Region,
+		BenchmarkSuite: info.BenchmarkSuite,
+		Architecture:   info.Architecture,
+		Timestamp:      info.Timestamp,
+		Metadata:       output.Metadata,
+		Data:           data,
+	}, true, nil
+}
+
+// getResults collects the results of a query, sorts them by SortBy and
+// SortOrder and applies MaxResults. It implements GetResults for every
+// ResultStore.
+func getResults(ctx context.Context, store ResultStore, query ResultQuery) ([]interface{}, error) {
+	// Limit after sorting rather than while streaming
+	maxResults := query.MaxResults
+	if query.SortBy != "" {
+		query.MaxResults = 0
+	}
+
+	iterator := store.QueryResults(ctx, query)
+	defer iterator.Close()
+
+	var stored []StoredResult
+	for iterator.Next() {
+		stored = append(stored, iterator.Result())
+	}
+	if err := iterator.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query results: %w", err)
+	}
+
+	sortResults(stored, query.SortBy, query.SortOrder)
+	if maxResults > 0 && len(stored) > maxResults {
+		stored = stored[:maxResults]
+	}
+
+	results := make([]interface{}, len(stored))
+	for i, result := range stored {
+		if query.IncludeMetadata {
+			results[i] = result
+		} else {
+			results[i] = result.Data
+		}
+	}
+	return results, nil
+}
+
+// readResultBody reads a result, decompressing it if it was stored gzipped.
+func readResultBody(body io.Reader) ([]byte, error) {
+	reader := bufio.NewReader(body)
+	if magic, err := reader.Peek(2); err == nil && bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+		return io.ReadAll(gzipReader)
+	}
+	return io.ReadAll(reader)
+}
+
+// sortResults orders results by a SortBy field: "timestamp",
+// "instance_type", "region" or "benchmark_suite". SortOrder "desc"
+// reverses the order; other fields keep the key order.
+func sortResults(results []StoredResult, sortBy, sortOrder string) {
+	var less func(a, b StoredResult) bool
+	switch sortBy {
+	case "timestamp":
+		less = func(a, b StoredResult) bool { return a.Timestamp.Before(b.Timestamp) }
+	case "instance_type":
+		less = func(a, b StoredResult) bool { return a.InstanceType < b.InstanceType }
+	case "region":
+		less = func(a, b StoredResult) bool { return a.Region < b.Region }
+	case "benchmark_suite":
+		less = func(a, b StoredResult) bool { return a.BenchmarkSuite < b.BenchmarkSuite }
+	default:
+		less = func(a, b StoredResult) bool { return a.Key < b.Key }
+	}
+
+	descending := strings.EqualFold(sortOrder, "desc")
+	sort.SliceStable(results, func(i, j int) bool {
+		if descending {
+			return less(results[j], results[i])
+		}
+		return less(results[i], results[j])
+	})
+}
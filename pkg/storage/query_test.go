@@ -0,0 +1,398 @@
+package storage
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// s3StandIn is a minimal S3-compatible server for a single bucket,
+// supporting PutObject with If-None-Match, GetObject and paged
+// ListObjectsV2.
+type s3StandIn struct {
+	mu       sync.Mutex
+	objects  map[string]standInObject
+	prefixes []string
+	pageSize int
+	
+	// listCode, if set, is the status every listing fails with.
+	listCode int
+}
+
+type standInObject struct {
+	body     []byte
+	metadata http.Header
+	modified time.Time
+}
+
+type listBucketResult struct {
+	XMLName               xml.Name       `xml:"ListBucketResult"`
+	Prefix                string         `xml:"Prefix"`
+	KeyCount              int            `xml:"KeyCount"`
+	IsTruncated           bool           `xml:"IsTruncated"`
+	NextContinuationToken string         `xml:"NextContinuationToken,omitempty"`
+	Contents              []listedObject `xml:"Contents"`
+}
+
+type listedObject struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	Size         int    `xml:"Size"`
+}
+
+// newTestStorage starts an S3 stand-in and returns storage backed by it.
+func newTestStorage(t *testing.T) (*S3Storage, *s3StandIn) {
+	standIn := &s3StandIn{objects: make(map[string]standInObject), pageSize: 2}
+	server := httptest.NewServer(standIn)
+	t.Cleanup(server.Close)
+	
+	client := s3.New(s3.Options{
+		Region:                     "us-east-1",
+		BaseEndpoint:               aws.String(server.URL),
+		UsePathStyle:               true,
+		Credentials:                aws.AnonymousCredentials{},
+		RequestChecksumCalculation: aws.RequestChecksumCalculationWhenRequired,
+		ResponseChecksumValidation: aws.ResponseChecksumValidationWhenRequired,
+	})
+	storage := NewS3StorageWithClient(client, Config{BucketName: "test-bucket", KeyPrefix: "test/"})
+	return storage, standIn
+}
+
+func (s *s3StandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/test-bucket"), "/")
+	switch {
+	case r.Method == http.MethodPut:
+		if _, exists := s.objects[key]; exists && r.Header.Get("If-None-Match") == "*" {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			fmt.Fprint(w, `<Error><Code>PreconditionFailed</Code></Error>`)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		metadata := http.Header{}
+		for name, values := range r.Header {
+			if strings.HasPrefix(strings.ToLower(name), "x-amz-meta-") {
+				metadata[name] = values
+			}
+		}
+		s.objects[key] = standInObject{body: body, metadata: metadata, modified: time.Now().UTC()}
+	case r.Method == http.MethodGet && key == "":
+		s.list(w, r)
+	case r.Method == http.MethodGet:
+		object, ok := s.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<Error><Code>NoSuchKey</Code></Error>`)
+			return
+		}
+		for name, values := range object.metadata {
+			w.Header()[name] = values
+		}
+		w.Header().Set("Last-Modified", object.modified.Format(http.TimeFormat))
+		w.Write(object.body)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *s3StandIn) list(w http.ResponseWriter, r *http.Request) {
+	if s.listCode != 0 {
+		w.WriteHeader(s.listCode)
+		fmt.Fprint(w, `<Error><Code>AccessDenied</Code></Error>`)
+		return
+	}
+	prefix := r.URL.Query().Get("prefix")
+	s.prefixes = append(s.prefixes, prefix)
+	
+	var keys []string
+	for key := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	
+	start, _ := strconv.Atoi(r.URL.Query().Get("continuation-token"))
+	end := start + s.pageSize
+	result := listBucketResult{Prefix: prefix}
+	if end < len(keys) {
+		result.IsTruncated = true
+		result.NextContinuationToken = strconv.Itoa(end)
+	} else {
+		end = len(keys)
+	}
+	for _, key := range keys[start:end] {
+		result.Contents = append(result.Contents, listedObject{
+			Key:          key,
+			LastModified: s.objects[key].modified.Format(time.RFC3339),
+			Size:         len(s.objects[key].body),
+		})
+	}
+	result.KeyCount = len(result.Contents)
+	
+	w.Header().Set("Content-Type", "application/xml")
+	xml.NewEncoder(w).Encode(result)
+}
+
+// storedResult builds a result in the format stored by the run command.
+func storedResult(instanceType, region, suite string, timestamp time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"schema_version": "1.0.0",
+		"metadata": map[string]interface{}{
+			"instanceType":          instanceType,
+			"region":                region,
+			"benchmark_suite":       suite,
+			"processorArchitecture": "graviton3",
+			"timestamp":             timestamp.Format(time.RFC3339),
+		},
+	}
+}
+
+func TestStoreResultKeyAndMetadata(t *testing.T) {
+	storage := &S3Storage{config: Config{KeyPrefix: "test/"}}
+	result := storedResult("m7i.large", "us-east-1", "stream", time.Date(2024, 6, 26, 14, 30, 22, 0, time.UTC))
+	
+	hash, err := ContentHash(result)
+	if err != nil {
+		t.Fatalf("ContentHash failed: %v", err)
+	}
+	key := storage.generateResultKey(result)
+	if key != "test/raw/2024/06/26/us-east-1/m7i.large/20240626-143022-stream-"+hash[:16]+".json" {
+		t.Errorf("Unexpected key: %s", key)
+	}
+	if info, ok := storage.keyInfo(key); !ok || info.BenchmarkSuite != "stream" || info.InstanceType != "m7i.large" {
+		t.Errorf("Expected the key to parse back, got %+v", info)
+	}
+	
+	metadata := storage.createObjectMetadata(result)
+	if metadata["instance-type"] != "m7i.large" || metadata["benchmark-suite"] != "stream" ||
+		metadata["region"] != "us-east-1" || metadata["architecture"] != "graviton3" {
+		t.Errorf("Expected result attributes in metadata, got %v", metadata)
+	}
+}
+
+func TestGetResultsQuery(t *testing.T) {
+	storage, standIn := newTestStorage(t)
+	ctx := context.Background()
+	day := time.Date(2024, 6, 26, 10, 0, 0, 0, time.UTC)
+	
+	for _, result := range []map[string]interface{}{
+		storedResult("m7i.large", "us-east-1", "stream", day),
+		storedResult("m7i.large", "us-east-1", "hpl", day.Add(time.Hour)),
+		storedResult("c7g.large", "us-east-1", "stream", day.Add(2*time.Hour)),
+		storedResult("m7i.large", "us-west-2", "stream", day.Add(3*time.Hour)),
+		storedResult("m7i.large", "us-east-1", "stream", day.AddDate(0, 0, 1)),
+		storedResult("m7i.large", "us-east-1", "stream", day.AddDate(0, 0, -1)),
+	} {
+		if err := storage.StoreResult(ctx, result); err != nil {
+			t.Fatalf("StoreResult failed: %v", err)
+		}
+	}
+	
+	testCases := []struct {
+		name           string
+		query          ResultQuery
+		expectCount    int
+		expectPrefixes []string
+	}{
+		{
+			name:           "everything",
+			query:          ResultQuery{},
+			expectCount:    6,
+			expectPrefixes: []string{"test/raw/"},
+		},
+		{
+			name: "one day",
+			query: ResultQuery{
+				DateRange: DateRange{Start: day.Truncate(24 * time.Hour), End: day.Truncate(24*time.Hour).AddDate(0, 0, 1)},
+			},
+			expectCount:    4,
+			expectPrefixes: []string{"test/raw/2024/06/26/"},
+		},
+		{
+			name: "region and instance type prefixes",
+			query: ResultQuery{
+				DateRange:     DateRange{Start: day.AddDate(0, 0, -1), End: day.AddDate(0, 0, 1)},
+				Regions:       []string{"us-east-1"},
+				InstanceTypes: []string{"m7i.large"},
+			},
+			expectCount: 3,
+			expectPrefixes: []string{
+				"test/raw/2024/06/25/us-east-1/m7i.large/",
+				"test/raw/2024/06/26/us-east-1/m7i.large/",
+				"test/raw/2024/06/27/us-east-1/m7i.large/",
+			},
+		},
+		{
+			name:           "benchmark suite from metadata",
+			query:          ResultQuery{BenchmarkSuites: []string{"hpl"}},
+			expectCount:    1,
+			expectPrefixes: []string{"test/raw/"},
+		},
+		{
+			name:           "architecture and tags",
+			query:          ResultQuery{Architectures: []string{"graviton3"}, Tags: map[string]string{"data-version": "1.0"}},
+			expectCount:    6,
+			expectPrefixes: []string{"test/raw/"},
+		},
+		{
+			name:           "max results",
+			query:          ResultQuery{MaxResults: 2},
+			expectCount:    2,
+			expectPrefixes: []string{"test/raw/"},
+		},
+	}
+	
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			standIn.mu.Lock()
+			standIn.prefixes = nil
+			standIn.mu.Unlock()
+			
+			results, err := storage.GetResults(ctx, tc.query)
+			if err != nil {
+				t.Fatalf("GetResults failed: %v", err)
+			}
+			if len(results) != tc.expectCount {
+				t.Errorf("Expected %d results, got %d", tc.expectCount, len(results))
+			}
+			
+			standIn.mu.Lock()
+			defer standIn.mu.Unlock()
+			prefixes := uniqueStrings(standIn.prefixes)
+			if tc.query.MaxResults == 0 && strings.Join(prefixes, ",") != strings.Join(tc.expectPrefixes, ",") {
+				t.Errorf("Listed prefixes %v, want %v", prefixes, tc.expectPrefixes)
+			}
+		})
+	}
+}
+
+func TestGetResultsSorted(t *testing.T) {
+	storage, _ := newTestStorage(t)
+	ctx := context.Background()
+	day := time.Date(2024, 6, 26, 10, 0, 0, 0, time.UTC)
+	
+	for i := 0; i < 5; i++ {
+		result := storedResult("m7i.large", "us-east-1", "stream", day.Add(time.Duration(i)*time.Hour))
+		if err := storage.StoreResult(ctx, result); err != nil {
+			t.Fatalf("StoreResult failed: %v", err)
+		}
+	}
+	
+	results, err := storage.GetResults(ctx, ResultQuery{
+		SortBy:          "timestamp",
+		SortOrder:       "desc",
+		MaxResults:      3,
+		IncludeMetadata: true,
+	})
+	if err != nil {
+		t.Fatalf("GetResults failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	for i, result := range results {
+		stored, ok := result.(StoredResult)
+		if !ok {
+			t.Fatalf("Expected StoredResult with IncludeMetadata, got %T", result)
+		}
+		if want := day.Add(time.Duration(4-i) * time.Hour); !stored.Timestamp.Equal(want) {
+			t.Errorf("Result %d timestamp = %v, want %v", i, stored.Timestamp, want)
+		}
+		if stored.Metadata["benchmark-suite"] != "stream" {
+			t.Errorf("Expected object metadata, got %v", stored.Metadata)
+		}
+	}
+}
+
+func TestQueryResultsErrors(t *testing.T) {
+	storage, standIn := newTestStorage(t)
+	ctx := context.Background()
+	
+	standIn.objects["test/raw/2024/06/26/us-east-1/m7i.large/20240626-100000-stream.json"] = standInObject{
+		body:     []byte("not json"),
+		metadata: http.Header{},
+		modified: time.Now(),
+	}
+	
+	if err := storage.StoreResult(ctx, storedResult("m7i.large", "us-east-1", "stream", time.Date(2024, 6, 26, 11, 0, 0, 0, time.UTC))); err != nil {
+		t.Fatalf("StoreResult failed: %v", err)
+	}
+	
+	var reported []SkippedResult
+	results := storage.QueryResults(ctx, ResultQuery{OnSkip: func(skipped SkippedResult) {
+		reported = append(reported, skipped)
+	}})
+	count := 0
+	for results.Next() {
+		count++
+	}
+	results.Close()
+	if err := results.Err(); err != nil {
+		t.Errorf("Expected the invalid result to be skipped, got error %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 valid result, got %d", count)
+	}
+	skipped := results.Skipped()
+	if len(skipped) != 1 || skipped[0].Key != "test/raw/2024/06/26/us-east-1/m7i.large/20240626-100000-stream.json" || skipped[0].Err == nil {
+		t.Errorf("Expected the invalid result to be reported as skipped, got %v", skipped)
+	}
+	if len(reported) != 1 {
+		t.Errorf("Expected OnSkip to be called once, got %v", reported)
+	}
+	
+	standIn.listCode = http.StatusForbidden
+	if _, err := storage.GetResults(ctx, ResultQuery{}); err == nil {
+		t.Error("Expected a listing error to fail the query")
+	}
+	
+	uninitialized := &S3Storage{}
+	if _, err := uninitialized.GetResults(ctx, ResultQuery{}); !errors.Is(err, ErrClientNotInitialized) {
+		t.Errorf("Expected ErrClientNotInitialized, got %v", err)
+	}
+}
+
+func TestQueryPrefixesLongRange(t *testing.T) {
+	storage := &S3Storage{config: Config{KeyPrefix: "test/"}}
+	prefixes := storage.queryPrefixes(ResultQuery{
+		DateRange: DateRange{
+			Start: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Regions: []string{"us-east-1"},
+	})
+	
+	expected := []string{"test/raw/2024/01/", "test/raw/2024/02/", "test/raw/2024/03/", "test/raw/2024/04/", "test/raw/2024/05/"}
+	if strings.Join(prefixes, ",") != strings.Join(expected, ",") {
+		t.Errorf("queryPrefixes = %v, want %v", prefixes, expected)
+	}
+}
+
+// uniqueStrings returns the distinct values in order of first appearance.
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -52,8 +52,10 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -62,6 +64,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 )
 
 // S3Storage provides comprehensive S3-based storage for benchmark results
@@ -93,7 +96,7 @@ import (
 //   }
 type S3Storage struct {
 	// client is the AWS S3 client for API operations.
-	client *s3.Client
+	client S3API
 	
 	// config contains storage configuration including bucket names and organization.
 	config Config
@@ -102,6 +105,18 @@ type S3Storage struct {
 	region string
 }
 
+// S3API is the subset of the S3 client used by S3Storage, satisfied by
+// *s3.Client and by fakes in tests.
+type S3API interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+}
+
+// ErrClientNotInitialized is returned by operations on an S3Storage that
+// was not created with NewS3Storage or NewS3StorageWithClient.
+var ErrClientNotInitialized = errors.New("S3 client not initialized")
+
 // Config defines comprehensive configuration for S3 storage behavior
 // including organization, performance, and lifecycle management settings.
 type Config struct {
@@ -138,6 +153,10 @@ type Config struct {
 	
 	// DataVersion specifies the schema version for data compatibility.
 	DataVersion string
+	
+	// Profile is the shared AWS config profile NewS3Storage loads
+	// credentials from. Default: "aws"
+	Profile string
 }
 
 // Metadata contains comprehensive metadata for benchmark result context
@@ -206,6 +225,11 @@ type ResultQuery struct {
 	
 	// Tags provides tag-based filtering for advanced queries.
 	Tags map[string]string
+	
+	// OnSkip, if set, is called for each stored object the query skips
+	// because it is not a valid result, e.g. to print a warning. Calls
+	// are not concurrent.
+	OnSkip func(SkippedResult)
 }
 
 // DateRange specifies a time range for result filtering and analysis.
@@ -275,9 +299,13 @@ type DateRange struct {
 //   - Optimal part size for multipart uploads
 //   - Regional endpoint selection for latency optimization
 func NewS3Storage(ctx context.Context, storageConfig Config, region string) (*S3Storage, error) {
-	// Load AWS configuration with default settings and aws profile
+	// Load AWS configuration with default settings and the configured profile
+	profile := storageConfig.Profile
+	if profile == "" {
+		profile = "aws"
+	}
 	cfg, err := config.LoadDefaultConfig(ctx,
-		config.WithSharedConfigProfile("aws"), // Use 'aws' profile as specified
+		config.WithSharedConfigProfile(profile),
 		config.WithRegion(region), // Use the specified region
 		config.WithRetryMaxAttempts(storageConfig.RetryAttempts),
 	)
@@ -288,6 +316,27 @@ func NewS3Storage(ctx context.Context, storageConfig Config, region string) (*S3
 	// Create S3 client with optimized settings
 	client := s3.NewFromConfig(cfg)
 	
+	// Validate bucket access
+	if err := validateBucketAccess(ctx, client, storageConfig.BucketName); err != nil {
+		return nil, fmt.Errorf("bucket access validation failed: %w", err)
+	}
+	
+	storage := NewS3StorageWithClient(client, storageConfig)
+	storage.region = cfg.Region
+	return storage, nil
+}
+
+// NewS3StorageWithClient creates an S3Storage that uses the given client,
+// e.g. one pointed at an S3-compatible endpoint or a fake in tests. The
+// bucket is not validated.
+//
+// Parameters:
+//   - client: S3 client for API operations
+//   - storageConfig: Storage configuration; defaults are applied as in NewS3Storage
+//
+// Returns:
+//   - *S3Storage: Storage instance ready for operations
+func NewS3StorageWithClient(client S3API, storageConfig Config) *S3Storage {
 	// Apply configuration defaults
 	if storageConfig.RetryAttempts == 0 {
 		storageConfig.RetryAttempts = 3
@@ -305,16 +354,10 @@ func NewS3Storage(ctx context.Context, storageConfig Config, region string) (*S3
 		storageConfig.DataVersion = "1.0"
 	}
 	
-	// Validate bucket access
-	if err := validateBucketAccess(ctx, client, storageConfig.BucketName); err != nil {
-		return nil, fmt.Errorf("bucket access validation failed: %w", err)
-	}
-	
 	return &S3Storage{
 		client: client,
 		config: storageConfig,
-		region: cfg.Region,
-	}, nil
+	}
 }
 
 // validateBucketAccess performs a lightweight validation of S3 bucket accessibility.
@@ -367,14 +410,23 @@ func validateBucketAccess(ctx context.Context, client *s3.Client, bucketName str
 //   }
 //
 // S3 Key Organization:
-//   Format: {prefix}/raw/{YYYY}/{MM}/{DD}/{region}/{instance-type}/{timestamp}-{uuid}.json
-//   Example: instance-benchmarks/raw/2024/06/26/us-east-1/m7i.large/20240626-143022-abc123.json
+//   Format: {prefix}raw/{YYYY}/{MM}/{DD}/{region}/{instance-type}/{timestamp}-{suite}-{hash}.json
+//   Example: instance-benchmarks/raw/2024/06/26/us-east-1/m7i.large/20240626-143022-stream-9f86d081884c7d65.json
+//
+//   The date and timestamp are when the benchmark ran, if the result
+//   records it, so GetResults can find results by date range. The hash
+//   is the start of the result's ContentHash; the full hash is recorded
+//   in the content-sha256 object metadata and under {prefix}sha256/{hash}.
+//
+// Duplicate Detection:
+//   Storing a result whose content is already stored returns a
+//   *DuplicateResultError (errors.Is ErrDuplicateResult) without uploading.
 //
 // Storage Features:
 //   - Automatic compression for large results (configurable)
 //   - Rich metadata for enhanced discovery and filtering
 //   - Structured organization for optimal query performance
-//   - Integrity validation with checksums
+//   - Content-hash keys with duplicate upload detection
 //   - Proper content types and encoding settings
 //
 // Performance Characteristics:
@@ -389,6 +441,10 @@ func validateBucketAccess(ctx context.Context, client *s3.Client, bucketName str
 //   - Serialization failures for invalid result structures
 //   - Storage quota exceeded for large-scale benchmark runs
 func (s *S3Storage) StoreResult(ctx context.Context, result interface{}) error {
+	if s.client == nil {
+		return ErrClientNotInitialized
+	}
+	
 	// Create upload context with timeout
 	uploadCtx, cancel := context.WithTimeout(ctx, s.config.UploadTimeout)
 	defer cancel()
@@ -398,12 +454,22 @@ func (s *S3Storage) StoreResult(ctx context.Context, result interface{}) error {
 	if err != nil {
 		return fmt.Errorf("failed to serialize result: %w", err)
 	}
+	hash, err := ContentHash(result)
+	if err != nil {
+		return err
+	}
 	
-	// Generate structured S3 key
-	key := s.generateResultKey(result)
+	// Generate structured S3 key ending with the content hash
+	key := resultKey(s.config.KeyPrefix, result, hash)
+	
+	// Detect duplicate uploads before storing anything
+	if err := s.claimHash(uploadCtx, hash, key); err != nil {
+		return err
+	}
 	
 	// Create metadata for the object
 	metadata := s.createObjectMetadata(result)
+	metadata[metadataContentHash] = hash
 	
 	// Prepare upload input
 	putInput := &s3.PutObjectInput{
@@ -431,34 +497,105 @@ func (s *S3Storage) StoreResult(ctx context.Context, result interface{}) error {
 }
 
 // generateResultKey creates a structured S3 key for optimal organization and retrieval.
-func (s *S3Storage) generateResultKey(_ interface{}) string {
-	now := time.Now().UTC()
-	
-	// Extract metadata from result (simplified for now)
-	// In a real implementation, this would use type assertion or reflection
-	// to extract the actual metadata from the result
-	
-	// Generate structured key: prefix/raw/YYYY/MM/DD/region/instance-type/timestamp-uuid.json
-	key := fmt.Sprintf("%sraw/%04d/%02d/%02d/unknown-region/unknown-instance/%s.json",
-		s.config.KeyPrefix,
-		now.Year(),
-		now.Month(),
-		now.Day(),
-		now.Format("20060102-150405"))
-	
-	return key
+//
+// See resultKey for the layout; keys start with the configured KeyPrefix.
+func (s *S3Storage) generateResultKey(result interface{}) string {
+	hash, _ := ContentHash(result)
+	return resultKey(s.config.KeyPrefix, result, hash)
+}
+
+// claimHash records key as the result with a content hash, using a
+// conditional write so concurrent uploads of the same content cannot both
+// succeed. It fails with a *DuplicateResultError if another stored result
+// has the hash; a record left behind by a failed upload, whose result is
+// missing, is taken over.
+func (s *S3Storage) claimHash(ctx context.Context, hash, key string) error {
+	index := hashIndexKey(s.config.KeyPrefix, hash)
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.config.BucketName),
+		Key:         aws.String(index),
+		Body:        strings.NewReader(key),
+		ContentType: aws.String("text/plain"),
+		IfNoneMatch: aws.String("*"),
+	})
+	if err == nil {
+		return nil
+	}
+	if !isPreconditionFailed(err) {
+		return fmt.Errorf("failed to record content hash: %w", err)
+	}
+
+	existing, err := s.getObject(ctx, index)
+	if err != nil {
+		return fmt.Errorf("failed to read hash index: %w", err)
+	}
+	if _, err := s.getObject(ctx, string(existing)); err == nil {
+		return &DuplicateResultError{Hash: hash, Key: string(existing)}
+	} else if !isNoSuchKey(err) {
+		return fmt.Errorf("failed to check stored result %s: %w", existing, err)
+	}
+
+	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.config.BucketName),
+		Key:         aws.String(index),
+		Body:        strings.NewReader(key),
+		ContentType: aws.String("text/plain"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record content hash: %w", err)
+	}
+	return nil
+}
+
+// getObject reads an object, decompressing it if it was stored gzipped.
+func (s *S3Storage) getObject(ctx context.Context, key string) ([]byte, error) {
+	output, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.config.BucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer output.Body.Close()
+	return readResultBody(output.Body)
+}
+
+// isPreconditionFailed reports whether a conditional write failed because
+// the object already exists.
+func isPreconditionFailed(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) &&
+		(apiErr.ErrorCode() == "PreconditionFailed" || apiErr.ErrorCode() == "ConditionalRequestConflict")
+}
+
+// isNoSuchKey reports whether a GetObject error means the object does not exist.
+func isNoSuchKey(err error) bool {
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return true
+	}
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && (apiErr.ErrorCode() == "NoSuchKey" || apiErr.ErrorCode() == "NotFound")
 }
 
 // createObjectMetadata generates comprehensive metadata for S3 object storage.
-func (s *S3Storage) createObjectMetadata(_ interface{}) map[string]string {
+//
+// Besides the upload details, the metadata records the attributes of the
+// result that GetResults filters on without downloading the object.
+func (s *S3Storage) createObjectMetadata(result interface{}) map[string]string {
+	dataVersion := s.config.DataVersion
+	if dataVersion == "" {
+		dataVersion = "1.0"
+	}
 	metadata := map[string]string{
 		"upload-timestamp": time.Now().UTC().Format(time.RFC3339),
-		"data-version":     "1.0",
+		"data-version":     dataVersion,
 		"content-type":     "benchmark-result",
 	}
 	
-	// In a real implementation, we would extract actual metadata from the result
-	// and add it to the metadata map for enhanced searchability
+	for key, value := range attributeMetadata(describeResult(result)) {
+		metadata[key] = value
+	}
 	
 	return metadata
 }
@@ -466,23 +603,24 @@ func (s *S3Storage) createObjectMetadata(_ interface{}) map[string]string {
 // GetResults retrieves benchmark results from S3 based on specified query parameters
 // with efficient filtering and pagination support.
 //
-// This method provides comprehensive result retrieval with intelligent query optimization,
-// metadata filtering, and structured result organization. It handles large datasets
-// efficiently with streaming and pagination capabilities.
+// This method collects the results of QueryResults, then sorts them by
+// SortBy and SortOrder and applies MaxResults. Use QueryResults directly
+// to stream large result sets without holding them in memory.
 //
 // The retrieval process:
-//   1. Translates query parameters to S3 list operations
-//   2. Applies intelligent prefix filtering for performance
-//   3. Retrieves matching objects with metadata
-//   4. Applies post-processing filters as needed
-//   5. Returns structured results with comprehensive metadata
+//   1. Translates the date range, regions and instance types to key prefixes
+//   2. Lists each prefix page by page, skipping keys outside the query
+//   3. Fetches matching objects concurrently, filtering on object metadata
+//   4. Decodes the JSON results and applies the remaining filters
+//   5. Sorts the results and limits them to MaxResults
 //
 // Parameters:
 //   - ctx: Context for timeout control and cancellation
 //   - query: Comprehensive query parameters for result filtering
 //
 // Returns:
-//   - []interface{}: Retrieved benchmark results matching query criteria
+//   - []interface{}: Decoded results as map[string]interface{}, or as
+//     StoredResult with key and metadata if IncludeMetadata is set
 //   - error: Retrieval failures, query errors, or S3 access issues
 //
 // Example:
@@ -505,38 +643,97 @@ func (s *S3Storage) createObjectMetadata(_ interface{}) map[string]string {
 //   }
 //
 // Query Optimization:
-//   - Intelligent prefix matching for performance
-//   - Parallel retrieval for multiple criteria
-//   - Streaming for large result sets
+//   - Day prefixes for date ranges up to 92 days, month prefixes beyond
+//   - Region and instance type prefixes within each day
+//   - Parallel retrieval and decoding of matching objects
 //   - Metadata-based filtering to reduce data transfer
 //
-// Performance Characteristics:
-//   - Query time: 100ms-10s depending on dataset size and filters
-//   - Memory usage: Optimized streaming for large datasets
-//   - Network efficiency: Metadata filtering reduces unnecessary downloads
-//   - Scalability: Handles datasets with millions of results
-//
 // Common Use Cases:
 //   - Performance analysis across instance families
 //   - Regional performance comparison
 //   - Time-series analysis for trend identification
 //   - Cost-performance optimization studies
-func (s *S3Storage) GetResults(_ context.Context, _ ResultQuery) ([]interface{}, error) {
-	// This is a simplified implementation
-	// A real implementation would:
-	// 1. Build S3 list operations based on query parameters
-	// 2. Use intelligent prefix matching for performance
-	// 3. Apply metadata filtering
-	// 4. Handle pagination and large result sets
-	// 5. Deserialize JSON results
-	// 6. Apply post-processing filters
-	
-	var results []interface{}
-	
-	// For now, return empty results
-	// Real implementation would populate this with actual S3 data
-	
-	return results, nil
+func (s *S3Storage) GetResults(ctx context.Context, query ResultQuery) ([]interface{}, error) {
+	return getResults(ctx, s, query)
+}
+
+// ArchiveRawOutput uploads the gzipped JSON of the raw output a result was
+// parsed from next to the result's key, replacing any earlier archive, and
+// returns the key of the archive.
+//
+// Archive Organization:
+//   Format: {result key without .json}.output.json.gz
+//   Example: instance-benchmarks/raw/2024/06/26/us-east-1/m7i.large/20240626-143022-stream-9f86d081884c7d65.output.json.gz
+//
+//   Archives carry the result attributes in their object metadata like
+//   results do, with content-type "raw-output".
+func (s *S3Storage) ArchiveRawOutput(ctx context.Context, result interface{}, output interface{}) (string, error) {
+	if s.client == nil {
+		return "", ErrClientNotInitialized
+	}
+	
+	uploadCtx, cancel := context.WithTimeout(ctx, s.config.UploadTimeout)
+	defer cancel()
+	
+	hash, err := ContentHash(result)
+	if err != nil {
+		return "", err
+	}
+	data, err := compressJSON(output)
+	if err != nil {
+		return "", err
+	}
+	
+	metadata := s.createObjectMetadata(result)
+	metadata["content-type"] = "raw-output"
+	metadata[metadataContentHash] = hash
+	
+	key := rawOutputKey(resultKey(s.config.KeyPrefix, result, hash))
+	_, err = s.client.PutObject(uploadCtx, &s3.PutObjectInput{
+		Bucket:          aws.String(s.config.BucketName),
+		Key:             aws.String(key),
+		Body:            bytes.NewReader(data),
+		ContentType:     aws.String("application/json"),
+		ContentEncoding: aws.String("gzip"),
+		Metadata:        metadata,
+		StorageClass:    types.StorageClass(s.config.StorageClass),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload raw output to S3: %w", err)
+	}
+	return key, nil
+}
+
+// LoadRawOutput downloads and decodes the raw output archived for the
+// result stored under resultKey into output.
+func (s *S3Storage) LoadRawOutput(ctx context.Context, resultKey string, output interface{}) error {
+	if s.client == nil {
+		return ErrClientNotInitialized
+	}
+	body, err := s.getObject(ctx, rawOutputKey(resultKey))
+	if isNoSuchKey(err) {
+		return fmt.Errorf("%w: %s", ErrRawOutputNotFound, resultKey)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get raw output of %s: %w", resultKey, err)
+	}
+	return decodeRawOutput(resultKey, body, output)
+}
+
+// VerifyResults re-hashes the results matching a query and reports, in
+// key order, whether each still matches the content hash in its key and
+// the checksum of its benchmark data. Unreadable results are reported as
+// corrupt rather than failing the verification.
+func (s *S3Storage) VerifyResults(ctx context.Context, query ResultQuery) ([]VerifiedResult, error) {
+	if s.client == nil {
+		return nil, ErrClientNotInitialized
+	}
+	return verifyResults(ctx, s, query)
+}
+
+// readResult downloads the stored bytes of one result.
+func (s *S3Storage) readResult(ctx context.Context, key string) ([]byte, error) {
+	return s.getObject(ctx, key)
 }
 
 // Close gracefully shuts down the S3Storage instance and releases resources.
@@ -29,7 +29,7 @@ func TestNewS3Storage(t *testing.T) {
 	ctx := context.Background()
 	
 	// This will likely fail without proper AWS setup, but tests the interface
-	_, err := NewS3Storage(ctx, config)
+	_, err := NewS3Storage(ctx, config, "us-east-1")
 	
 	// In CI/CD without AWS credentials, we expect an error
 	if err == nil {
@@ -48,7 +48,7 @@ func TestStorageConfigDefaults(t *testing.T) {
 	ctx := context.Background()
 	
 	// Create storage to test default application (will fail on AWS call)
-	_, err := NewS3Storage(ctx, config)
+	_, err := NewS3Storage(ctx, config, "us-east-1")
 	
 	// We expect an error due to missing AWS credentials, but that's fine
 	// The important part is that defaults would be applied internally
@@ -171,31 +171,47 @@ func TestStoreResult(t *testing.T) {
 }
 
 func TestGetResults(t *testing.T) {
-	storage := &S3Storage{}
+	storage, _ := newTestStorage(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	
+	for _, result := range []map[string]interface{}{
+		storedResult("m7i.large", "us-east-1", "stream", now.Add(-48*time.Hour)),
+		storedResult("c7g.xlarge", "us-east-1", "stream", now.Add(-24*time.Hour)),
+		storedResult("c7g.xlarge", "us-west-2", "stream", now.Add(-24*time.Hour)),
+		storedResult("m7i.large", "us-east-1", "hpl", now.Add(-24*time.Hour)),
+		storedResult("m7i.large", "us-east-1", "stream", now.AddDate(0, 0, -30)),
+	} {
+		if err := storage.StoreResult(ctx, result); err != nil {
+			t.Fatalf("StoreResult failed: %v", err)
+		}
+	}
 	
 	query := ResultQuery{
 		InstanceTypes:   []string{"m7i.large", "c7g.xlarge"},
 		Regions:        []string{"us-east-1"},
 		BenchmarkSuites: []string{"stream"},
 		DateRange: DateRange{
-			Start: time.Now().AddDate(0, 0, -7),
-			End:   time.Now(),
+			Start: now.AddDate(0, 0, -7),
+			End:   now.Add(time.Hour),
 		},
 		MaxResults: 100,
 		SortBy:     "timestamp",
 		SortOrder:  "desc",
 	}
 	
-	ctx := context.Background()
-	
 	results, err := storage.GetResults(ctx, query)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 	
-	// Should return empty results in current implementation
-	if len(results) != 0 {
-		t.Errorf("Expected empty results, got %d", len(results))
+	// Only the stream results in us-east-1 from the last week match
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	newest := results[0].(map[string]interface{})["metadata"].(map[string]interface{})
+	if newest["instanceType"] != "c7g.xlarge" {
+		t.Errorf("Expected the newest result first, got %v", newest["instanceType"])
 	}
 }
 
@@ -0,0 +1,46 @@
+package storage
+
+import "context"
+
+// ResultStore persists benchmark results and queries them back.
+//
+// S3Storage stores results in a bucket and LocalStorage in a directory,
+// both under the same key layout, so benchmarks, data processing and
+// analysis work the same against either.
+type ResultStore interface {
+	// StoreResult serializes a result to JSON and stores it under a key
+	// derived from its timestamp, region, instance type, suite and
+	// content hash. It returns a *DuplicateResultError if a result with
+	// the same content is already stored.
+	StoreResult(ctx context.Context, result interface{}) error
+
+	// GetResults returns the results matching a query, sorted by SortBy.
+	GetResults(ctx context.Context, query ResultQuery) ([]interface{}, error)
+
+	// QueryResults streams the results matching a query in no
+	// particular order.
+	QueryResults(ctx context.Context, query ResultQuery) *ResultIterator
+
+	// ArchiveRawOutput stores the raw output a result was parsed from,
+	// as gzipped JSON next to the key the result is stored under, and
+	// returns the key of the archive. Archive the output before storing
+	// the result, so that no stored result is left without it.
+	ArchiveRawOutput(ctx context.Context, result interface{}, output interface{}) (string, error)
+
+	// LoadRawOutput decodes the raw output archived for the result stored
+	// under resultKey into output. It returns an error wrapping
+	// ErrRawOutputNotFound if the result has no archived output.
+	LoadRawOutput(ctx context.Context, resultKey string, output interface{}) error
+
+	// VerifyResults re-hashes the results matching a query and reports
+	// those whose content no longer matches their recorded hashes.
+	VerifyResults(ctx context.Context, query ResultQuery) ([]VerifiedResult, error)
+
+	// Close releases the resources of the store.
+	Close() error
+}
+
+var (
+	_ ResultStore = (*S3Storage)(nil)
+	_ ResultStore = (*LocalStorage)(nil)
+)
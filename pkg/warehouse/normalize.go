@@ -0,0 +1,183 @@
+package warehouse
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	awspkg "github.com/scttfrdmn/aws-instance-benchmarks/pkg/aws"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/profiling"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/storage"
+)
+
+// unitlessMetrics are counts and ratios that do not take the unit recorded
+// next to them.
+var unitlessMetrics = map[string]bool{
+	"iterations":   true,
+	"matrix_size":  true,
+	"total_events": true,
+	"efficiency":   true,
+	"residual":     true,
+	"size_kb":      true,
+}
+
+// Normalize converts a stored result of any schema version into rows, one
+// per numeric value in its benchmark results. It returns no rows for
+// documents without benchmark results.
+//
+// Identifying attributes come from the result metadata, falling back to
+// those the result store derived from the key. Performance data is decoded
+// with the same compatibility layer as the orchestrator, so flat legacy
+// layouts, "performance_data" sections, per-category "performance"
+// sections and the "benchmark_data" of async results yield the same
+// metrics.
+func Normalize(result storage.StoredResult) []Row {
+	data := result.Data
+	metadata, _ := data["metadata"].(map[string]interface{})
+	systemInfo, _ := data["system_info"].(map[string]interface{})
+
+	var decoded *awspkg.BenchmarkResults
+	if benchmarkData, ok := data["benchmark_data"].(map[string]interface{}); ok {
+		decoded, _ = awspkg.DecodeBenchmarkResults(benchmarkData)
+	} else if performanceData, ok := data["performance_data"].(map[string]interface{}); ok {
+		decoded, _ = awspkg.DecodeBenchmarkResults(performanceData)
+	} else {
+		decoded, _ = awspkg.DecodeBenchmarkResults(data)
+		if _, ok := data["performance"]; !ok && decoded != nil {
+			// The other top-level keys of a flat legacy document are
+			// sections such as "system_info", not suites
+			decoded.Extra = nil
+		}
+	}
+	if decoded.Empty() {
+		return nil
+	}
+
+	id, err := resultID(data)
+	if err != nil {
+		id = result.Key
+	}
+	base := Row{
+		ResultKey:      result.Key,
+		ResultID:       id,
+		InstanceType:   firstString(stringValue(metadata, "instanceType"), stringValue(metadata, "instance_type"), result.InstanceType),
+		InstanceFamily: firstString(stringValue(metadata, "instanceFamily"), stringValue(systemInfo, "instance_family")),
+		Architecture:   firstString(stringValue(metadata, "processorArchitecture"), stringValue(systemInfo, "architecture"), result.Architecture),
+		Region:         firstString(stringValue(metadata, "region"), result.Region),
+		Iteration:      1,
+		Timestamp:      result.Timestamp,
+		SchemaVersion:  stringValue(data, "schema_version"),
+	}
+	if base.InstanceFamily == "" {
+		base.InstanceFamily = strings.SplitN(base.InstanceType, ".", 2)[0]
+	}
+	if iteration, ok := metadata["iteration"].(float64); ok && iteration >= 1 {
+		base.Iteration = int(iteration)
+	}
+	if topology, ok := data["system_topology"]; ok {
+		applyTopology(&base, topology)
+	}
+
+	var rows []Row
+	suites := decoded.AsMap()
+	for _, suite := range sortedKeys(suites) {
+		values, ok := suites[suite].(map[string]interface{})
+		if !ok || suite == "metadata" {
+			continue
+		}
+		// Suites are keyed by their registered name
+		row := base
+		row.Suite = suite
+		rows = appendMetrics(rows, row, "", values)
+	}
+	return rows
+}
+
+// appendMetrics appends a row for every numeric value below values, named
+// by its dotted path after prefix.
+func appendMetrics(rows []Row, row Row, prefix string, values map[string]interface{}) []Row {
+	unit, _ := values["unit"].(string)
+	timeUnit, _ := values["time_unit"].(string)
+
+	for _, key := range sortedKeys(values) {
+		switch value := values[key].(type) {
+		case float64:
+			metric := row
+			metric.Metric = prefix + key
+			metric.Value = value
+			switch {
+			case unitlessMetrics[key]:
+			case strings.Contains(key, "time") && timeUnit != "":
+				metric.Unit = timeUnit
+			default:
+				metric.Unit = unit
+			}
+			rows = append(rows, metric)
+		case map[string]interface{}:
+			rows = appendMetrics(rows, row, prefix+key+".", value)
+		}
+	}
+	return rows
+}
+
+// applyTopology copies the topology columns from a recorded
+// profiling.SystemTopology. Fields that fail to decode are left empty.
+func applyTopology(row *Row, recorded interface{}) {
+	encoded, err := json.Marshal(recorded)
+	if err != nil {
+		return
+	}
+	var topology profiling.SystemTopology
+	_ = json.Unmarshal(encoded, &topology) // Partial topology is still useful
+
+	layout := topology.CPUTopology.PhysicalLayout
+	row.VCPUs = layout.TotalLogicalCPUs
+	row.PhysicalCores = layout.TotalPhysicalCores
+	row.Sockets = layout.Sockets
+	row.ThreadsPerCore = layout.ThreadsPerCore
+	row.NUMANodes = len(topology.MemoryTopology.NUMATopology.Nodes)
+	row.MemoryGB = topology.MemoryTopology.TotalMemoryGB
+	row.L3CacheKB = topology.CacheHierarchy.L3Unified.SizeKB
+	row.CPUModel = topology.CPUTopology.Identification.ModelName
+}
+
+// stringValue returns the string at key, or "" if there is none.
+func stringValue(values map[string]interface{}, key string) string {
+	value, _ := values[key].(string)
+	return value
+}
+
+// firstString returns the first non-empty value.
+func firstString(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// sortedKeys returns the keys of values in order, so that rows are
+// ingested deterministically.
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// resultID returns the hex SHA-256 of the JSON encoding of a result
+// document. Object keys are encoded in sorted order, so the ID does not
+// depend on how the file was formatted.
+func resultID(data map[string]interface{}) (string, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
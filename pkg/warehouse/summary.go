@@ -0,0 +1,154 @@
+package warehouse
+
+import (
+	"math"
+	"sort"
+)
+
+// HeadlineMetrics names the metric that ranks instances for each suite.
+// Higher values are better for all of them.
+var HeadlineMetrics = map[string]string{
+	"stream":   "triad.bandwidth",
+	"hpl":      "gflops",
+	"dgemm":    "large_matrix_gflops",
+	"coremark": "score",
+	"7zip":     "total_mips",
+	"sysbench": "events_per_second",
+}
+
+// Summary aggregates the values of one metric across a group of rows.
+type Summary struct {
+	// Group is the value rows were grouped by, e.g. an instance family.
+	Group  string `json:"group"`
+	Suite  string `json:"suite"`
+	Metric string `json:"metric"`
+	Unit   string `json:"unit,omitempty"`
+
+	Count  int     `json:"count"`
+	Mean   float64 `json:"mean"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	StdDev float64 `json:"std_dev"`
+}
+
+// Summarize groups rows by the value groupBy returns and summarizes each
+// metric within each group, ordered by group, suite and metric.
+//
+// Example:
+//   families := warehouse.Summarize(w.Query(warehouse.Filter{}), func(r warehouse.Row) string {
+//       return r.InstanceFamily
+//   })
+func Summarize(rows []Row, groupBy func(Row) string) []Summary {
+	type summaryKey struct{ group, suite, metric string }
+	values := make(map[summaryKey][]float64)
+	units := make(map[summaryKey]string)
+	for _, row := range rows {
+		key := summaryKey{groupBy(row), row.Suite, row.Metric}
+		values[key] = append(values[key], row.Value)
+		if row.Unit != "" {
+			units[key] = row.Unit
+		}
+	}
+
+	summaries := make([]Summary, 0, len(values))
+	for key, group := range values {
+		summary := Summary{
+			Group:  key.group,
+			Suite:  key.suite,
+			Metric: key.metric,
+			Unit:   units[key],
+			Count:  len(group),
+			Min:    group[0],
+			Max:    group[0],
+		}
+		var sum float64
+		for _, value := range group {
+			sum += value
+			summary.Min = math.Min(summary.Min, value)
+			summary.Max = math.Max(summary.Max, value)
+		}
+		summary.Mean = sum / float64(len(group))
+		if len(group) > 1 {
+			var squares float64
+			for _, value := range group {
+				squares += (value - summary.Mean) * (value - summary.Mean)
+			}
+			summary.StdDev = math.Sqrt(squares / float64(len(group)-1))
+		}
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Suite != b.Suite {
+			return a.Suite < b.Suite
+		}
+		return a.Metric < b.Metric
+	})
+	return summaries
+}
+
+// IndexEntry is the standing of one instance type in a suite's headline
+// metric.
+type IndexEntry struct {
+	Suite        string  `json:"suite"`
+	Metric       string  `json:"metric"`
+	Rank         int     `json:"rank"`
+	InstanceType string  `json:"instance_type"`
+	Mean         float64 `json:"mean"`
+	Unit         string  `json:"unit,omitempty"`
+
+	// Index is the mean as a percentage of the best instance type's mean.
+	Index float64 `json:"index"`
+}
+
+// PerformanceIndices ranks instance types by the mean of each suite's
+// headline metric. Suites without a headline metric are not ranked.
+func PerformanceIndices(rows []Row) []IndexEntry {
+	var headline []Row
+	for _, row := range rows {
+		if HeadlineMetrics[row.Suite] == row.Metric {
+			headline = append(headline, row)
+		}
+	}
+
+	bySuite := make(map[string][]Summary)
+	for _, summary := range Summarize(headline, func(r Row) string { return r.InstanceType }) {
+		bySuite[summary.Suite] = append(bySuite[summary.Suite], summary)
+	}
+
+	var entries []IndexEntry
+	for _, suite := range sortedSuites(bySuite) {
+		summaries := bySuite[suite]
+		sort.SliceStable(summaries, func(i, j int) bool { return summaries[i].Mean > summaries[j].Mean })
+		best := summaries[0].Mean
+		for i, summary := range summaries {
+			entry := IndexEntry{
+				Suite:        suite,
+				Metric:       summary.Metric,
+				Rank:         i + 1,
+				InstanceType: summary.Group,
+				Mean:         summary.Mean,
+				Unit:         summary.Unit,
+			}
+			if best > 0 {
+				entry.Index = summary.Mean / best * 100
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// sortedSuites returns the suites of bySuite in order.
+func sortedSuites(bySuite map[string][]Summary) []string {
+	suites := make([]string, 0, len(bySuite))
+	for suite := range bySuite {
+		suites = append(suites, suite)
+	}
+	sort.Strings(suites)
+	return suites
+}
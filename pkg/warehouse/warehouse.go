@@ -0,0 +1,437 @@
+// Package warehouse keeps benchmark results normalized into an embedded,
+// columnar results store for analysis.
+//
+// Result files have changed shape over time: early files carry flat STREAM
+// and HPL keys or a "performance_data" section, schema 1.0.0 nests results
+// under "performance" by category, and schema 2.0.0 adds the system
+// topology. Ingesting results normalizes every version into rows of one
+// metric each, so analysis queries a single table instead of re-parsing
+// files:
+//
+//   w, err := warehouse.Open(warehouse.DefaultPath)
+//   report, err := w.Ingest(ctx, storage.NewLocalStorage("results", storage.Config{}), storage.ResultQuery{})
+//   err = w.Save()
+//
+//   rows := w.Query(warehouse.Filter{Suites: []string{"stream"}, Metrics: []string{"triad.bandwidth"}})
+//
+// The warehouse is stored as one gzip-compressed JSON document holding a
+// column per attribute. It is safe for concurrent use.
+package warehouse
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/storage"
+)
+
+// DefaultPath is where the ingest command keeps the warehouse.
+var DefaultPath = filepath.Join("data", "warehouse.json.gz")
+
+// formatVersion identifies the layout of the stored warehouse document.
+const formatVersion = 1
+
+// ErrNoPath is returned by Save for a warehouse created with New.
+var ErrNoPath = errors.New("warehouse has no file to save to")
+
+// Row is one measured metric of one benchmark result.
+type Row struct {
+	// ResultKey is the key of the result the row was ingested from.
+	ResultKey string `json:"result_key"`
+
+	// ResultID identifies the result independently of where it was
+	// ingested from: the SHA-256 of the result document's JSON encoding.
+	ResultID string `json:"result_id"`
+
+	InstanceType   string `json:"instance_type"`
+	InstanceFamily string `json:"instance_family"`
+	Architecture   string `json:"architecture"`
+	Region         string `json:"region"`
+	Suite          string `json:"suite"`
+
+	// Metric is the dotted path of the value within the suite's results,
+	// e.g. "triad.bandwidth" for STREAM or "gflops" for HPL.
+	Metric string  `json:"metric"`
+	Value  float64 `json:"value"`
+	Unit   string  `json:"unit,omitempty"`
+
+	// Iteration is the 1-based iteration of the run that produced the
+	// result; results that do not record it count as iteration 1.
+	Iteration     int       `json:"iteration"`
+	Timestamp     time.Time `json:"timestamp"`
+	SchemaVersion string    `json:"schema_version,omitempty"`
+
+	// System topology, where the result recorded it (schema 2.0.0 and later).
+	VCPUs          int     `json:"vcpus,omitempty"`
+	PhysicalCores  int     `json:"physical_cores,omitempty"`
+	Sockets        int     `json:"sockets,omitempty"`
+	ThreadsPerCore int     `json:"threads_per_core,omitempty"`
+	NUMANodes      int     `json:"numa_nodes,omitempty"`
+	MemoryGB       float64 `json:"memory_gb,omitempty"`
+	L3CacheKB      int     `json:"l3_cache_kb,omitempty"`
+	CPUModel       string  `json:"cpu_model,omitempty"`
+}
+
+// columns holds the rows of the warehouse column by column.
+type columns struct {
+	ResultKey      []string    `json:"result_key"`
+	ResultID       []string    `json:"result_id"`
+	InstanceType   []string    `json:"instance_type"`
+	InstanceFamily []string    `json:"instance_family"`
+	Architecture   []string    `json:"architecture"`
+	Region         []string    `json:"region"`
+	Suite          []string    `json:"suite"`
+	Metric         []string    `json:"metric"`
+	Value          []float64   `json:"value"`
+	Unit           []string    `json:"unit"`
+	Iteration      []int       `json:"iteration"`
+	Timestamp      []time.Time `json:"timestamp"`
+	SchemaVersion  []string    `json:"schema_version"`
+	VCPUs          []int       `json:"vcpus"`
+	PhysicalCores  []int       `json:"physical_cores"`
+	Sockets        []int       `json:"sockets"`
+	ThreadsPerCore []int       `json:"threads_per_core"`
+	NUMANodes      []int       `json:"numa_nodes"`
+	MemoryGB       []float64   `json:"memory_gb"`
+	L3CacheKB      []int       `json:"l3_cache_kb"`
+	CPUModel       []string    `json:"cpu_model"`
+}
+
+// len returns the number of rows, or -1 if the columns differ in length.
+func (c *columns) len() int {
+	n := len(c.ResultKey)
+	for _, length := range []int{
+		len(c.ResultID), len(c.InstanceType), len(c.InstanceFamily), len(c.Architecture), len(c.Region),
+		len(c.Suite), len(c.Metric), len(c.Value), len(c.Unit), len(c.Iteration),
+		len(c.Timestamp), len(c.SchemaVersion), len(c.VCPUs), len(c.PhysicalCores),
+		len(c.Sockets), len(c.ThreadsPerCore), len(c.NUMANodes), len(c.MemoryGB),
+		len(c.L3CacheKB), len(c.CPUModel),
+	} {
+		if length != n {
+			return -1
+		}
+	}
+	return n
+}
+
+// append adds a row to the end of the columns.
+func (c *columns) append(r Row) {
+	c.ResultKey = append(c.ResultKey, r.ResultKey)
+	c.ResultID = append(c.ResultID, r.ResultID)
+	c.InstanceType = append(c.InstanceType, r.InstanceType)
+	c.InstanceFamily = append(c.InstanceFamily, r.InstanceFamily)
+	c.Architecture = append(c.Architecture, r.Architecture)
+	c.Region = append(c.Region, r.Region)
+	c.Suite = append(c.Suite, r.Suite)
+	c.Metric = append(c.Metric, r.Metric)
+	c.Value = append(c.Value, r.Value)
+	c.Unit = append(c.Unit, r.Unit)
+	c.Iteration = append(c.Iteration, r.Iteration)
+	c.Timestamp = append(c.Timestamp, r.Timestamp)
+	c.SchemaVersion = append(c.SchemaVersion, r.SchemaVersion)
+	c.VCPUs = append(c.VCPUs, r.VCPUs)
+	c.PhysicalCores = append(c.PhysicalCores, r.PhysicalCores)
+	c.Sockets = append(c.Sockets, r.Sockets)
+	c.ThreadsPerCore = append(c.ThreadsPerCore, r.ThreadsPerCore)
+	c.NUMANodes = append(c.NUMANodes, r.NUMANodes)
+	c.MemoryGB = append(c.MemoryGB, r.MemoryGB)
+	c.L3CacheKB = append(c.L3CacheKB, r.L3CacheKB)
+	c.CPUModel = append(c.CPUModel, r.CPUModel)
+}
+
+// row returns the i-th row.
+func (c *columns) row(i int) Row {
+	return Row{
+		ResultKey:      c.ResultKey[i],
+		ResultID:       c.ResultID[i],
+		InstanceType:   c.InstanceType[i],
+		InstanceFamily: c.InstanceFamily[i],
+		Architecture:   c.Architecture[i],
+		Region:         c.Region[i],
+		Suite:          c.Suite[i],
+		Metric:         c.Metric[i],
+		Value:          c.Value[i],
+		Unit:           c.Unit[i],
+		Iteration:      c.Iteration[i],
+		Timestamp:      c.Timestamp[i],
+		SchemaVersion:  c.SchemaVersion[i],
+		VCPUs:          c.VCPUs[i],
+		PhysicalCores:  c.PhysicalCores[i],
+		Sockets:        c.Sockets[i],
+		ThreadsPerCore: c.ThreadsPerCore[i],
+		NUMANodes:      c.NUMANodes[i],
+		MemoryGB:       c.MemoryGB[i],
+		L3CacheKB:      c.L3CacheKB[i],
+		CPUModel:       c.CPUModel[i],
+	}
+}
+
+// document is the stored form of a warehouse.
+type document struct {
+	FormatVersion int       `json:"format_version"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Columns       columns   `json:"columns"`
+}
+
+// Warehouse is an embedded columnar store of normalized benchmark results.
+type Warehouse struct {
+	// path is the file the warehouse is saved to; empty for New.
+	path string
+
+	mu   sync.RWMutex
+	cols columns
+}
+
+// New creates an empty in-memory warehouse that cannot be saved, e.g. to
+// analyze a results directory without keeping the ingested rows.
+func New() *Warehouse {
+	return &Warehouse{}
+}
+
+// Open loads the warehouse stored at path. A missing file yields an empty
+// warehouse that Save creates.
+//
+// Parameters:
+//   - path: File the warehouse is stored in, normally DefaultPath
+//
+// Returns:
+//   - *Warehouse: The loaded warehouse
+//   - error: If the file exists but cannot be read or decoded
+func Open(path string) (*Warehouse, error) {
+	w := &Warehouse{path: path}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return w, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open warehouse: %w", err)
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read warehouse %s: %w", path, err)
+	}
+	defer reader.Close()
+
+	var doc document
+	if err := json.NewDecoder(reader).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode warehouse %s: %w", path, err)
+	}
+	if doc.FormatVersion != formatVersion {
+		return nil, fmt.Errorf("warehouse %s has unsupported format version %d", path, doc.FormatVersion)
+	}
+	if doc.Columns.len() < 0 {
+		return nil, fmt.Errorf("warehouse %s is corrupt: columns differ in length", path)
+	}
+	w.cols = doc.Columns
+	return w, nil
+}
+
+// Save writes the warehouse to the file it was opened from, atomically
+// replacing the previous version.
+func (w *Warehouse) Save() error {
+	if w.path == "" {
+		return ErrNoPath
+	}
+
+	w.mu.RLock()
+	doc := document{FormatVersion: formatVersion, UpdatedAt: time.Now().UTC(), Columns: w.cols}
+	data, err := json.Marshal(doc)
+	w.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode warehouse: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(w.path), 0755); err != nil {
+		return fmt.Errorf("failed to create warehouse directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(w.path), ".tmp-"+filepath.Base(w.path)+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to write warehouse: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	writer := gzip.NewWriter(tmp)
+	if _, err := writer.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write warehouse: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write warehouse: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write warehouse: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), w.path); err != nil {
+		return fmt.Errorf("failed to write warehouse: %w", err)
+	}
+	return nil
+}
+
+// Len returns the number of rows in the warehouse.
+func (w *Warehouse) Len() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return len(w.cols.ResultKey)
+}
+
+// IngestReport summarizes an Ingest call.
+type IngestReport struct {
+	// Results is the number of results ingested, Rows the number of rows
+	// they produced.
+	Results int
+	Rows    int
+
+	// Replaced counts results that were already in the warehouse; their
+	// earlier rows were dropped.
+	Replaced int
+
+	// Duplicates lists the keys of results with the same content as a
+	// result ingested before them in the same call; they add no rows.
+	Duplicates []string
+
+	// Skipped lists the keys of results without any metrics, such as
+	// summaries or failed runs.
+	Skipped []string
+}
+
+// Ingest normalizes the results in store that match query into the
+// warehouse. Results are identified by their content, not their key, so
+// ingesting a result again replaces its rows, whether it is re-read from
+// the same directory or bucket or from a copy under another root.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - store: Store to read results from, e.g. storage.LocalStorage
+//   - query: Results to ingest; the zero query ingests everything
+//
+// Returns:
+//   - IngestReport: What was ingested
+//   - error: If the results cannot be read
+func (w *Warehouse) Ingest(ctx context.Context, store storage.ResultStore, query storage.ResultQuery) (IngestReport, error) {
+	var report IngestReport
+
+	iterator := store.QueryResults(ctx, query)
+	defer iterator.Close()
+
+	ingested := make(map[string]bool)
+	var rows []Row
+	for iterator.Next() {
+		result := iterator.Result()
+		normalized := Normalize(result)
+		if len(normalized) == 0 {
+			report.Skipped = append(report.Skipped, result.Key)
+			continue
+		}
+		id := normalized[0].ResultID
+		if ingested[id] {
+			report.Duplicates = append(report.Duplicates, result.Key)
+			continue
+		}
+		ingested[id] = true
+		rows = append(rows, normalized...)
+		report.Results++
+	}
+	if err := iterator.Err(); err != nil {
+		return report, fmt.Errorf("failed to read results: %w", err)
+	}
+
+	report.Rows = len(rows)
+	report.Replaced = w.replace(ingested, rows)
+	return report, nil
+}
+
+// replace drops the rows of the results with the given IDs and appends
+// rows, returning how many of the results had rows before.
+func (w *Warehouse) replace(ids map[string]bool, rows []Row) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	replaced := make(map[string]bool)
+	var kept columns
+	for i, id := range w.cols.ResultID {
+		if ids[id] {
+			replaced[id] = true
+			continue
+		}
+		kept.append(w.cols.row(i))
+	}
+	for _, row := range rows {
+		kept.append(row)
+	}
+	w.cols = kept
+	return len(replaced)
+}
+
+// Filter selects rows. Empty fields match everything; within a field any
+// listed value matches.
+type Filter struct {
+	InstanceTypes    []string
+	InstanceFamilies []string
+	Architectures    []string
+	Regions          []string
+	Suites           []string
+	Metrics          []string
+}
+
+// Query returns the rows matching the filter in the order they were
+// ingested.
+func (w *Warehouse) Query(filter Filter) []Row {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	// Scan one column at a time, narrowing the candidate rows
+	candidates := make([]int, len(w.cols.ResultKey))
+	for i := range candidates {
+		candidates[i] = i
+	}
+	for _, predicate := range []struct {
+		column []string
+		values []string
+	}{
+		{w.cols.Suite, filter.Suites},
+		{w.cols.Metric, filter.Metrics},
+		{w.cols.InstanceType, filter.InstanceTypes},
+		{w.cols.InstanceFamily, filter.InstanceFamilies},
+		{w.cols.Architecture, filter.Architectures},
+		{w.cols.Region, filter.Regions},
+	} {
+		if len(predicate.values) == 0 {
+			continue
+		}
+		matching := candidates[:0]
+		for _, i := range candidates {
+			if contains(predicate.values, predicate.column[i]) {
+				matching = append(matching, i)
+			}
+		}
+		candidates = matching
+	}
+
+	rows := make([]Row, len(candidates))
+	for n, i := range candidates {
+		rows[n] = w.cols.row(i)
+	}
+	return rows
+}
+
+// contains reports whether values contains value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,277 @@
+package warehouse
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	awspkg "github.com/scttfrdmn/aws-instance-benchmarks/pkg/aws"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/fakecloud"
+	"github.com/scttfrdmn/aws-instance-benchmarks/pkg/storage"
+)
+
+// resultFiles are result documents of every schema version, in the layout
+// written by the run command.
+var resultFiles = map[string]string{
+	// Schema 1.0.0: per-category performance section
+	"2025-06-29/c5.large-stream-20250629-180635.json": `{
+		"schema_version": "1.0.0",
+		"metadata": {"instanceType": "c5.large", "instanceFamily": "c5", "region": "us-east-1",
+			"processorArchitecture": "x86_64", "benchmark_suite": "stream", "timestamp": "2025-06-29T18:06:35Z"},
+		"performance": {"memory": {"stream": {
+			"copy": {"bandwidth": 45.2, "unit": "GB/s"}, "triad": {"bandwidth": 41.9, "unit": "GB/s"}}}}
+	}`,
+	// Schema 2.0.0: adds the system topology and the iteration
+	"2025-06-29/m7i.large-hpl-20250629-190000.json": `{
+		"schema_version": "2.0.0",
+		"metadata": {"instanceType": "m7i.large", "region": "us-west-2", "benchmark_suite": "hpl",
+			"timestamp": "2025-06-29T19:00:00Z", "iteration": 2},
+		"performance": {"cpu": {"hpl": {"gflops": 123.4, "efficiency": 0.82, "execution_time": 41.5,
+			"unit": "GFLOPS", "time_unit": "seconds"}}},
+		"system_topology": {
+			"cpu_topology": {"identification": {"model_name": "Intel Xeon Platinum 8488C"},
+				"physical_layout": {"sockets": 1, "threads_per_core": 2, "total_logical_cpus": 2, "total_physical_cores": 1}},
+			"cache_hierarchy": {"l3_unified": {"size_kb": 107520}},
+			"memory_topology": {"total_memory_gb": 8, "numa_topology": {"nodes": [{"node_id": 0}]}}}
+	}`,
+	// Legacy analysis file with a flat performance_data section
+	"2025-06-30/c7g.large-stream-20250630-010000.json": `{
+		"metadata": {"instance_type": "c7g.large", "region": "us-east-1"},
+		"performance_data": {"triad_bandwidth": 52.3, "copy_bandwidth": 55.0}
+	}`,
+	// Summaries have no benchmark results
+	"2025-06-30/summary.json": `{"total": 3}`,
+}
+
+func writeResultFiles(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range resultFiles {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestIngestNormalizesSchemaVersions(t *testing.T) {
+	ctx := context.Background()
+	w := New()
+	report, err := w.Ingest(ctx, storage.NewLocalStorage(writeResultFiles(t), storage.Config{}), storage.ResultQuery{})
+	if err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if report.Results != 3 || report.Rows != 7 {
+		t.Errorf("Expected 3 results with 7 rows, got %+v", report)
+	}
+
+	triads := w.Query(Filter{Suites: []string{"stream"}, Metrics: []string{"triad.bandwidth"}})
+	if len(triads) != 2 {
+		t.Fatalf("Expected 2 triad rows, got %d", len(triads))
+	}
+	for _, row := range triads {
+		switch row.InstanceType {
+		case "c5.large":
+			if row.Value != 41.9 || row.Unit != "GB/s" || row.InstanceFamily != "c5" || row.Architecture != "x86_64" || row.SchemaVersion != "1.0.0" {
+				t.Errorf("Unexpected schema 1.0.0 row %+v", row)
+			}
+		case "c7g.large":
+			if row.Value != 52.3 || row.InstanceFamily != "c7g" || row.Iteration != 1 {
+				t.Errorf("Unexpected legacy row %+v", row)
+			}
+		default:
+			t.Errorf("Unexpected instance type %q", row.InstanceType)
+		}
+	}
+
+	hpl := w.Query(Filter{InstanceTypes: []string{"m7i.large"}})
+	if len(hpl) != 3 {
+		t.Fatalf("Expected 3 HPL rows, got %d", len(hpl))
+	}
+	units := map[string]string{}
+	for _, row := range hpl {
+		units[row.Metric] = row.Unit
+		if row.Suite != "hpl" || row.Region != "us-west-2" || row.Iteration != 2 {
+			t.Errorf("Unexpected HPL row %+v", row)
+		}
+		if row.VCPUs != 2 || row.PhysicalCores != 1 || row.NUMANodes != 1 || row.L3CacheKB != 107520 || row.CPUModel != "Intel Xeon Platinum 8488C" {
+			t.Errorf("Expected topology columns, got %+v", row)
+		}
+	}
+	if units["gflops"] != "GFLOPS" || units["execution_time"] != "seconds" || units["efficiency"] != "" {
+		t.Errorf("Unexpected units %v", units)
+	}
+}
+
+func TestIngestAsyncCollectedResult(t *testing.T) {
+	ctx := context.Background()
+	cloud := fakecloud.New("us-east-1")
+	orchestrator := awspkg.NewOrchestratorWithClients(cloud.Region(), cloud.EC2(), cloud.SSM())
+	objects := awspkg.NewMemoryObjectStore()
+	results := storage.NewLocalStorage(t.TempDir(), storage.Config{})
+	launcher := awspkg.NewAsyncLauncherWithStore(orchestrator, objects)
+	collector := awspkg.NewAsyncCollectorWithStore(orchestrator, objects).WithResultStore(results)
+
+	job, err := launcher.LaunchSingleBenchmark(ctx, awspkg.BenchmarkConfig{
+		InstanceType:    "m7i.large",
+		BenchmarkSuite:  "stream",
+		Region:          "us-east-1",
+		KeyPairName:     "test-key",
+		SecurityGroupID: "sg-12345678",
+		SubnetID:        "subnet-12345678",
+	}, "results", "warehouse", time.Hour)
+	if err != nil {
+		t.Fatalf("LaunchSingleBenchmark failed: %v", err)
+	}
+	sentinels := awspkg.NewS3SentinelFiles(job.S3Prefix)
+	for key, body := range map[string]string{
+		sentinels.SystemInfo:       `{"architecture": "x86_64", "memory_gb": 8}`,
+		sentinels.BenchmarkResults: `{"benchmark_suite": "stream", "results": {"triad_bandwidth_mbps": 41932.8}, "success": true, "exit_code": 0}`,
+		sentinels.StatusCompleted:  "COMPLETED",
+	} {
+		if err := objects.PutObject(ctx, job.S3Bucket, key, []byte(body), "text/plain"); err != nil {
+			t.Fatalf("PutObject failed: %v", err)
+		}
+	}
+	if _, err := collector.CheckAllBenchmarks(ctx, "results"); err != nil {
+		t.Fatalf("CheckAllBenchmarks failed: %v", err)
+	}
+
+	w := New()
+	report, err := w.Ingest(ctx, results, storage.ResultQuery{})
+	if err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if report.Results != 1 {
+		t.Fatalf("Expected the collected result, got %+v", report)
+	}
+	rows := w.Query(Filter{})
+	if len(rows) != 1 {
+		t.Fatalf("Expected only the triad row, got %+v", rows)
+	}
+	if row := rows[0]; row.Suite != "stream" || row.Metric != "triad.bandwidth" || row.Unit != "GB/s" || row.InstanceType != "m7i.large" {
+		t.Errorf("Unexpected row %+v", row)
+	}
+}
+
+func TestWarehouseSaveAndReingest(t *testing.T) {
+	ctx := context.Background()
+	root := writeResultFiles(t)
+	path := filepath.Join(t.TempDir(), "warehouse.json.gz")
+
+	w, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if _, err := w.Ingest(ctx, storage.NewLocalStorage(root, storage.Config{}), storage.ResultQuery{}); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if err := w.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if reopened.Len() != w.Len() {
+		t.Fatalf("Expected %d rows after reopening, got %d", w.Len(), reopened.Len())
+	}
+
+	// Ingesting the same results again replaces their rows
+	report, err := reopened.Ingest(ctx, storage.NewLocalStorage(root, storage.Config{}), storage.ResultQuery{})
+	if err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if report.Replaced != 3 || reopened.Len() != w.Len() {
+		t.Errorf("Expected re-ingesting to replace 3 results, got %+v with %d rows", report, reopened.Len())
+	}
+
+	if err := New().Save(); err != ErrNoPath {
+		t.Errorf("Expected ErrNoPath saving an in-memory warehouse, got %v", err)
+	}
+}
+
+func TestIngestSameResultsFromTwoRoots(t *testing.T) {
+	ctx := context.Background()
+	w := New()
+
+	// The same files, once as a directory and once nested in a copy
+	first := writeResultFiles(t)
+	second := t.TempDir()
+	for name, content := range resultFiles {
+		path := filepath.Join(second, "backup", "results", filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := w.Ingest(ctx, storage.NewLocalStorage(first, storage.Config{}), storage.ResultQuery{}); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	rows := w.Len()
+
+	report, err := w.Ingest(ctx, storage.NewLocalStorage(second, storage.Config{}), storage.ResultQuery{})
+	if err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if report.Replaced != 3 || w.Len() != rows {
+		t.Errorf("Expected the copies to replace 3 results, got %+v with %d rows, want %d", report, w.Len(), rows)
+	}
+
+	// Both copies in one ingest count once
+	both := t.TempDir()
+	for _, root := range []string{"a", "b"} {
+		name := "2025-06-29/c5.large-stream-20250629-180635.json"
+		path := filepath.Join(both, root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(resultFiles[name]), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	report, err = New().Ingest(ctx, storage.NewLocalStorage(both, storage.Config{}), storage.ResultQuery{})
+	if err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if report.Results != 1 || len(report.Duplicates) != 1 {
+		t.Errorf("Expected 1 result and 1 duplicate, got %+v", report)
+	}
+}
+
+func TestSummarizeAndPerformanceIndices(t *testing.T) {
+	rows := []Row{
+		{InstanceType: "c5.large", InstanceFamily: "c5", Suite: "stream", Metric: "triad.bandwidth", Value: 40, Unit: "GB/s"},
+		{InstanceType: "c5.large", InstanceFamily: "c5", Suite: "stream", Metric: "triad.bandwidth", Value: 44, Unit: "GB/s"},
+		{InstanceType: "c7g.large", InstanceFamily: "c7g", Suite: "stream", Metric: "triad.bandwidth", Value: 84, Unit: "GB/s"},
+		{InstanceType: "c7g.large", InstanceFamily: "c7g", Suite: "stream", Metric: "copy.bandwidth", Value: 90, Unit: "GB/s"},
+	}
+
+	families := Summarize(rows, func(r Row) string { return r.InstanceFamily })
+	if len(families) != 3 {
+		t.Fatalf("Expected 3 summaries, got %d", len(families))
+	}
+	c5 := families[0]
+	if c5.Group != "c5" || c5.Count != 2 || c5.Mean != 42 || c5.Min != 40 || c5.Max != 44 || math.Abs(c5.StdDev-2.8284) > 0.001 {
+		t.Errorf("Unexpected c5 summary %+v", c5)
+	}
+
+	indices := PerformanceIndices(rows)
+	if len(indices) != 2 {
+		t.Fatalf("Expected 2 ranked instance types, got %d", len(indices))
+	}
+	if indices[0].InstanceType != "c7g.large" || indices[0].Index != 100 || indices[1].Rank != 2 || indices[1].Index != 50 {
+		t.Errorf("Unexpected indices %+v", indices)
+	}
+}
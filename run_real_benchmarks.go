@@ -1,3 +1,5 @@
+//go:build ignore
+
 package main
 
 import (
@@ -91,7 +93,7 @@ func main() {
 		fmt.Printf("   💰 Cost: ~$%.4f\n", estimateInstanceCost(config.InstanceType, testDuration))
 		
 		if result.BenchmarkData != nil {
-			printActualResults(config.BenchmarkSuite, result.BenchmarkData)
+			printActualResults(config.BenchmarkSuite, result.BenchmarkData.AsMap())
 		}
 		
 		fmt.Printf("   ⏱️  Completed at: %s\n", time.Now().Format("15:04:05"))
@@ -112,7 +114,7 @@ func main() {
 		fmt.Printf("\n🏆 SUCCESSFUL BENCHMARKS:\n")
 		for testKey, result := range results {
 			fmt.Printf("   ✅ %s\n", testKey)
-			printResultSummary(testKey, result.BenchmarkData)
+			printResultSummary(testKey, result.BenchmarkData.AsMap())
 		}
 		
 		// Cross-architecture analysis
@@ -240,12 +242,12 @@ func analyzePerformanceComparison(results map[string]*awspkg.InstanceResult) flo
 		}
 		
 		// Extract performance score
-		if vectorData, ok := result.BenchmarkData["vector_ops"].(map[string]interface{}); ok {
+		if vectorData, ok := result.BenchmarkData.AsMap()["vector_ops"].(map[string]interface{}); ok {
 			if overall, ok := vectorData["overall_avg_gflops"].(float64); ok {
 				scores = append(scores, overall)
 			}
 		}
-		if mixedData, ok := result.BenchmarkData["mixed_precision"].(map[string]interface{}); ok {
+		if mixedData, ok := result.BenchmarkData.AsMap()["mixed_precision"].(map[string]interface{}); ok {
 			if overall, ok := mixedData["overall_mixed_precision_score"].(float64); ok {
 				scores = append(scores, overall)
 			}
@@ -1,3 +1,5 @@
+//go:build ignore
+
 package main
 
 import (
@@ -104,7 +106,7 @@ func main() {
 		// Print immediate results for validation
 		fmt.Printf("   ✅ Benchmark completed successfully\n")
 		if result.BenchmarkData != nil {
-			printPhase2BenchmarkSummary(config.BenchmarkSuite, result.BenchmarkData)
+			printPhase2BenchmarkSummary(config.BenchmarkSuite, result.BenchmarkData.AsMap())
 		}
 	}
 
@@ -182,12 +184,12 @@ func analyzePhase2Results(results map[string]*awspkg.InstanceResult) {
 		var benchmarkType string
 		
 		// Extract scientific computing performance scores
-		if fftwData, ok := result.BenchmarkData["fftw"].(map[string]interface{}); ok {
+		if fftwData, ok := result.BenchmarkData.AsMap()["fftw"].(map[string]interface{}); ok {
 			if overall, ok := fftwData["overall_gflops"].(float64); ok {
 				score = overall
 				benchmarkType = "fftw_gflops"
 			}
-		} else if vectorData, ok := result.BenchmarkData["vector_ops"].(map[string]interface{}); ok {
+		} else if vectorData, ok := result.BenchmarkData.AsMap()["vector_ops"].(map[string]interface{}); ok {
 			if overall, ok := vectorData["overall_avg_gflops"].(float64); ok {
 				score = overall
 				benchmarkType = "vector_ops_gflops"
@@ -1,3 +1,5 @@
+//go:build ignore
+
 package main
 
 import (
@@ -131,7 +133,7 @@ func main() {
 		// Print immediate results for validation
 		fmt.Printf("   ✅ Test completed successfully in %.1f minutes\n", duration.Minutes())
 		if result.BenchmarkData != nil {
-			printBenchmarkSummary(config.BenchmarkSuite, result.BenchmarkData)
+			printBenchmarkSummary(config.BenchmarkSuite, result.BenchmarkData.AsMap())
 		}
 		fmt.Println()
 	}
@@ -272,22 +274,22 @@ func analyzeCrossArchitectureResults(results map[string]*awspkg.InstanceResult)
 		var metricType string
 		
 		// Extract performance scores based on benchmark type
-		if mixedData, ok := result.BenchmarkData["mixed_precision"].(map[string]interface{}); ok {
+		if mixedData, ok := result.BenchmarkData.AsMap()["mixed_precision"].(map[string]interface{}); ok {
 			if overall, ok := mixedData["overall_mixed_precision_score"].(float64); ok {
 				score = overall
 				metricType = "mixed_precision_score"
 			}
-		} else if fftwData, ok := result.BenchmarkData["fftw"].(map[string]interface{}); ok {
+		} else if fftwData, ok := result.BenchmarkData.AsMap()["fftw"].(map[string]interface{}); ok {
 			if overall, ok := fftwData["overall_gflops"].(float64); ok {
 				score = overall
 				metricType = "fftw_gflops"
 			}
-		} else if vectorData, ok := result.BenchmarkData["vector_ops"].(map[string]interface{}); ok {
+		} else if vectorData, ok := result.BenchmarkData.AsMap()["vector_ops"].(map[string]interface{}); ok {
 			if overall, ok := vectorData["overall_avg_gflops"].(float64); ok {
 				score = overall
 				metricType = "vector_ops_gflops"
 			}
-		} else if compData, ok := result.BenchmarkData["compilation"].(map[string]interface{}); ok {
+		} else if compData, ok := result.BenchmarkData.AsMap()["compilation"].(map[string]interface{}); ok {
 			if speedup, ok := compData["parallel_speedup"].(float64); ok {
 				score = speedup
 				metricType = "compilation_speedup"
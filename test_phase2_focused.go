@@ -1,3 +1,5 @@
+//go:build ignore
+
 package main
 
 import (
@@ -99,7 +101,7 @@ func main() {
 		fmt.Printf("   ✅ Test completed successfully in %.1f minutes\n", duration.Minutes())
 		fmt.Printf("   📊 Instance ID: %s\n", result.InstanceID)
 		if result.BenchmarkData != nil {
-			printDetailedResults(config.BenchmarkSuite, result.BenchmarkData)
+			printDetailedResults(config.BenchmarkSuite, result.BenchmarkData.AsMap())
 		}
 		fmt.Println("   " + strings.Repeat("-", 60))
 		fmt.Println()
@@ -246,7 +248,7 @@ func validatePhase2Implementation(results map[string]*awspkg.InstanceResult) {
 		}
 		
 		// Validate benchmark-specific results
-		if vectorData, ok := result.BenchmarkData["vector_ops"].(map[string]interface{}); ok {
+		if vectorData, ok := result.BenchmarkData.AsMap()["vector_ops"].(map[string]interface{}); ok {
 			benchmarkTypes["vector_operations"] = true
 			if overall, ok := vectorData["overall_avg_gflops"].(float64); ok {
 				performanceScores["vector_ops_"+result.InstanceType] = overall
@@ -254,7 +256,7 @@ func validatePhase2Implementation(results map[string]*awspkg.InstanceResult) {
 			}
 		}
 		
-		if mixedData, ok := result.BenchmarkData["mixed_precision"].(map[string]interface{}); ok {
+		if mixedData, ok := result.BenchmarkData.AsMap()["mixed_precision"].(map[string]interface{}); ok {
 			benchmarkTypes["mixed_precision"] = true
 			if overall, ok := mixedData["overall_mixed_precision_score"].(float64); ok {
 				performanceScores["mixed_precision_"+result.InstanceType] = overall
@@ -262,7 +264,7 @@ func validatePhase2Implementation(results map[string]*awspkg.InstanceResult) {
 			}
 		}
 		
-		if fftwData, ok := result.BenchmarkData["fftw"].(map[string]interface{}); ok {
+		if fftwData, ok := result.BenchmarkData.AsMap()["fftw"].(map[string]interface{}); ok {
 			benchmarkTypes["fftw_scientific"] = true
 			if overall, ok := fftwData["overall_gflops"].(float64); ok {
 				performanceScores["fftw_"+result.InstanceType] = overall
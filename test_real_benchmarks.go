@@ -1,3 +1,5 @@
+//go:build ignore
+
 package main
 
 import (
@@ -104,7 +106,7 @@ func main() {
 		fmt.Printf("   💰 Cost: ~$%.4f\n", estimateCost(config.InstanceType, testDuration))
 		
 		if result.BenchmarkData != nil {
-			printDetailedBenchmarkResults(config.BenchmarkSuite, result.BenchmarkData)
+			printDetailedBenchmarkResults(config.BenchmarkSuite, result.BenchmarkData.AsMap())
 		}
 		
 		fmt.Printf("   ⏱️  Completed at: %s\n", time.Now().Format("15:04:05"))
@@ -131,7 +133,7 @@ func main() {
 		for testKey, result := range results {
 			fmt.Printf("   ✅ %s\n", testKey)
 			if result.BenchmarkData != nil {
-				printSummaryMetrics(testKey, result.BenchmarkData)
+				printSummaryMetrics(testKey, result.BenchmarkData.AsMap())
 			}
 			totalCost += estimateCost(result.InstanceType, 15*time.Minute) // Rough estimate
 		}
@@ -275,7 +277,7 @@ func analyzeArchitecturePerformance(results map[string]*awspkg.InstanceResult) {
 		}
 		
 		arch := getArchitecture(result.InstanceType)
-		score := extractPerformanceScore(result.BenchmarkData)
+		score := extractPerformanceScore(result.BenchmarkData.AsMap())
 		
 		if score > 0 {
 			architectures[arch] = append(architectures[arch], score)
@@ -1,3 +1,5 @@
+//go:build ignore
+
 package main
 
 import (
@@ -78,7 +80,7 @@ func main() {
 	
 	if result.BenchmarkData != nil {
 		fmt.Printf("\n🎉 REAL PHASE 2 BENCHMARK RESULTS:\n")
-		if vectorData, ok := result.BenchmarkData["vector_ops"].(map[string]interface{}); ok {
+		if vectorData, ok := result.BenchmarkData.AsMap()["vector_ops"].(map[string]interface{}); ok {
 			if axpy, ok := vectorData["avg_axpy_gflops"].(float64); ok {
 				fmt.Printf("   AXPY (Y = a*X + Y): %.2f GFLOPS 🚀\n", axpy)
 				validateResult("AXPY", axpy, 85, 105)
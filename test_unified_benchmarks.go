@@ -1,3 +1,5 @@
+//go:build ignore
+
 package main
 
 import (
@@ -88,7 +90,7 @@ func main() {
 		// Print immediate results for validation
 		fmt.Printf("   ✅ Benchmark completed successfully\n")
 		if result.BenchmarkData != nil {
-			printBenchmarkSummary(config.BenchmarkSuite, result.BenchmarkData)
+			printBenchmarkSummary(config.BenchmarkSuite, result.BenchmarkData.AsMap())
 		}
 	}
 
@@ -163,17 +165,17 @@ func analyzeUnifiedResults(results map[string]*awspkg.InstanceResult) {
 		var benchmarkType string
 		
 		// Extract performance scores
-		if sevenZipData, ok := result.BenchmarkData["7zip"].(map[string]interface{}); ok {
+		if sevenZipData, ok := result.BenchmarkData.AsMap()["7zip"].(map[string]interface{}); ok {
 			if totalMIPS, ok := sevenZipData["total_mips"].(float64); ok {
 				score = totalMIPS
 				benchmarkType = "7zip_mips"
 			}
-		} else if sysbenchData, ok := result.BenchmarkData["sysbench"].(map[string]interface{}); ok {
+		} else if sysbenchData, ok := result.BenchmarkData.AsMap()["sysbench"].(map[string]interface{}); ok {
 			if eps, ok := sysbenchData["events_per_second"].(float64); ok {
 				score = eps
 				benchmarkType = "sysbench_eps"
 			}
-		} else if dgemmData, ok := result.BenchmarkData["dgemm"].(map[string]interface{}); ok {
+		} else if dgemmData, ok := result.BenchmarkData.AsMap()["dgemm"].(map[string]interface{}); ok {
 			if peakGflops, ok := dgemmData["peak_gflops"].(float64); ok {
 				score = peakGflops
 				benchmarkType = "dgemm_gflops"